@@ -15,6 +15,10 @@ import (
 
 func cmdBackup(args []string) error {
 	start := time.Now()
+
+	if len(args) > 0 && args[0] == "show" {
+		return backupShow(args[1:])
+	}
 	var targetName string
 	doList := false
 	doCleanup := false
@@ -42,6 +46,14 @@ func cmdBackup(args []string) error {
 		return backupList()
 	}
 
+	if !dryRun {
+		unlock, err := acquireOpLock(config.ConfigPath(), "backup")
+		if err != nil {
+			return err
+		}
+		defer unlock()
+	}
+
 	if doCleanup {
 		if dryRun {
 			return backupCleanupDryRun()
@@ -186,6 +198,50 @@ func backupList() error {
 	return nil
 }
 
+// backupShow lists the skills stored in one backup, per target.
+func backupShow(args []string) error {
+	var timestamp, targetName string
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "--target", "-t":
+			if i+1 < len(args) {
+				targetName = args[i+1]
+				i++
+			}
+		default:
+			timestamp = args[i]
+		}
+	}
+	if timestamp == "" {
+		return fmt.Errorf("usage: skillshare backup show <timestamp> [--target <name>]")
+	}
+
+	b, err := backup.GetBackupByTimestamp(timestamp)
+	if err != nil {
+		return err
+	}
+
+	targets := b.Targets
+	if targetName != "" {
+		targets = []string{targetName}
+	}
+
+	for _, t := range targets {
+		snap, err := backup.LoadSnapshot(b.Path, t)
+		if err != nil {
+			return err
+		}
+		ui.Header(fmt.Sprintf("%s / %s (%.1f KB)", b.Timestamp, t, float64(snap.Size())/1024))
+		for _, skill := range snap.Skills() {
+			fmt.Printf("  %-32s %3d file(s)  %8.1f KB\n", skill.Name, skill.Files, float64(skill.Size)/1024)
+		}
+	}
+
+	fmt.Println()
+	ui.Info("Restore a skill with: skillshare restore <target> --from %s --skill <name>", b.Timestamp)
+	return nil
+}
+
 func backupCleanup() error {
 	ui.Header("Cleaning up old backups")
 
@@ -253,6 +309,7 @@ func planBackupCleanup(backups []backup.BackupInfo, cfg backup.CleanupConfig, no
 	removed := 0
 	var removedSize int64
 	var totalSize int64
+	sizes := backup.IncrementalSizes(backups)
 
 	for i, backupInfo := range backups {
 		shouldRemove := false
@@ -265,7 +322,7 @@ func planBackupCleanup(backups []backup.BackupInfo, cfg backup.CleanupConfig, no
 			shouldRemove = true
 		}
 
-		size := backup.Size(backupInfo.Path)
+		size := sizes[i]
 		totalSize += size
 		if cfg.MaxSizeMB > 0 && totalSize > cfg.MaxSizeMB*1024*1024 {
 			shouldRemove = true
@@ -284,13 +341,15 @@ func cmdRestore(args []string) error {
 	start := time.Now()
 
 	if len(args) < 1 {
-		return fmt.Errorf("usage: skillshare restore <target> [--from <timestamp>] [--force] [--dry-run]")
+		return fmt.Errorf("usage: skillshare restore <target> [--from <timestamp>] [--skill <name>[,<name>]] [--to-source] [--force] [--dry-run]")
 	}
 
 	var targetName string
 	var fromTimestamp string
+	var skills []string
 	force := false
 	dryRun := false
+	toSource := false
 
 	for i := 0; i < len(args); i++ {
 		switch args[i] {
@@ -299,6 +358,13 @@ func cmdRestore(args []string) error {
 				fromTimestamp = args[i+1]
 				i++
 			}
+		case "--skill", "-s":
+			if i+1 < len(args) {
+				skills = append(skills, splitSkillNames(args[i+1])...)
+				i++
+			}
+		case "--to-source":
+			toSource = true
 		case "--force":
 			force = true
 		case "--dry-run", "-n":
@@ -310,6 +376,10 @@ func cmdRestore(args []string) error {
 		}
 	}
 
+	if toSource && len(skills) == 0 {
+		return fmt.Errorf("--to-source requires --skill")
+	}
+
 	cfg, err := config.Load()
 	if err != nil {
 		return err
@@ -320,6 +390,14 @@ func cmdRestore(args []string) error {
 		return fmt.Errorf("target '%s' not found in config", targetName)
 	}
 
+	if !dryRun {
+		unlock, err := acquireOpLock(config.ConfigPath(), "restore")
+		if err != nil {
+			return err
+		}
+		defer unlock()
+	}
+
 	ui.Header(fmt.Sprintf("Restoring %s", targetName))
 
 	if dryRun {
@@ -328,6 +406,29 @@ func cmdRestore(args []string) error {
 
 	opts := backup.RestoreOptions{Force: force}
 
+	if len(skills) > 0 {
+		destDir := target.Path
+		if toSource {
+			destDir = cfg.Source
+		}
+		restoreErr := restoreSkillsFromBackup(targetName, destDir, fromTimestamp, skills, opts, dryRun)
+		if !dryRun {
+			e := oplog.NewEntry("restore", statusFromErr(restoreErr), time.Since(start))
+			e.Args = map[string]any{"target": targetName, "skills": skills}
+			if fromTimestamp != "" {
+				e.Args["from"] = fromTimestamp
+			}
+			if toSource {
+				e.Args["to"] = "source"
+			}
+			if restoreErr != nil {
+				e.Message = restoreErr.Error()
+			}
+			oplog.Write(config.ConfigPath(), oplog.OpsFile, e) //nolint:errcheck
+		}
+		return restoreErr
+	}
+
 	if dryRun {
 		if fromTimestamp != "" {
 			return previewRestoreFromTimestamp(targetName, target.Path, fromTimestamp, opts)
@@ -355,6 +456,57 @@ func cmdRestore(args []string) error {
 	return restoreErr
 }
 
+// restoreSkillsFromBackup restores only the given skills of targetName
+// into destDir, from the backup at timestamp or the latest one holding them.
+func restoreSkillsFromBackup(targetName, destDir, timestamp string, skills []string, opts backup.RestoreOptions, dryRun bool) error {
+	var b *backup.BackupInfo
+	if timestamp != "" {
+		found, err := backup.GetBackupByTimestamp(timestamp)
+		if err != nil {
+			return err
+		}
+		b = found
+	} else {
+		backups, err := backup.FindBackupsForTarget(targetName)
+		if err != nil {
+			return err
+		}
+		if len(backups) == 0 {
+			return fmt.Errorf("no backup found for target '%s'", targetName)
+		}
+		b = &backups[0]
+	}
+
+	if dryRun {
+		if err := backup.ValidateRestoreSkills(b.Path, targetName, destDir, skills, opts); err != nil {
+			return err
+		}
+		for _, name := range skills {
+			ui.Info("Would restore %s from backup %s into %s", name, b.Timestamp, destDir)
+		}
+		return nil
+	}
+
+	if err := backup.RestoreSkills(b.Path, targetName, destDir, skills, opts); err != nil {
+		return err
+	}
+	for _, name := range skills {
+		ui.Success("Restored %s from backup %s", name, b.Timestamp)
+	}
+	return nil
+}
+
+// splitSkillNames splits a comma-separated --skill value.
+func splitSkillNames(value string) []string {
+	var names []string
+	for _, n := range strings.Split(value, ",") {
+		if n = strings.TrimSpace(n); n != "" {
+			names = append(names, n)
+		}
+	}
+	return names
+}
+
 func restoreFromTimestamp(targetName, targetPath, timestamp string, opts backup.RestoreOptions) error {
 	backupInfo, err := backup.GetBackupByTimestamp(timestamp)
 	if err != nil {
@@ -0,0 +1,199 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"skillshare/internal/config"
+	"skillshare/internal/install"
+	"skillshare/internal/oplog"
+	"skillshare/internal/ui"
+)
+
+func cmdCache(args []string) error {
+	if len(args) == 0 {
+		return cacheList(nil)
+	}
+
+	sub := args[0]
+	subArgs := args[1:]
+
+	switch sub {
+	case "list", "ls":
+		return cacheList(subArgs)
+	case "prune":
+		return cachePrune(subArgs)
+	case "--json":
+		return cacheList(args)
+	case "--help", "-h", "help":
+		printCacheHelp()
+		return nil
+	default:
+		printCacheHelp()
+		return fmt.Errorf("unknown subcommand: %s", sub)
+	}
+}
+
+// cacheLimit returns the size limit from the global config. The cache is
+// shared by global and project mode, so project config has no say.
+func cacheLimit() int64 {
+	cfg, err := config.Load()
+	if err != nil {
+		return config.CacheConfig{}.MaxBytes()
+	}
+	return cfg.Cache.MaxBytes()
+}
+
+func cacheList(args []string) error {
+	jsonOutput := false
+	for _, arg := range args {
+		switch arg {
+		case "--json":
+			jsonOutput = true
+		case "--help", "-h":
+			printCacheHelp()
+			return nil
+		default:
+			return fmt.Errorf("unknown option: %s", arg)
+		}
+	}
+
+	entries, err := install.ListCache()
+	if err != nil {
+		return fmt.Errorf("failed to read clone cache: %w", err)
+	}
+	var total int64
+	for _, e := range entries {
+		total += e.Size
+	}
+	limit := cacheLimit()
+
+	if jsonOutput {
+		if entries == nil {
+			entries = []install.CacheEntry{}
+		}
+		out, _ := json.MarshalIndent(map[string]any{
+			"path":     install.CacheDir,
+			"size":     total,
+			"max_size": limit,
+			"repos":    entries,
+		}, "", "  ")
+		fmt.Println(string(out))
+		return nil
+	}
+
+	ui.Header("Clone cache")
+	ui.StepStart("Path", install.CacheDir)
+	if len(entries) == 0 {
+		ui.StepEnd("Repos", "none")
+		return nil
+	}
+	ui.StepEnd("Size", fmt.Sprintf("%s of %s, %d repo(s)", formatBytes(total), formatBytes(limit), len(entries)))
+	fmt.Println()
+	for _, e := range entries {
+		ui.ListItem("info", formatSourceShort(e.URL), fmt.Sprintf("%s  used %s", formatBytes(e.Size), formatCacheTime(e.UsedAt)))
+	}
+	if total > limit {
+		fmt.Println()
+		ui.Info("Run 'skillshare cache prune' to shrink the cache to its limit")
+	}
+	return nil
+}
+
+func cachePrune(args []string) error {
+	start := time.Now()
+	var all, dryRun bool
+	for _, arg := range args {
+		switch arg {
+		case "--all", "-a":
+			all = true
+		case "--dry-run", "-n":
+			dryRun = true
+		case "--help", "-h":
+			printCacheHelp()
+			return nil
+		default:
+			return fmt.Errorf("unknown option: %s", arg)
+		}
+	}
+
+	if !dryRun {
+		unlock, err := acquireOpLock(config.ConfigPath(), "cache")
+		if err != nil {
+			return err
+		}
+		defer unlock()
+	}
+
+	limit := cacheLimit()
+	if all {
+		limit = 0
+	}
+	removed, err := install.PruneCache(limit, dryRun)
+
+	if !dryRun {
+		e := oplog.NewEntry("cache", statusFromErr(err), time.Since(start))
+		e.Args = map[string]any{"action": "prune", "removed": len(removed)}
+		if all {
+			e.Args["all"] = true
+		}
+		if err != nil {
+			e.Message = err.Error()
+		}
+		oplog.Write(config.ConfigPath(), oplog.OpsFile, e) //nolint:errcheck
+	}
+	if err != nil {
+		return err
+	}
+
+	if len(removed) == 0 {
+		ui.Success("Clone cache is within its limit (%s)", formatBytes(cacheLimit()))
+		return nil
+	}
+
+	var freed int64
+	for _, e := range removed {
+		freed += e.Size
+		ui.ListItem("info", formatSourceShort(e.URL), fmt.Sprintf("%s  used %s", formatBytes(e.Size), formatCacheTime(e.UsedAt)))
+	}
+	fmt.Println()
+	if dryRun {
+		ui.Info("[dry-run] Would remove %d repo(s), freeing %s", len(removed), formatBytes(freed))
+		return nil
+	}
+	ui.Success("Removed %d repo(s), freed %s", len(removed), formatBytes(freed))
+	return nil
+}
+
+func formatCacheTime(t time.Time) string {
+	if t.IsZero() {
+		return "never"
+	}
+	return t.Local().Format("2006-01-02 15:04")
+}
+
+func printCacheHelp() {
+	fmt.Println(`Usage: skillshare cache [list|prune] [options]
+
+Manage the clone cache. Installs and updates fetch each repository into
+the cache once and copy skills out of it, so installing several skills
+from one repository clones it once, and installs work offline from a
+cached copy. The cache is shared by global and project mode.
+
+Subcommands:
+  list, ls           List cached repositories (default)
+  prune              Remove least recently used repositories until the
+                     cache fits cache.max_size_mb (default 1024)
+
+Options:
+  --json             Output the list as JSON
+  --all, -a          prune: remove every cached repository
+  --dry-run, -n      prune: show what would be removed
+  --help, -h         Show this help
+
+Examples:
+  skillshare cache                   List cached repositories
+  skillshare cache prune             Shrink the cache to its limit
+  skillshare cache prune --all       Empty the cache`)
+}
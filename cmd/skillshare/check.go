@@ -10,6 +10,8 @@ import (
 	"skillshare/internal/config"
 	"skillshare/internal/git"
 	"skillshare/internal/install"
+	"skillshare/internal/parallel"
+	"skillshare/internal/review"
 	ssync "skillshare/internal/sync"
 	"skillshare/internal/ui"
 )
@@ -17,24 +19,28 @@ import (
 // checkRepoResult holds the check result for a tracked repo
 type checkRepoResult struct {
 	Name    string `json:"name"`
-	Status  string `json:"status"` // "up_to_date", "behind", "dirty", "error"
+	Status  string `json:"status"` // "up_to_date", "behind", "pinned", "dirty", "error"
 	Behind  int    `json:"behind"`
+	Pinned  string `json:"pinned,omitempty"` // tag or commit a pinned repo is at
+	Latest  string `json:"latest,omitempty"` // newer version tag of a pinned repo
 	Message string `json:"message,omitempty"`
 }
 
 // checkSkillResult holds the check result for a regular skill
 type checkSkillResult struct {
-	Name        string `json:"name"`
-	Source      string `json:"source"`
-	Version     string `json:"version"`
-	Status      string `json:"status"` // "up_to_date", "update_available", "local", "error"
-	InstalledAt string `json:"installed_at,omitempty"`
+	Name        string   `json:"name"`
+	Source      string   `json:"source"`
+	Version     string   `json:"version"`
+	Status      string   `json:"status"` // "up_to_date", "update_available", "local", "error"
+	InstalledAt string   `json:"installed_at,omitempty"`
+	NewerTags   []string `json:"newer_tags,omitempty"` // OCI skills: version tags above the installed one
 }
 
 // checkOutput is the JSON output structure
 type checkOutput struct {
 	TrackedRepos []checkRepoResult  `json:"tracked_repos"`
 	Skills       []checkSkillResult `json:"skills"`
+	Stale        []review.Status    `json:"stale,omitempty"` // skills due for review
 }
 
 // checkOptions holds parsed arguments for check command
@@ -42,18 +48,25 @@ type checkOptions struct {
 	names  []string // positional (0+ = all)
 	groups []string // --group/-G
 	json   bool
+	jobs   int // --jobs/-j
 }
 
 // parseCheckArgs parses command line arguments for the check command.
 // Returns (opts, showHelp, error).
 func parseCheckArgs(args []string) (*checkOptions, bool, error) {
-	opts := &checkOptions{}
+	opts := &checkOptions{jobs: parallel.DefaultJobs}
 
 	for i := 0; i < len(args); i++ {
 		arg := args[i]
 		switch {
 		case arg == "--json":
 			opts.json = true
+		case arg == "--jobs" || arg == "-j" || strings.HasPrefix(arg, "--jobs="):
+			n, next, err := parseJobsFlag(args, i)
+			if err != nil {
+				return nil, false, err
+			}
+			opts.jobs, i = n, next
 		case arg == "--group" || arg == "-G":
 			i++
 			if i >= len(args) {
@@ -113,14 +126,14 @@ func cmdCheck(args []string) error {
 
 	// No names and no groups → check all (existing behavior)
 	if len(opts.names) == 0 && len(opts.groups) == 0 {
-		return runCheck(cfg.Source, opts.json)
+		return runCheck(cfg.Source, opts.json, opts.jobs, globalReviewScope(cfg))
 	}
 
 	// Filtered check: resolve targets then check only those
 	return runCheckFiltered(cfg.Source, opts)
 }
 
-func runCheck(sourceDir string, jsonOutput bool) error {
+func runCheck(sourceDir string, jsonOutput bool, jobs int, reviewed reviewScope) error {
 	repos, err := install.GetTrackedRepos(sourceDir)
 	if err != nil {
 		repos = nil // Non-fatal: source dir might not exist yet
@@ -131,18 +144,18 @@ func runCheck(sourceDir string, jsonOutput bool) error {
 		skills = nil
 	}
 
+	discovered, _ := ssync.DiscoverSourceSkills(sourceDir)
+	stale := staleSkills(reviewed, discovered)
+
 	if len(repos) == 0 && len(skills) == 0 {
 		if jsonOutput {
-			out, _ := json.MarshalIndent(checkOutput{
-				TrackedRepos: []checkRepoResult{},
-				Skills:       []checkSkillResult{},
-			}, "", "  ")
-			fmt.Println(string(out))
+			printCheckJSON(nil, nil, stale)
 			return nil
 		}
 		ui.Header(ui.WithModeLabel("Checking for updates"))
 		ui.Info("No tracked repositories or updatable skills found")
 		ui.Info("Use 'skillshare install <repo> --track' to add a tracked repository")
+		printReviewSection(reviewed, discovered)
 		return nil
 	}
 
@@ -152,137 +165,28 @@ func runCheck(sourceDir string, jsonOutput bool) error {
 		ui.StepContinue("Items", fmt.Sprintf("%d tracked repo(s), %d skill(s)", len(repos), len(skills)))
 	}
 
-	var repoResults []checkRepoResult
-	var skillResults []checkSkillResult
-
-	// Check tracked repos
-	if len(repos) > 0 {
-		if !jsonOutput {
-			fmt.Println()
-			spinner := ui.StartSpinner(fmt.Sprintf("Fetching updates for %d tracked repo(s)...", len(repos)))
-
-			for _, repo := range repos {
-				repoPath := filepath.Join(sourceDir, repo)
-				result := checkTrackedRepo(repo, repoPath)
-				repoResults = append(repoResults, result)
-			}
-
-			spinner.Success(fmt.Sprintf("Checked %d tracked repo(s)", len(repos)))
-			fmt.Println()
-
-			for _, r := range repoResults {
-				switch r.Status {
-				case "up_to_date":
-					ui.ListItem("success", r.Name, "up to date")
-				case "behind":
-					ui.ListItem("info", r.Name, fmt.Sprintf("%d commit(s) behind", r.Behind))
-				case "dirty":
-					ui.ListItem("warning", r.Name, "has uncommitted changes")
-				case "error":
-					ui.ListItem("error", r.Name, fmt.Sprintf("error: %s", r.Message))
-				}
-			}
-		} else {
-			for _, repo := range repos {
-				repoPath := filepath.Join(sourceDir, repo)
-				result := checkTrackedRepo(repo, repoPath)
-				repoResults = append(repoResults, result)
-			}
-		}
+	targets := make([]resolvedMatch, 0, len(repos)+len(skills))
+	for _, repo := range repos {
+		targets = append(targets, resolvedMatch{relPath: repo, isRepo: true})
 	}
-
-	// Check regular skills
-	if len(skills) > 0 {
-		if !jsonOutput {
-			fmt.Println()
-			spinner := ui.StartSpinner(fmt.Sprintf("Checking %d installed skill(s)...", len(skills)))
-
-			for _, skill := range skills {
-				skillPath := filepath.Join(sourceDir, skill)
-				result := checkRegularSkill(skill, skillPath)
-				skillResults = append(skillResults, result)
-			}
-
-			spinner.Success(fmt.Sprintf("Checked %d installed skill(s)", len(skills)))
-			fmt.Println()
-
-			for _, s := range skillResults {
-				switch s.Status {
-				case "up_to_date":
-					detail := "up to date"
-					if s.Source != "" {
-						detail += fmt.Sprintf("  %s", formatSourceShort(s.Source))
-					}
-					ui.ListItem("success", s.Name, detail)
-				case "update_available":
-					detail := "update available"
-					if s.Source != "" {
-						detail += fmt.Sprintf("  %s", formatSourceShort(s.Source))
-					}
-					ui.ListItem("info", s.Name, detail)
-				case "local":
-					ui.ListItem("info", s.Name, "local source")
-				case "error":
-					ui.ListItem("warning", s.Name, "cannot reach remote")
-				}
-			}
-		} else {
-			for _, skill := range skills {
-				skillPath := filepath.Join(sourceDir, skill)
-				result := checkRegularSkill(skill, skillPath)
-				skillResults = append(skillResults, result)
-			}
-		}
+	for _, skill := range skills {
+		targets = append(targets, resolvedMatch{relPath: skill})
 	}
 
+	repoResults, skillResults := checkItems(sourceDir, targets, jobs, !jsonOutput)
+
 	if jsonOutput {
-		output := checkOutput{
-			TrackedRepos: repoResults,
-			Skills:       skillResults,
-		}
-		if output.TrackedRepos == nil {
-			output.TrackedRepos = []checkRepoResult{}
-		}
-		if output.Skills == nil {
-			output.Skills = []checkSkillResult{}
-		}
-		out, _ := json.MarshalIndent(output, "", "  ")
-		fmt.Println(string(out))
+		printCheckJSON(repoResults, skillResults, stale)
 		return nil
 	}
 
-	// Summary
-	updatableRepos := 0
-	for _, r := range repoResults {
-		if r.Status == "behind" {
-			updatableRepos++
-		}
-	}
-	updatableSkills := 0
-	for _, s := range skillResults {
-		if s.Status == "update_available" {
-			updatableSkills++
-		}
-	}
-
-	fmt.Println()
-	if updatableRepos+updatableSkills == 0 {
-		ui.SuccessMsg("Everything is up to date")
-	} else {
-		parts := []string{}
-		if updatableRepos > 0 {
-			parts = append(parts, fmt.Sprintf("%d repo(s)", updatableRepos))
-		}
-		if updatableSkills > 0 {
-			parts = append(parts, fmt.Sprintf("%d skill(s)", updatableSkills))
-		}
-		ui.Info("%s have updates available", strings.Join(parts, " + "))
-		ui.Info("Run 'skillshare update <name>' or 'skillshare update --all'")
-	}
+	printCheckResults(repoResults, skillResults)
 
 	// Warn about unknown target names in skill-level targets field
 	warnUnknownSkillTargets(sourceDir)
 
+	printReviewSection(reviewed, discovered)
+
 	return nil
 }
 
@@ -350,11 +254,7 @@ func runCheckFiltered(sourceDir string, opts *checkOptions) error {
 
 	if len(targets) == 0 {
 		if opts.json {
-			out, _ := json.MarshalIndent(checkOutput{
-				TrackedRepos: []checkRepoResult{},
-				Skills:       []checkSkillResult{},
-			}, "", "  ")
-			fmt.Println(string(out))
+			printCheckJSON(nil, nil, nil)
 			return nil
 		}
 		if len(resolveWarnings) > 0 {
@@ -364,49 +264,94 @@ func runCheckFiltered(sourceDir string, opts *checkOptions) error {
 	}
 
 	// --- Check resolved targets ---
-	var repoResults []checkRepoResult
-	var skillResults []checkSkillResult
+	repoResults, skillResults := checkItems(sourceDir, targets, opts.jobs, !opts.json)
 
-	if !opts.json {
-		fmt.Println()
+	if opts.json {
+		printCheckJSON(repoResults, skillResults, nil)
+		return nil
 	}
 
-	var spinner *ui.Spinner
-	if !opts.json {
-		spinner = ui.StartSpinner(fmt.Sprintf("Checking %d target(s)...", len(targets)))
+	printCheckResults(repoResults, skillResults)
+	return nil
+}
+
+// checkItems checks tracked repos and skills on a pool of jobs workers,
+// limited per git host, and returns the results in input order. Skills
+// installed from the same repository share a single ls-remote.
+func checkItems(sourceDir string, targets []resolvedMatch, jobs int, showProgress bool) ([]checkRepoResult, []checkSkillResult) {
+	hosts := make([]string, len(targets))
+	for i, t := range targets {
+		hosts[i] = parallel.HostOf(remoteURLOf(filepath.Join(sourceDir, t.relPath), t.isRepo))
 	}
 
-	for _, t := range targets {
+	var progress *ui.MultiProgress
+	if showProgress {
+		fmt.Println()
+		progress = ui.StartMultiProgress(fmt.Sprintf("Checking %d item(s)", len(targets)), len(targets))
+	}
+
+	repoResults := make([]checkRepoResult, len(targets))
+	skillResults := make([]checkSkillResult, len(targets))
+	remotes := &parallel.Memo[string]{}
+
+	pool := parallel.NewPool(jobs, parallel.DefaultPerHost)
+	pool.Run(len(targets), func(i int) string { return hosts[i] }, func(i int) {
+		t := targets[i]
+		progress.Begin(t.relPath)
+		defer progress.End(t.relPath)
 		itemPath := filepath.Join(sourceDir, t.relPath)
 		if t.isRepo {
-			repoResults = append(repoResults, checkTrackedRepo(t.relPath, itemPath))
+			repoResults[i] = checkTrackedRepo(t.relPath, itemPath)
 		} else {
-			skillResults = append(skillResults, checkRegularSkill(t.relPath, itemPath))
+			skillResults[i] = checkRegularSkill(t.relPath, itemPath, remotes)
 		}
-	}
+	})
 
-	if spinner != nil {
-		spinner.Success(fmt.Sprintf("Checked %d target(s)", len(targets)))
-	}
+	progress.Stop(fmt.Sprintf("Checked %d item(s)", len(targets)))
 
-	if opts.json {
-		output := checkOutput{
-			TrackedRepos: repoResults,
-			Skills:       skillResults,
-		}
-		if output.TrackedRepos == nil {
-			output.TrackedRepos = []checkRepoResult{}
-		}
-		if output.Skills == nil {
-			output.Skills = []checkSkillResult{}
+	var repos []checkRepoResult
+	var skills []checkSkillResult
+	for i, t := range targets {
+		if t.isRepo {
+			repos = append(repos, repoResults[i])
+		} else {
+			skills = append(skills, skillResults[i])
 		}
-		out, _ := json.MarshalIndent(output, "", "  ")
-		fmt.Println(string(out))
-		return nil
 	}
+	return repos, skills
+}
 
-	// --- Display results ---
+// remoteURLOf returns the remote a tracked repo or installed skill is
+// checked against, or "" for local skills.
+func remoteURLOf(itemPath string, isRepo bool) string {
+	if isRepo {
+		return git.GetRemoteURL(itemPath)
+	}
+	meta, err := install.ReadMeta(itemPath)
+	if err != nil || meta == nil {
+		return ""
+	}
+	return meta.RemoteURL()
+}
+
+func printCheckJSON(repoResults []checkRepoResult, skillResults []checkSkillResult, stale []review.Status) {
+	output := checkOutput{
+		TrackedRepos: repoResults,
+		Skills:       skillResults,
+		Stale:        stale,
+	}
+	if output.TrackedRepos == nil {
+		output.TrackedRepos = []checkRepoResult{}
+	}
+	if output.Skills == nil {
+		output.Skills = []checkSkillResult{}
+	}
+	out, _ := json.MarshalIndent(output, "", "  ")
+	fmt.Println(string(out))
+}
 
+// printCheckResults lists each result followed by a summary line.
+func printCheckResults(repoResults []checkRepoResult, skillResults []checkSkillResult) {
 	if len(repoResults) > 0 {
 		fmt.Println()
 		for _, r := range repoResults {
@@ -415,6 +360,12 @@ func runCheckFiltered(sourceDir string, opts *checkOptions) error {
 				ui.ListItem("success", r.Name, "up to date")
 			case "behind":
 				ui.ListItem("info", r.Name, fmt.Sprintf("%d commit(s) behind", r.Behind))
+			case "pinned":
+				if r.Latest != "" {
+					ui.ListItem("info", r.Name, fmt.Sprintf("pinned at %s, %s available", r.Pinned, r.Latest))
+				} else {
+					ui.ListItem("success", r.Name, fmt.Sprintf("pinned at %s", r.Pinned))
+				}
 			case "dirty":
 				ui.ListItem("warning", r.Name, "has uncommitted changes")
 			case "error":
@@ -435,6 +386,9 @@ func runCheckFiltered(sourceDir string, opts *checkOptions) error {
 				ui.ListItem("success", s.Name, detail)
 			case "update_available":
 				detail := "update available"
+				if len(s.NewerTags) > 0 {
+					detail = "newer tags: " + strings.Join(s.NewerTags, ", ")
+				}
 				if s.Source != "" {
 					detail += fmt.Sprintf("  %s", formatSourceShort(s.Source))
 				}
@@ -448,11 +402,14 @@ func runCheckFiltered(sourceDir string, opts *checkOptions) error {
 	}
 
 	// Summary
-	updatableRepos := 0
+	updatableRepos, movablePins := 0, 0
 	for _, r := range repoResults {
 		if r.Status == "behind" {
 			updatableRepos++
 		}
+		if r.Status == "pinned" && r.Latest != "" {
+			movablePins++
+		}
 	}
 	updatableSkills := 0
 	for _, s := range skillResults {
@@ -462,9 +419,9 @@ func runCheckFiltered(sourceDir string, opts *checkOptions) error {
 	}
 
 	fmt.Println()
-	if updatableRepos+updatableSkills == 0 {
+	if updatableRepos+updatableSkills+movablePins == 0 {
 		ui.SuccessMsg("Everything is up to date")
-	} else {
+	} else if updatableRepos+updatableSkills > 0 {
 		parts := []string{}
 		if updatableRepos > 0 {
 			parts = append(parts, fmt.Sprintf("%d repo(s)", updatableRepos))
@@ -475,8 +432,9 @@ func runCheckFiltered(sourceDir string, opts *checkOptions) error {
 		ui.Info("%s have updates available", strings.Join(parts, " + "))
 		ui.Info("Run 'skillshare update <name>' or 'skillshare update --all'")
 	}
-
-	return nil
+	if movablePins > 0 {
+		ui.Info("%d pinned repo(s) have newer tags; run 'skillshare update <name> --latest' to move them", movablePins)
+	}
 }
 
 func warnUnknownSkillTargets(sourceDir string) {
@@ -504,6 +462,19 @@ func checkTrackedRepo(name, repoPath string) checkRepoResult {
 		return result
 	}
 
+	// Pinned repos don't follow a branch; compare the pin with the tags
+	if pin, err := install.CheckPinnedRepo(repoPath); pin != nil {
+		if err != nil {
+			result.Status = "error"
+			result.Message = err.Error()
+			return result
+		}
+		result.Status = "pinned"
+		result.Pinned = pin.Ref
+		result.Latest = pin.Latest
+		return result
+	}
+
 	// Fetch and compare
 	behind, err := git.GetBehindCountWithAuth(repoPath)
 	if err != nil {
@@ -522,7 +493,9 @@ func checkTrackedRepo(name, repoPath string) checkRepoResult {
 	return result
 }
 
-func checkRegularSkill(name, skillPath string) checkSkillResult {
+// checkRegularSkill compares an installed skill with its remote HEAD.
+// remotes shares ls-remote results between skills from the same repository.
+func checkRegularSkill(name, skillPath string, remotes *parallel.Memo[string]) checkSkillResult {
 	result := checkSkillResult{Name: name}
 
 	meta, err := install.ReadMeta(skillPath)
@@ -537,6 +510,35 @@ func checkRegularSkill(name, skillPath string) checkSkillResult {
 		result.InstalledAt = meta.InstalledAt.Format("2006-01-02")
 	}
 
+	// OCI skills are checked against the registry's digest and tags
+	if meta.IsOCI() {
+		update, err := install.CheckOCIUpdate(meta)
+		switch {
+		case err != nil:
+			result.Status = "error"
+		case update.Changed || len(update.NewerTags) > 0:
+			result.Status = "update_available"
+			result.NewerTags = update.NewerTags
+		default:
+			result.Status = "up_to_date"
+		}
+		return result
+	}
+
+	// Archives are checked by ETag or checksum
+	if meta.IsArchive() {
+		changed, err := install.CheckArchiveUpdate(meta)
+		switch {
+		case err != nil:
+			result.Status = "error"
+		case changed:
+			result.Status = "update_available"
+		default:
+			result.Status = "up_to_date"
+		}
+		return result
+	}
+
 	// If no repo URL, it's a local source
 	if meta.RepoURL == "" {
 		result.Status = "local"
@@ -544,7 +546,9 @@ func checkRegularSkill(name, skillPath string) checkSkillResult {
 	}
 
 	// Compare with remote
-	remoteHash, err := git.GetRemoteHeadHashWithAuth(meta.RepoURL)
+	remoteHash, err := remotes.Do(parallel.RemoteKey(meta.RepoURL), func() (string, error) {
+		return git.GetRemoteHeadHashWithAuth(meta.RepoURL)
+	})
 	if err != nil {
 		result.Status = "error"
 		return result
@@ -574,8 +578,15 @@ func printCheckHelp() {
 
 Check for available updates to tracked repositories and installed skills.
 
-For tracked repos: fetches from origin and checks if behind
+For tracked repos: fetches from origin and checks if behind; repos pinned
+at a tag or commit list the newest version tag above the pin instead
 For regular skills: compares installed version with remote HEAD
+For archive skills: asks the server whether the archive changed (ETag),
+or compares its checksum; archives pinned with #sha256= never change
+For OCI skills: compares the tag's digest and lists newer version tags
+
+Items are checked in parallel, at most 4 at a time per git host. Skills
+installed from the same repository share a single remote lookup.
 
 If no names or groups are specified, all items are checked.
 If a positional name matches a group directory, it is automatically expanded.
@@ -587,6 +598,7 @@ Options:
   --group, -G <name>  Check all updatable skills in a group (repeatable)
   --project, -p       Check project-level skills (.skillshare/)
   --global, -g        Check global skills (~/.config/skillshare)
+  --jobs, -j <n>      Check up to n items at once (default: 8)
   --json              Output results as JSON
   --help, -h          Show this help
 
@@ -597,5 +609,6 @@ Examples:
   skillshare check --group frontend     Check all skills in frontend/
   skillshare check x -G backend         Mix names and groups
   skillshare check --json               Output as JSON (for CI)
+  skillshare check -j 16                Check 16 items at once
   skillshare check -p                   Check project skills`)
 }
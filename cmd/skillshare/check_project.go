@@ -18,7 +18,11 @@ func cmdCheckProject(root string, opts *checkOptions) error {
 
 	// No names and no groups → check all (existing behavior)
 	if len(opts.names) == 0 && len(opts.groups) == 0 {
-		return runCheck(sourcePath, opts.json)
+		rt, err := loadProjectRuntime(root)
+		if err != nil {
+			return err
+		}
+		return runCheck(sourcePath, opts.json, opts.jobs, projectReviewScope(root, rt))
 	}
 
 	// Filtered check
@@ -10,7 +10,9 @@ import (
 	"skillshare/internal/config"
 	"skillshare/internal/oplog"
 	"skillshare/internal/sync"
+	"skillshare/internal/trash"
 	"skillshare/internal/ui"
+	"skillshare/internal/undo"
 )
 
 // collectLocalSkills collects local skills from targets (non-symlinked)
@@ -34,7 +36,11 @@ func collectLocalSkills(targets map[string]config.TargetConfig, source string) [
 func displayLocalSkills(skills []sync.LocalSkillInfo) {
 	ui.Header(ui.WithModeLabel("Local skills found"))
 	for _, skill := range skills {
-		ui.ListItem("info", skill.Name, fmt.Sprintf("[%s] %s", skill.TargetName, skill.Path))
+		detail := fmt.Sprintf("[%s] %s", skill.TargetName, skill.Path)
+		if skill.Modified {
+			detail += " (edited since sync)"
+		}
+		ui.ListItem("info", skill.Name, detail)
 	}
 }
 
@@ -61,14 +67,22 @@ func cmdCollect(args []string) error {
 
 	applyModeLabel(mode)
 
+	unlock, err := lockOperation("collect", mode, cwd, rest)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	rec := beginUndoRecord("collect", mode, cwd, rest)
+
 	if mode == modeProject {
-		err := cmdCollectProject(rest, cwd)
-		logCollectOp(config.ProjectConfigPath(cwd), start, err)
+		err := cmdCollectProject(rest, cwd, rec)
+		logCollectOp(rec, config.ProjectConfigPath(cwd), start, err)
 		return err
 	}
 
 	dryRun := false
 	force := false
+	merge := false
 	collectAll := false
 	var targetName string
 
@@ -78,6 +92,8 @@ func cmdCollect(args []string) error {
 			dryRun = true
 		case "--force", "-f":
 			force = true
+		case "--merge", "-m":
+			merge = true
 		case "--all", "-a":
 			collectAll = true
 		default:
@@ -126,16 +142,25 @@ func cmdCollect(args []string) error {
 	}
 
 	// Execute collect
-	err = executeCollect(allLocalSkills, cfg.Source, dryRun, force)
-	logCollectOp(config.ConfigPath(), start, err)
+	scope := &collectScope{
+		source:      cfg.Source,
+		cfgPath:     config.ConfigPath(),
+		trashDir:    trash.TrashDir(),
+		targets:     targets,
+		defaultMode: cfg.Mode,
+		rec:         rec,
+	}
+	err = executeCollect(allLocalSkills, scope, force, merge)
+	logCollectOp(rec, config.ConfigPath(), start, err)
 	return err
 }
 
-func logCollectOp(cfgPath string, start time.Time, cmdErr error) {
+func logCollectOp(rec *undo.Recorder, cfgPath string, start time.Time, cmdErr error) {
 	e := oplog.NewEntry("collect", statusFromErr(cmdErr), time.Since(start))
 	if cmdErr != nil {
 		e.Message = cmdErr.Error()
 	}
+	commitUndoRecord(rec, &e)
 	oplog.Write(cfgPath, oplog.OpsFile, e) //nolint:errcheck
 }
 
@@ -169,12 +194,11 @@ func confirmCollect() bool {
 	return input == "y" || input == "yes"
 }
 
-func executeCollect(skills []sync.LocalSkillInfo, source string, dryRun, force bool) error {
+func executeCollect(skills []sync.LocalSkillInfo, scope *collectScope, force, merge bool) error {
 	ui.Header(ui.WithModeLabel("Collecting skills"))
-	result, err := sync.PullSkills(skills, source, sync.PullOptions{
-		DryRun: dryRun,
-		Force:  force,
-	})
+	fresh, conflicts := splitCollectConflicts(skills, scope.source)
+
+	result, err := sync.PullSkills(fresh, scope.source, sync.PullOptions{})
 	if err != nil {
 		return err
 	}
@@ -183,15 +207,14 @@ func executeCollect(skills []sync.LocalSkillInfo, source string, dryRun, force b
 	for _, name := range result.Pulled {
 		ui.Success("%s: copied to source", name)
 	}
-	for _, name := range result.Skipped {
-		ui.Warning("%s: skipped (already exists in source, use --force to overwrite)", name)
-	}
 	for name, err := range result.Failed {
 		ui.Error("%s: %v", name, err)
 	}
 
-	if len(result.Pulled) > 0 {
-		showCollectNextSteps(source)
+	resolved := resolveCollectConflicts(conflicts, scope, merge, force)
+
+	if len(result.Pulled) > 0 || resolved.Merged+resolved.Taken > 0 {
+		showCollectNextSteps(scope.source)
 	}
 
 	return nil
@@ -0,0 +1,302 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/AlecAivazis/survey/v2"
+
+	"skillshare/internal/config"
+	"skillshare/internal/sync"
+	"skillshare/internal/syncbase"
+	"skillshare/internal/trash"
+	"skillshare/internal/ui"
+	"skillshare/internal/undo"
+)
+
+// maxCollectDiffLines caps the diff shown per skill before prompting.
+const maxCollectDiffLines = 40
+
+// Resolutions for a local skill that also exists in the source.
+const (
+	collectMerge      = "merge"       // three-way merge into the source
+	collectKeepSource = "keep-source" // discard the local changes
+	collectTakeLocal  = "take-local"  // overwrite the source with the local copy
+)
+
+// collectScope describes where collect reads from and writes to.
+type collectScope struct {
+	source      string
+	cfgPath     string
+	trashDir    string
+	targets     map[string]config.TargetConfig
+	defaultMode string
+	rec         *undo.Recorder // records replaced source skills for undo
+}
+
+// collectConflict is a local skill whose name matches a source skill.
+type collectConflict struct {
+	local  sync.LocalSkillInfo
+	source sync.DiscoveredSkill
+}
+
+// collectResolveStats counts how same-name skills were resolved.
+type collectResolveStats struct {
+	Merged    int
+	Kept      int
+	Taken     int
+	Conflicts int
+	Skipped   int
+}
+
+// splitCollectConflicts separates local skills that are new to the source
+// from those whose flat name already exists there.
+func splitCollectConflicts(skills []sync.LocalSkillInfo, source string) ([]sync.LocalSkillInfo, []collectConflict) {
+	discovered, _ := sync.DiscoverSourceSkills(source)
+	byFlat := make(map[string]sync.DiscoveredSkill, len(discovered))
+	for _, d := range discovered {
+		byFlat[d.FlatName] = d
+	}
+
+	var fresh []sync.LocalSkillInfo
+	var conflicts []collectConflict
+	for _, s := range skills {
+		if d, ok := byFlat[s.Name]; ok {
+			conflicts = append(conflicts, collectConflict{local: s, source: d})
+			continue
+		}
+		fresh = append(fresh, s)
+	}
+	return fresh, conflicts
+}
+
+// resolveCollectConflicts merges, keeps or takes each same-name skill and
+// relinks the target so the local copy is managed by sync again.
+func resolveCollectConflicts(conflicts []collectConflict, scope *collectScope, merge, force bool) collectResolveStats {
+	var stats collectResolveStats
+	store := syncbase.Open(syncbase.Dir(scope.cfgPath))
+	interactive := ui.IsTTY() && !merge && !force
+
+	for _, c := range conflicts {
+		name, target := c.local.Name, c.local.TargetName
+		action, conflictCount, err := resolveCollectConflict(c, scope, store, interactive, merge, force)
+		if err != nil {
+			ui.Error("%s: %v", name, err)
+			stats.Skipped++
+			continue
+		}
+
+		switch action {
+		case "":
+			stats.Skipped++
+		case collectMerge:
+			stats.Merged++
+			if conflictCount > 0 {
+				stats.Conflicts += conflictCount
+				ui.Warning("%s: merged with %d conflict(s); resolve the markers in %s", name, conflictCount, c.source.SourcePath)
+			} else {
+				ui.Success("%s: merged into source and relinked in %s", name, target)
+			}
+		case collectTakeLocal:
+			stats.Taken++
+			ui.Success("%s: copied to source from %s (replaced source version) and relinked", name, target)
+		default:
+			stats.Kept++
+			ui.Success("%s: kept source version, relinked in %s", name, target)
+		}
+	}
+	return stats
+}
+
+// resolveCollectConflict decides and applies the resolution for one
+// same-name skill. It returns the applied action ("" when skipped) and the
+// number of files left with conflict markers.
+func resolveCollectConflict(c collectConflict, scope *collectScope, store *syncbase.Store, interactive, merge, force bool) (string, int, error) {
+	name, target := c.local.Name, c.local.TargetName
+	diffs, err := syncbase.Compare(c.source.SourcePath, c.local.Path)
+	if err != nil {
+		return "", 0, err
+	}
+	if len(diffs) == 0 {
+		// Identical content: only the link is missing.
+		return collectKeepSource, 0, relinkCollected(c, scope)
+	}
+
+	preview, err := os.MkdirTemp("", "skillshare-collect-")
+	if err != nil {
+		return "", 0, err
+	}
+	defer os.RemoveAll(preview)
+
+	basePath := store.Path(name)
+	merged := filepath.Join(preview, "merged")
+	result, err := syncbase.MergeDirs(basePath, c.source.SourcePath, c.local.Path, merged, target)
+	if err != nil {
+		return "", 0, fmt.Errorf("merge failed: %w", err)
+	}
+
+	var action string
+	switch {
+	case interactive:
+		printCollectConflict(c, diffs, basePath != "")
+		action = promptCollectResolution(name, result.Conflicts)
+	case merge && (result.Conflicts == 0 || force):
+		action = collectMerge
+	case merge:
+		ui.Warning("%s: %d conflict(s) in the merge, skipped (resolve interactively or use --merge --force)", name, result.Conflicts)
+	case force:
+		action = collectTakeLocal
+	default:
+		ui.Warning("%s: skipped (already exists in source, use --merge or --force)", name)
+	}
+
+	switch action {
+	case "":
+		return "", 0, nil
+	case collectMerge:
+		err = replaceSourceSkill(c.source.SourcePath, merged, scope.rec)
+	case collectTakeLocal:
+		// Merging against the source itself as base takes every local
+		// change while keeping the source's install metadata.
+		taken := filepath.Join(preview, "local")
+		if _, err = syncbase.MergeDirs(c.source.SourcePath, c.source.SourcePath, c.local.Path, taken, target); err == nil {
+			err = replaceSourceSkill(c.source.SourcePath, taken, scope.rec)
+		}
+	}
+	if err == nil {
+		err = relinkCollected(c, scope)
+	}
+	if err != nil {
+		return "", 0, err
+	}
+	store.Record([]sync.DiscoveredSkill{c.source}, false) //nolint:errcheck
+
+	if action == collectMerge {
+		return action, result.Conflicts, nil
+	}
+	return action, 0, nil
+}
+
+// replaceSourceSkill swaps the content of a source skill for dir,
+// preserving the old content in rec first.
+func replaceSourceSkill(skillPath, dir string, rec *undo.Recorder) error {
+	rec.Preserve(skillPath)
+
+	staging := filepath.Join(filepath.Dir(skillPath), ".collect-"+filepath.Base(skillPath))
+	os.RemoveAll(staging)
+	if err := copyDir(dir, staging); err != nil {
+		os.RemoveAll(staging)
+		return fmt.Errorf("failed to stage merge result: %w", err)
+	}
+	old := staging + ".old"
+	os.RemoveAll(old)
+	if err := os.Rename(skillPath, old); err != nil {
+		os.RemoveAll(staging)
+		return fmt.Errorf("failed to replace source skill: %w", err)
+	}
+	if err := os.Rename(staging, skillPath); err != nil {
+		os.Rename(old, skillPath) //nolint:errcheck
+		return fmt.Errorf("failed to replace source skill: %w", err)
+	}
+	return os.RemoveAll(old)
+}
+
+// relinkCollected moves the local copy to trash and resyncs its target so
+// the skill is a managed link (or copy) again.
+func relinkCollected(c collectConflict, scope *collectScope) error {
+	if _, err := trash.MoveToTrash(c.local.Path, c.local.Name, scope.trashDir); err != nil {
+		return fmt.Errorf("failed to move local copy to trash: %w", err)
+	}
+
+	name := c.local.TargetName
+	target, ok := scope.targets[name]
+	if !ok {
+		return nil
+	}
+	mode := target.Mode
+	if mode == "" {
+		mode = scope.defaultMode
+	}
+	var err error
+	if mode == "copy" {
+		_, err = sync.SyncTargetCopy(name, target, scope.source, false, false)
+	} else {
+		_, err = sync.SyncTargetMerge(name, target, scope.source, false, false)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to resync %s: %w", name, err)
+	}
+	return nil
+}
+
+func printCollectConflict(c collectConflict, diffs []syncbase.FileResult, hasBase bool) {
+	fmt.Println()
+	fmt.Printf("%s%s%s [%s] differs from source (%s)\n", ui.Cyan, c.local.Name, ui.Reset, c.local.TargetName, c.source.RelPath)
+	if hasBase {
+		fmt.Printf("  %sbase: version from the last sync%s\n", ui.Gray, ui.Reset)
+	} else {
+		fmt.Printf("  %sno base recorded (never synced since upgrade); differing lines will conflict%s\n", ui.Gray, ui.Reset)
+	}
+	for _, d := range diffs {
+		fmt.Printf("  %-12s %s\n", d.Status, d.Path)
+	}
+
+	shown := 0
+	for _, d := range diffs {
+		if d.Status != "modified" || shown >= maxCollectDiffLines {
+			continue
+		}
+		a, _ := os.ReadFile(filepath.Join(c.source.SourcePath, filepath.FromSlash(d.Path)))
+		b, _ := os.ReadFile(filepath.Join(c.local.Path, filepath.FromSlash(d.Path)))
+		if strings.IndexByte(string(a), 0) >= 0 || strings.IndexByte(string(b), 0) >= 0 {
+			continue
+		}
+		fmt.Printf("\n  %s--- source/%s  +++ %s/%s%s\n", ui.Gray, d.Path, c.local.TargetName, d.Path, ui.Reset)
+		for _, line := range syncbase.Diff(string(a), string(b)) {
+			if shown >= maxCollectDiffLines {
+				fmt.Printf("  %s…%s\n", ui.Gray, ui.Reset)
+				break
+			}
+			color := ui.Green
+			if strings.HasPrefix(line, "-") {
+				color = ui.Red
+			}
+			fmt.Printf("  %s%s%s\n", color, line, ui.Reset)
+			shown++
+		}
+	}
+	fmt.Println()
+}
+
+// promptCollectResolution asks how to resolve one same-name skill. It
+// returns "" to skip.
+func promptCollectResolution(name string, conflicts int) string {
+	mergeLabel := "Merge both versions into source"
+	if conflicts > 0 {
+		mergeLabel = fmt.Sprintf("Merge into source (%d conflict(s), markers written)", conflicts)
+	}
+	options := []string{
+		mergeLabel,
+		"Keep source version (discard local changes)",
+		"Take local version (overwrite source)",
+		"Skip",
+	}
+	actions := []string{collectMerge, collectKeepSource, collectTakeLocal, ""}
+
+	var idx int
+	prompt := &survey.Select{
+		Message:  fmt.Sprintf("Resolve %s:", name),
+		Options:  options,
+		PageSize: len(options),
+	}
+	err := survey.AskOne(prompt, &idx, survey.WithIcons(func(icons *survey.IconSet) {
+		icons.SelectFocus.Text = "▸"
+		icons.SelectFocus.Format = "yellow"
+	}))
+	if err != nil {
+		return ""
+	}
+	return actions[idx]
+}
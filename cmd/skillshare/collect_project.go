@@ -5,12 +5,15 @@ import (
 	"strings"
 
 	"skillshare/internal/config"
+	"skillshare/internal/trash"
 	"skillshare/internal/ui"
+	"skillshare/internal/undo"
 )
 
-func cmdCollectProject(args []string, root string) error {
+func cmdCollectProject(args []string, root string, rec *undo.Recorder) error {
 	dryRun := false
 	force := false
+	merge := false
 	collectAll := false
 	var targetName string
 
@@ -20,6 +23,8 @@ func cmdCollectProject(args []string, root string) error {
 			dryRun = true
 		case "--force", "-f":
 			force = true
+		case "--merge", "-m":
+			merge = true
 		case "--all", "-a":
 			collectAll = true
 		default:
@@ -63,7 +68,15 @@ func cmdCollectProject(args []string, root string) error {
 		}
 	}
 
-	return executeCollect(allLocalSkills, runtime.sourcePath, dryRun, force)
+	scope := &collectScope{
+		source:      runtime.sourcePath,
+		cfgPath:     config.ProjectConfigPath(root),
+		trashDir:    trash.ProjectTrashDir(root),
+		targets:     targets,
+		defaultMode: "merge",
+		rec:         rec,
+	}
+	return executeCollect(allLocalSkills, scope, force, merge)
 }
 
 func selectCollectProjectTargets(runtime *projectRuntime, targetName string, collectAll bool) (map[string]config.TargetConfig, error) {
@@ -0,0 +1,375 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"os/signal"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"syscall"
+	"time"
+
+	"skillshare/internal/backup"
+	"skillshare/internal/config"
+	"skillshare/internal/daemon"
+	"skillshare/internal/oplog"
+	"skillshare/internal/trash"
+	"skillshare/internal/ui"
+)
+
+func cmdDaemon(args []string) error {
+	mode, rest, err := parseModeArgs(args)
+	if err != nil {
+		return err
+	}
+	if mode == modeProject {
+		return fmt.Errorf("daemon runs in global mode only")
+	}
+
+	sub := "run"
+	if len(rest) > 0 && !strings.HasPrefix(rest[0], "-") {
+		sub = rest[0]
+		rest = rest[1:]
+	}
+
+	switch sub {
+	case "run":
+		if len(rest) > 0 {
+			if rest[0] == "--help" || rest[0] == "-h" {
+				printDaemonHelp()
+				return nil
+			}
+			return fmt.Errorf("unknown option: %s", rest[0])
+		}
+		return daemonRun()
+	case "once":
+		return daemonOnce()
+	case "status":
+		return daemonStatus()
+	case "stop":
+		pid, err := daemon.Stop()
+		if err != nil {
+			return err
+		}
+		ui.Success("Stopped daemon (pid %d)", pid)
+		return nil
+	case "systemd":
+		return daemonSystemd(rest)
+	case "help":
+		printDaemonHelp()
+		return nil
+	default:
+		return fmt.Errorf("unknown daemon subcommand: %s", sub)
+	}
+}
+
+// daemonRun runs the scheduler in the foreground until interrupted.
+func daemonRun() error {
+	sched, err := newDaemonScheduler()
+	if err != nil {
+		return err
+	}
+
+	if err := daemon.Lock(); err != nil {
+		return err
+	}
+	defer daemon.Unlock()
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	ui.Header("skillshare daemon")
+	for _, t := range sched.State().Tasks {
+		ui.Info("%-8s every %s", t.Name, t.Interval)
+	}
+	fmt.Println()
+
+	return sched.Run(ctx, os.Getpid())
+}
+
+// daemonOnce runs every enabled task immediately and exits (for cron).
+func daemonOnce() error {
+	sched, err := newDaemonScheduler()
+	if err != nil {
+		return err
+	}
+
+	if err := daemon.Lock(); err != nil {
+		return err
+	}
+	defer daemon.Unlock()
+
+	sched.RunOnce(context.Background())
+	failed := 0
+	for _, t := range sched.State().Tasks {
+		if t.LastStatus == "error" {
+			failed++
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d daemon task(s) failed", failed)
+	}
+	return nil
+}
+
+// startBackgroundDaemon runs the scheduler inside another long-running
+// process (ui --daemon). The returned function stops it.
+func startBackgroundDaemon() (func(), error) {
+	sched, err := newDaemonScheduler()
+	if err != nil {
+		return nil, err
+	}
+	if err := daemon.Lock(); err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		sched.Run(ctx, os.Getpid()) //nolint:errcheck
+	}()
+
+	return func() {
+		cancel()
+		<-done
+		daemon.Unlock()
+	}, nil
+}
+
+func newDaemonScheduler() (*daemon.Scheduler, error) {
+	cfg, err := config.Load()
+	if err != nil {
+		return nil, err
+	}
+	tasks, err := buildDaemonTasks(cfg.Daemon)
+	if err != nil {
+		return nil, err
+	}
+	if len(tasks) == 0 {
+		return nil, fmt.Errorf("no daemon tasks configured (add a 'daemon:' section to %s)", config.ConfigPath())
+	}
+	return daemon.NewScheduler(tasks, logDaemonRun), nil
+}
+
+// buildDaemonTasks turns the configured intervals into scheduler tasks.
+// Order matters when several are due at once: pull before sync, sync
+// before check and audit, cleanup last.
+func buildDaemonTasks(dc config.DaemonConfig) ([]daemon.Task, error) {
+	specs := []struct {
+		name     string
+		interval string
+		run      func(ctx context.Context) (string, error)
+	}{
+		{"pull", dc.Pull, daemonCLITask("pull")},
+		{"sync", dc.Sync, daemonCLITask("sync", "--global")},
+		{"check", dc.Check, daemonCLITask("check", "--global")},
+		{"audit", dc.Audit, daemonCLITask("audit", "--global")},
+		{"cleanup", dc.Cleanup, daemonCleanup},
+	}
+
+	var tasks []daemon.Task
+	for _, s := range specs {
+		d, err := daemon.ParseInterval(s.interval)
+		if err != nil {
+			return nil, fmt.Errorf("daemon.%s: %w", s.name, err)
+		}
+		if d == 0 {
+			continue
+		}
+		tasks = append(tasks, daemon.Task{Name: s.name, Interval: d, Run: s.run})
+	}
+	return tasks, nil
+}
+
+var ansiPattern = regexp.MustCompile(`\x1b\[[0-9;]*m`)
+
+// daemonCLITask runs a skillshare command as a child process, so it gets
+// the exact CLI behavior (including its own oplog entry). Stdin is empty,
+// so any confirmation prompt is declined.
+func daemonCLITask(args ...string) func(ctx context.Context) (string, error) {
+	return func(ctx context.Context) (string, error) {
+		exe, err := os.Executable()
+		if err != nil {
+			return "", err
+		}
+		cmd := exec.CommandContext(ctx, exe, args...)
+		var out bytes.Buffer
+		cmd.Stdout = &out
+		cmd.Stderr = &out
+		runErr := cmd.Run()
+
+		summary := lastOutputLine(out.String())
+		if runErr != nil {
+			if summary != "" {
+				return "", fmt.Errorf("%s", summary)
+			}
+			return "", runErr
+		}
+		return summary, nil
+	}
+}
+
+// lastOutputLine returns the last non-empty line of command output
+// without color codes, as a one-line summary.
+func lastOutputLine(out string) string {
+	lines := strings.Split(strings.TrimSpace(ansiPattern.ReplaceAllString(out, "")), "\n")
+	for i := len(lines) - 1; i >= 0; i-- {
+		line := strings.TrimSpace(lines[i])
+		if line != "" && strings.Trim(line, "─") != "" {
+			return line
+		}
+	}
+	return ""
+}
+
+// daemonCleanup removes expired trash items and prunes old backups.
+func daemonCleanup(_ context.Context) (string, error) {
+	unlock, err := acquireOpLock(config.ConfigPath(), "daemon cleanup")
+	if err != nil {
+		return "", err
+	}
+	defer unlock()
+
+	trashed, err := trash.Cleanup(trash.TrashDir(), 0)
+	if err != nil {
+		return "", err
+	}
+	backups, err := backup.Cleanup(backup.DefaultCleanupConfig())
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("removed %d trash item(s), %d backup(s)", trashed, backups), nil
+}
+
+func logDaemonRun(task string, st daemon.TaskStatus, d time.Duration) {
+	e := oplog.NewEntry("daemon", st.LastStatus, d)
+	e.Args = map[string]any{"task": task}
+	e.Message = st.LastMessage
+	oplog.Write(config.ConfigPath(), oplog.OpsFile, e) //nolint:errcheck
+
+	ts := time.Now().Format("15:04:05")
+	if st.LastStatus == "error" {
+		ui.Error("%s %s: %s", ts, task, st.LastMessage)
+	} else {
+		ui.Success("%s %s: %s", ts, task, st.LastMessage)
+	}
+}
+
+func daemonStatus() error {
+	pid, running := daemon.RunningPID()
+	st, err := daemon.ReadState()
+	if err != nil {
+		return err
+	}
+
+	ui.Header("Daemon")
+	if running {
+		ui.Success("Running (pid %d)", pid)
+	} else {
+		ui.Info("Not running")
+	}
+	if st == nil || len(st.Tasks) == 0 {
+		return nil
+	}
+
+	fmt.Println()
+	now := time.Now()
+	for _, t := range st.Tasks {
+		last := "never"
+		if !t.LastRun.IsZero() {
+			last = formatAge(now.Sub(t.LastRun)) + " ago"
+		}
+		next := "-"
+		if running && !t.NextRun.IsZero() {
+			if t.NextRun.After(now) {
+				next = "in " + formatAge(t.NextRun.Sub(now))
+			} else {
+				next = "due"
+			}
+		}
+		if running && t.Running {
+			next = "running"
+		}
+		status := t.LastStatus
+		if status == "" {
+			status = "-"
+		}
+		fmt.Printf("  %-8s every %-5s  last %-10s %-6s next %s\n", t.Name, t.Interval, last, status, next)
+		if t.LastMessage != "" {
+			fmt.Printf("           %s%s%s\n", ui.Gray, t.LastMessage, ui.Reset)
+		}
+	}
+	return nil
+}
+
+func daemonSystemd(args []string) error {
+	write := false
+	for _, a := range args {
+		switch a {
+		case "--write", "-w":
+			write = true
+		default:
+			return fmt.Errorf("unknown option: %s", a)
+		}
+	}
+
+	exe, err := os.Executable()
+	if err != nil {
+		return err
+	}
+	if resolved, err := filepath.EvalSymlinks(exe); err == nil {
+		exe = resolved
+	}
+	unit := daemon.SystemdUnit(exe)
+
+	if !write {
+		fmt.Print(unit)
+		return nil
+	}
+
+	path := daemon.SystemdUnitPath()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, []byte(unit), 0644); err != nil {
+		return err
+	}
+	ui.Success("Wrote %s", path)
+	ui.Info("Enable with: systemctl --user daemon-reload && systemctl --user enable --now %s", daemon.SystemdUnitName)
+	return nil
+}
+
+func printDaemonHelp() {
+	fmt.Println(`Usage: skillshare daemon [run|once|status|stop|systemd] [options]
+
+Run periodic maintenance in the background: update checks, pull, sync,
+audit re-scans and cleanup of trash and old backups. Global mode only.
+
+Schedule in config.yaml (omit a task to disable it):
+  daemon:
+    pull: 1h          # git pull + sync
+    sync: 30m         # sync source to targets
+    check: 6h         # check for skill updates
+    audit: 1d         # re-scan skills with current rules
+    cleanup: 1d       # expired trash + backup pruning
+
+Subcommands:
+  run                 Run the scheduler in the foreground (default)
+  once                Run every configured task now, then exit
+  status              Show schedule, last run and next run per task
+  stop                Stop the running daemon
+  systemd [--write]   Print (or install) a systemd user unit
+
+Each run is recorded in the operation log ('skillshare log').
+'skillshare ui --daemon' runs the scheduler inside the web UI server.
+
+Examples:
+  skillshare daemon                  # Foreground
+  skillshare daemon status
+  skillshare daemon systemd --write  # ~/.config/systemd/user/skillshare-daemon.service`)
+}
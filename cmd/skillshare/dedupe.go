@@ -0,0 +1,553 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/AlecAivazis/survey/v2"
+
+	"skillshare/internal/config"
+	"skillshare/internal/dedupe"
+	"skillshare/internal/oplog"
+	"skillshare/internal/sync"
+	"skillshare/internal/trash"
+	"skillshare/internal/ui"
+	"skillshare/internal/undo"
+	"skillshare/internal/utils"
+)
+
+// diffColumnWidth is the width of each side of the side-by-side diff.
+const diffColumnWidth = 36
+
+type dedupeOptions struct {
+	action    string // "", keep, merge, ignore, unignore
+	names     []string
+	threshold float64
+	list      bool
+	all       bool
+	json      bool
+	dryRun    bool
+}
+
+// dedupeScope holds the paths of the mode dedupe runs in.
+type dedupeScope struct {
+	mode       runMode
+	cwd        string
+	sourcePath string
+	cfgPath    string
+	trashDir   string
+	rec        *undo.Recorder // set once the operation is locked
+}
+
+func cmdDedupe(args []string) error {
+	start := time.Now()
+
+	mode, rest, err := parseModeArgs(args)
+	if err != nil {
+		return err
+	}
+
+	opts, showHelp, err := parseDedupeArgs(rest)
+	if showHelp {
+		printDedupeHelp()
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	cwd, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("cannot determine working directory: %w", err)
+	}
+	if mode == modeAuto {
+		if projectConfigExists(cwd) {
+			mode = modeProject
+		} else {
+			mode = modeGlobal
+		}
+	}
+	applyModeLabel(mode)
+
+	scope := &dedupeScope{mode: mode, cwd: cwd}
+	if mode == modeProject {
+		rt, err := loadProjectRuntime(cwd)
+		if err != nil {
+			return err
+		}
+		scope.sourcePath = rt.sourcePath
+		scope.cfgPath = config.ProjectConfigPath(cwd)
+		scope.trashDir = trash.ProjectTrashDir(cwd)
+	} else {
+		cfg, err := config.Load()
+		if err != nil {
+			return err
+		}
+		scope.sourcePath = cfg.Source
+		scope.cfgPath = config.ConfigPath()
+		scope.trashDir = trash.TrashDir()
+	}
+
+	decisions, err := dedupe.LoadDecisions(dedupe.DecisionsPath(scope.cfgPath))
+	if err != nil {
+		return fmt.Errorf("failed to read dedupe decisions: %w", err)
+	}
+
+	if opts.action != "" {
+		return runDedupeAction(scope, decisions, opts, rest, start)
+	}
+
+	discovered, err := sync.DiscoverSourceSkills(scope.sourcePath)
+	if err != nil {
+		return fmt.Errorf("failed to discover skills: %w", err)
+	}
+	skip := decisions.Ignored
+	if opts.all {
+		skip = nil
+	}
+	pairs := dedupe.Find(dedupe.Load(discovered), opts.threshold, skip)
+
+	if opts.json {
+		if pairs == nil {
+			pairs = []dedupe.Pair{}
+		}
+		out, _ := json.MarshalIndent(map[string]any{"pairs": pairs}, "", "  ")
+		fmt.Println(string(out))
+		return nil
+	}
+
+	ui.HeaderBox("skillshare dedupe",
+		fmt.Sprintf("Comparing %d skills\nmode: %s\npath: %s", len(discovered), modeString(mode), scope.sourcePath))
+	fmt.Println()
+
+	if len(pairs) == 0 {
+		ui.Success("No duplicate skills found")
+		return nil
+	}
+
+	if opts.list || opts.dryRun || !ui.IsTTY() {
+		printDuplicatePairs(pairs)
+		fmt.Println()
+		ui.Info("Resolve with: skillshare dedupe keep|merge <keep> <drop>, or dedupe ignore <a> <b>")
+		return nil
+	}
+
+	return resolveDuplicatesInteractive(scope, decisions, pairs, rest, start)
+}
+
+func parseDedupeArgs(args []string) (dedupeOptions, bool, error) {
+	opts := dedupeOptions{threshold: dedupe.DefaultThreshold}
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		switch {
+		case arg == "--help" || arg == "-h":
+			return opts, true, nil
+		case arg == "--list" || arg == "-l":
+			opts.list = true
+		case arg == "--all" || arg == "-a":
+			opts.all = true
+		case arg == "--json":
+			opts.json = true
+		case arg == "--dry-run" || arg == "-n":
+			opts.dryRun = true
+		case arg == "--threshold" || strings.HasPrefix(arg, "--threshold="):
+			val, ok := strings.CutPrefix(arg, "--threshold=")
+			if !ok {
+				if i+1 >= len(args) {
+					return opts, false, fmt.Errorf("--threshold requires a value between 0 and 1")
+				}
+				i++
+				val = args[i]
+			}
+			f, err := strconv.ParseFloat(val, 64)
+			if err != nil || f <= 0 || f > 1 {
+				return opts, false, fmt.Errorf("invalid --threshold %q (use a value between 0 and 1, e.g. 0.8)", val)
+			}
+			opts.threshold = f
+		case strings.HasPrefix(arg, "-"):
+			return opts, false, fmt.Errorf("unknown option: %s", arg)
+		case opts.action == "" && len(opts.names) == 0:
+			switch arg {
+			case dedupe.ActionKeep, dedupe.ActionMerge, dedupe.ActionIgnore, "unignore":
+				opts.action = arg
+			default:
+				return opts, false, fmt.Errorf("unknown action: %s (use keep, merge, ignore or unignore)", arg)
+			}
+		default:
+			opts.names = append(opts.names, strings.Trim(filepath.ToSlash(arg), "/"))
+		}
+	}
+	if opts.action != "" && len(opts.names) != 2 {
+		return opts, false, fmt.Errorf("dedupe %s requires two skill names", opts.action)
+	}
+	return opts, false, nil
+}
+
+// runDedupeAction applies a decision given on the command line.
+func runDedupeAction(scope *dedupeScope, decisions *dedupe.Decisions, opts dedupeOptions, args []string, start time.Time) error {
+	a, b := opts.names[0], opts.names[1]
+	if a == b {
+		return fmt.Errorf("cannot compare a skill with itself")
+	}
+
+	switch opts.action {
+	case "unignore":
+		if !decisions.Forget(a, b) {
+			return fmt.Errorf("no decision recorded for %s and %s", a, b)
+		}
+		if err := decisions.Save(); err != nil {
+			return err
+		}
+		ui.Success("%s and %s will be reported again", a, b)
+		return nil
+	case dedupe.ActionIgnore:
+		decisions.Record(dedupe.Decision{A: a, B: b, Action: dedupe.ActionIgnore})
+		if err := decisions.Save(); err != nil {
+			return err
+		}
+		ui.Success("Ignoring %s and %s from now on", a, b)
+		return nil
+	}
+
+	for _, name := range opts.names {
+		if _, err := os.Stat(filepath.Join(scope.sourcePath, filepath.FromSlash(name), "SKILL.md")); err != nil {
+			return fmt.Errorf("skill '%s' not found in %s", name, scope.sourcePath)
+		}
+	}
+
+	dec := dedupe.Decision{A: a, B: b, Action: opts.action, Kept: a, Dropped: b}
+	if opts.dryRun {
+		verb := "trash"
+		if opts.action == dedupe.ActionMerge {
+			verb = "merge into " + a + ", then trash"
+		}
+		ui.Info("Would keep %s and %s %s", a, verb, b)
+		return nil
+	}
+
+	unlock, err := lockOperation("dedupe", scope.mode, scope.cwd, args)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	scope.rec = beginUndoRecord("dedupe", scope.mode, scope.cwd, args)
+
+	err = applyDedupeDecision(scope, decisions, dec)
+	logDedupeOp(scope.rec, scope.cfgPath, []dedupe.Decision{dec}, start, err)
+	if err != nil {
+		return err
+	}
+	fmt.Println()
+	ui.Info("Run 'skillshare sync' to update all targets")
+	return nil
+}
+
+func resolveDuplicatesInteractive(scope *dedupeScope, decisions *dedupe.Decisions, pairs []dedupe.Pair, args []string, start time.Time) error {
+	unlock, err := lockOperation("dedupe", scope.mode, scope.cwd, args)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	scope.rec = beginUndoRecord("dedupe", scope.mode, scope.cwd, args)
+
+	var applied []dedupe.Decision
+	gone := map[string]bool{}
+	var runErr error
+	for i, p := range pairs {
+		if gone[p.A] || gone[p.B] {
+			continue
+		}
+		fmt.Printf("%s[%d/%d]%s ", ui.Gray, i+1, len(pairs), ui.Reset)
+		printDuplicatePair(scope.sourcePath, p)
+
+		dec, ok := promptDuplicateResolution(p)
+		if !ok {
+			fmt.Println()
+			continue
+		}
+		if err := applyDedupeDecision(scope, decisions, dec); err != nil {
+			ui.Warning("%v", err)
+			runErr = err
+			fmt.Println()
+			continue
+		}
+		applied = append(applied, dec)
+		if dec.Dropped != "" {
+			gone[dec.Dropped] = true
+		}
+		fmt.Println()
+	}
+
+	if len(applied) > 0 {
+		logDedupeOp(scope.rec, scope.cfgPath, applied, start, runErr)
+		ui.Info("Run 'skillshare sync' to update all targets")
+	}
+	return nil
+}
+
+// applyDedupeDecision executes dec and records it.
+func applyDedupeDecision(scope *dedupeScope, decisions *dedupe.Decisions, dec dedupe.Decision) error {
+	if dec.Action == dedupe.ActionIgnore {
+		decisions.Record(dec)
+		if err := decisions.Save(); err != nil {
+			return fmt.Errorf("failed to record decision: %w", err)
+		}
+		ui.Success("Ignoring %s and %s from now on", dec.A, dec.B)
+		return nil
+	}
+
+	if utils.IsTrackedRepoDir(strings.SplitN(dec.Dropped, "/", 2)[0]) {
+		return fmt.Errorf("%s belongs to a tracked repository; keep it instead or uninstall the repository", dec.Dropped)
+	}
+	keepPath := filepath.Join(scope.sourcePath, filepath.FromSlash(dec.Kept))
+	dropPath := filepath.Join(scope.sourcePath, filepath.FromSlash(dec.Dropped))
+
+	if dec.Action == dedupe.ActionMerge {
+		if utils.IsTrackedRepoDir(strings.SplitN(dec.Kept, "/", 2)[0]) {
+			return fmt.Errorf("cannot merge into %s: it belongs to a tracked repository", dec.Kept)
+		}
+		scope.rec.Preserve(keepPath)
+		res, err := dedupe.Merge(keepPath, dropPath)
+		if err != nil {
+			return fmt.Errorf("failed to merge %s into %s: %w", dec.Dropped, dec.Kept, err)
+		}
+		for _, f := range res.Added {
+			ui.Success("Merged %s into %s", f, dec.Kept)
+		}
+		for _, f := range res.Conflicts {
+			ui.Info("Kept %s's version of %s", dec.Kept, f)
+		}
+	}
+
+	trashPath, err := trash.MoveToTrash(dropPath, filepath.Base(dropPath), scope.trashDir)
+	if err != nil {
+		return fmt.Errorf("failed to move %s to trash: %w", dec.Dropped, err)
+	}
+	scope.rec.Trashed(dropPath, trashPath)
+	removeSkillEntry(scope, dec.Dropped)
+
+	decisions.Record(dec)
+	if err := decisions.Save(); err != nil {
+		ui.Warning("Failed to record decision: %v", err)
+	}
+	ui.Success("Kept %s, moved %s to trash", dec.Kept, dec.Dropped)
+	return nil
+}
+
+// removeSkillEntry drops a trashed skill from the config's skills list so
+// it is not reinstalled.
+func removeSkillEntry(scope *dedupeScope, name string) {
+	filter := func(skills []config.SkillEntry) ([]config.SkillEntry, bool) {
+		updated := make([]config.SkillEntry, 0, len(skills))
+		for _, s := range skills {
+			if s.FullName() != name {
+				updated = append(updated, s)
+			}
+		}
+		return updated, len(updated) != len(skills)
+	}
+
+	if scope.mode == modeProject {
+		cfg, err := config.LoadProject(scope.cwd)
+		if err != nil {
+			return
+		}
+		if updated, changed := filter(cfg.Skills); changed {
+			cfg.Skills = updated
+			if err := cfg.Save(scope.cwd); err != nil {
+				ui.Warning("Failed to update project config: %v", err)
+			}
+		}
+		return
+	}
+	cfg, err := config.Load()
+	if err != nil {
+		return
+	}
+	if updated, changed := filter(cfg.Skills); changed {
+		cfg.Skills = updated
+		if err := cfg.Save(); err != nil {
+			ui.Warning("Failed to update config: %v", err)
+		}
+	}
+}
+
+func logDedupeOp(rec *undo.Recorder, cfgPath string, decs []dedupe.Decision, start time.Time, cmdErr error) {
+	e := oplog.NewEntry("dedupe", statusFromErr(cmdErr), time.Since(start))
+	var kept, dropped []string
+	for _, d := range decs {
+		if d.Action == dedupe.ActionIgnore {
+			continue
+		}
+		kept = append(kept, d.Kept)
+		dropped = append(dropped, d.Dropped)
+	}
+	e.Args = map[string]any{"decisions": len(decs)}
+	if len(dropped) > 0 {
+		e.Args["kept"] = kept
+		e.Args["dropped"] = dropped
+	}
+	if cmdErr != nil {
+		e.Message = cmdErr.Error()
+	}
+	commitUndoRecord(rec, &e)
+	oplog.Write(cfgPath, oplog.OpsFile, e) //nolint:errcheck
+}
+
+func printDuplicatePairs(pairs []dedupe.Pair) {
+	for _, p := range pairs {
+		fmt.Printf("  %-8s %s%4.0f%%%s  %s  %s\n", p.Kind, ui.Gray, p.Similarity*100, ui.Reset, p.A, p.B)
+	}
+	fmt.Println()
+	ui.Warning("%d duplicate pair(s) found", len(pairs))
+}
+
+// printDuplicatePair shows which files differ and a side-by-side diff of
+// the two SKILL.md files.
+func printDuplicatePair(sourcePath string, p dedupe.Pair) {
+	pathA := filepath.Join(sourcePath, filepath.FromSlash(p.A))
+	pathB := filepath.Join(sourcePath, filepath.FromSlash(p.B))
+	fmt.Printf("%s%s%s ↔ %s%s%s  (%s, %.0f%%)\n", ui.Cyan, p.A, ui.Reset, ui.Cyan, p.B, ui.Reset, p.Kind, p.Similarity*100)
+	if p.Kind == dedupe.KindExact {
+		return
+	}
+
+	filesA, _ := dedupe.Files(pathA)
+	filesB, _ := dedupe.Files(pathB)
+	inB := map[string]bool{}
+	for _, f := range filesB {
+		inB[f] = true
+	}
+	for _, f := range filesA {
+		if !inB[f] {
+			fmt.Printf("  %sonly in %s: %s%s\n", ui.Gray, p.A, f, ui.Reset)
+		}
+		delete(inB, f)
+	}
+	for _, f := range filesB {
+		if inB[f] {
+			fmt.Printf("  %sonly in %s: %s%s\n", ui.Gray, p.B, f, ui.Reset)
+		}
+	}
+
+	a, _ := os.ReadFile(filepath.Join(pathA, "SKILL.md"))
+	b, _ := os.ReadFile(filepath.Join(pathB, "SKILL.md"))
+	rows := dedupe.SideBySide(string(a), string(b))
+	if !dedupe.Changed(rows) {
+		return
+	}
+	fmt.Printf("\n  %-*s │ %s\n", diffColumnWidth+2, p.A+"/SKILL.md", p.B+"/SKILL.md")
+	unchanged := 0
+	for _, r := range rows {
+		if r.Op == dedupe.RowSame {
+			unchanged++
+			continue
+		}
+		if unchanged > 0 {
+			fmt.Printf("  %s… %d unchanged line(s)%s\n", ui.Gray, unchanged, ui.Reset)
+			unchanged = 0
+		}
+		left, right := diffCell(r.Left), diffCell(r.Right)
+		switch r.Op {
+		case dedupe.RowLeft:
+			fmt.Printf("  %s- %s%s │\n", ui.Red, left, ui.Reset)
+		case dedupe.RowRight:
+			fmt.Printf("  %-*s │ %s+ %s%s\n", diffColumnWidth+2, "", ui.Green, right, ui.Reset)
+		default:
+			fmt.Printf("  %s~ %s%s │ %s~ %s%s\n", ui.Yellow, left, ui.Reset, ui.Yellow, right, ui.Reset)
+		}
+	}
+}
+
+// diffCell truncates or pads a line to the diff column width.
+func diffCell(s string) string {
+	s = strings.ReplaceAll(s, "\t", "  ")
+	runes := []rune(s)
+	if len(runes) > diffColumnWidth {
+		return string(runes[:diffColumnWidth-1]) + "…"
+	}
+	return s + strings.Repeat(" ", diffColumnWidth-len(runes))
+}
+
+// promptDuplicateResolution asks how to resolve one pair. ok is false when
+// the pair is skipped for now.
+func promptDuplicateResolution(p dedupe.Pair) (dedupe.Decision, bool) {
+	type choice struct {
+		label string
+		dec   dedupe.Decision
+	}
+	keep := func(action, kept, dropped string) dedupe.Decision {
+		return dedupe.Decision{A: p.A, B: p.B, Action: action, Kept: kept, Dropped: dropped}
+	}
+	var choices []choice
+	if p.Kind == dedupe.KindSimilar {
+		choices = append(choices,
+			choice{fmt.Sprintf("Merge %s into %s", p.B, p.A), keep(dedupe.ActionMerge, p.A, p.B)},
+			choice{fmt.Sprintf("Merge %s into %s", p.A, p.B), keep(dedupe.ActionMerge, p.B, p.A)},
+		)
+	}
+	choices = append(choices,
+		choice{fmt.Sprintf("Keep %s, trash %s", p.A, p.B), keep(dedupe.ActionKeep, p.A, p.B)},
+		choice{fmt.Sprintf("Keep %s, trash %s", p.B, p.A), keep(dedupe.ActionKeep, p.B, p.A)},
+		choice{"Ignore (intentional, don't report again)", dedupe.Decision{A: p.A, B: p.B, Action: dedupe.ActionIgnore}},
+		choice{"Skip for now", dedupe.Decision{}},
+	)
+
+	options := make([]string, len(choices))
+	for i, c := range choices {
+		options[i] = c.label
+	}
+	var idx int
+	prompt := &survey.Select{
+		Message:  "Resolve:",
+		Options:  options,
+		PageSize: len(options),
+	}
+	err := survey.AskOne(prompt, &idx, survey.WithIcons(func(icons *survey.IconSet) {
+		icons.SelectFocus.Text = "▸"
+		icons.SelectFocus.Format = "yellow"
+	}))
+	if err != nil || choices[idx].dec.Action == "" {
+		return dedupe.Decision{}, false
+	}
+	return choices[idx].dec, true
+}
+
+func printDedupeHelp() {
+	fmt.Println(`Usage: skillshare dedupe [options]
+       skillshare dedupe keep <keep> <drop>
+       skillshare dedupe merge <keep> <drop>
+       skillshare dedupe ignore|unignore <a> <b>
+
+Find skills in the source that are exact copies (identical files) or near
+duplicates (mostly the same description and SKILL.md body). In a terminal,
+each pair is shown with a side-by-side diff and can be resolved:
+
+  merge     Copy files only the other skill has into <keep>, then trash <drop>
+  keep      Keep <keep> and move <drop> to trash
+  ignore    The pair is intentional; don't report it again
+
+Decisions are recorded in dedupe.json (state directory, or .skillshare/
+in project mode). Trashed skills can be restored with 'skillshare undo'.
+
+Options:
+  --threshold <n>     Minimum similarity for near duplicates (default: 0.8)
+  --list, -l          Report pairs without prompting
+  --all, -a           Include ignored pairs
+  --json              Output pairs as JSON
+  --dry-run, -n       Preview without changing anything
+  --project, -p       Use project-level config in current directory
+  --global, -g        Use global config (~/.config/skillshare)
+  --help, -h          Show this help
+
+Examples:
+  skillshare dedupe
+  skillshare dedupe --threshold 0.6 --list
+  skillshare dedupe merge pdf claude-pdf
+  skillshare dedupe ignore react-native react-web`)
+}
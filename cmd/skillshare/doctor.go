@@ -14,6 +14,7 @@ import (
 
 	"skillshare/internal/backup"
 	"skillshare/internal/config"
+	"skillshare/internal/network"
 	"skillshare/internal/sync"
 	"skillshare/internal/trash"
 	"skillshare/internal/ui"
@@ -88,6 +89,7 @@ func cmdDoctorGlobal() error {
 	}
 
 	runDoctorChecks(cfg, result, false)
+	checkStaleSkills(globalReviewScope(cfg), result)
 	checkBackupStatus(false, backup.BackupDir())
 	checkTrashStatus(trash.TrashDir())
 	checkVersionDoctor(cfg)
@@ -122,6 +124,7 @@ func cmdDoctorProject(root string) error {
 	}
 
 	runDoctorChecks(cfg, result, true)
+	checkStaleSkills(projectReviewScope(root, rt), result)
 	checkBackupStatus(true, "")
 	checkTrashStatus(trash.ProjectTrashDir(root))
 	checkVersionDoctor(cfg)
@@ -634,7 +637,8 @@ func checkBackupStatus(isProject bool, backupDir string) {
 	var latest string
 	var latestTime time.Time
 	for _, entry := range entries {
-		if !entry.IsDir() {
+		// Skip the object store; timestamp dirs hold the snapshots
+		if !entry.IsDir() || strings.HasPrefix(entry.Name(), ".") {
 			continue
 		}
 		info, err := entry.Info()
@@ -752,7 +756,7 @@ func checkVersionDoctor(cfg *config.Config) {
 // checkForUpdates checks if a newer version is available
 func checkForUpdates() {
 	// Use a short timeout for version check
-	client := &http.Client{Timeout: 3 * time.Second}
+	client := network.NewClient(3 * time.Second)
 	resp, err := client.Get("https://api.github.com/repos/runkids/skillshare/releases/latest")
 	if err != nil {
 		return // Silently skip if network unavailable
@@ -0,0 +1,394 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"skillshare/internal/config"
+	"skillshare/internal/hooks"
+	"skillshare/internal/sync"
+	"skillshare/internal/ui"
+)
+
+// commandHooks runs the user and skill hooks of one command. The command
+// calls begin once it holds its operation lock; its entry point calls
+// finish after it returned and released the lock, so a hook may itself
+// call skillshare. Commands that run another command inside them (sync
+// after pull) pass their commandHooks on.
+type commandHooks struct {
+	pending *hookSession
+}
+
+type hookSession struct {
+	event    string
+	runner   *hooks.Runner
+	snapshot hooks.Snapshot
+}
+
+// begin prepares the post-* hooks for event: it resolves the scope and
+// snapshots the source, so the skills the command installs or changes can
+// be found afterwards. Skipped for dry runs and help output.
+func (h *commandHooks) begin(event string, mode runMode, cwd string, args []string) {
+	for _, a := range args {
+		switch a {
+		case "--dry-run", "-n", "--help", "-h":
+			return
+		}
+	}
+	user, ok := loadUserHooks()
+	if !ok || (event == config.HookPostSync && len(user.PostSync) == 0) {
+		return
+	}
+	scope, ok := hookScope(mode, cwd)
+	if !ok {
+		return
+	}
+	s := &hookSession{event: event, runner: newHookRunner(scope, user)}
+	if event != config.HookPostSync {
+		s.snapshot = hooks.TakeSnapshot(scope.SourceDir)
+	}
+	h.pending = s
+}
+
+// runPreInstall runs the pre-install hooks before an install starts; a
+// failing hook aborts the install.
+func (h *commandHooks) runPreInstall(args []string) error {
+	if h.pending == nil || len(h.pending.runner.Hooks.PreInstall) == 0 {
+		return nil
+	}
+	var source string
+	if parsed, _, err := parseInstallArgs(args); err == nil {
+		source = parsed.sourceArg
+	}
+	results := h.pending.runner.Run(config.HookPreInstall, map[string]string{"SKILLSHARE_INSTALL_SOURCE": source})
+	reportHookResults(results)
+	if err := hooks.FirstError(results); err != nil {
+		h.pending = nil
+		return err
+	}
+	return nil
+}
+
+// finish runs the pending post-* hooks once the command returned and
+// returns cmdErr, or the first hook error. Nothing runs if the command
+// failed.
+func (h *commandHooks) finish(cmdErr error) error {
+	s := h.pending
+	h.pending = nil
+	if s == nil || cmdErr != nil {
+		return cmdErr
+	}
+	var results []hooks.Result
+	if s.event == config.HookPostSync {
+		results = s.runner.Run(s.event, nil)
+	} else if changed := s.snapshot.Changed(s.runner.Scope.SourceDir); len(changed) > 0 {
+		results = s.runner.RunSkills(s.event, changed)
+	}
+	reportHookResults(results)
+	return hooks.FirstError(results)
+}
+
+// loadUserHooks returns the hooks from the global config.yaml. They are
+// user level: project mode runs them too, but a project config cannot add
+// any.
+func loadUserHooks() (config.HooksConfig, bool) {
+	cfg, err := config.Load()
+	if err != nil {
+		return config.HooksConfig{}, false
+	}
+	return cfg.Hooks, true
+}
+
+func hookScope(mode runMode, cwd string) (hooks.Scope, bool) {
+	if mode == modeProject {
+		return hooks.Scope{
+			Mode:       "project",
+			ConfigPath: config.ProjectConfigPath(cwd),
+			SourceDir:  filepath.Join(cwd, ".skillshare", "skills"),
+		}, true
+	}
+	cfg, err := config.Load()
+	if err != nil {
+		return hooks.Scope{}, false
+	}
+	return hooks.Scope{Mode: "global", ConfigPath: config.ConfigPath(), SourceDir: cfg.Source}, true
+}
+
+func newHookRunner(scope hooks.Scope, user config.HooksConfig) *hooks.Runner {
+	trust, err := hooks.LoadTrust(hooks.TrustPath())
+	if err != nil {
+		ui.Warning("Ignoring hook trust: %v", err)
+	}
+	return &hooks.Runner{
+		Hooks: user,
+		Scope: scope,
+		Trust: trust,
+		Out:   os.Stdout,
+		Start: func(res hooks.Result) {
+			if res.Skill != "" {
+				ui.Info("Running %s hook for %s: %s", res.Event, res.Skill, res.Command)
+				return
+			}
+			ui.Info("Running %s hook: %s", res.Event, res.Command)
+		},
+	}
+}
+
+// reportHookResults warns about failed hooks and declared hooks that were
+// not run because the skill is not trusted.
+func reportHookResults(results []hooks.Result) {
+	var skipped []string
+	for _, res := range results {
+		switch {
+		case res.Skipped != "":
+			ui.Warning("%s declares a %s hook (%s), not run: %s", res.Skill, res.Event, res.Command, res.Skipped)
+			skipped = appendUnique(skipped, res.Skill)
+		case res.Err != nil:
+			ui.Warning("%s hook %q failed: %v", res.Event, res.Command, res.Err)
+		}
+	}
+	if len(skipped) > 0 {
+		ui.Info("Review the hooks, then run: skillshare hooks trust %s", strings.Join(skipped, " "))
+	}
+}
+
+func appendUnique(list []string, s string) []string {
+	for _, v := range list {
+		if v == s {
+			return list
+		}
+	}
+	return append(list, s)
+}
+
+func cmdHooks(args []string) error {
+	mode, rest, err := parseModeArgs(args)
+	if err != nil {
+		return err
+	}
+
+	sub := "list"
+	if len(rest) > 0 && !strings.HasPrefix(rest[0], "-") {
+		sub, rest = rest[0], rest[1:]
+	}
+	for _, a := range rest {
+		if a == "--help" || a == "-h" {
+			printHooksHelp()
+			return nil
+		}
+	}
+
+	cwd, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("cannot determine working directory: %w", err)
+	}
+	if mode == modeAuto {
+		if projectConfigExists(cwd) {
+			mode = modeProject
+		} else {
+			mode = modeGlobal
+		}
+	}
+	applyModeLabel(mode)
+
+	scope, ok := hookScope(mode, cwd)
+	if !ok {
+		_, err := config.Load()
+		return err
+	}
+
+	if sub != "list" {
+		unlock, err := lockOperation("hooks", mode, cwd, rest)
+		if err != nil {
+			return err
+		}
+		defer unlock()
+	}
+
+	switch sub {
+	case "list":
+		return hooksList(scope)
+	case "trust":
+		return hooksTrust(scope, rest, true)
+	case "untrust":
+		return hooksTrust(scope, rest, false)
+	case "run":
+		return hooksRun(scope, rest)
+	default:
+		return fmt.Errorf("unknown hooks subcommand: %s", sub)
+	}
+}
+
+func hooksList(scope hooks.Scope) error {
+	ui.Header(ui.WithModeLabel("Hooks"))
+
+	userHooks, _ := loadUserHooks()
+	if userHooks.IsEmpty() {
+		ui.Info("No hooks in %s", config.ConfigPath())
+	} else {
+		for _, event := range config.HookEvents {
+			for _, h := range userHooks.For(event) {
+				fmt.Printf("  %-13s %s\n", event, h.Run)
+			}
+		}
+	}
+
+	discovered, err := sync.DiscoverSourceSkills(scope.SourceDir)
+	if err != nil {
+		return fmt.Errorf("failed to discover skills: %w", err)
+	}
+	trust, err := hooks.LoadTrust(hooks.TrustPath())
+	if err != nil {
+		return err
+	}
+
+	var printed bool
+	for _, skill := range discovered {
+		declared, err := hooks.SkillHooks(skill.SourcePath)
+		if err != nil || len(declared) == 0 {
+			continue
+		}
+		if !printed {
+			fmt.Println()
+			fmt.Println("Skill hooks:")
+			printed = true
+		}
+		fmt.Printf("  %s (%s)\n", skill.RelPath, trust.State(skill.SourcePath))
+		for _, event := range hooks.SkillEvents {
+			for _, h := range declared[event] {
+				fmt.Printf("    %-13s %s\n", event, h.Run)
+			}
+		}
+	}
+	return nil
+}
+
+// hooksTrust grants or revokes trust for the current version of skills.
+func hooksTrust(scope hooks.Scope, names []string, grant bool) error {
+	if len(names) == 0 {
+		verb := "trust"
+		if !grant {
+			verb = "untrust"
+		}
+		return fmt.Errorf("usage: skillshare hooks %s <skill>...", verb)
+	}
+	discovered, err := sync.DiscoverSourceSkills(scope.SourceDir)
+	if err != nil {
+		return fmt.Errorf("failed to discover skills: %w", err)
+	}
+	trust, err := hooks.LoadTrust(hooks.TrustPath())
+	if err != nil {
+		return err
+	}
+
+	for _, name := range names {
+		skill, err := findReviewSkill(discovered, name)
+		if err != nil {
+			return err
+		}
+		if !grant {
+			if trust.Revoke(skill.SourcePath) {
+				ui.Success("Untrusted %s", skill.RelPath)
+			} else {
+				ui.Info("%s was not trusted", skill.RelPath)
+			}
+			continue
+		}
+		declared, err := hooks.SkillHooks(skill.SourcePath)
+		if err != nil {
+			return fmt.Errorf("%s: invalid hooks in SKILL.md: %w", skill.RelPath, err)
+		}
+		if len(declared) == 0 {
+			ui.Info("%s declares no hooks", skill.RelPath)
+			continue
+		}
+		if err := trust.Grant(skill.SourcePath); err != nil {
+			return err
+		}
+		ui.Success("Trusted %s", skill.RelPath)
+		for _, event := range hooks.SkillEvents {
+			for _, h := range declared[event] {
+				fmt.Printf("  %-13s %s\n", event, h.Run)
+			}
+		}
+	}
+	return trust.Save()
+}
+
+// hooksRun runs the hooks a trusted skill declares, e.g. after trusting it.
+func hooksRun(scope hooks.Scope, args []string) error {
+	event := config.HookPostInstall
+	var names []string
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "--event":
+			if i+1 >= len(args) {
+				return fmt.Errorf("--event requires a value")
+			}
+			i++
+			event = args[i]
+		default:
+			if strings.HasPrefix(args[i], "-") {
+				return fmt.Errorf("unknown option: %s", args[i])
+			}
+			names = append(names, args[i])
+		}
+	}
+	if event != config.HookPostInstall && event != config.HookPostUpdate {
+		return fmt.Errorf("--event must be %s or %s", config.HookPostInstall, config.HookPostUpdate)
+	}
+	if len(names) == 0 {
+		return fmt.Errorf("usage: skillshare hooks run <skill>... [--event post-install|post-update]")
+	}
+
+	discovered, err := sync.DiscoverSourceSkills(scope.SourceDir)
+	if err != nil {
+		return fmt.Errorf("failed to discover skills: %w", err)
+	}
+	user, _ := loadUserHooks()
+	runner := newHookRunner(scope, user)
+	var results []hooks.Result
+	for _, name := range names {
+		skill, err := findReviewSkill(discovered, name)
+		if err != nil {
+			return err
+		}
+		got := runner.RunDeclared(event, skill)
+		if len(got) == 0 {
+			ui.Info("%s declares no %s hooks", skill.RelPath, event)
+		}
+		results = append(results, got...)
+	}
+	reportHookResults(results)
+	return hooks.FirstError(results)
+}
+
+func printHooksHelp() {
+	fmt.Println(`Usage: skillshare hooks [list|trust|untrust|run] [skill...] [options]
+
+Show and manage hooks. User hooks come from the 'hooks:' section of
+config.yaml and run around install, sync and update. Skills may declare
+post-install and post-update hooks in their SKILL.md frontmatter; those
+only run once you trust that exact version of the skill.
+
+Subcommands:
+  list                 List user hooks and skills that declare hooks (default)
+  trust <skill>...     Trust the skills' current content to run their hooks
+  untrust <skill>...   Revoke trust
+  run <skill>...       Run a trusted skill's declared hooks now
+
+Options:
+  --event <event>      Event to run with 'run': post-install (default)
+                       or post-update
+  --project, -p        Use the project's .skillshare/skills
+  --global, -g         Use the global source directory
+  --help, -h           Show this help
+
+Examples:
+  skillshare hooks
+  skillshare hooks trust pdf
+  skillshare hooks run pdf
+  skillshare hooks untrust pdf`)
+}
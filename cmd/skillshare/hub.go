@@ -39,6 +39,11 @@ func cmdHub(args []string) error {
 			mode = modeGlobal
 		}
 		applyModeLabel(mode)
+		unlock, err := lockOperation("hub", mode, cwd, rest)
+		if err != nil {
+			return err
+		}
+		defer unlock()
 		return cmdHubAdd(rest, mode, cwd)
 	case "list", "ls":
 		mode, _, err := parseModeArgs(subargs)
@@ -71,6 +76,11 @@ func cmdHub(args []string) error {
 			mode = modeGlobal
 		}
 		applyModeLabel(mode)
+		unlock, err := lockOperation("hub", mode, cwd, rest)
+		if err != nil {
+			return err
+		}
+		defer unlock()
 		return cmdHubRemove(rest, mode, cwd)
 	case "default":
 		mode, rest, err := parseModeArgs(subargs)
@@ -87,6 +97,11 @@ func cmdHub(args []string) error {
 			mode = modeGlobal
 		}
 		applyModeLabel(mode)
+		unlock, err := lockOperation("hub", mode, cwd, rest)
+		if err != nil {
+			return err
+		}
+		defer unlock()
 		return cmdHubDefault(rest, mode, cwd)
 	case "help", "-h", "--help":
 		printHubHelp()
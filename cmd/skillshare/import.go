@@ -0,0 +1,329 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"skillshare/internal/audit"
+	"skillshare/internal/config"
+	"skillshare/internal/convert"
+	"skillshare/internal/oplog"
+	"skillshare/internal/ui"
+	"skillshare/internal/undo"
+	"skillshare/internal/utils"
+)
+
+type importOptions struct {
+	kind      string
+	path      string
+	dryRun    bool
+	force     bool
+	skipAudit bool
+}
+
+// importScope holds the paths and policy of the mode import runs in.
+type importScope struct {
+	sourcePath  string
+	cfgPath     string
+	threshold   string
+	projectRoot string         // set in project mode for project audit rules
+	rec         *undo.Recorder // nil for dry runs
+}
+
+func cmdImport(args []string) error {
+	start := time.Now()
+
+	mode, rest, err := parseModeArgs(args)
+	if err != nil {
+		return err
+	}
+
+	opts, showHelp, err := parseImportArgs(rest)
+	if showHelp {
+		printImportHelp()
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	cwd, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("cannot determine working directory: %w", err)
+	}
+	if mode == modeAuto {
+		if projectConfigExists(cwd) {
+			mode = modeProject
+		} else {
+			mode = modeGlobal
+		}
+	}
+	applyModeLabel(mode)
+
+	scope := &importScope{}
+	if mode == modeProject {
+		rt, err := loadProjectRuntime(cwd)
+		if err != nil {
+			return err
+		}
+		scope.sourcePath = rt.sourcePath
+		scope.cfgPath = config.ProjectConfigPath(cwd)
+		scope.threshold = rt.config.Audit.BlockThreshold
+		scope.projectRoot = cwd
+	} else {
+		cfg, err := config.Load()
+		if err != nil {
+			return err
+		}
+		scope.sourcePath = cfg.Source
+		scope.cfgPath = config.ConfigPath()
+		scope.threshold = cfg.Audit.BlockThreshold
+	}
+
+	path := opts.path
+	if path == "" {
+		home, _ := os.UserHomeDir()
+		path = convert.DefaultPath(opts.kind, home, cwd, mode == modeProject)
+	} else if utils.HasTildePrefix(path) {
+		home, _ := os.UserHomeDir()
+		path = filepath.Join(home, path[1:])
+	}
+	if abs, err := filepath.Abs(path); err == nil {
+		path = abs
+	}
+
+	result, err := convert.Scan(opts.kind, path)
+	if err != nil {
+		return fmt.Errorf("cannot read %s: %w", path, err)
+	}
+	if len(result.Skills) == 0 && len(result.Failures) == 0 {
+		ui.Info("Nothing to import from %s", path)
+		return nil
+	}
+
+	if !opts.dryRun {
+		unlock, err := lockOperation("import", mode, cwd, rest)
+		if err != nil {
+			return err
+		}
+		defer unlock()
+		scope.rec = beginUndoRecord("import", mode, cwd, rest)
+	}
+
+	title := fmt.Sprintf("Importing %s from %s", opts.kind, path)
+	if opts.dryRun {
+		title += " (dry-run)"
+	}
+	ui.Header(ui.WithModeLabel(title))
+
+	imported, failures := importConvertedSkills(result.Skills, scope, opts)
+	failures = append(result.Failures, failures...)
+
+	if len(failures) > 0 {
+		fmt.Println()
+		ui.Warning("Could not convert %d file(s):", len(failures))
+		for _, f := range failures {
+			fmt.Printf("  %s✗%s %s %s— %s%s\n", ui.Red, ui.Reset, f.Path, ui.Gray, f.Reason, ui.Reset)
+		}
+	}
+
+	fmt.Println()
+	if opts.dryRun {
+		ui.Info("Dry run: %d skill(s) would be imported, %d file(s) skipped", len(imported), len(failures))
+		return nil
+	}
+	ui.Info("%d skill(s) imported, %d file(s) skipped", len(imported), len(failures))
+
+	var cmdErr error
+	if len(imported) == 0 {
+		cmdErr = fmt.Errorf("no skills imported")
+	}
+	e := oplog.NewEntry("import", statusFromErr(cmdErr), time.Since(start))
+	e.Args = map[string]any{
+		"from":     opts.kind,
+		"path":     path,
+		"imported": imported,
+		"failed":   len(failures),
+	}
+	if cmdErr != nil {
+		e.Message = cmdErr.Error()
+	}
+	commitUndoRecord(scope.rec, &e)
+	oplog.Write(scope.cfgPath, oplog.OpsFile, e) //nolint:errcheck
+
+	if cmdErr != nil {
+		return cmdErr
+	}
+	if mode == modeProject {
+		ui.Info("Run 'skillshare sync -p' to distribute to all targets")
+	} else {
+		ui.Info("Run 'skillshare sync' to distribute to all targets")
+	}
+	return nil
+}
+
+// importConvertedSkills stages, audits and writes each converted skill into
+// the source. It returns the names imported and the skills that were not.
+func importConvertedSkills(skills []convert.Skill, scope *importScope, opts importOptions) ([]string, []convert.Failure) {
+	var imported []string
+	var failures []convert.Failure
+
+	threshold, err := audit.NormalizeThreshold(scope.threshold)
+	if err != nil {
+		threshold = audit.DefaultThreshold()
+	}
+
+	for i := range skills {
+		s := &skills[i]
+		dest := filepath.Join(scope.sourcePath, s.Name)
+		if _, err := os.Stat(dest); err == nil && !opts.force {
+			failures = append(failures, convert.Failure{
+				Path:   s.Source,
+				Reason: fmt.Sprintf("skill %q already exists in source (use --force to overwrite)", s.Name),
+			})
+			continue
+		}
+
+		if err := importSkill(s, dest, scope, threshold, opts); err != nil {
+			failures = append(failures, convert.Failure{Path: s.Source, Reason: err.Error()})
+			continue
+		}
+		imported = append(imported, s.Name)
+
+		verb := "imported"
+		if opts.dryRun {
+			verb = "would import"
+		}
+		ui.Success("%s: %s from %s", s.Name, verb, filepath.Base(s.Source))
+		for _, note := range s.Notes {
+			fmt.Printf("    %s! %s%s\n", ui.Yellow, note, ui.Reset)
+		}
+	}
+	return imported, failures
+}
+
+// importSkill writes s to a staging directory, audits it, then moves it to
+// dest. Findings at or above threshold block the skill unless --force.
+func importSkill(s *convert.Skill, dest string, scope *importScope, threshold string, opts importOptions) error {
+	staging, err := os.MkdirTemp("", "skillshare-import-")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(staging)
+
+	staged := filepath.Join(staging, s.Name)
+	if err := s.Write(staged); err != nil {
+		return fmt.Errorf("failed to write skill: %w", err)
+	}
+
+	if !opts.skipAudit {
+		var res *audit.Result
+		if scope.projectRoot != "" {
+			res, err = audit.ScanSkillForProject(staged, scope.projectRoot)
+		} else {
+			res, err = audit.ScanSkill(staged)
+		}
+		if err != nil {
+			ui.Warning("%s: audit scan error: %v", s.Name, err)
+		} else if len(res.Findings) > 0 {
+			if res.HasSeverityAtOrAbove(threshold) && !opts.force {
+				f := res.Findings[0]
+				return fmt.Errorf("blocked by audit: %s %s (%s:%d), use --force to import anyway",
+					f.Severity, f.Message, f.File, f.Line)
+			}
+			s.Notes = append(s.Notes, fmt.Sprintf("audit: %s risk, %d finding(s); run 'skillshare audit %s'",
+				res.RiskLabel, len(res.Findings), s.Name))
+		}
+	}
+
+	if opts.dryRun {
+		return nil
+	}
+	if _, err := os.Stat(dest); err == nil {
+		scope.rec.Preserve(dest)
+		if err := os.RemoveAll(dest); err != nil {
+			return fmt.Errorf("failed to replace existing skill: %w", err)
+		}
+	}
+	if err := copyDir(staged, dest); err != nil {
+		os.RemoveAll(dest)
+		return fmt.Errorf("failed to write skill: %w", err)
+	}
+	return nil
+}
+
+func parseImportArgs(args []string) (importOptions, bool, error) {
+	var opts importOptions
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		switch {
+		case arg == "--help" || arg == "-h":
+			return opts, true, nil
+		case arg == "--dry-run" || arg == "-n":
+			opts.dryRun = true
+		case arg == "--force" || arg == "-f":
+			opts.force = true
+		case arg == "--skip-audit":
+			opts.skipAudit = true
+		case arg == "--from" || strings.HasPrefix(arg, "--from="):
+			val, ok := strings.CutPrefix(arg, "--from=")
+			if !ok {
+				if i+1 >= len(args) {
+					return opts, false, fmt.Errorf("--from requires a kind (%s)", strings.Join(convert.Kinds, ", "))
+				}
+				i++
+				val = args[i]
+			}
+			kind, err := convert.ParseKind(val)
+			if err != nil {
+				return opts, false, err
+			}
+			opts.kind = kind
+		case strings.HasPrefix(arg, "-"):
+			return opts, false, fmt.Errorf("unknown option: %s", arg)
+		case opts.path == "":
+			opts.path = arg
+		default:
+			return opts, false, fmt.Errorf("unexpected argument: %s", arg)
+		}
+	}
+	if opts.kind == "" {
+		return opts, false, fmt.Errorf("--from is required (%s)", strings.Join(convert.Kinds, ", "))
+	}
+	return opts, false, nil
+}
+
+func printImportHelp() {
+	fmt.Println(`Usage: skillshare import --from <kind> [path] [options]
+
+Convert agent configuration that is not a skill yet into skill folders in
+the source, with generated frontmatter. Each result is audited; files that
+cannot be converted are reported.
+
+Kinds:
+  claude-commands   Claude slash commands (*.md)     default: ~/.claude/commands
+  cursor            .cursorrules, .cursor/rules/*.mdc default: current directory
+  copilot           .github/copilot-instructions.md,
+                    .github/instructions/*.instructions.md
+                                                    default: current directory
+
+[path] can be a single file, the directory holding the files, or a project
+root.
+
+Options:
+  --from <kind>       What to convert (required)
+  --dry-run, -n       Preview without writing skills
+  --force, -f         Overwrite existing skills and import despite audit findings
+  --skip-audit        Skip the security audit
+  --project, -p       Import into project skills (.skillshare/skills/)
+  --global, -g        Import into global skills
+  --help, -h          Show this help
+
+Examples:
+  skillshare import --from claude-commands
+  skillshare import --from cursor ~/code/webapp
+  skillshare import --from copilot . --dry-run`)
+}
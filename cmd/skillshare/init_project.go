@@ -9,6 +9,7 @@ import (
 	"github.com/AlecAivazis/survey/v2"
 	"skillshare/internal/config"
 	"skillshare/internal/install"
+	"skillshare/internal/oplock"
 	"skillshare/internal/ui"
 )
 
@@ -456,6 +457,9 @@ func ensureProjectGitignore(root string) error {
 	if err := install.UpdateGitIgnore(gitignoreDir, "logs"); err != nil {
 		return fmt.Errorf("failed to update .skillshare/.gitignore: %w", err)
 	}
+	if err := install.UpdateGitIgnore(gitignoreDir, oplock.FileName); err != nil {
+		return fmt.Errorf("failed to update .skillshare/.gitignore: %w", err)
+	}
 
 	return nil
 }
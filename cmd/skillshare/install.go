@@ -1,8 +1,10 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"os"
+	"os/signal"
 	"path/filepath"
 	"sort"
 	"strings"
@@ -15,6 +17,7 @@ import (
 	"skillshare/internal/install"
 	"skillshare/internal/oplog"
 	"skillshare/internal/ui"
+	"skillshare/internal/undo"
 	"skillshare/internal/validate"
 	appversion "skillshare/internal/version"
 )
@@ -85,6 +88,20 @@ func parseInstallArgs(args []string) (*installArgs, bool, error) {
 			}
 			i++
 			result.opts.Into = args[i]
+		case arg == "--branch" || arg == "-b":
+			if i+1 >= len(args) {
+				return nil, false, fmt.Errorf("--branch requires a value")
+			}
+			i++
+			result.opts.Branch = args[i]
+		case arg == "--ref":
+			if i+1 >= len(args) {
+				return nil, false, fmt.Errorf("--ref requires a value")
+			}
+			i++
+			result.opts.Ref = args[i]
+		case arg == "--allow-partial":
+			result.opts.AllowPartial = true
 		case arg == "--all":
 			result.opts.All = true
 		case arg == "--yes" || arg == "-y":
@@ -142,6 +159,12 @@ func parseInstallArgs(args []string) (*installArgs, bool, error) {
 	if result.opts.ShouldInstallAll() && result.opts.Track {
 		return nil, false, fmt.Errorf("--all/--yes cannot be used with --track")
 	}
+	if (result.opts.Branch != "" || result.opts.Ref != "") && !result.opts.Track {
+		return nil, false, fmt.Errorf("--branch and --ref require --track")
+	}
+	if result.opts.Branch != "" && result.opts.Ref != "" {
+		return nil, false, fmt.Errorf("--branch and --ref cannot be used together")
+	}
 
 	// When no source is given, only bare "install" is valid — reject incompatible flags
 	if result.sourceArg == "" {
@@ -221,22 +244,29 @@ func resolveInstallSource(sourceArg string, opts install.InstallOptions, cfg *co
 }
 
 // dispatchInstall routes to the appropriate install handler
-func dispatchInstall(source *install.Source, cfg *config.Config, opts install.InstallOptions) (installLogSummary, error) {
+func dispatchInstall(source *install.Source, cfg *config.Config, opts install.InstallOptions, rec *undo.Recorder) (installLogSummary, error) {
 	if opts.Track {
-		return handleTrackedRepoInstall(source, cfg, opts)
+		return handleTrackedRepoInstall(source, cfg, opts, rec)
 	}
 
-	if source.IsGit() {
+	// Archives hold several skills just like repositories do
+	if source.IsGit() || source.IsArchive() {
 		if !source.HasSubdir() {
-			return handleGitDiscovery(source, cfg, opts)
+			return handleGitDiscovery(source, cfg, opts, rec)
 		}
-		return handleGitSubdirInstall(source, cfg, opts)
+		return handleGitSubdirInstall(source, cfg, opts, rec)
 	}
 
-	return handleDirectInstall(source, cfg, opts)
+	return handleDirectInstall(source, cfg, opts, rec)
 }
 
 func cmdInstall(args []string) error {
+	post := &commandHooks{}
+	return post.finish(runInstall(args, post))
+}
+
+// runInstall is cmdInstall; post runs the install hooks.
+func runInstall(args []string, post *commandHooks) error {
 	start := time.Now()
 
 	mode, rest, err := parseModeArgs(args)
@@ -259,12 +289,23 @@ func cmdInstall(args []string) error {
 
 	applyModeLabel(mode)
 
+	unlock, err := lockOperation("install", mode, cwd, rest)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	rec := beginUndoRecord("install", mode, cwd, rest)
+	post.begin(config.HookPostInstall, mode, cwd, rest)
+	if err := post.runPreInstall(rest); err != nil {
+		return err
+	}
+
 	if mode == modeProject {
-		summary, err := cmdInstallProject(rest, cwd)
+		summary, err := cmdInstallProject(rest, cwd, rec)
 		if summary.Mode == "" {
 			summary.Mode = "project"
 		}
-		logInstallOp(config.ProjectConfigPath(cwd), rest, start, err, summary)
+		logInstallOp(rec, config.ProjectConfigPath(cwd), rest, start, err, summary)
 		return err
 	}
 
@@ -286,13 +327,13 @@ func cmdInstall(args []string) error {
 	// No source argument: install from global config
 	if parsed.sourceArg == "" {
 		summary, err := installFromGlobalConfig(cfg, parsed.opts)
-		logInstallOp(config.ConfigPath(), rest, start, err, summary)
+		logInstallOp(rec, config.ConfigPath(), rest, start, err, summary)
 		return err
 	}
 
 	source, resolvedFromMeta, err := resolveInstallSource(parsed.sourceArg, parsed.opts, cfg)
 	if err != nil {
-		logInstallOp(config.ConfigPath(), rest, start, err, installLogSummary{
+		logInstallOp(rec, config.ConfigPath(), rest, start, err, installLogSummary{
 			Source: parsed.sourceArg,
 			Mode:   "global",
 		})
@@ -311,7 +352,7 @@ func cmdInstall(args []string) error {
 
 	// If resolved from metadata with update/force, go directly to install
 	if resolvedFromMeta {
-		summary, err = handleDirectInstall(source, cfg, parsed.opts)
+		summary, err = handleDirectInstall(source, cfg, parsed.opts, rec)
 		if summary.Mode == "" {
 			summary.Mode = "global"
 		}
@@ -323,11 +364,11 @@ func cmdInstall(args []string) error {
 				ui.Warning("Failed to reconcile global skills config: %v", rErr)
 			}
 		}
-		logInstallOp(config.ConfigPath(), rest, start, err, summary)
+		logInstallOp(rec, config.ConfigPath(), rest, start, err, summary)
 		return err
 	}
 
-	summary, err = dispatchInstall(source, cfg, parsed.opts)
+	summary, err = dispatchInstall(source, cfg, parsed.opts, rec)
 	if summary.Mode == "" {
 		summary.Mode = "global"
 	}
@@ -339,11 +380,11 @@ func cmdInstall(args []string) error {
 			ui.Warning("Failed to reconcile global skills config: %v", rErr)
 		}
 	}
-	logInstallOp(config.ConfigPath(), rest, start, err, summary)
+	logInstallOp(rec, config.ConfigPath(), rest, start, err, summary)
 	return err
 }
 
-func logInstallOp(cfgPath string, args []string, start time.Time, cmdErr error, summary installLogSummary) {
+func logInstallOp(rec *undo.Recorder, cfgPath string, args []string, start time.Time, cmdErr error, summary installLogSummary) {
 	e := oplog.NewEntry("install", statusFromErr(cmdErr), time.Since(start))
 	fields := map[string]any{}
 	source := summary.Source
@@ -389,10 +430,11 @@ func logInstallOp(cfgPath string, args []string, start time.Time, cmdErr error,
 	if cmdErr != nil {
 		e.Message = cmdErr.Error()
 	}
+	commitUndoRecord(rec, &e)
 	oplog.Write(cfgPath, oplog.OpsFile, e) //nolint:errcheck
 }
 
-func handleTrackedRepoInstall(source *install.Source, cfg *config.Config, opts install.InstallOptions) (installLogSummary, error) {
+func handleTrackedRepoInstall(source *install.Source, cfg *config.Config, opts install.InstallOptions, rec *undo.Recorder) (installLogSummary, error) {
 	logSummary := installLogSummary{
 		Source:         source.Raw,
 		DryRun:         opts.DryRun,
@@ -413,10 +455,17 @@ func handleTrackedRepoInstall(source *install.Source, cfg *config.Config, opts i
 	if opts.Into != "" {
 		ui.StepContinue("Into", opts.Into)
 	}
+	if opts.Branch != "" {
+		ui.StepContinue("Branch", opts.Branch)
+	}
+	if opts.Ref != "" {
+		ui.StepContinue("Pinned", opts.Ref)
+	}
 
 	// Step 2: Clone with tree spinner
 	treeSpinner := ui.StartTreeSpinner("Cloning repository...", false)
 
+	rec.Preserve(install.TrackedRepoDest(source, cfg.Source, opts))
 	result, err := install.InstallTrackedRepo(source, cfg.Source, opts)
 	if err != nil {
 		treeSpinner.Fail("Failed to clone")
@@ -466,7 +515,7 @@ func handleTrackedRepoInstall(source *install.Source, cfg *config.Config, opts i
 	return logSummary, nil
 }
 
-func handleGitDiscovery(source *install.Source, cfg *config.Config, opts install.InstallOptions) (installLogSummary, error) {
+func handleGitDiscovery(source *install.Source, cfg *config.Config, opts install.InstallOptions, rec *undo.Recorder) (installLogSummary, error) {
 	logSummary := installLogSummary{
 		Source:         source.Raw,
 		DryRun:         opts.DryRun,
@@ -485,16 +534,20 @@ func handleGitDiscovery(source *install.Source, cfg *config.Config, opts install
 	}
 
 	// Step 2: Clone with tree spinner animation
-	treeSpinner := ui.StartTreeSpinner("Cloning repository...", false)
+	fetching, fetched, failed := fetchLabels(source)
+	treeSpinner := ui.StartTreeSpinner(fetching, false)
 
-	discovery, err := install.DiscoverFromGit(source)
+	discovery, err := discoverSource(source)
 	if err != nil {
-		treeSpinner.Fail("Failed to clone")
+		treeSpinner.Fail(failed)
 		return logSummary, err
 	}
 	defer install.CleanupDiscovery(discovery)
 
-	treeSpinner.Success("Cloned")
+	treeSpinner.Success(withDownloaded(fetched, discovery.Downloaded))
+	for _, w := range discovery.Warnings {
+		ui.Warning("%s", w)
+	}
 
 	// Step 3: Show found skills
 	if len(discovery.Skills) == 0 {
@@ -542,6 +595,7 @@ func handleGitDiscovery(source *install.Source, cfg *config.Config, opts install
 		if err := ensureIntoDirExists(cfg.Source, opts); err != nil {
 			return logSummary, fmt.Errorf("failed to create --into directory: %w", err)
 		}
+		rec.Preserve(destPath)
 		fmt.Println()
 
 		installSpinner := ui.StartSpinner(fmt.Sprintf("Installing %s...", skill.Name))
@@ -590,11 +644,11 @@ func handleGitDiscovery(source *install.Source, cfg *config.Config, opts install
 		}
 
 		fmt.Println()
-		batchSummary := installSelectedSkills(selected, discovery, cfg, opts)
+		batchSummary, err := installSelectedSkills(selected, discovery, cfg, opts, rec)
 		logSummary.InstalledSkills = append(logSummary.InstalledSkills, batchSummary.InstalledSkills...)
 		logSummary.FailedSkills = append(logSummary.FailedSkills, batchSummary.FailedSkills...)
 		logSummary.SkillCount = len(logSummary.InstalledSkills)
-		return logSummary, nil
+		return logSummary, err
 	}
 
 	if opts.DryRun {
@@ -621,12 +675,12 @@ func handleGitDiscovery(source *install.Source, cfg *config.Config, opts install
 	}
 
 	fmt.Println()
-	batchSummary := installSelectedSkills(selected, discovery, cfg, opts)
+	batchSummary, err := installSelectedSkills(selected, discovery, cfg, opts, rec)
 	logSummary.InstalledSkills = append(logSummary.InstalledSkills, batchSummary.InstalledSkills...)
 	logSummary.FailedSkills = append(logSummary.FailedSkills, batchSummary.FailedSkills...)
 	logSummary.SkillCount = len(logSummary.InstalledSkills)
 
-	return logSummary, nil
+	return logSummary, err
 }
 
 // selectSkills routes to the appropriate skill selection method:
@@ -831,18 +885,27 @@ type skillInstallResult struct {
 	message string
 }
 
-// installSelectedSkills installs multiple skills with progress display
-func installSelectedSkills(selected []install.SkillInfo, discovery *install.DiscoveryResult, cfg *config.Config, opts install.InstallOptions) installBatchSummary {
+// installSelectedSkills installs multiple skills with progress display.
+// Skills are staged and audited first and moved into the source together;
+// if any fails (or the user hits Ctrl-C), nothing is installed unless
+// --allow-partial keeps the ones that passed.
+func installSelectedSkills(selected []install.SkillInfo, discovery *install.DiscoveryResult, cfg *config.Config, opts install.InstallOptions, rec *undo.Recorder) (installBatchSummary, error) {
+	summary := installBatchSummary{
+		InstalledSkills: make([]string, 0, len(selected)),
+		FailedSkills:    make([]string, 0, len(selected)),
+	}
 	results := make([]skillInstallResult, 0, len(selected))
 	installSpinner := ui.StartSpinnerWithSteps("Installing...", len(selected))
 
-	// Ensure Into directory exists for batch installs
-	if opts.Into != "" {
-		if err := ensureIntoDirExists(cfg.Source, opts); err != nil {
-			installSpinner.Fail("Failed to create --into directory")
-			return installBatchSummary{}
-		}
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	batch, err := install.NewBatch(cfg.Source)
+	if err != nil {
+		installSpinner.Fail("Failed to prepare install")
+		return summary, err
 	}
+	defer batch.Rollback() //nolint:errcheck
 
 	// Detect orchestrator: if root skill (path=".") is selected, children nest under it
 	var parentName string
@@ -868,6 +931,10 @@ func installSelectedSkills(selected []install.SkillInfo, discovery *install.Disc
 	rootInstalled := false
 
 	for i, skill := range orderedSkills {
+		if ctx.Err() != nil {
+			installSpinner.Fail("Interrupted")
+			return summary, fmt.Errorf("install interrupted; no skills were installed")
+		}
 		installSpinner.NextStep(fmt.Sprintf("Installing %s...", skill.Name))
 		if i == 0 {
 			installSpinner.Update(fmt.Sprintf("Installing %s...", skill.Name))
@@ -885,6 +952,7 @@ func installSelectedSkills(selected []install.SkillInfo, discovery *install.Disc
 			// Standalone child skill - install to root
 			destPath = destWithInto(cfg.Source, opts, skill.Name)
 		}
+		rec.Preserve(destPath)
 
 		// If root was installed, children are already included - skip reinstall
 		if rootInstalled && skill.Path != "." {
@@ -892,7 +960,7 @@ func installSelectedSkills(selected []install.SkillInfo, discovery *install.Disc
 			continue
 		}
 
-		_, err := install.InstallFromDiscovery(discovery, skill, destPath, opts)
+		_, err := batch.Stage(discovery, skill, destPath, opts)
 		if err != nil {
 			results = append(results, skillInstallResult{skill: skill, success: false, message: err.Error()})
 			continue
@@ -904,20 +972,65 @@ func installSelectedSkills(selected []install.SkillInfo, discovery *install.Disc
 		results = append(results, skillInstallResult{skill: skill, success: true, message: "installed"})
 	}
 
-	displayInstallResults(results, installSpinner)
+	for _, r := range results {
+		if !r.success {
+			summary.FailedSkills = append(summary.FailedSkills, r.skill.Name)
+		}
+	}
+	if len(summary.FailedSkills) > 0 && !opts.AllowPartial {
+		displayRolledBackResults(results, installSpinner)
+		return summary, fmt.Errorf("%d of %d skill(s) failed; no skills were installed (use --allow-partial to keep the rest)",
+			len(summary.FailedSkills), len(results))
+	}
 
-	summary := installBatchSummary{
-		InstalledSkills: make([]string, 0, len(results)),
-		FailedSkills:    make([]string, 0, len(results)),
+	if batch.Staged() > 0 {
+		if err := batch.Commit(ctx); err != nil {
+			installSpinner.Fail("Install rolled back")
+			return installBatchSummary{FailedSkills: skillNamesOf(results)}, err
+		}
 	}
+
+	displayInstallResults(results, installSpinner)
+
 	for _, r := range results {
 		if r.success {
 			summary.InstalledSkills = append(summary.InstalledSkills, r.skill.Name)
-			continue
 		}
-		summary.FailedSkills = append(summary.FailedSkills, r.skill.Name)
 	}
-	return summary
+	return summary, nil
+}
+
+// skillNamesOf returns the skill names of results.
+func skillNamesOf(results []skillInstallResult) []string {
+	names := make([]string, len(results))
+	for i, r := range results {
+		names[i] = r.skill.Name
+	}
+	return names
+}
+
+// displayRolledBackResults shows why a batch was rolled back: the skills
+// that failed, and how many passed but were not installed.
+func displayRolledBackResults(results []skillInstallResult, spinner *ui.Spinner) {
+	passed := 0
+	for _, r := range results {
+		if r.success {
+			passed++
+		}
+	}
+	failed := len(results) - passed
+	spinner.Fail(fmt.Sprintf("Failed to install %d of %d skill(s); nothing was installed", failed, len(results)))
+
+	fmt.Println()
+	for _, r := range results {
+		if !r.success {
+			ui.StepFail(r.skill.Name, r.message)
+		}
+	}
+	if passed > 0 {
+		fmt.Println()
+		ui.Info("%d skill(s) passed but were rolled back; rerun with --allow-partial to install them", passed)
+	}
 }
 
 // displayInstallResults shows the final install results
@@ -972,7 +1085,7 @@ func displayInstallResults(results []skillInstallResult, spinner *ui.Spinner) {
 	}
 }
 
-func handleGitSubdirInstall(source *install.Source, cfg *config.Config, opts install.InstallOptions) (installLogSummary, error) {
+func handleGitSubdirInstall(source *install.Source, cfg *config.Config, opts install.InstallOptions, rec *undo.Recorder) (installLogSummary, error) {
 	logSummary := installLogSummary{
 		Source:         source.Raw,
 		DryRun:         opts.DryRun,
@@ -992,17 +1105,21 @@ func handleGitSubdirInstall(source *install.Source, cfg *config.Config, opts ins
 	}
 
 	// Step 2: Clone with tree spinner
-	treeSpinner := ui.StartTreeSpinner("Cloning repository...", false)
+	fetching, fetched, failed := fetchLabels(source)
+	treeSpinner := ui.StartTreeSpinner(fetching, false)
 
 	// Discover skills in subdir
-	discovery, err := install.DiscoverFromGitSubdir(source)
+	discovery, err := discoverSource(source)
 	if err != nil {
-		treeSpinner.Fail("Failed to clone")
+		treeSpinner.Fail(failed)
 		return logSummary, err
 	}
 	defer install.CleanupDiscovery(discovery)
 
-	treeSpinner.Success("Cloned")
+	treeSpinner.Success(withDownloaded(fetched, discovery.Downloaded))
+	for _, w := range discovery.Warnings {
+		ui.Warning("%s", w)
+	}
 
 	// If only one skill found, install directly
 	if len(discovery.Skills) == 1 {
@@ -1030,6 +1147,7 @@ func handleGitSubdirInstall(source *install.Source, cfg *config.Config, opts ins
 		if err := ensureIntoDirExists(cfg.Source, opts); err != nil {
 			return logSummary, fmt.Errorf("failed to create --into directory: %w", err)
 		}
+		rec.Preserve(destPath)
 
 		fmt.Println()
 		installSpinner := ui.StartSpinner(fmt.Sprintf("Installing %s...", skill.Name))
@@ -1099,11 +1217,11 @@ func handleGitSubdirInstall(source *install.Source, cfg *config.Config, opts ins
 		}
 
 		fmt.Println()
-		batchSummary := installSelectedSkills(selected, discovery, cfg, opts)
+		batchSummary, err := installSelectedSkills(selected, discovery, cfg, opts, rec)
 		logSummary.InstalledSkills = append(logSummary.InstalledSkills, batchSummary.InstalledSkills...)
 		logSummary.FailedSkills = append(logSummary.FailedSkills, batchSummary.FailedSkills...)
 		logSummary.SkillCount = len(logSummary.InstalledSkills)
-		return logSummary, nil
+		return logSummary, err
 	}
 
 	if opts.DryRun {
@@ -1129,15 +1247,15 @@ func handleGitSubdirInstall(source *install.Source, cfg *config.Config, opts ins
 	}
 
 	fmt.Println()
-	batchSummary := installSelectedSkills(selected, discovery, cfg, opts)
+	batchSummary, err := installSelectedSkills(selected, discovery, cfg, opts, rec)
 	logSummary.InstalledSkills = append(logSummary.InstalledSkills, batchSummary.InstalledSkills...)
 	logSummary.FailedSkills = append(logSummary.FailedSkills, batchSummary.FailedSkills...)
 	logSummary.SkillCount = len(logSummary.InstalledSkills)
 
-	return logSummary, nil
+	return logSummary, err
 }
 
-func handleDirectInstall(source *install.Source, cfg *config.Config, opts install.InstallOptions) (installLogSummary, error) {
+func handleDirectInstall(source *install.Source, cfg *config.Config, opts install.InstallOptions, rec *undo.Recorder) (installLogSummary, error) {
 	logSummary := installLogSummary{
 		Source:         source.Raw,
 		DryRun:         opts.DryRun,
@@ -1172,6 +1290,7 @@ func handleDirectInstall(source *install.Source, cfg *config.Config, opts instal
 	if err := ensureIntoDirExists(cfg.Source, opts); err != nil {
 		return logSummary, fmt.Errorf("failed to create --into directory: %w", err)
 	}
+	rec.Preserve(destPath)
 
 	// Show logo with version
 	ui.Logo(appversion.Version)
@@ -1188,8 +1307,8 @@ func handleDirectInstall(source *install.Source, cfg *config.Config, opts instal
 
 	// Step 2: Clone/copy with tree spinner
 	var actionMsg string
-	if source.IsGit() {
-		actionMsg = "Cloning repository..."
+	if source.IsGit() || source.IsArchive() || source.IsOCI() {
+		actionMsg, _, _ = fetchLabels(source)
 	} else {
 		actionMsg = "Copying files..."
 	}
@@ -1208,7 +1327,7 @@ func handleDirectInstall(source *install.Source, cfg *config.Config, opts instal
 		fmt.Println()
 		ui.Warning("[dry-run] %s", result.Action)
 	} else {
-		treeSpinner.Success(fmt.Sprintf("Installed: %s", skillName))
+		treeSpinner.Success(withDownloaded(fmt.Sprintf("Installed: %s", skillName), result.Downloaded))
 	}
 
 	// Display warnings
@@ -1270,6 +1389,7 @@ func installFromGlobalConfig(cfg *config.Config, opts install.InstallOptions) (i
 
 		if skill.Tracked {
 			trackOpts := opts
+			trackOpts.Branch, trackOpts.Ref = skill.Branch, skill.Ref
 			if groupDir != "" {
 				trackOpts.Into = groupDir
 			}
@@ -1339,7 +1459,7 @@ func installFromGlobalConfig(cfg *config.Config, opts install.InstallOptions) (i
 func printInstallHelp() {
 	fmt.Println(`Usage: skillshare install [source|skill-name] [options]
 
-Install skills from a local path, git repository, or global config.
+Install skills from a local path, git repository, archive, or global config.
 When run with no arguments, installs all skills listed in config.yaml.
 When using --update or --force with a skill name, skillshare uses stored metadata to resolve the source.
 
@@ -1350,6 +1470,9 @@ Sources:
   github.com/user/repo/path  Subdirectory in GitHub repo (direct install)
   https://github.com/...     HTTPS git URL
   git@github.com:...         SSH git URL
+  https://.../x.tar.gz       Archive (.tar.gz, .tgz, .tar, .zip; also file://)
+  https://.../x.zip//path    Subdirectory in archive; append #sha256=<hex> to pin
+  oci://registry/repo:tag    OCI registry artifact (pin with @sha256:<digest>)
   ~/path/to/skill            Local directory
 
 Options:
@@ -1358,10 +1481,14 @@ Options:
   --force, -f         Overwrite existing skill; also continue if audit would block
   --update, -u        Update existing (git pull if possible, else reinstall)
   --track, -t         Install as tracked repo (preserves .git for updates)
+  --branch, -b <name> Tracked repo: clone and follow a branch other than the default
+  --ref <tag|sha>     Tracked repo: pin at a tag or commit; update leaves it there
   --skill, -s <names> Select specific skills from multi-skill repo (comma-separated)
   --exclude <names>   Skip specific skills during install (comma-separated)
   --all               Install all discovered skills without prompting
   --yes, -y           Auto-accept all prompts (equivalent to --all for multi-skill repos)
+  --allow-partial     Multi-skill installs keep the skills that passed when others fail
+                      (default: install all selected skills or none)
   --dry-run, -n       Preview the installation without making changes
   --skip-audit        Skip security audit entirely for this install
   --project, -p       Use project-level config in current directory
@@ -1382,6 +1509,7 @@ Selective install (non-interactive):
   skillshare install anthropics/skills -y                # Auto-accept
   skillshare install anthropics/skills -s pdf --dry-run  # Preview selection
   skillshare install repo --all --exclude cli-sentry     # All except specific
+  skillshare install repo --all --allow-partial          # Keep skills that pass audit
 
 Organize into subdirectories:
   skillshare install anthropics/skills -s pdf --into frontend
@@ -1391,6 +1519,8 @@ Organize into subdirectories:
 Tracked repositories (Team Edition):
   skillshare install team/shared-skills --track   # Clone as _shared-skills
   skillshare install _shared-skills --update      # Update tracked repo
+  skillshare install team/shared-skills --track --branch next
+  skillshare install team/shared-skills --track --ref v1.3.0   # Pinned
 
 Install from config (no arguments):
   skillshare install                         # Install all skills from config.yaml
@@ -1401,3 +1531,36 @@ Update existing skills:
   skillshare install my-skill --force        # Reinstall using stored source
   skillshare install my-skill --update -n    # Preview update`)
 }
+
+// withDownloaded appends the size a clone downloaded to a status message.
+// Installs served from the clone cache download nothing and show none.
+func withDownloaded(message string, downloaded int64) string {
+	if downloaded <= 0 {
+		return message
+	}
+	return fmt.Sprintf("%s (%s downloaded)", message, formatBytes(downloaded))
+}
+
+// discoverSource fetches a repository or archive source and discovers the
+// skills in it (or in its subdirectory).
+func discoverSource(source *install.Source) (*install.DiscoveryResult, error) {
+	switch {
+	case source.IsArchive():
+		return install.DiscoverFromArchive(source)
+	case source.HasSubdir():
+		return install.DiscoverFromGitSubdir(source)
+	default:
+		return install.DiscoverFromGit(source)
+	}
+}
+
+// fetchLabels returns the spinner messages for fetching source.
+func fetchLabels(source *install.Source) (fetching, fetched, failed string) {
+	if source.IsArchive() {
+		return "Downloading archive...", "Downloaded", "Failed to download"
+	}
+	if source.IsOCI() {
+		return "Pulling from registry...", "Pulled", "Failed to pull"
+	}
+	return "Cloning repository...", "Cloned", "Failed to clone"
+}
@@ -9,6 +9,7 @@ import (
 	"skillshare/internal/config"
 	"skillshare/internal/install"
 	"skillshare/internal/ui"
+	"skillshare/internal/undo"
 	"skillshare/internal/validate"
 	appversion "skillshare/internal/version"
 )
@@ -59,6 +60,20 @@ func parseProjectInstallArgs(args []string) (*projectInstallArgs, bool, error) {
 			}
 			i++
 			result.opts.Into = args[i]
+		case arg == "--branch" || arg == "-b":
+			if i+1 >= len(args) {
+				return nil, false, fmt.Errorf("--branch requires a value")
+			}
+			i++
+			result.opts.Branch = args[i]
+		case arg == "--ref":
+			if i+1 >= len(args) {
+				return nil, false, fmt.Errorf("--ref requires a value")
+			}
+			i++
+			result.opts.Ref = args[i]
+		case arg == "--allow-partial":
+			result.opts.AllowPartial = true
 		case arg == "--all":
 			result.opts.All = true
 		case arg == "--yes" || arg == "-y":
@@ -116,6 +131,12 @@ func parseProjectInstallArgs(args []string) (*projectInstallArgs, bool, error) {
 	if result.opts.ShouldInstallAll() && result.opts.Track {
 		return nil, false, fmt.Errorf("--all/--yes cannot be used with --track")
 	}
+	if (result.opts.Branch != "" || result.opts.Ref != "") && !result.opts.Track {
+		return nil, false, fmt.Errorf("--branch and --ref require --track")
+	}
+	if result.opts.Branch != "" && result.opts.Ref != "" {
+		return nil, false, fmt.Errorf("--branch and --ref cannot be used together")
+	}
 
 	if result.opts.Into != "" {
 		if err := validate.IntoPath(result.opts.Into); err != nil {
@@ -126,7 +147,7 @@ func parseProjectInstallArgs(args []string) (*projectInstallArgs, bool, error) {
 	return result, false, nil
 }
 
-func cmdInstallProject(args []string, root string) (installLogSummary, error) {
+func cmdInstallProject(args []string, root string, rec *undo.Recorder) (installLogSummary, error) {
 	summary := installLogSummary{
 		Mode: "project",
 	}
@@ -177,7 +198,7 @@ func cmdInstallProject(args []string, root string) (installLogSummary, error) {
 	}
 
 	if resolvedFromMeta {
-		summary, err = handleDirectInstall(source, cfg, parsed.opts)
+		summary, err = handleDirectInstall(source, cfg, parsed.opts, rec)
 		summary.Mode = "project"
 		if err != nil {
 			return summary, err
@@ -188,7 +209,7 @@ func cmdInstallProject(args []string, root string) (installLogSummary, error) {
 		return summary, nil
 	}
 
-	summary, err = dispatchInstall(source, cfg, parsed.opts)
+	summary, err = dispatchInstall(source, cfg, parsed.opts, rec)
 	summary.Mode = "project"
 	if err != nil {
 		return summary, err
@@ -243,6 +264,7 @@ func installFromProjectConfig(runtime *projectRuntime, opts install.InstallOptio
 
 		if skill.Tracked {
 			trackOpts := opts
+			trackOpts.Branch, trackOpts.Ref = skill.Branch, skill.Ref
 			if groupDir != "" {
 				trackOpts.Into = groupDir
 			}
@@ -39,7 +39,7 @@ func TestHandleGitSubdirInstall_SingleSkill_ShowsLicense(t *testing.T) {
 	}
 
 	output := captureStdoutStderr(t, func() {
-		_, err := handleGitSubdirInstall(source, cfg, install.InstallOptions{DryRun: true})
+		_, err := handleGitSubdirInstall(source, cfg, install.InstallOptions{DryRun: true}, nil)
 		if err != nil {
 			t.Fatalf("handleGitSubdirInstall() error = %v", err)
 		}
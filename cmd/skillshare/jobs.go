@@ -0,0 +1,26 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// parseJobsFlag reads the value of --jobs/-j at args[i], accepting both
+// "--jobs N" and "--jobs=N". Returns the value and the index of the last
+// argument consumed.
+func parseJobsFlag(args []string, i int) (int, int, error) {
+	value, ok := strings.CutPrefix(args[i], "--jobs=")
+	if !ok {
+		i++
+		if i >= len(args) {
+			return 0, i, fmt.Errorf("--jobs requires a value")
+		}
+		value = args[i]
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil || n < 1 {
+		return 0, i, fmt.Errorf("--jobs must be a positive number, got %q", value)
+	}
+	return n, i, nil
+}
@@ -0,0 +1,358 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"skillshare/internal/config"
+	"skillshare/internal/lint"
+	"skillshare/internal/oplog"
+	"skillshare/internal/sync"
+	"skillshare/internal/ui"
+	"skillshare/internal/utils"
+	versioncheck "skillshare/internal/version"
+)
+
+type lintOptions struct {
+	Target string
+	Fix    bool
+	Format string // text, json, sarif
+}
+
+type lintSummary struct {
+	Skills   int `json:"skills"`
+	Clean    int `json:"clean"`
+	Errors   int `json:"errors"`
+	Warnings int `json:"warnings"`
+	Infos    int `json:"infos"`
+	Fixable  int `json:"fixable"`
+	Fixed    int `json:"fixed"`
+}
+
+type lintJSONOutput struct {
+	Results []*lint.Result `json:"results"`
+	Summary lintSummary    `json:"summary"`
+}
+
+func cmdLint(args []string) error {
+	start := time.Now()
+
+	mode, rest, err := parseModeArgs(args)
+	if err != nil {
+		return err
+	}
+
+	opts, showHelp, err := parseLintArgs(rest)
+	if showHelp || err != nil {
+		return err
+	}
+
+	cwd, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("cannot determine working directory: %w", err)
+	}
+	if mode == modeAuto {
+		if projectConfigExists(cwd) {
+			mode = modeProject
+		} else {
+			mode = modeGlobal
+		}
+	}
+	applyModeLabel(mode)
+
+	var (
+		sourcePath string
+		cfgPath    string
+		lintOpts   lint.Options
+	)
+	if mode == modeProject {
+		rt, err := loadProjectRuntime(cwd)
+		if err != nil {
+			return err
+		}
+		sourcePath = rt.sourcePath
+		cfgPath = config.ProjectConfigPath(cwd)
+		lintOpts.Config = rt.config.Lint
+		for name := range rt.targets {
+			lintOpts.Targets = append(lintOpts.Targets, name)
+		}
+	} else {
+		cfg, err := config.Load()
+		if err != nil {
+			return err
+		}
+		sourcePath = cfg.Source
+		cfgPath = config.ConfigPath()
+		lintOpts.Config = cfg.Lint
+		for name := range cfg.Targets {
+			lintOpts.Targets = append(lintOpts.Targets, name)
+		}
+	}
+
+	skills, err := lintSkillPaths(sourcePath, opts.Target)
+	if err != nil {
+		return err
+	}
+
+	if opts.Fix {
+		unlock, err := lockOperation("lint", mode, cwd, rest)
+		if err != nil {
+			return err
+		}
+		defer unlock()
+	}
+
+	results := make([]*lint.Result, 0, len(skills))
+	for _, sk := range skills {
+		res := lint.Check(sk.path, sk.name, lintOpts)
+		if opts.Fix && hasFixable(res) {
+			if res, err = lint.Fix(res, lintOpts); err != nil {
+				return fmt.Errorf("failed to fix %s: %w", sk.name, err)
+			}
+		}
+		results = append(results, res)
+	}
+	summary := summarizeLint(results)
+
+	if opts.Fix {
+		e := oplog.NewEntry("lint", "ok", time.Since(start))
+		e.Args = map[string]any{"fix": true, "fixed": summary.Fixed, "errors": summary.Errors, "warnings": summary.Warnings}
+		oplog.Write(cfgPath, oplog.OpsFile, e) //nolint:errcheck
+	}
+
+	switch opts.Format {
+	case "json":
+		out, _ := json.MarshalIndent(lintJSONOutput{Results: results, Summary: summary}, "", "  ")
+		fmt.Println(string(out))
+	case "sarif":
+		out, err := lint.SARIF(results, sourcePath, versioncheck.Version)
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(out))
+	default:
+		printLintResults(results, summary, sourcePath, modeString(mode), opts.Fix)
+	}
+
+	if summary.Errors > 0 {
+		os.Exit(1)
+	}
+	return nil
+}
+
+func parseLintArgs(args []string) (lintOptions, bool, error) {
+	opts := lintOptions{Format: "text"}
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		switch {
+		case arg == "--help" || arg == "-h":
+			printLintHelp()
+			return opts, true, nil
+		case arg == "--fix":
+			opts.Fix = true
+		case arg == "--json":
+			opts.Format = "json"
+		case arg == "--format" || strings.HasPrefix(arg, "--format="):
+			val, ok := strings.CutPrefix(arg, "--format=")
+			if !ok {
+				if i+1 >= len(args) {
+					return opts, false, fmt.Errorf("--format requires a value (text, json, sarif)")
+				}
+				i++
+				val = args[i]
+			}
+			switch val {
+			case "text", "json", "sarif":
+				opts.Format = val
+			default:
+				return opts, false, fmt.Errorf("invalid --format %q (use text, json or sarif)", val)
+			}
+		case strings.HasPrefix(arg, "-"):
+			return opts, false, fmt.Errorf("unknown option: %s", arg)
+		default:
+			if opts.Target != "" {
+				return opts, false, fmt.Errorf("unexpected argument: %s", arg)
+			}
+			opts.Target = arg
+		}
+	}
+	return opts, false, nil
+}
+
+type lintSkill struct {
+	name string
+	path string
+}
+
+// lintSkillPaths returns the skills to lint: target as a path or skill
+// name, or every skill in the source plus top-level directories that look
+// like skills but lack a SKILL.md.
+func lintSkillPaths(sourcePath, target string) ([]lintSkill, error) {
+	if target != "" {
+		if info, err := os.Stat(target); err == nil && info.IsDir() {
+			abs, _ := filepath.Abs(target)
+			return []lintSkill{{name: filepath.Base(abs), path: abs}}, nil
+		}
+		dir, err := findSkillDir(sourcePath, target)
+		if err != nil {
+			return nil, err
+		}
+		return []lintSkill{{name: target, path: dir}}, nil
+	}
+
+	discovered, err := sync.DiscoverSourceSkills(sourcePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to discover skills: %w", err)
+	}
+	var skills []lintSkill
+	containers := map[string]bool{}
+	for _, d := range discovered {
+		skills = append(skills, lintSkill{name: d.RelPath, path: d.SourcePath})
+		if i := strings.Index(d.RelPath, "/"); i > 0 {
+			containers[d.RelPath[:i]] = true
+		}
+	}
+
+	entries, _ := os.ReadDir(sourcePath)
+	for _, e := range entries {
+		name := e.Name()
+		if !e.IsDir() || utils.IsHidden(name) || utils.IsTrackedRepoDir(name) || containers[name] {
+			continue
+		}
+		if _, err := os.Stat(filepath.Join(sourcePath, name, "SKILL.md")); os.IsNotExist(err) {
+			skills = append(skills, lintSkill{name: name, path: filepath.Join(sourcePath, name)})
+		}
+	}
+
+	sort.Slice(skills, func(i, j int) bool { return skills[i].name < skills[j].name })
+	return skills, nil
+}
+
+func hasFixable(r *lint.Result) bool {
+	for _, f := range r.Findings {
+		if f.Fixable {
+			return true
+		}
+	}
+	return false
+}
+
+func summarizeLint(results []*lint.Result) lintSummary {
+	s := lintSummary{Skills: len(results)}
+	for _, r := range results {
+		e, w, i := r.Count()
+		s.Errors += e
+		s.Warnings += w
+		s.Infos += i
+		s.Fixed += len(r.Fixed)
+		if len(r.Findings) == 0 {
+			s.Clean++
+		}
+		for _, f := range r.Findings {
+			if f.Fixable {
+				s.Fixable++
+			}
+		}
+	}
+	return s
+}
+
+func printLintResults(results []*lint.Result, summary lintSummary, sourcePath, mode string, fixed bool) {
+	displayPath := sourcePath
+	if abs, err := filepath.Abs(sourcePath); err == nil {
+		displayPath = abs
+	}
+	ui.HeaderBox("skillshare lint", fmt.Sprintf("Linting %d skills\nmode: %s\npath: %s", len(results), mode, displayPath))
+
+	for _, r := range results {
+		for _, f := range r.Fixed {
+			ui.Success("%s: fixed %s", r.Skill, f)
+		}
+		if len(r.Findings) == 0 {
+			continue
+		}
+		fmt.Println()
+		fmt.Printf("%s\n", r.Skill)
+		for _, f := range r.Findings {
+			loc := f.File
+			if f.Line > 0 {
+				loc = fmt.Sprintf("%s:%d", f.File, f.Line)
+			}
+			fix := ""
+			if f.Fixable {
+				fix = " (fixable)"
+			}
+			fmt.Printf("  %s %-22s %s  %s%s%s\n", lintSeverityLabel(f.Severity), loc, f.Message, ui.Gray, f.Rule+fix, ui.Reset)
+		}
+	}
+
+	fmt.Println()
+	line := fmt.Sprintf("%d skills, %d clean · %d errors, %d warnings", summary.Skills, summary.Clean, summary.Errors, summary.Warnings)
+	if summary.Infos > 0 {
+		line += fmt.Sprintf(", %d info", summary.Infos)
+	}
+	switch {
+	case summary.Errors > 0:
+		ui.Error("%s", line)
+	case summary.Warnings > 0:
+		ui.Warning("%s", line)
+	default:
+		ui.Success("%s", line)
+	}
+	if summary.Fixable > 0 && !fixed {
+		ui.Info("%d problem(s) can be fixed with: skillshare lint --fix", summary.Fixable)
+	}
+}
+
+func lintSeverityLabel(severity string) string {
+	label := fmt.Sprintf("%-7s", severity)
+	if !ui.IsTTY() {
+		return label
+	}
+	switch severity {
+	case lint.SeverityError:
+		return ui.Red + label + ui.Reset
+	case lint.SeverityWarning:
+		return ui.Yellow + label + ui.Reset
+	default:
+		return ui.Cyan + label + ui.Reset
+	}
+}
+
+func printLintHelp() {
+	fmt.Println(`Usage: skillshare lint [skill|path] [options]
+
+Check skills for frontmatter and structure problems: name and description,
+unknown keys and targets, body size, broken links, unreferenced files in
+references/, non-executable scripts and malformed allowed-tools.
+
+Arguments:
+  skill|path          Lint one skill by name or a skill directory (default: all)
+
+Options:
+  --fix               Apply safe fixes (name, script permissions)
+  --format <f>        Output format: text (default), json, sarif
+  --json              Same as --format json
+  --project, -p       Use project-level config in current directory
+  --global, -g        Use global config (~/.config/skillshare)
+  --help, -h          Show this help
+
+Rules can be tuned under 'lint:' in config.yaml (or .skillshare/config.yaml):
+  lint:
+    rules:
+      description-trigger: off
+      body-size: error
+    max_body_lines: 300
+
+Exits with status 1 when any error-level problem is found.
+
+Examples:
+  skillshare lint
+  skillshare lint pdf --fix
+  skillshare lint --format sarif > lint.sarif`)
+}
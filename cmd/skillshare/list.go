@@ -166,6 +166,9 @@ func printVerboseDetails(s skillEntry, indent string) {
 	} else {
 		fmt.Printf("%s%sSource:%s      (local - no metadata)\n", indent, ui.Gray, ui.Reset)
 	}
+	if s.Usage != "" {
+		fmt.Printf("%s%sUsed:%s        %s\n", indent, ui.Gray, ui.Reset, s.Usage)
+	}
 	fmt.Println()
 }
 
@@ -317,6 +320,11 @@ func cmdList(args []string) error {
 	if len(skills) > 0 {
 		ui.Header("Installed skills")
 		if verbose {
+			if summary := loadUsageSummary(cfg); summary != nil {
+				for i := range skills {
+					skills[i].Usage = formatUsageLine(summary, skills[i].Name)
+				}
+			}
 			displaySkillsVerbose(skills)
 		} else {
 			displaySkillsCompact(skills)
@@ -343,6 +351,7 @@ type skillEntry struct {
 	IsNested    bool
 	RepoName    string
 	RelPath     string
+	Usage       string // list --verbose usage summary; empty when tracking is off
 }
 
 // abbreviateSource shortens long sources for display
@@ -364,7 +373,7 @@ func printListHelp() {
 List all installed skills in the source directory.
 
 Options:
-  --verbose, -v   Show detailed information (source, type, install date)
+  --verbose, -v   Show detailed information (source, type, install date, usage)
   --project, -p   Use project-level config in current directory
   --global, -g    Use global config (~/.config/skillshare)
   --help, -h      Show this help
@@ -245,6 +245,9 @@ func printLogDetailMultiLine(w io.Writer, e oplog.Entry, termWidth int) {
 	if e.Message != "" {
 		pairs = append(pairs, logDetailPair{key: "message", value: e.Message})
 	}
+	if e.Changeset != "" {
+		pairs = append(pairs, logDetailPair{key: "undo", value: "skillshare undo " + e.Changeset})
+	}
 
 	if len(pairs) == 0 {
 		return
@@ -281,6 +284,8 @@ func formatLogDetailPairs(e oplog.Entry) []logDetailPair {
 		return formatInstallLogPairs(e.Args)
 	case "audit":
 		return formatAuditLogPairs(e.Args)
+	case "hook":
+		return formatHookLogPairs(e.Args)
 	default:
 		return formatGenericLogPairs(e.Args)
 	}
@@ -416,6 +421,25 @@ func formatAuditLogPairs(args map[string]any) []logDetailPair {
 	return pairs
 }
 
+func formatHookLogPairs(args map[string]any) []logDetailPair {
+	var pairs []logDetailPair
+
+	if event, ok := logArgString(args, "event"); ok {
+		pairs = append(pairs, logDetailPair{key: "event", value: event})
+	}
+	if skill, ok := logArgString(args, "skill"); ok {
+		pairs = append(pairs, logDetailPair{key: "skill", value: skill})
+	}
+	if declared, ok := logArgBool(args, "declared_by_skill"); ok && declared {
+		pairs = append(pairs, logDetailPair{key: "declared by skill", value: "yes"})
+	}
+	if command, ok := logArgString(args, "command"); ok {
+		pairs = append(pairs, logDetailPair{key: "command", value: command})
+	}
+
+	return pairs
+}
+
 func formatGenericLogPairs(args map[string]any) []logDetailPair {
 	var pairs []logDetailPair
 
@@ -524,6 +548,8 @@ func formatLogDetail(e oplog.Entry, truncate bool) string {
 			detail = formatInstallLogDetail(e.Args)
 		case "audit":
 			detail = formatAuditLogDetail(e.Args)
+		case "hook":
+			detail = formatHookLogDetail(e.Args)
 		default:
 			detail = formatGenericLogDetail(e.Args)
 		}
@@ -673,6 +699,22 @@ func formatAuditLogDetail(args map[string]any) string {
 	return strings.Join(parts, ", ")
 }
 
+func formatHookLogDetail(args map[string]any) string {
+	parts := make([]string, 0, 3)
+
+	if event, ok := logArgString(args, "event"); ok {
+		parts = append(parts, event)
+	}
+	if skill, ok := logArgString(args, "skill"); ok {
+		parts = append(parts, skill)
+	}
+	if command, ok := logArgString(args, "command"); ok {
+		parts = append(parts, command)
+	}
+
+	return strings.Join(parts, ", ")
+}
+
 func formatGenericLogDetail(args map[string]any) string {
 	parts := make([]string, 0, 4)
 
@@ -245,6 +245,21 @@ func TestFormatInstallLogDetail_IncludesInstalledSkills(t *testing.T) {
 	}
 }
 
+func TestFormatHookLogDetail_IncludesEventSkillAndCommand(t *testing.T) {
+	args := map[string]any{
+		"event":   "post-install",
+		"skill":   "pdf",
+		"command": "pip install -r requirements.txt",
+		"mode":    "global",
+		"output":  "Successfully installed",
+	}
+
+	detail := formatHookLogDetail(args)
+	if detail != "post-install, pdf, pip install -r requirements.txt" {
+		t.Fatalf("unexpected hook detail: %s", detail)
+	}
+}
+
 func TestFormatAuditLogDetail_IncludesExtendedFields(t *testing.T) {
 	args := map[string]any{
 		"scope":      "all",
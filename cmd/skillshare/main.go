@@ -7,6 +7,9 @@ import (
 	"runtime"
 
 	"skillshare/internal/config"
+	"skillshare/internal/credential"
+	"skillshare/internal/install"
+	"skillshare/internal/network"
 	"skillshare/internal/ui"
 	versioncheck "skillshare/internal/version"
 )
@@ -17,6 +20,7 @@ var version = "dev"
 var commands = map[string]func([]string) error{
 	"init":      cmdInit,
 	"install":   cmdInstall,
+	"import":    cmdImport,
 	"uninstall": cmdUninstall,
 	"list":      cmdList,
 	"sync":      cmdSync,
@@ -35,9 +39,20 @@ var commands = map[string]func([]string) error{
 	"new":       cmdNew,
 	"search":    cmdSearch,
 	"trash":     cmdTrash,
+	"undo":      cmdUndo,
 	"audit":     cmdAudit,
+	"lint":      cmdLint,
+	"stats":     cmdStats,
+	"usage":     cmdUsage,
+	"dedupe":    cmdDedupe,
+	"review":    cmdReview,
+	"publish":   cmdPublish,
+	"hooks":     cmdHooks,
+	"repo":      cmdRepo,
+	"cache":     cmdCache,
 	"hub":       cmdHub,
 	"log":       cmdLog,
+	"daemon":    cmdDaemon,
 	"ui":        cmdUI,
 }
 
@@ -55,6 +70,19 @@ func main() {
 	// Set version for other packages to use
 	versioncheck.Version = version
 
+	// Installs and updates share one clone cache across global and project mode
+	install.CacheDir = filepath.Join(config.CacheDir(), "repos")
+
+	// Per-host credentials, network and cache settings apply in both modes;
+	// a missing or invalid config is reported by the command itself
+	if cfg, err := config.Load(); err == nil {
+		install.CacheMaxBytes = cfg.Cache.MaxBytes()
+		credential.Configure(cfg.Auth)
+		if err := network.Configure(cfg.Network); err != nil {
+			ui.Warning("Ignoring network config: %v", err)
+		}
+	}
+
 	if len(os.Args) < 2 {
 		printUsage()
 		os.Exit(1)
@@ -172,10 +200,13 @@ func printUsage() {
 
 	// Skill Management
 	fmt.Println("SKILL MANAGEMENT")
-	cmd("new", "<name>", "Create a new skill with SKILL.md template")
+	cmd("new", "<name> [-t T]", "Create a new skill with SKILL.md template")
+	cmd("import", "--from <kind> [path]", "Convert commands, rules and instructions into skills")
 	cmd("check", "", "Check for available updates")
 	cmd("update", "<name>", "Update a skill or tracked repository")
 	cmd("update", "--all", "Update all tracked repositories")
+	cmd("repo checkout", "<repo> <ref>", "Switch a tracked repo's branch or pin it at a tag/commit")
+	cmd("publish", "<name> oci://<ref>", "Publish a skill to an OCI registry")
 	cmd("upgrade", "", "Upgrade CLI and/or skillshare skill")
 	fmt.Println()
 
@@ -191,23 +222,34 @@ func printUsage() {
 	fmt.Println("SYNC & BACKUP")
 	cmd("collect", "[target]", "Collect local skills from target(s) to source")
 	cmd("backup", "", "Create backup of target(s)")
+	cmd("backup", "show <timestamp>", "List skills stored in a backup")
 	cmd("restore", "<target>", "Restore target from latest backup")
+	cmd("restore", "<target> --skill <name>", "Restore individual skills from a backup")
 	cmd("trash", "list", "List trashed skills")
 	cmd("trash", "restore <name>", "Restore a skill from trash")
+	cmd("undo", "[op-id]", "Revert the last mutating operation")
 	fmt.Println()
 
 	// Git Remote
 	fmt.Println("GIT REMOTE")
-	cmd("push", "", "Commit and push source to git remote")
+	cmd("push", "[--per-skill] [--branch B]", "Commit and push source to git remote")
 	cmd("pull", "", "Pull from git remote and sync to targets")
 	fmt.Println()
 
 	// Utilities
 	fmt.Println("UTILITIES")
 	cmd("audit", "[name]", "Scan skills for security threats")
+	cmd("lint", "[name] [--fix]", "Check skills for frontmatter and structure problems")
+	cmd("stats", "[--target name]", "Estimate context token cost per skill and target")
+	cmd("usage", "[skill] [--days N]", "Count skill invocations from agent transcripts (opt-in)")
+	cmd("dedupe", "[keep|merge|ignore]", "Find and resolve duplicate skills")
+	cmd("hooks", "[list|trust|run]", "Show hooks and trust skills to run their own")
+	cmd("review", "[skill...]", "List skills due for review, or mark them reviewed")
+	cmd("cache", "[list|prune]", "Show or prune the shared clone cache")
 	cmd("hub", "<subcommand>", "Manage hubs (add, list, remove, default, index)")
 	cmd("log", "", "View operation log")
 	cmd("ui", "", "Launch web dashboard")
+	cmd("daemon", "[status|stop]", "Run scheduled maintenance in the background")
 	cmd("doctor", "", "Check environment and diagnose issues")
 	cmd("version", "", "Show version")
 	cmd("help", "", "Show this help")
@@ -18,18 +18,40 @@ func cmdNew(args []string) error {
 	}
 
 	var skillName string
-	var dryRun bool
+	var dryRun, listTemplates bool
+	var templateSpec, fromSkill, author, targets string
 
 	// Parse arguments
 	i := 0
 	for i < len(rest) {
 		arg := rest[i]
+		key, val, hasVal := strings.Cut(arg, "=")
 		switch {
 		case arg == "--dry-run" || arg == "-n":
 			dryRun = true
 		case arg == "--help" || arg == "-h":
 			printNewHelp()
 			return nil
+		case arg == "--list-templates":
+			listTemplates = true
+		case key == "--template" || key == "-t" || key == "--from" || key == "--author" || key == "--targets":
+			if !hasVal {
+				if i+1 >= len(rest) {
+					return fmt.Errorf("%s requires a value", key)
+				}
+				i++
+				val = rest[i]
+			}
+			switch key {
+			case "--template", "-t":
+				templateSpec = val
+			case "--from":
+				fromSkill = val
+			case "--author":
+				author = val
+			case "--targets":
+				targets = val
+			}
 		case strings.HasPrefix(arg, "-"):
 			return fmt.Errorf("unknown option: %s", arg)
 		default:
@@ -41,13 +63,16 @@ func cmdNew(args []string) error {
 		i++
 	}
 
-	if skillName == "" {
+	if skillName == "" && !listTemplates {
 		printNewHelp()
 		return fmt.Errorf("skill name is required")
 	}
+	if templateSpec != "" && fromSkill != "" {
+		return fmt.Errorf("--template and --from cannot be used together")
+	}
 
 	// Validate skill name
-	if !isValidSkillName(skillName) {
+	if skillName != "" && !isValidSkillName(skillName) {
 		return fmt.Errorf("invalid skill name: use lowercase letters, numbers, and hyphens only")
 	}
 
@@ -66,16 +91,25 @@ func cmdNew(args []string) error {
 
 	applyModeLabel(mode)
 
-	// Resolve source directory
-	var sourceDir string
-	if mode == modeProject {
-		sourceDir = filepath.Join(cwd, ".skillshare", "skills")
-	} else {
-		cfg, err := config.Load()
+	if listTemplates {
+		sourceDir, err := newSourceDir(mode, cwd)
+		if err != nil {
+			return err
+		}
+		return printTemplates(sourceDir)
+	}
+
+	if !dryRun {
+		unlock, err := lockOperation("new", mode, cwd, nil)
 		if err != nil {
-			return fmt.Errorf("failed to load config: %w (run 'skillshare init' first)", err)
+			return err
 		}
-		sourceDir = cfg.Source
+		defer unlock()
+	}
+
+	sourceDir, err := newSourceDir(mode, cwd)
+	if err != nil {
+		return err
 	}
 
 	// Create skill directory path
@@ -87,6 +121,19 @@ func cmdNew(args []string) error {
 		return fmt.Errorf("skill '%s' already exists at %s", skillName, skillDir)
 	}
 
+	if templateSpec != "" || fromSkill != "" {
+		return newFromScaffold(scaffoldRequest{
+			name:      skillName,
+			sourceDir: sourceDir,
+			skillDir:  skillDir,
+			template:  templateSpec,
+			from:      fromSkill,
+			author:    author,
+			targets:   targets,
+			dryRun:    dryRun,
+		})
+	}
+
 	// Generate template
 	template := generateSkillTemplate(skillName)
 
@@ -114,16 +161,28 @@ func cmdNew(args []string) error {
 
 	ui.Header(ui.WithModeLabel("New Skill Created"))
 	ui.Success("Created: %s", skillFile)
-	fmt.Println()
-	ui.Info("Next steps:")
-	fmt.Printf("  1. Edit %s\n", skillFile)
+	printNewNextSteps(skillFile)
+
+	return nil
+}
+
+// newSourceDir resolves the skills source for the given mode.
+func newSourceDir(mode runMode, cwd string) (string, error) {
 	if mode == modeProject {
-		fmt.Println("  2. Run 'skillshare sync' to deploy")
-	} else {
-		fmt.Println("  2. Run 'skillshare sync' to deploy")
+		return filepath.Join(cwd, ".skillshare", "skills"), nil
+	}
+	cfg, err := config.Load()
+	if err != nil {
+		return "", fmt.Errorf("failed to load config: %w (run 'skillshare init' first)", err)
 	}
+	return cfg.Source, nil
+}
 
-	return nil
+func printNewNextSteps(skillFile string) {
+	fmt.Println()
+	ui.Info("Next steps:")
+	fmt.Printf("  1. Edit %s\n", skillFile)
+	fmt.Println("  2. Run 'skillshare sync' to deploy")
 }
 
 // isValidSkillName validates skill name format
@@ -220,16 +279,29 @@ func printNewHelp() {
 Create a new skill with a SKILL.md template.
 
 Options:
-  --project, -p   Create in project (.skillshare/skills/)
-  --global, -g    Create in global (~/.config/skillshare/skills/)
-  --dry-run, -n   Preview without creating files
-  --help, -h      Show this help
+  --template, -t <t>  Scaffold from a template: a name from templates/ or a
+                      skill marked "template: true", a local path, or a git
+                      source (owner/repo[/path], URL)
+  --from <skill>      Copy an existing skill under the new name
+  --author <name>     Value for {{author}} (default: git user.name)
+  --targets <a,b>     Value for {{targets}}
+  --list-templates    List available templates
+  --project, -p       Create in project (.skillshare/skills/)
+  --global, -g        Create in global (~/.config/skillshare/skills/)
+  --dry-run, -n       Preview without creating files
+  --help, -h          Show this help
 
 Arguments:
-  <name>          Skill name (lowercase, hyphens allowed)
+  <name>              Skill name (lowercase, hyphens allowed)
+
+Template variables: {{name}}, {{title}}, {{author}}, {{targets}}, {{date}}
+are replaced in file contents and file names.
 
 Examples:
-  skillshare new my-skill              # Create a new skill
-  skillshare new my-skill -p           # Create in project
-  skillshare new my-skill --dry-run    # Preview first`)
+  skillshare new my-skill                        # Create a new skill
+  skillshare new my-skill -p                     # Create in project
+  skillshare new my-skill --dry-run              # Preview first
+  skillshare new pdf-tools --template python-tool
+  skillshare new pdf-tools -t acme/skill-templates/python
+  skillshare new review-v2 --from code-review`)
 }
@@ -0,0 +1,215 @@
+package main
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"skillshare/internal/install"
+	"skillshare/internal/scaffold"
+	"skillshare/internal/ui"
+	"skillshare/internal/utils"
+)
+
+// scaffoldRequest holds the inputs of `new --template` / `new --from`.
+type scaffoldRequest struct {
+	name      string
+	sourceDir string
+	skillDir  string
+	template  string
+	from      string
+	author    string
+	targets   string
+	dryRun    bool
+}
+
+// newFromScaffold creates a skill from a template or an existing skill.
+func newFromScaffold(req scaffoldRequest) error {
+	var srcDir, label string
+	var cleanup func()
+
+	if req.from != "" {
+		dir, err := findSkillDir(req.sourceDir, req.from)
+		if err != nil {
+			return err
+		}
+		srcDir, label = dir, "skill "+req.from
+	} else {
+		dir, name, done, err := resolveTemplate(req.template, req.sourceDir)
+		if err != nil {
+			return err
+		}
+		srcDir, label, cleanup = dir, "template "+name, done
+	}
+	if cleanup != nil {
+		defer cleanup()
+	}
+
+	if req.dryRun {
+		ui.Header(ui.WithModeLabel("New Skill (dry-run)"))
+		ui.Info("Would create: %s", req.skillDir)
+		ui.Info("From %s (%s)", label, srcDir)
+		return nil
+	}
+
+	var written []string
+	var err error
+	if req.from != "" {
+		written, err = scaffold.Clone(srcDir, req.skillDir, req.name)
+	} else {
+		author := req.author
+		if author == "" {
+			author = gitUserName()
+		}
+		written, err = scaffold.Render(srcDir, req.skillDir, scaffold.Vars{
+			Name:    req.name,
+			Title:   toTitleCase(req.name),
+			Author:  author,
+			Targets: splitCommaList(req.targets),
+		})
+	}
+	if err != nil {
+		return fmt.Errorf("failed to create skill from %s: %w", label, err)
+	}
+
+	ui.Header(ui.WithModeLabel("New Skill Created"))
+	ui.Success("Created %s from %s", req.skillDir, label)
+	for _, f := range written {
+		fmt.Printf("  %s%s%s\n", ui.Gray, f, ui.Reset)
+	}
+	printNewNextSteps(filepath.Join(req.skillDir, "SKILL.md"))
+	return nil
+}
+
+// resolveTemplate finds the template directory for spec: a discovered
+// template name, a local directory, or a git source cloned to a temp dir.
+// The returned cleanup removes any temporary clone.
+func resolveTemplate(spec, sourceDir string) (dir, name string, cleanup func(), err error) {
+	templates := scaffold.Discover(scaffold.TemplatesDir(sourceDir), sourceDir)
+	if t, ok := scaffold.Find(templates, spec); ok {
+		return t.Path, t.Name, nil, nil
+	}
+
+	local := spec
+	if utils.HasTildePrefix(local) {
+		if home, err := os.UserHomeDir(); err == nil {
+			local = filepath.Join(home, local[1:])
+		}
+	}
+	if info, err := os.Stat(local); err == nil && info.IsDir() {
+		abs, _ := filepath.Abs(local)
+		return abs, filepath.Base(abs), nil, nil
+	}
+
+	source, parseErr := install.ParseSource(spec)
+	if parseErr != nil || !source.IsGit() {
+		return "", "", nil, templateNotFound(spec, templates)
+	}
+
+	spinner := ui.StartSpinner(fmt.Sprintf("Fetching template %s...", spec))
+	var discovery *install.DiscoveryResult
+	if source.HasSubdir() {
+		discovery, err = install.DiscoverFromGitSubdir(source)
+	} else {
+		discovery, err = install.DiscoverFromGit(source)
+	}
+	if err != nil {
+		spinner.Fail("Failed to fetch template")
+		return "", "", nil, err
+	}
+	spinner.Stop()
+
+	dir = filepath.Join(discovery.RepoPath, "repo", filepath.FromSlash(source.Subdir))
+	return dir, source.Name, func() { install.CleanupDiscovery(discovery) }, nil
+}
+
+func templateNotFound(spec string, templates []scaffold.Template) error {
+	if len(templates) == 0 {
+		return fmt.Errorf("template '%s' not found (no templates available)", spec)
+	}
+	names := make([]string, len(templates))
+	for i, t := range templates {
+		names[i] = t.Name
+	}
+	return fmt.Errorf("template '%s' not found (available: %s)", spec, strings.Join(names, ", "))
+}
+
+// findSkillDir locates an existing skill by relative path, directory name
+// or frontmatter name.
+func findSkillDir(sourceDir, name string) (string, error) {
+	direct := filepath.Join(sourceDir, filepath.FromSlash(name))
+	if _, err := os.Stat(filepath.Join(direct, "SKILL.md")); err == nil {
+		return direct, nil
+	}
+
+	var matches []string
+	filepath.WalkDir(sourceDir, func(path string, d fs.DirEntry, err error) error { //nolint:errcheck
+		if err != nil {
+			return nil
+		}
+		if d.IsDir() && path != sourceDir && (d.Name() == ".git" || utils.IsHidden(d.Name())) {
+			return filepath.SkipDir
+		}
+		if d.IsDir() || d.Name() != "SKILL.md" {
+			return nil
+		}
+		dir := filepath.Dir(path)
+		if filepath.Base(dir) == name {
+			matches = append(matches, dir)
+		} else if fm, _ := utils.ParseSkillName(dir); fm == name {
+			matches = append(matches, dir)
+		}
+		return nil
+	})
+
+	switch len(matches) {
+	case 0:
+		return "", fmt.Errorf("skill '%s' not found in %s", name, sourceDir)
+	case 1:
+		return matches[0], nil
+	default:
+		for i, m := range matches {
+			matches[i], _ = filepath.Rel(sourceDir, m)
+		}
+		return "", fmt.Errorf("skill '%s' is ambiguous, use its path: %s", name, strings.Join(matches, ", "))
+	}
+}
+
+func printTemplates(sourceDir string) error {
+	templatesDir := scaffold.TemplatesDir(sourceDir)
+	templates := scaffold.Discover(templatesDir, sourceDir)
+
+	ui.Header(ui.WithModeLabel("Skill Templates"))
+	if len(templates) == 0 {
+		ui.Info("No templates found")
+		fmt.Println()
+		ui.Info("Add template directories to %s", templatesDir)
+		ui.Info("or set 'template: true' in an installed skill's frontmatter")
+		return nil
+	}
+	for _, t := range templates {
+		fmt.Printf("  %-24s %s%s%s\n", t.Name, ui.Gray, t.Path, ui.Reset)
+	}
+	return nil
+}
+
+func gitUserName() string {
+	out, err := exec.Command("git", "config", "user.name").Output()
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(out))
+}
+
+func splitCommaList(s string) []string {
+	var out []string
+	for _, part := range strings.Split(s, ",") {
+		if part = strings.TrimSpace(part); part != "" {
+			out = append(out, part)
+		}
+	}
+	return out
+}
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+
+	"skillshare/internal/config"
+	"skillshare/internal/install"
+	"skillshare/internal/oplock"
+	"skillshare/internal/ui"
+)
+
+// lockOperation takes the cross-process operation lock for the scope of a
+// mutating command, waiting for another skillshare process (CLI, web UI or
+// daemon) to finish first. Dry runs and help output skip the lock. The
+// returned function releases it.
+func lockOperation(cmd string, mode runMode, cwd string, args []string) (func(), error) {
+	return lockNestedOperation(nil, cmd, mode, cwd, args)
+}
+
+// lockNestedOperation is lockOperation for a command run inside another
+// that holds held, such as the sync after a pull: it shares held when that
+// covers the same scope instead of waiting for it.
+func lockNestedOperation(held *oplock.Lock, cmd string, mode runMode, cwd string, args []string) (func(), error) {
+	for _, a := range args {
+		switch a {
+		case "--dry-run", "-n", "--help", "-h":
+			return func() {}, nil
+		}
+	}
+
+	cfgPath := config.ConfigPath()
+	if mode == modeProject {
+		projectDir := filepath.Join(cwd, ".skillshare")
+		if _, err := os.Stat(projectDir); err != nil {
+			// Not initialized yet; the command reports that itself
+			return func() {}, nil
+		}
+		cfgPath = config.ProjectConfigPath(cwd)
+		_ = install.UpdateGitIgnore(projectDir, oplock.FileName)
+	}
+	if l := held.Nested(oplock.Path(cfgPath)); l != nil {
+		return l.Release, nil
+	}
+	return acquireOpLock(cfgPath, cmd)
+}
+
+// acquireOpLock takes the operation lock for the scope of cfgPath.
+func acquireOpLock(cfgPath, cmd string) (func(), error) {
+	l, err := acquireHeldOpLock(cfgPath, cmd)
+	if err != nil {
+		return nil, err
+	}
+	return l.Release, nil
+}
+
+// acquireHeldOpLock is acquireOpLock for commands that pass the lock on to
+// nested commands.
+func acquireHeldOpLock(cfgPath, cmd string) (*oplock.Lock, error) {
+	return oplock.Acquire(oplock.Path(cfgPath), cmd, oplock.Timeout(), func(h oplock.Holder) {
+		ui.Info("Waiting for %s to finish...", h)
+	})
+}
@@ -0,0 +1,191 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"skillshare/internal/config"
+	"skillshare/internal/oci"
+	"skillshare/internal/oplog"
+	"skillshare/internal/sync"
+	"skillshare/internal/ui"
+	"skillshare/internal/utils"
+)
+
+type publishOptions struct {
+	skill  string
+	ref    string
+	dryRun bool
+}
+
+func cmdPublish(args []string) error {
+	start := time.Now()
+
+	mode, rest, err := parseModeArgs(args)
+	if err != nil {
+		return err
+	}
+
+	opts, showHelp, err := parsePublishArgs(rest)
+	if showHelp {
+		printPublishHelp()
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	cwd, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("cannot determine working directory: %w", err)
+	}
+	if mode == modeAuto {
+		if projectConfigExists(cwd) {
+			mode = modeProject
+		} else {
+			mode = modeGlobal
+		}
+	}
+	applyModeLabel(mode)
+
+	var sourcePath, cfgPath string
+	if mode == modeProject {
+		rt, err := loadProjectRuntime(cwd)
+		if err != nil {
+			return err
+		}
+		sourcePath, cfgPath = rt.sourcePath, config.ProjectConfigPath(cwd)
+	} else {
+		cfg, err := config.Load()
+		if err != nil {
+			return err
+		}
+		sourcePath, cfgPath = cfg.Source, config.ConfigPath()
+	}
+
+	digest, err := publishSkill(sourcePath, opts)
+	if !opts.dryRun {
+		e := oplog.NewEntry("publish", statusFromErr(err), time.Since(start))
+		e.Args = map[string]any{"skill": opts.skill, "ref": opts.ref}
+		if digest != "" {
+			e.Args["digest"] = digest
+		}
+		if err != nil {
+			e.Message = err.Error()
+		}
+		oplog.Write(cfgPath, oplog.OpsFile, e) //nolint:errcheck
+	}
+	return err
+}
+
+func parsePublishArgs(args []string) (publishOptions, bool, error) {
+	var opts publishOptions
+	var positional []string
+	for _, arg := range args {
+		switch arg {
+		case "--help", "-h":
+			return opts, true, nil
+		case "--dry-run", "-n":
+			opts.dryRun = true
+		default:
+			if strings.HasPrefix(arg, "-") {
+				return opts, false, fmt.Errorf("unknown option: %s", arg)
+			}
+			positional = append(positional, arg)
+		}
+	}
+	if len(positional) != 2 {
+		return opts, false, fmt.Errorf("usage: skillshare publish <skill> oci://<registry>/<repository>:<tag>")
+	}
+	opts.skill, opts.ref = positional[0], positional[1]
+	return opts, false, nil
+}
+
+// publishSkill packs a skill from sourcePath and pushes it to the registry.
+// Returns the manifest digest.
+func publishSkill(sourcePath string, opts publishOptions) (string, error) {
+	if !strings.HasPrefix(opts.ref, oci.Scheme) {
+		return "", fmt.Errorf("publish target must be an oci:// reference, got %s", opts.ref)
+	}
+	ref, err := oci.ParseReference(opts.ref)
+	if err != nil {
+		return "", err
+	}
+	if ref.Digest != "" {
+		return "", fmt.Errorf("publish target needs a tag, not a digest: %s", opts.ref)
+	}
+
+	discovered, err := sync.DiscoverSourceSkills(sourcePath)
+	if err != nil {
+		return "", fmt.Errorf("failed to discover skills: %w", err)
+	}
+	skill, err := findReviewSkill(discovered, opts.skill)
+	if err != nil {
+		return "", err
+	}
+	skillMD := filepath.Join(skill.SourcePath, "SKILL.md")
+	if _, err := os.Stat(skillMD); err != nil {
+		return "", fmt.Errorf("%s has no SKILL.md", skill.RelPath)
+	}
+
+	fields := utils.ParseFrontmatterFields(skillMD)
+	name := fields["name"]
+	if name == "" {
+		name = filepath.Base(skill.SourcePath)
+	}
+	layer, err := oci.PackDir(skill.SourcePath)
+	if err != nil {
+		return "", fmt.Errorf("failed to pack %s: %w", skill.RelPath, err)
+	}
+
+	ui.Header(ui.WithModeLabel("Publishing " + name))
+	ui.StepStart("Skill", skill.RelPath)
+	ui.StepContinue("Target", ref.String())
+	ui.StepEnd("Size", formatBytes(int64(len(layer))))
+
+	if opts.dryRun {
+		fmt.Println()
+		ui.Info("[dry-run] Would push %s (layer %s)", ref, oci.Digest(layer))
+		return "", nil
+	}
+
+	spinner := ui.StartSpinner("Pushing to " + ref.Registry + "...")
+	digest, err := oci.NewClient().Push(ref, layer, oci.Annotations(name, ref.Tag, fields))
+	if err != nil {
+		spinner.Fail("Push failed")
+		return "", err
+	}
+	spinner.Success(fmt.Sprintf("Published %s", ref))
+
+	fmt.Println()
+	ui.Info("Digest: %s", digest)
+	ui.Info("Install with: skillshare install %s%s@%s", oci.Scheme, ref, digest)
+	return digest, nil
+}
+
+func printPublishHelp() {
+	fmt.Println(`Usage: skillshare publish <skill> oci://<registry>/<repository>:<tag> [options]
+
+Publish a skill to an OCI registry (Harbor, GHCR, registry:2, ...) as an
+artifact of type application/vnd.skillshare.skill.v1. The skill directory
+is pushed as one layer, and its SKILL.md frontmatter is recorded as
+manifest annotations. Install it with 'skillshare install oci://...'.
+
+Registry credentials come from the docker config (~/.docker/config.json
+or $DOCKER_CONFIG): credential helpers, credsStore, or 'docker login'
+entries. Registries on localhost are reached over plain HTTP.
+
+Options:
+  --dry-run, -n       Pack the skill and show what would be pushed
+  --project, -p       Publish from the project's .skillshare/skills
+  --global, -g        Publish from the global source directory
+  --help, -h          Show this help
+
+Examples:
+  skillshare publish pdf oci://harbor.example.com/team/skills/pdf:1.2.0
+  skillshare publish frontend/react oci://ghcr.io/org/skills/react:2.0.0
+  skillshare publish pdf oci://localhost:5000/skills/pdf:dev --dry-run`)
+}
@@ -9,8 +9,11 @@ import (
 	"strings"
 	"time"
 
+	"github.com/AlecAivazis/survey/v2"
+
 	"skillshare/internal/config"
 	gitops "skillshare/internal/git"
+	"skillshare/internal/oplock"
 	"skillshare/internal/oplog"
 	"skillshare/internal/ui"
 )
@@ -22,29 +25,94 @@ const (
 	firstPullApplied
 )
 
-func cmdPull(args []string) error {
-	start := time.Now()
-	dryRun := false
-	force := false
+type pullOptions struct {
+	dryRun   bool
+	force    bool
+	strategy string // gitops.StrategyRebase or gitops.StrategyMerge
+	resolve  string // resolve all conflicts: gitops.SideOurs or gitops.SideTheirs
+	cont     bool
+	abort    bool
+}
 
+func parsePullArgs(args []string) (pullOptions, bool, error) {
+	opts := pullOptions{strategy: gitops.StrategyRebase}
 	for _, arg := range args {
 		switch arg {
 		case "--dry-run", "-n":
-			dryRun = true
+			opts.dryRun = true
 		case "--force", "-f":
-			force = true
+			opts.force = true
+		case "--rebase":
+			opts.strategy = gitops.StrategyRebase
+		case "--merge":
+			opts.strategy = gitops.StrategyMerge
+		case "--ours":
+			opts.resolve = gitops.SideOurs
+		case "--theirs":
+			opts.resolve = gitops.SideTheirs
+		case "--continue":
+			opts.cont = true
+		case "--abort":
+			opts.abort = true
+		case "--global", "-g":
+			// pull always uses the global source
+		case "--help", "-h":
+			return opts, true, nil
+		default:
+			return opts, false, fmt.Errorf("unknown option: %s", arg)
 		}
 	}
+	if opts.cont && opts.abort {
+		return opts, false, fmt.Errorf("--continue and --abort are mutually exclusive")
+	}
+	return opts, false, nil
+}
+
+func cmdPull(args []string) error {
+	post := &commandHooks{}
+	return post.finish(runPull(args, post))
+}
+
+// runPull is cmdPull; post runs the hooks of the sync that follows the pull.
+func runPull(args []string, post *commandHooks) error {
+	start := time.Now()
+
+	opts, showHelp, err := parsePullArgs(args)
+	if showHelp {
+		printPullHelp()
+		return nil
+	}
+	if err != nil {
+		return err
+	}
 
 	cfg, err := config.Load()
 	if err != nil {
 		return err
 	}
 
-	err = pullFromRemote(cfg, dryRun, force)
+	var lock *oplock.Lock
+	if !opts.dryRun {
+		lock, err = acquireHeldOpLock(config.ConfigPath(), "pull")
+		if err != nil {
+			return err
+		}
+		defer lock.Release()
+	}
+
+	err = pullFromRemote(cfg, opts, lock, post)
 
-	if !dryRun {
+	if !opts.dryRun {
 		e := oplog.NewEntry("pull", statusFromErr(err), time.Since(start))
+		if opts.strategy != gitops.StrategyRebase || opts.cont || opts.abort {
+			e.Args = map[string]any{"strategy": opts.strategy}
+			if opts.cont {
+				e.Args["continue"] = true
+			}
+			if opts.abort {
+				e.Args["abort"] = true
+			}
+		}
 		if err != nil {
 			e.Message = err.Error()
 		}
@@ -54,8 +122,10 @@ func cmdPull(args []string) error {
 	return err
 }
 
-// pullFromRemote pulls from git remote and syncs to all targets
-func pullFromRemote(cfg *config.Config, dryRun, force bool) error {
+// pullFromRemote pulls from git remote and syncs to all targets. Local
+// changes are stashed and restored; conflicts are resolved per skill
+// before anything is synced.
+func pullFromRemote(cfg *config.Config, opts pullOptions, lock *oplock.Lock, post *commandHooks) error {
 	ui.Header("Pulling from remote")
 
 	spinner := ui.StartSpinner("Checking repository...")
@@ -78,6 +148,31 @@ func pullFromRemote(cfg *config.Config, dryRun, force bool) error {
 		return nil
 	}
 
+	if opts.abort {
+		if err := gitops.AbortPull(cfg.Source); err != nil {
+			spinner.Fail("Abort failed")
+			return err
+		}
+		spinner.Success("Pull aborted")
+		return nil
+	}
+
+	if opts.cont {
+		spinner.Update("Continuing pull...")
+		res, err := gitops.ContinuePull(cfg.Source)
+		if err != nil {
+			spinner.Fail("Pull failed")
+			return err
+		}
+		return finishPullResult(cfg, res, opts, spinner, lock, post)
+	}
+
+	if gitops.InPullProgress(cfg.Source) {
+		spinner.Fail("A previous pull is waiting for conflict resolution")
+		printPullConflictHelp(cfg.Source)
+		return fmt.Errorf("pull in progress: resolve conflicts, then run 'skillshare pull --continue' (or --abort)")
+	}
+
 	// Check for uncommitted changes
 	cmd = exec.Command("git", "status", "--porcelain")
 	cmd.Dir = cfg.Source
@@ -86,19 +181,30 @@ func pullFromRemote(cfg *config.Config, dryRun, force bool) error {
 		spinner.Fail("Failed to check git status")
 		return fmt.Errorf("failed to check git status: %w", err)
 	}
+	changes := strings.TrimSpace(string(output))
+	localChanges := 0
+	if changes != "" {
+		localChanges = len(strings.Split(changes, "\n"))
+	}
+
+	hasUpstream := gitops.HasUpstream(cfg.Source)
 
-	if len(strings.TrimSpace(string(output))) > 0 {
+	// The first pull may reset to the remote, so it still needs a clean tree
+	if !hasUpstream && localChanges > 0 {
 		spinner.Fail("Local changes detected")
 		ui.Info("  Run: skillshare push")
 		ui.Info("  Or:  cd %s && git stash", cfg.Source)
 		return nil
 	}
 
-	if dryRun {
+	if opts.dryRun {
 		spinner.Stop()
 		ui.Warning("[dry-run] No changes will be made")
 		fmt.Println()
-		ui.Info("Would run: git pull")
+		if localChanges > 0 {
+			ui.Info("Would stash %d local change(s) and restore them after pulling", localChanges)
+		}
+		ui.Info("Would run: git pull --%s", opts.strategy)
 		ui.Info("Would run: skillshare sync")
 		return nil
 	}
@@ -106,34 +212,172 @@ func pullFromRemote(cfg *config.Config, dryRun, force bool) error {
 	// First pull (no upstream): fetch + reset to remote branch, then set
 	// upstream. This mirrors tryPullAfterRemoteSetup() in init.go and avoids
 	// merge conflicts between the local init commit and remote history.
-	// Subsequent pulls: normal git pull.
+	// Subsequent pulls: stash, rebase or merge, restore the stash.
 	authEnv := gitops.AuthEnvForRepo(cfg.Source)
-	if !gitops.HasUpstream(cfg.Source) {
-		if _, err := firstPull(cfg.Source, authEnv, force, spinner); err != nil {
+	if !hasUpstream {
+		if _, err := firstPull(cfg.Source, authEnv, opts.force, spinner); err != nil {
 			return err
 		}
+		spinner.Success("Pull complete")
+		fmt.Println()
+		return runSync([]string{}, lock, post)
+	}
+
+	if localChanges > 0 {
+		spinner.Update(fmt.Sprintf("Stashing %d local change(s)...", localChanges))
 	} else {
-		spinner.Update("Running git pull...")
-		cmd = exec.Command("git", "pull")
-		cmd.Dir = cfg.Source
-		if len(authEnv) > 0 {
-			cmd.Env = append(os.Environ(), authEnv...)
+		spinner.Update(fmt.Sprintf("Running git pull --%s...", opts.strategy))
+	}
+	res, err := gitops.SyncPull(cfg.Source, gitops.PullOptions{Strategy: opts.strategy, Env: authEnv})
+	if err != nil {
+		spinner.Fail("git pull failed")
+		hintGitRemoteError(err.Error())
+		return err
+	}
+	return finishPullResult(cfg, res, opts, spinner, lock, post)
+}
+
+// finishPullResult resolves conflicts (per skill, interactively or with
+// --ours/--theirs) until the pull is clean, then syncs to all targets.
+func finishPullResult(cfg *config.Config, res *gitops.PullResult, opts pullOptions, spinner *ui.Spinner, lock *oplock.Lock, post *commandHooks) error {
+	for !res.Clean() {
+		spinner.Warn(fmt.Sprintf("Conflicts in %d skill(s) while %s", len(res.Conflicts), pullStageLabel(res.Stage)))
+		printPullConflicts(res.Conflicts)
+
+		manual := 0
+		for _, c := range res.Conflicts {
+			side := opts.resolve
+			if side == "" && ui.IsTTY() {
+				side = promptConflictResolution(c.Skill)
+			}
+			if side == "" {
+				manual++
+				continue
+			}
+			if err := gitops.ResolveSkill(cfg.Source, c.Skill, side); err != nil {
+				return err
+			}
+			ui.Success("%s: kept %s", c.Skill, pullSideLabel(side))
 		}
-		pullOutput, err := cmd.CombinedOutput()
+		if manual > 0 {
+			fmt.Println()
+			printPullConflictHelp(cfg.Source)
+			return fmt.Errorf("pull stopped with conflicts in %d skill(s)", manual)
+		}
+
+		spinner = ui.StartSpinner("Continuing pull...")
+		next, err := gitops.ContinuePull(cfg.Source)
 		if err != nil {
-			spinner.Fail("git pull failed")
-			outStr := string(pullOutput)
-			fmt.Print(outStr)
-			hintGitRemoteError(outStr)
-			return fmt.Errorf("git pull failed: %w", err)
+			spinner.Fail("Pull failed")
+			return err
 		}
+		res = next
 	}
 
-	spinner.Success("Pull complete")
+	switch {
+	case res.UpToDate:
+		spinner.Success("Already up to date")
+	default:
+		spinner.Success(fmt.Sprintf("Pull complete (%d commit(s), %d file(s) changed)", len(res.Commits), res.Stats.FilesChanged))
+	}
+	if res.Stashed {
+		ui.Info("Local changes restored")
+	}
 
 	// Sync to all targets
 	fmt.Println()
-	return cmdSync([]string{})
+	return runSync([]string{}, lock, post)
+}
+
+func pullStageLabel(stage string) string {
+	switch stage {
+	case gitops.StageMerge:
+		return "merging"
+	case gitops.StageStash:
+		return "restoring local changes"
+	default:
+		return "rebasing"
+	}
+}
+
+func pullSideLabel(side string) string {
+	if side == gitops.SideTheirs {
+		return "remote version"
+	}
+	return "local version"
+}
+
+func printPullConflicts(conflicts []gitops.Conflict) {
+	for _, c := range conflicts {
+		fmt.Printf("  %s%s%s\n", ui.Yellow, c.Skill, ui.Reset)
+		for _, f := range c.Files {
+			fmt.Printf("    %s%s%s\n", ui.Gray, f, ui.Reset)
+		}
+	}
+}
+
+func printPullConflictHelp(source string) {
+	ui.Info("  Resolve all:    skillshare pull --continue --ours   (keep local)")
+	ui.Info("                  skillshare pull --continue --theirs (take remote)")
+	ui.Info("  Or edit the files in %s, 'git add' them, then: skillshare pull --continue", source)
+	ui.Info("  Roll back:      skillshare pull --abort")
+}
+
+// promptConflictResolution asks how to resolve one skill. It returns ""
+// for manual resolution.
+func promptConflictResolution(skill string) string {
+	options := []string{
+		"Keep mine (local)",
+		"Take theirs (remote)",
+		"Resolve manually",
+	}
+	var idx int
+	prompt := &survey.Select{
+		Message:  fmt.Sprintf("Resolve %s:", skill),
+		Options:  options,
+		PageSize: 3,
+	}
+	err := survey.AskOne(prompt, &idx, survey.WithIcons(func(icons *survey.IconSet) {
+		icons.SelectFocus.Text = "▸"
+		icons.SelectFocus.Format = "yellow"
+	}))
+	if err != nil {
+		return ""
+	}
+	switch idx {
+	case 0:
+		return gitops.SideOurs
+	case 1:
+		return gitops.SideTheirs
+	}
+	return ""
+}
+
+func printPullHelp() {
+	fmt.Println(`Usage: skillshare pull [options]
+
+Pull the source repository from its git remote, then sync to all targets.
+Uncommitted changes are stashed before pulling and restored afterwards.
+
+Options:
+  --rebase            Rebase local commits onto the remote (default)
+  --merge             Merge the remote into local commits
+  --ours              Resolve every conflict with the local version
+  --theirs            Resolve every conflict with the remote version
+  --continue          Resume after resolving conflicts
+  --abort             Roll back an interrupted pull
+  --force, -f         First pull only: replace local skills with the remote
+  --dry-run, -n       Preview without making changes
+  --help, -h          Show this help
+
+On conflicts, skillshare lists the affected skills and asks per skill
+whether to keep yours, take theirs, or resolve manually. Targets are only
+synced once the pull is clean.
+
+Examples:
+  skillshare pull
+  skillshare pull --merge
+  skillshare pull --continue --theirs`)
 }
 
 // firstPull handles the initial pull when no upstream tracking exists.
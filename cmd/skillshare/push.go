@@ -15,12 +15,16 @@ import (
 
 // pushOptions holds parsed push command options
 type pushOptions struct {
-	dryRun  bool
-	message string
+	dryRun     bool
+	message    string
+	messageSet bool   // -m given explicitly
+	perSkill   bool   // one commit per changed skill
+	branch     string // push to this new branch instead of upstream
+	help       bool
 }
 
 // parsePushArgs parses push command arguments
-func parsePushArgs(args []string) *pushOptions {
+func parsePushArgs(args []string) (*pushOptions, error) {
 	opts := &pushOptions{message: "Update skills"}
 
 	for i := 0; i < len(args); i++ {
@@ -32,17 +36,35 @@ func parsePushArgs(args []string) *pushOptions {
 			if i+1 < len(args) {
 				i++
 				opts.message = args[i]
+				opts.messageSet = true
 			}
+		case "--per-skill":
+			opts.perSkill = true
+		case "--branch", "-b":
+			if i+1 >= len(args) {
+				return nil, fmt.Errorf("%s requires a branch name", arg)
+			}
+			i++
+			opts.branch = args[i]
+		case "--help", "-h":
+			opts.help = true
 		default:
 			if strings.HasPrefix(arg, "-m=") {
 				opts.message = strings.TrimPrefix(arg, "-m=")
+				opts.messageSet = true
 			} else if strings.HasPrefix(arg, "--message=") {
 				opts.message = strings.TrimPrefix(arg, "--message=")
+				opts.messageSet = true
+			} else if strings.HasPrefix(arg, "--branch=") {
+				opts.branch = strings.TrimPrefix(arg, "--branch=")
+				if opts.branch == "" {
+					return nil, fmt.Errorf("--branch requires a branch name")
+				}
 			}
 		}
 	}
 
-	return opts
+	return opts, nil
 }
 
 // checkGitRepo verifies source is a git repo with remote
@@ -99,6 +121,39 @@ func stageAndCommit(sourcePath, message string, spinner *ui.Spinner) error {
 	return nil
 }
 
+// commitPerSkill commits each changed skill separately with a generated
+// message. An explicit -m message becomes the body of every commit.
+func commitPerSkill(sourcePath string, opts *pushOptions, spinner *ui.Spinner) ([]string, error) {
+	changes, err := gitops.ChangedSkills(sourcePath)
+	if err != nil {
+		spinner.Fail("Failed to check git status")
+		return nil, err
+	}
+
+	body := ""
+	if opts.messageSet {
+		body = opts.message
+	}
+	var subjects []string
+	for _, c := range changes {
+		spinner.Update(fmt.Sprintf("Committing %s...", skillChangeLabel(c)))
+		subject, err := gitops.CommitSkillChange(sourcePath, c, body)
+		if err != nil {
+			spinner.Fail("Failed to commit")
+			return subjects, err
+		}
+		subjects = append(subjects, subject)
+	}
+	return subjects, nil
+}
+
+func skillChangeLabel(c gitops.SkillChange) string {
+	if c.Skill == "" {
+		return "repository files"
+	}
+	return c.Skill
+}
+
 // hintGitRemoteError prints helpful hints based on common git remote errors.
 func hintGitRemoteError(output string) {
 	switch {
@@ -157,6 +212,59 @@ func gitPush(sourcePath string, spinner *ui.Spinner) error {
 	return nil
 }
 
+// gitPushBranch pushes HEAD to a new remote branch without switching the
+// local branch, and returns the base branch to compare it against.
+// branchPushTarget returns the remote a --branch push goes to and the
+// branch a pull request should target. Call it on the original branch.
+func branchPushTarget(sourcePath string) (remoteName, base string) {
+	remoteName, base = upstreamTrackingBranch(sourcePath)
+	if remoteName == "" {
+		remoteName = "origin"
+		base = detectRemoteDefaultBranchForPush(sourcePath, gitops.AuthEnvForRepo(sourcePath))
+	}
+	return remoteName, base
+}
+
+func gitPushBranch(sourcePath, remoteName, branch string, spinner *ui.Spinner) error {
+	spinner.Update(fmt.Sprintf("Pushing to branch %s...", branch))
+
+	authEnv := gitops.AuthEnvForRepo(sourcePath)
+	cmd := exec.Command("git", "push", remoteName, "HEAD:refs/heads/"+branch)
+	cmd.Dir = sourcePath
+	if len(authEnv) > 0 {
+		cmd.Env = append(os.Environ(), authEnv...)
+	}
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		spinner.Fail("Push failed")
+		outStr := string(output)
+		fmt.Print(outStr)
+		hintGitRemoteError(outStr)
+		if strings.Contains(outStr, "[rejected]") {
+			ui.Info("  Branch %s already exists on the remote with different history", branch)
+			ui.Info("  Choose another name: skillshare push --branch <name>")
+		}
+		return fmt.Errorf("push failed")
+	}
+	return nil
+}
+
+// abandonPushBranch undoes a --branch push that failed after StartBranch:
+// the commits become uncommitted changes again, origBranch (or head, when
+// detached) is checked out and the local branch is deleted.
+func abandonPushBranch(sourcePath, branch, origBranch, head string) {
+	err := gitops.ResetBranch(sourcePath, head)
+	if err == nil {
+		err = gitops.CheckoutBack(sourcePath, origBranch, head)
+	}
+	if err == nil {
+		err = gitops.DeleteBranch(sourcePath, branch)
+	}
+	if err != nil {
+		ui.Warning("Failed to restore the local branch: %v", err)
+	}
+}
+
 func upstreamTrackingBranch(sourcePath string) (remoteName, branch string) {
 	cmd := exec.Command("git", "rev-parse", "--abbrev-ref", "--symbolic-full-name", "@{u}")
 	cmd.Dir = sourcePath
@@ -191,13 +299,33 @@ func detectRemoteDefaultBranchForPush(sourcePath string, authEnv []string) strin
 
 func cmdPush(args []string) error {
 	start := time.Now()
-	opts := parsePushArgs(args)
+	opts, err := parsePushArgs(args)
+	if err != nil {
+		return err
+	}
+	if opts.help {
+		printPushHelp()
+		return nil
+	}
+	if opts.branch != "" {
+		if err := gitops.CheckBranchName(opts.branch); err != nil {
+			return err
+		}
+	}
 
 	cfg, err := config.Load()
 	if err != nil {
 		return fmt.Errorf("config not found: run 'skillshare init' first")
 	}
 
+	if !opts.dryRun {
+		unlock, err := acquireOpLock(config.ConfigPath(), "push")
+		if err != nil {
+			return err
+		}
+		defer unlock()
+	}
+
 	ui.Header("Pushing to remote")
 
 	spinner := ui.StartSpinner("Checking repository...")
@@ -223,29 +351,129 @@ func cmdPush(args []string) error {
 			for _, line := range lines {
 				ui.Info("  %s", line)
 			}
-			ui.Info("Would commit with message: %s", opts.message)
+			if opts.perSkill {
+				skillChanges, _ := gitops.ChangedSkills(cfg.Source)
+				ui.Info("Would create %d commit(s):", len(skillChanges))
+				for _, c := range skillChanges {
+					ui.Info("  %s", gitops.SkillCommitMessage(c, gitops.DiffStats{}))
+				}
+			} else {
+				ui.Info("Would commit with message: %s", opts.message)
+			}
 		} else {
 			ui.Info("No changes to commit")
 		}
-		ui.Info("Would push to remote")
+		if opts.branch != "" {
+			ui.Info("Would push to new branch %s", opts.branch)
+		} else {
+			ui.Info("Would push to remote")
+		}
 		return nil
 	}
 
+	// With --branch the new commits go on a local branch of that name and
+	// the current branch is checked out again after the push, so it doesn't
+	// diverge from its upstream and 'pull' brings the changes back once the
+	// branch is merged.
+	var remoteName, base, origBranch, head string
+	started := false
+	if opts.branch != "" {
+		remoteName, base = branchPushTarget(cfg.Source)
+		if hasChanges {
+			head, _ = gitops.GetCurrentHash(cfg.Source)
+			if head == "" {
+				spinner.Fail("Nothing to branch from")
+				return fmt.Errorf("--branch needs an existing commit; run 'skillshare push' once without it")
+			}
+			origBranch, _ = gitops.GetCurrentBranch(cfg.Source)
+			if origBranch == "HEAD" {
+				origBranch = "" // detached
+			}
+			if err := gitops.StartBranch(cfg.Source, opts.branch); err != nil {
+				spinner.Fail("Failed to create branch")
+				return err
+			}
+			started = true
+		}
+	}
+
+	var subjects []string
 	if hasChanges {
-		if err := stageAndCommit(cfg.Source, opts.message, spinner); err != nil {
+		if opts.perSkill {
+			subjects, err = commitPerSkill(cfg.Source, opts, spinner)
+		} else {
+			err = stageAndCommit(cfg.Source, opts.message, spinner)
+		}
+		if err != nil {
+			if started {
+				abandonPushBranch(cfg.Source, opts.branch, origBranch, head)
+			}
 			return err
 		}
 	}
 
-	if err := gitPush(cfg.Source, spinner); err != nil {
-		return nil // Error already displayed
+	compareURL := ""
+	if opts.branch != "" {
+		if err := gitPushBranch(cfg.Source, remoteName, opts.branch, spinner); err != nil {
+			if started {
+				abandonPushBranch(cfg.Source, opts.branch, origBranch, head)
+			}
+			return nil // Error already displayed
+		}
+		if started {
+			if err := gitops.CheckoutBack(cfg.Source, origBranch, head); err != nil {
+				ui.Warning("Failed to check out the previous branch: %v", err)
+			}
+		}
+		compareURL = gitops.CompareURL(gitops.GetRemoteURL(cfg.Source), base, opts.branch)
+		spinner.Success(fmt.Sprintf("Pushed to branch %s", opts.branch))
+	} else {
+		if err := gitPush(cfg.Source, spinner); err != nil {
+			return nil // Error already displayed
+		}
+		spinner.Success("Push complete")
 	}
 
-	spinner.Success("Push complete")
+	for _, subject := range subjects {
+		ui.Info("  %s", subject)
+	}
+	if compareURL != "" {
+		fmt.Println()
+		ui.Info("Open a pull request: %s", compareURL)
+	}
+	if started {
+		ui.Info("Your changes are on local branch %s; 'skillshare pull' brings them back once it is merged", opts.branch)
+	}
 
 	e := oplog.NewEntry("push", "ok", time.Since(start))
 	e.Args = map[string]any{"message": opts.message}
+	if opts.perSkill {
+		e.Args["per_skill"] = true
+		e.Args["commits"] = len(subjects)
+	}
+	if opts.branch != "" {
+		e.Args["branch"] = opts.branch
+	}
 	oplog.Write(config.ConfigPath(), oplog.OpsFile, e) //nolint:errcheck
 
 	return nil
 }
+
+func printPushHelp() {
+	fmt.Println(`Usage: skillshare push [options]
+
+Commit all changes in the source directory and push them to the git remote.
+
+Options:
+  -m, --message <msg>   Commit message (default: "Update skills")
+  --per-skill           One commit per changed skill with a generated message;
+                        -m becomes the body of each commit
+  -b, --branch <name>   Push to a new remote branch and print a pull request link
+  --dry-run, -n         Preview without making changes
+  --help, -h            Show this help
+
+Examples:
+  skillshare push -m "Add pdf skill"
+  skillshare push --per-skill
+  skillshare push --per-skill --branch update-skills`)
+}
@@ -0,0 +1,289 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"skillshare/internal/config"
+	"skillshare/internal/git"
+	"skillshare/internal/install"
+	"skillshare/internal/oci"
+	"skillshare/internal/oplog"
+	"skillshare/internal/ui"
+)
+
+func cmdRepo(args []string) error {
+	start := time.Now()
+
+	mode, rest, err := parseModeArgs(args)
+	if err != nil {
+		return err
+	}
+	if len(rest) == 0 || rest[0] == "--help" || rest[0] == "-h" {
+		printRepoHelp()
+		return nil
+	}
+	if rest[0] != "checkout" {
+		return fmt.Errorf("unknown repo subcommand: %s", rest[0])
+	}
+
+	var positional []string
+	var force bool
+	for _, arg := range rest[1:] {
+		switch arg {
+		case "--force", "-f":
+			force = true
+		case "--help", "-h":
+			printRepoHelp()
+			return nil
+		default:
+			if strings.HasPrefix(arg, "-") {
+				return fmt.Errorf("unknown option: %s", arg)
+			}
+			positional = append(positional, arg)
+		}
+	}
+	if len(positional) != 2 {
+		return fmt.Errorf("usage: skillshare repo checkout <repo> <branch|tag|sha>")
+	}
+	name, ref := positional[0], positional[1]
+
+	cwd, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("cannot determine working directory: %w", err)
+	}
+	if mode == modeAuto {
+		if projectConfigExists(cwd) {
+			mode = modeProject
+		} else {
+			mode = modeGlobal
+		}
+	}
+	applyModeLabel(mode)
+
+	unlock, err := lockOperation("repo", mode, cwd, rest)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	rec := beginUndoRecord("repo", mode, cwd, rest)
+
+	var sourcePath, cfgPath string
+	var reconcile func() error
+	if mode == modeProject {
+		rt, err := loadProjectRuntime(cwd)
+		if err != nil {
+			return err
+		}
+		sourcePath, cfgPath = rt.sourcePath, config.ProjectConfigPath(cwd)
+		reconcile = func() error { return reconcileProjectRemoteSkills(rt) }
+	} else {
+		cfg, err := config.Load()
+		if err != nil {
+			return err
+		}
+		sourcePath, cfgPath = cfg.Source, config.ConfigPath()
+		reconcile = func() error { return config.ReconcileGlobalSkills(cfg) }
+	}
+
+	repoName, err := resolveTrackedRepo(sourcePath, name)
+	if err == nil {
+		repoPath := filepath.Join(sourcePath, repoName)
+		rec.Preserve(repoPath)
+		ui.Header(ui.WithModeLabel("Checking out " + repoName))
+		if err = moveTrackedRepo(repoName, repoPath, ref, false, force); err == nil {
+			err = reconcile()
+		}
+	}
+
+	e := oplog.NewEntry("repo", statusFromErr(err), time.Since(start))
+	e.Args = map[string]any{"name": name, "ref": ref}
+	if err != nil {
+		e.Message = err.Error()
+	}
+	commitUndoRecord(rec, &e)
+	oplog.Write(cfgPath, oplog.OpsFile, e) //nolint:errcheck
+	return err
+}
+
+// resolveTrackedRepo finds a tracked repo by path, by name with or without
+// the leading underscore, or by basename when unique.
+func resolveTrackedRepo(sourceDir, name string) (string, error) {
+	for _, candidate := range []string{name, "_" + name} {
+		if install.IsGitRepo(filepath.Join(sourceDir, candidate)) {
+			return candidate, nil
+		}
+	}
+	match, err := resolveByBasename(sourceDir, name)
+	if err != nil {
+		return "", err
+	}
+	if !match.isRepo {
+		return "", fmt.Errorf("'%s' is not a tracked repository", name)
+	}
+	return match.relPath, nil
+}
+
+// moveTrackedRepo switches a tracked repo to a branch, tag or commit, with
+// the same uncommitted-changes check as update.
+func moveTrackedRepo(repoName, repoPath, ref string, dryRun, force bool) error {
+	if isDirty, _ := git.IsDirty(repoPath); isDirty {
+		if !force {
+			ui.Warning("%s has uncommitted changes (use --force to discard)", repoName)
+			return fmt.Errorf("uncommitted changes in %s", repoName)
+		}
+		if !dryRun {
+			if err := git.Restore(repoPath); err != nil {
+				return fmt.Errorf("failed to discard changes: %w", err)
+			}
+		}
+	}
+
+	if dryRun {
+		ui.Info("[dry-run] would check out %s in %s", ref, repoName)
+		return nil
+	}
+
+	spinner := ui.StartSpinner(fmt.Sprintf("Checking out %s...", ref))
+	before, _ := git.GetCurrentHash(repoPath)
+	pinned, err := install.CheckoutTrackedRepo(repoPath, ref)
+	if err != nil {
+		spinner.Fail(fmt.Sprintf("%s: %v", repoName, err))
+		return err
+	}
+	after, _ := git.GetCurrentHash(repoPath)
+
+	moved := after
+	if before != after {
+		moved = before + " → " + after
+	}
+	if pinned {
+		spinner.Success(fmt.Sprintf("%s pinned at %s (%s)", repoName, ref, moved))
+	} else {
+		spinner.Success(fmt.Sprintf("%s follows branch %s (%s)", repoName, ref, moved))
+	}
+	fmt.Println()
+	ui.Info("Run 'skillshare sync' to distribute changes")
+	return nil
+}
+
+// updatePinnedRepo handles a single tracked repo that update must not just
+// pull: one given --to, or a pinned repo, which moves only with --latest.
+// Reports false for repos to pull as usual.
+func updatePinnedRepo(repoName, repoPath string, pin repoPinOptions, dryRun, force bool) (bool, error) {
+	if pin.to != "" {
+		return true, moveTrackedRepo(repoName, repoPath, pin.to, dryRun, force)
+	}
+	_, ref := install.TrackedRepoRefs(repoPath)
+	if ref == "" {
+		return false, nil
+	}
+	if !pin.latest {
+		ui.Info("%s is pinned at %s; use --to <ref> or --latest to move it", repoName, ref)
+		return true, nil
+	}
+	latest, err := latestPinTarget(repoPath)
+	if err != nil {
+		return true, err
+	}
+	if latest == ref {
+		ui.Success("%s already at the latest tag %s", repoName, ref)
+		return true, nil
+	}
+	return true, moveTrackedRepo(repoName, repoPath, latest, dryRun, force)
+}
+
+// updatePinnedRepoQuick is updatePinnedRepo for a repo of a batch: pinned
+// repos are skipped unless latest is set.
+func updatePinnedRepoQuick(repoPath, ref string, dryRun, force, latest bool) updateOutcome {
+	if !latest {
+		return updateOutcome{status: "info", detail: fmt.Sprintf("pinned at %s (use --to or --latest)", ref)}
+	}
+	target, err := latestPinTarget(repoPath)
+	if err != nil {
+		return updateOutcome{status: "warning", detail: err.Error()}
+	}
+	if target == ref {
+		return updateOutcome{status: "success", detail: "already at " + ref, updated: true}
+	}
+
+	if isDirty, _ := git.IsDirty(repoPath); isDirty {
+		if !force {
+			return updateOutcome{status: "warning", detail: "has uncommitted changes (use --force)"}
+		}
+		if !dryRun {
+			if err := git.Restore(repoPath); err != nil {
+				return updateOutcome{status: "warning", detail: fmt.Sprintf("failed to discard changes: %v", err)}
+			}
+		}
+	}
+	if dryRun {
+		return updateOutcome{status: "info", detail: fmt.Sprintf("[dry-run] would move pin %s → %s", ref, target)}
+	}
+	if _, err := install.CheckoutTrackedRepo(repoPath, target); err != nil {
+		return updateOutcome{status: "warning", detail: err.Error()}
+	}
+	return updateOutcome{status: "success", detail: fmt.Sprintf("pin %s → %s", ref, target), updated: true}
+}
+
+// latestPinTarget returns the newest version tag of a tracked repo's origin.
+func latestPinTarget(repoPath string) (string, error) {
+	tags, err := install.RemoteVersionTags(repoPath)
+	if err != nil {
+		return "", fmt.Errorf("failed to list tags: %w", err)
+	}
+	latest := oci.LatestTag(tags)
+	if latest == "" {
+		return "", fmt.Errorf("no version tags to move to; use --to <ref>")
+	}
+	return latest, nil
+}
+
+// reconcileGlobalPins records moved pins in the global config.
+func reconcileGlobalPins(cfg *config.Config, opts *updateOptions) {
+	if opts.dryRun || (opts.pin.to == "" && !opts.pin.latest) {
+		return
+	}
+	if err := config.ReconcileGlobalSkills(cfg); err != nil {
+		ui.Warning("Failed to update config: %v", err)
+	}
+}
+
+// reconcileProjectPins records moved pins in the project config.
+func reconcileProjectPins(root string, opts *updateOptions) {
+	if opts.dryRun || (opts.pin.to == "" && !opts.pin.latest) {
+		return
+	}
+	rt, err := loadProjectRuntime(root)
+	if err == nil {
+		err = reconcileProjectRemoteSkills(rt)
+	}
+	if err != nil {
+		ui.Warning("Failed to update config: %v", err)
+	}
+}
+
+func printRepoHelp() {
+	fmt.Println(`Usage: skillshare repo checkout <repo> <branch|tag|sha> [options]
+
+Switch a tracked repository to another branch, or pin it at a tag or
+commit. A branch is followed by 'skillshare update' from then on; a pinned
+repo stays where it is until moved with 'update --to <ref>' or
+'update --latest'. The branch or pin is recorded in the skills list of the
+config, so 'skillshare install' recreates the repo at the same place.
+
+Options:
+  --force, -f         Discard uncommitted changes in the repository
+  --project, -p       Use the project's tracked repositories
+  --global, -g        Use the global tracked repositories
+  --help, -h          Show this help
+
+Examples:
+  skillshare repo checkout _team-skills v1.3.0    # Pin at a tag
+  skillshare repo checkout team-skills next       # Follow branch 'next'
+  skillshare repo checkout _team-skills main      # Back to the default branch
+  skillshare repo checkout _team-skills 4f1c2ab   # Pin at a commit`)
+}
@@ -0,0 +1,313 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"skillshare/internal/config"
+	"skillshare/internal/oplog"
+	"skillshare/internal/review"
+	"skillshare/internal/sync"
+	"skillshare/internal/ui"
+)
+
+type reviewOptions struct {
+	names []string
+	all   bool
+	json  bool
+}
+
+// reviewScope holds what review checks need for one mode.
+type reviewScope struct {
+	sourcePath   string
+	cfgPath      string
+	maxAgeMonths int
+}
+
+func globalReviewScope(cfg *config.Config) reviewScope {
+	return reviewScope{sourcePath: cfg.Source, cfgPath: config.ConfigPath(), maxAgeMonths: cfg.Review.MaxAgeMonths}
+}
+
+func projectReviewScope(root string, rt *projectRuntime) reviewScope {
+	return reviewScope{sourcePath: rt.sourcePath, cfgPath: config.ProjectConfigPath(root), maxAgeMonths: rt.config.Review.MaxAgeMonths}
+}
+
+func cmdReview(args []string) error {
+	start := time.Now()
+
+	mode, rest, err := parseModeArgs(args)
+	if err != nil {
+		return err
+	}
+
+	opts, showHelp, err := parseReviewArgs(rest)
+	if showHelp {
+		printReviewHelp()
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	cwd, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("cannot determine working directory: %w", err)
+	}
+	if mode == modeAuto {
+		if projectConfigExists(cwd) {
+			mode = modeProject
+		} else {
+			mode = modeGlobal
+		}
+	}
+	applyModeLabel(mode)
+
+	var scope reviewScope
+	if mode == modeProject {
+		rt, err := loadProjectRuntime(cwd)
+		if err != nil {
+			return err
+		}
+		scope = projectReviewScope(cwd, rt)
+	} else {
+		cfg, err := config.Load()
+		if err != nil {
+			return err
+		}
+		scope = globalReviewScope(cfg)
+	}
+
+	discovered, err := sync.DiscoverSourceSkills(scope.sourcePath)
+	if err != nil {
+		return fmt.Errorf("failed to discover skills: %w", err)
+	}
+	if len(opts.names) > 0 {
+		unlock, err := acquireOpLock(scope.cfgPath, "review")
+		if err != nil {
+			return err
+		}
+		defer unlock()
+	}
+	reviews, err := review.LoadReviews(review.ReviewsPath(scope.cfgPath))
+	if err != nil {
+		return fmt.Errorf("failed to read reviews: %w", err)
+	}
+
+	if len(opts.names) > 0 {
+		err := markReviewed(reviews, discovered, opts.names)
+		e := oplog.NewEntry("review", statusFromErr(err), time.Since(start))
+		e.Args = map[string]any{"skills": opts.names}
+		if err != nil {
+			e.Message = err.Error()
+		}
+		oplog.Write(scope.cfgPath, oplog.OpsFile, e) //nolint:errcheck
+		return err
+	}
+
+	statuses := review.Check(discovered, reviews, review.Policy{MaxAgeMonths: scope.maxAgeMonths})
+	if !opts.all {
+		statuses = review.Stale(statuses)
+	}
+
+	if opts.json {
+		if statuses == nil {
+			statuses = []review.Status{}
+		}
+		out, _ := json.MarshalIndent(map[string]any{
+			"maxAgeMonths": scope.maxAgeMonths,
+			"skills":       statuses,
+		}, "", "  ")
+		fmt.Println(string(out))
+		return nil
+	}
+
+	ui.Header(ui.WithModeLabel("Skill reviews"))
+	if scope.maxAgeMonths > 0 {
+		ui.Info("Skills unchanged for %d months are due (review.max_age_months)", scope.maxAgeMonths)
+	}
+	if opts.all {
+		for _, st := range statuses {
+			printReviewStatus(st)
+		}
+		return nil
+	}
+	if len(statuses) == 0 {
+		ui.Success("No skills are due for review")
+		return nil
+	}
+	printStaleSkills(statuses)
+	return nil
+}
+
+// markReviewed records a review of each named skill. Names may be a flat
+// name, a path relative to the source, or a unique directory name.
+func markReviewed(reviews *review.Reviews, discovered []sync.DiscoveredSkill, names []string) error {
+	now := time.Now()
+	var marked []string
+	for _, name := range names {
+		skill, err := findReviewSkill(discovered, name)
+		if err != nil {
+			return err
+		}
+		reviews.Mark(skill.FlatName, now)
+		marked = append(marked, skill.FlatName)
+	}
+	if err := reviews.Save(); err != nil {
+		return fmt.Errorf("failed to save reviews: %w", err)
+	}
+	for _, name := range marked {
+		ui.Success("%s marked reviewed (%s)", name, now.Format("2006-01-02 15:04"))
+	}
+	return nil
+}
+
+func findReviewSkill(discovered []sync.DiscoveredSkill, name string) (sync.DiscoveredSkill, error) {
+	name = strings.Trim(filepath.ToSlash(name), "/")
+	var byBase []sync.DiscoveredSkill
+	for _, d := range discovered {
+		if d.FlatName == name || d.RelPath == name {
+			return d, nil
+		}
+		if filepath.Base(d.SourcePath) == name {
+			byBase = append(byBase, d)
+		}
+	}
+	switch len(byBase) {
+	case 0:
+		return sync.DiscoveredSkill{}, fmt.Errorf("skill '%s' not found", name)
+	case 1:
+		return byBase[0], nil
+	}
+	paths := make([]string, len(byBase))
+	for i, d := range byBase {
+		paths[i] = d.RelPath
+	}
+	return sync.DiscoveredSkill{}, fmt.Errorf("'%s' matches several skills: %s", name, strings.Join(paths, ", "))
+}
+
+// staleSkills returns the skills in scope that are due for review.
+func staleSkills(scope reviewScope, discovered []sync.DiscoveredSkill) []review.Status {
+	reviews, err := review.LoadReviews(review.ReviewsPath(scope.cfgPath))
+	if err != nil {
+		return nil
+	}
+	return review.Stale(review.Check(discovered, reviews, review.Policy{MaxAgeMonths: scope.maxAgeMonths}))
+}
+
+// printStaleSkills lists skills due for review, as shown by review, check,
+// doctor and status.
+func printStaleSkills(stale []review.Status) {
+	for _, st := range stale {
+		detail := st.Reason
+		if st.Owner != "" {
+			detail += "  owner: " + st.Owner
+		}
+		ui.ListItem("warning", st.Name, detail)
+	}
+	fmt.Println()
+	ui.Info("After reviewing, run 'skillshare review <skill>' to mark it reviewed")
+}
+
+func printReviewStatus(st review.Status) {
+	var parts []string
+	if st.ReviewBy != "" {
+		parts = append(parts, "review by "+st.ReviewBy)
+	}
+	if !st.ReviewedAt.IsZero() {
+		parts = append(parts, "reviewed "+st.ReviewedAt.Local().Format(review.DateFormat))
+	}
+	if st.Owner != "" {
+		parts = append(parts, "owner: "+st.Owner)
+	}
+	if st.Stale {
+		ui.ListItem("warning", st.Name, st.Reason+"  "+strings.Join(parts, ", "))
+		return
+	}
+	if len(parts) == 0 {
+		parts = append(parts, "no review date")
+	}
+	ui.ListItem("success", st.Name, strings.Join(parts, ", "))
+}
+
+// printReviewSection is the "Due for review" section of status and check.
+func printReviewSection(scope reviewScope, discovered []sync.DiscoveredSkill) {
+	stale := staleSkills(scope, discovered)
+	if len(stale) == 0 {
+		return
+	}
+	ui.Header(fmt.Sprintf("Due for review (%d)", len(stale)))
+	printStaleSkills(stale)
+}
+
+// checkStaleSkills is the doctor check for skills due for review.
+func checkStaleSkills(scope reviewScope, result *doctorResult) {
+	discovered, err := sync.DiscoverSourceSkills(scope.sourcePath)
+	if err != nil {
+		return
+	}
+	stale := staleSkills(scope, discovered)
+	if len(stale) == 0 {
+		return
+	}
+	names := make([]string, len(stale))
+	for i, st := range stale {
+		names[i] = st.Name
+	}
+	ui.Warning("Skills due for review: %s (run 'skillshare review')", strings.Join(names, ", "))
+	result.addWarning()
+}
+
+func parseReviewArgs(args []string) (reviewOptions, bool, error) {
+	var opts reviewOptions
+	for _, arg := range args {
+		switch {
+		case arg == "--help" || arg == "-h":
+			return opts, true, nil
+		case arg == "--all" || arg == "-a":
+			opts.all = true
+		case arg == "--json":
+			opts.json = true
+		case strings.HasPrefix(arg, "-"):
+			return opts, false, fmt.Errorf("unknown option: %s", arg)
+		default:
+			opts.names = append(opts.names, arg)
+		}
+	}
+	if len(opts.names) > 0 && (opts.all || opts.json) {
+		return opts, false, fmt.Errorf("--all and --json list skills; they cannot be combined with skill names")
+	}
+	return opts, false, nil
+}
+
+func printReviewHelp() {
+	fmt.Println(`Usage: skillshare review [skill...] [options]
+
+List skills that are due for review, or mark skills reviewed.
+
+A skill is due when the review-by date in its frontmatter has passed, or
+when it has not changed for review.max_age_months (last commit, install
+date or review, whichever is newest). Marking a skill reviewed clears a
+passed review-by date and restarts its age.
+
+  ---
+  name: pdf
+  owner: docs-team
+  review-by: 2027-01-31
+  ---
+
+Options:
+  --all, -a         List every skill with its review state
+  --json            Output as JSON
+  --project, -p     Use project skills (.skillshare/skills/)
+  --global, -g      Use global skills
+  --help, -h        Show this help
+
+Examples:
+  skillshare review                 List skills due for review
+  skillshare review pdf docx        Mark pdf and docx reviewed now
+  skillshare review --all --json    Review state of every skill`)
+}
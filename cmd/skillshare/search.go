@@ -508,7 +508,7 @@ func installFromSearchResultProject(result search.SearchResult, cwd string) (err
 		Mode:   "project",
 	}
 	defer func() {
-		logInstallOp(config.ProjectConfigPath(cwd), []string{result.Source}, start, err, logSummary)
+		logInstallOp(nil, config.ProjectConfigPath(cwd), []string{result.Source}, start, err, logSummary)
 	}()
 
 	// Auto-init project if not yet initialized
@@ -585,7 +585,7 @@ func installFromSearchResult(result search.SearchResult, cfg *config.Config) (er
 		Mode:   "global",
 	}
 	defer func() {
-		logInstallOp(config.ConfigPath(), []string{result.Source}, start, err, logSummary)
+		logInstallOp(nil, config.ConfigPath(), []string{result.Source}, start, err, logSummary)
 	}()
 
 	// Parse source
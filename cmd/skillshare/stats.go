@@ -0,0 +1,234 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"skillshare/internal/config"
+	"skillshare/internal/stats"
+	"skillshare/internal/ui"
+)
+
+type statsOptions struct {
+	Budget int
+	Top    int
+	Target string
+	JSON   bool
+}
+
+func cmdStats(args []string) error {
+	mode, rest, err := parseModeArgs(args)
+	if err != nil {
+		return err
+	}
+
+	opts, showHelp, err := parseStatsArgs(rest)
+	if showHelp || err != nil {
+		return err
+	}
+
+	cwd, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("cannot determine working directory: %w", err)
+	}
+	if mode == modeAuto {
+		if projectConfigExists(cwd) {
+			mode = modeProject
+		} else {
+			mode = modeGlobal
+		}
+	}
+	applyModeLabel(mode)
+
+	var (
+		sourcePath string
+		targets    map[string]config.TargetConfig
+		analyze    = stats.Options{Budget: opts.Budget, Top: opts.Top}
+	)
+	if mode == modeProject {
+		rt, err := loadProjectRuntime(cwd)
+		if err != nil {
+			return err
+		}
+		sourcePath = rt.sourcePath
+		targets = rt.targets
+		if analyze.Budget == 0 {
+			analyze.Budget = rt.config.Stats.Budget
+		}
+	} else {
+		cfg, err := config.Load()
+		if err != nil {
+			return err
+		}
+		sourcePath = cfg.Source
+		targets = cfg.Targets
+		analyze.DefaultMode = cfg.Mode
+		if analyze.Budget == 0 {
+			analyze.Budget = cfg.Stats.Budget
+		}
+	}
+
+	if opts.Target != "" {
+		target, ok := targets[opts.Target]
+		if !ok {
+			return fmt.Errorf("target '%s' not found", opts.Target)
+		}
+		targets = map[string]config.TargetConfig{opts.Target: target}
+	}
+
+	report, err := stats.Analyze(sourcePath, targets, analyze)
+	if err != nil {
+		return fmt.Errorf("failed to analyze skills: %w", err)
+	}
+
+	if opts.JSON {
+		out, _ := json.MarshalIndent(report, "", "  ")
+		fmt.Println(string(out))
+		return nil
+	}
+	printStats(report, sourcePath, modeString(mode))
+	return nil
+}
+
+func parseStatsArgs(args []string) (statsOptions, bool, error) {
+	var opts statsOptions
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		switch {
+		case arg == "--help" || arg == "-h":
+			printStatsHelp()
+			return opts, true, nil
+		case arg == "--json":
+			opts.JSON = true
+		case arg == "--budget" || strings.HasPrefix(arg, "--budget="),
+			arg == "--top" || strings.HasPrefix(arg, "--top="):
+			name, val, ok := strings.Cut(arg, "=")
+			if !ok {
+				if i+1 >= len(args) {
+					return opts, false, fmt.Errorf("%s requires a number", name)
+				}
+				i++
+				val = args[i]
+			}
+			n, err := strconv.Atoi(val)
+			if err != nil || n <= 0 {
+				return opts, false, fmt.Errorf("invalid %s value %q (must be a positive number)", name, val)
+			}
+			if name == "--budget" {
+				opts.Budget = n
+			} else {
+				opts.Top = n
+			}
+		case arg == "--target" || arg == "-t" || strings.HasPrefix(arg, "--target="):
+			val, ok := strings.CutPrefix(arg, "--target=")
+			if !ok {
+				if i+1 >= len(args) {
+					return opts, false, fmt.Errorf("--target requires a target name")
+				}
+				i++
+				val = args[i]
+			}
+			opts.Target = val
+		case strings.HasPrefix(arg, "-"):
+			return opts, false, fmt.Errorf("unknown option: %s", arg)
+		default:
+			return opts, false, fmt.Errorf("unexpected argument: %s", arg)
+		}
+	}
+	return opts, false, nil
+}
+
+func printStats(report *stats.Report, sourcePath, mode string) {
+	displayPath := sourcePath
+	if abs, err := filepath.Abs(sourcePath); err == nil {
+		displayPath = abs
+	}
+	ui.HeaderBox("skillshare stats",
+		fmt.Sprintf("%d skills · budget %s tokens per skill\nmode: %s\npath: %s",
+			len(report.Skills), formatTokens(report.Budget), mode, displayPath))
+
+	if len(report.Targets) == 0 {
+		fmt.Println()
+		ui.Info("No targets configured")
+		return
+	}
+
+	fmt.Println()
+	fmt.Printf("  %-16s %6s %12s %10s %12s %10s\n", "TARGET", "SKILLS", "DESCRIPTIONS", "BODIES", "REFERENCES", "TOTAL")
+	for _, t := range report.Targets {
+		fmt.Printf("  %-16s %6d %12s %10s %12s %10s\n", t.Name, t.Skills,
+			formatTokens(t.Description), formatTokens(t.Body), formatTokens(t.References), formatTokens(t.Total))
+	}
+
+	for _, t := range report.Targets {
+		if len(t.Top) == 0 {
+			continue
+		}
+		fmt.Println()
+		fmt.Printf("%s %s(%s, always loaded: ~%s tokens)%s\n", t.Name, ui.Gray, t.Mode, formatTokens(t.Description), ui.Reset)
+		for _, c := range t.Top {
+			flag := ""
+			if c.OverBudget {
+				flag = ui.Yellow + "  over budget" + ui.Reset
+			}
+			fmt.Printf("  %-30s %8s  %s(desc %s, body %s, refs %s)%s%s\n", c.Name, formatTokens(c.Total),
+				ui.Gray, formatTokens(c.Description), formatTokens(c.Body), formatTokens(c.References), ui.Reset, flag)
+		}
+	}
+
+	var over []string
+	for _, c := range report.Skills {
+		if c.OverBudget {
+			over = append(over, fmt.Sprintf("%s (~%s)", c.Name, formatTokens(c.Description+c.Body)))
+		}
+	}
+	fmt.Println()
+	if len(over) > 0 {
+		ui.Warning("%d skill(s) over the %s-token budget: %s", len(over), formatTokens(report.Budget), strings.Join(over, ", "))
+		return
+	}
+	ui.Success("All skills within the %s-token budget", formatTokens(report.Budget))
+}
+
+// formatTokens renders a token count compactly, e.g. 850, 1.2k, 34k.
+func formatTokens(n int) string {
+	switch {
+	case n < 1000:
+		return strconv.Itoa(n)
+	case n < 10000:
+		return strconv.FormatFloat(float64(n)/1000, 'f', 1, 64) + "k"
+	default:
+		return strconv.Itoa((n+500)/1000) + "k"
+	}
+}
+
+func printStatsHelp() {
+	fmt.Println(`Usage: skillshare stats [options]
+
+Estimate how much agent context your skills cost. Agents preload every
+skill's frontmatter (name, description) and read bodies and references on
+demand. Totals are aggregated per target after include/exclude and
+'targets:' filtering. Token counts are approximate (~4 characters/token).
+
+Options:
+  --budget <n>        Per-skill budget for frontmatter + body (default: 5000)
+  --top <n>           Heaviest skills listed per target (default: 5)
+  --target, -t <name> Only report one target
+  --json              Output as JSON
+  --project, -p       Use project-level config in current directory
+  --global, -g        Use global config (~/.config/skillshare)
+  --help, -h          Show this help
+
+The budget can be set under 'stats:' in config.yaml:
+  stats:
+    budget: 3000
+
+Examples:
+  skillshare stats
+  skillshare stats --target claude --top 10
+  skillshare stats --json`)
+}
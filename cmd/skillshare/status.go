@@ -12,6 +12,7 @@ import (
 
 	"skillshare/internal/config"
 	"skillshare/internal/install"
+	"skillshare/internal/network"
 	"skillshare/internal/sync"
 	"skillshare/internal/ui"
 	"skillshare/internal/utils"
@@ -60,6 +61,8 @@ func cmdStatus(args []string) error {
 	if err := printTargetsStatus(cfg, discovered); err != nil {
 		return err
 	}
+	printUsageStatus(cfg, discovered)
+	printReviewSection(globalReviewScope(cfg), discovered)
 	checkSkillVersion(cfg)
 
 	return nil
@@ -269,7 +272,7 @@ func checkSkillVersion(cfg *config.Config) {
 }
 
 func fetchRemoteSkillVersion() string {
-	client := &http.Client{Timeout: 3 * time.Second}
+	client := network.NewClient(3 * time.Second)
 	resp, err := client.Get(skillshareSkillURL)
 	if err != nil {
 		return ""
@@ -34,6 +34,7 @@ func cmdStatusProject(root string) error {
 	if err := printProjectTargetsStatus(runtime, discovered); err != nil {
 		return err
 	}
+	printReviewSection(projectReviewScope(root, runtime), discovered)
 
 	return nil
 }
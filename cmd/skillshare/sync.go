@@ -3,15 +3,20 @@ package main
 import (
 	"fmt"
 	"os"
+	"path/filepath"
 	"strings"
 	"time"
 
 	"skillshare/internal/backup"
 	"skillshare/internal/config"
+	"skillshare/internal/install"
+	"skillshare/internal/oplock"
 	"skillshare/internal/oplog"
 	"skillshare/internal/sync"
+	"skillshare/internal/syncbase"
 	"skillshare/internal/trash"
 	"skillshare/internal/ui"
+	"skillshare/internal/undo"
 	"skillshare/internal/utils"
 )
 
@@ -24,6 +29,14 @@ type syncLogStats struct {
 }
 
 func cmdSync(args []string) error {
+	post := &commandHooks{}
+	return post.finish(runSync(args, nil, post))
+}
+
+// runSync runs sync inside a command that holds the operation lock held,
+// or on its own when held is nil. post runs the post-sync hooks once the
+// outermost command returns.
+func runSync(args []string, held *oplock.Lock, post *commandHooks) error {
 	start := time.Now()
 
 	mode, rest, err := parseModeArgs(args)
@@ -46,12 +59,20 @@ func cmdSync(args []string) error {
 
 	applyModeLabel(mode)
 
+	unlock, err := lockNestedOperation(held, "sync", mode, cwd, rest)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	rec := beginUndoRecord("sync", mode, cwd, rest)
+	post.begin(config.HookPostSync, mode, cwd, rest)
+
 	dryRun, force := parseSyncFlags(rest)
 
 	if mode == modeProject {
 		stats, err := cmdSyncProject(cwd, dryRun, force)
 		stats.ProjectScope = true
-		logSyncOp(config.ProjectConfigPath(cwd), stats, start, err)
+		logSyncOp(rec, config.ProjectConfigPath(cwd), stats, start, err)
 		return err
 	}
 
@@ -67,7 +88,7 @@ func cmdSync(args []string) error {
 
 	// Backup targets before sync (only if not dry-run)
 	if !dryRun {
-		backupTargetsBeforeSync(cfg)
+		backupTargetsBeforeSync(cfg, rec)
 	}
 
 	// Check for name collisions before syncing (per-target aware)
@@ -94,6 +115,10 @@ func cmdSync(args []string) error {
 		syncErr = fmt.Errorf("some targets failed to sync")
 	}
 
+	if !dryRun {
+		recordSyncBases(config.ConfigPath(), cfg.Source)
+	}
+
 	// Opportunistic cleanup of expired trash items
 	if !dryRun {
 		if n, _ := trash.Cleanup(trash.TrashDir(), 0); n > 0 {
@@ -101,7 +126,7 @@ func cmdSync(args []string) error {
 		}
 	}
 
-	logSyncOp(config.ConfigPath(), syncLogStats{
+	logSyncOp(rec, config.ConfigPath(), syncLogStats{
 		Targets: len(cfg.Targets),
 		Failed:  failedTargets,
 		DryRun:  dryRun,
@@ -122,7 +147,18 @@ func parseSyncFlags(args []string) (dryRun bool, force bool) {
 	return dryRun, force
 }
 
-func logSyncOp(cfgPath string, stats syncLogStats, start time.Time, cmdErr error) {
+// recordSyncBases snapshots the synced source skills as the base for
+// three-way merges in collect.
+func recordSyncBases(cfgPath, source string) {
+	if filepath.Base(filepath.Dir(cfgPath)) == ".skillshare" {
+		_ = install.UpdateGitIgnore(filepath.Dir(cfgPath), syncbase.DirName)
+	}
+	if err := syncbase.RecordSource(syncbase.Dir(cfgPath), source); err != nil {
+		ui.Warning("Failed to record sync base: %v", err)
+	}
+}
+
+func logSyncOp(rec *undo.Recorder, cfgPath string, stats syncLogStats, start time.Time, cmdErr error) {
 	e := oplog.NewEntry("sync", statusFromErr(cmdErr), time.Since(start))
 	e.Args = map[string]any{
 		"targets_total":  stats.Targets,
@@ -137,10 +173,11 @@ func logSyncOp(cfgPath string, stats syncLogStats, start time.Time, cmdErr error
 	if cmdErr != nil {
 		e.Message = cmdErr.Error()
 	}
+	commitUndoRecord(rec, &e)
 	oplog.Write(cfgPath, oplog.OpsFile, e) //nolint:errcheck
 }
 
-func backupTargetsBeforeSync(cfg *config.Config) {
+func backupTargetsBeforeSync(cfg *config.Config, rec *undo.Recorder) {
 	backedUp := false
 	for name, target := range cfg.Targets {
 		backupPath, err := backup.Create(name, target.Path)
@@ -152,6 +189,7 @@ func backupTargetsBeforeSync(cfg *config.Config) {
 				backedUp = true
 			}
 			ui.Success("%s -> %s", name, backupPath)
+			rec.AddBackup(name, target.Path, backupPath)
 		}
 	}
 }
@@ -10,6 +10,19 @@ import (
 	"skillshare/internal/ui"
 )
 
+// syncProjectTarget syncs one project target; project targets default to
+// merge mode.
+func syncProjectTarget(name string, target config.TargetConfig, source string, dryRun, force bool) error {
+	switch target.Mode {
+	case "symlink":
+		return syncSymlinkMode(name, target, source, dryRun, force)
+	case "copy":
+		return syncCopyMode(name, target, source, dryRun, force)
+	default:
+		return syncMergeMode(name, target, source, dryRun, force)
+	}
+}
+
 func cmdSyncProject(root string, dryRun, force bool) (syncLogStats, error) {
 	stats := syncLogStats{
 		DryRun:       dryRun,
@@ -53,21 +66,7 @@ func cmdSyncProject(root string, dryRun, force bool) (syncLogStats, error) {
 			continue
 		}
 
-		mode := target.Mode
-		if mode == "" {
-			mode = "merge"
-		}
-
-		var syncErr error
-		switch mode {
-		case "symlink":
-			syncErr = syncSymlinkMode(name, target, runtime.sourcePath, dryRun, force)
-		case "copy":
-			syncErr = syncCopyMode(name, target, runtime.sourcePath, dryRun, force)
-		default:
-			syncErr = syncMergeMode(name, target, runtime.sourcePath, dryRun, force)
-		}
-		if syncErr != nil {
+		if syncErr := syncProjectTarget(name, target, runtime.sourcePath, dryRun, force); syncErr != nil {
 			ui.Error("%s: %v", name, syncErr)
 			failedTargets++
 		}
@@ -78,6 +77,10 @@ func cmdSyncProject(root string, dryRun, force bool) (syncLogStats, error) {
 		return stats, fmt.Errorf("some targets failed to sync")
 	}
 
+	if !dryRun {
+		recordSyncBases(config.ProjectConfigPath(root), runtime.sourcePath)
+	}
+
 	// Opportunistic cleanup of expired trash items
 	if !dryRun {
 		if n, _ := trash.Cleanup(trash.ProjectTrashDir(root), 0); n > 0 {
@@ -12,6 +12,7 @@ import (
 	"skillshare/internal/oplog"
 	"skillshare/internal/sync"
 	"skillshare/internal/ui"
+	"skillshare/internal/undo"
 	"skillshare/internal/utils"
 	"skillshare/internal/validate"
 )
@@ -49,15 +50,32 @@ func cmdTarget(args []string) error {
 		printTargetHelp()
 		return nil
 	case "add":
+		unlock, err := lockOperation("target", mode, cwd, subargs)
+		if err != nil {
+			return err
+		}
+		defer unlock()
 		if mode == modeProject {
 			return targetAddProject(subargs, cwd)
 		}
 		return targetAdd(subargs)
 	case "remove", "rm":
+		start := time.Now()
+		unlock, err := lockOperation("target", mode, cwd, subargs)
+		if err != nil {
+			return err
+		}
+		defer unlock()
+		rec := beginUndoRecord("target", mode, cwd, subargs)
+		cfgPath := config.ConfigPath()
 		if mode == modeProject {
-			return targetRemoveProject(subargs, cwd)
+			cfgPath = config.ProjectConfigPath(cwd)
+			err = targetRemoveProject(subargs, cwd)
+		} else {
+			err = targetRemove(subargs, rec)
 		}
-		return targetRemove(subargs)
+		logTargetRemoveOp(rec, cfgPath, subargs, start, err)
+		return err
 	case "list", "ls":
 		if mode == modeProject {
 			return targetListProject(cwd)
@@ -65,6 +83,13 @@ func cmdTarget(args []string) error {
 		return targetList()
 	default:
 		// Assume it's a target name - show info or modify settings
+		if len(subargs) > 0 {
+			unlock, err := lockOperation("target", mode, cwd, subargs)
+			if err != nil {
+				return err
+			}
+			defer unlock()
+		}
 		if mode == modeProject {
 			return targetInfoProject(subcmd, subargs, cwd)
 		}
@@ -222,7 +247,7 @@ func resolveTargetsToRemove(cfg *config.Config, opts *targetRemoveOptions) ([]st
 }
 
 // backupTargets creates backups for targets before removal
-func backupTargets(cfg *config.Config, toRemove []string) {
+func backupTargets(cfg *config.Config, toRemove []string, rec *undo.Recorder) {
 	ui.Header("Backing up before unlink")
 	for _, targetName := range toRemove {
 		target := cfg.Targets[targetName]
@@ -231,6 +256,7 @@ func backupTargets(cfg *config.Config, toRemove []string) {
 			ui.Warning("Failed to backup %s: %v", targetName, err)
 		} else if backupPath != "" {
 			ui.Success("%s -> %s", targetName, backupPath)
+			rec.AddBackup(targetName, target.Path, backupPath)
 		}
 	}
 }
@@ -259,7 +285,7 @@ func unlinkTarget(targetName string, target config.TargetConfig, sourcePath stri
 	return nil
 }
 
-func targetRemove(args []string) error {
+func targetRemove(args []string, rec *undo.Recorder) error {
 	opts, err := parseTargetRemoveArgs(args)
 	if err != nil {
 		return err
@@ -279,7 +305,7 @@ func targetRemove(args []string) error {
 		return targetRemoveDryRun(cfg, toRemove)
 	}
 
-	backupTargets(cfg, toRemove)
+	backupTargets(cfg, toRemove, rec)
 
 	ui.Header("Unlinking targets")
 	for _, targetName := range toRemove {
@@ -294,6 +320,26 @@ func targetRemove(args []string) error {
 	return cfg.Save()
 }
 
+func logTargetRemoveOp(rec *undo.Recorder, cfgPath string, args []string, start time.Time, cmdErr error) {
+	e := oplog.NewEntry("target", statusFromErr(cmdErr), time.Since(start))
+	e.Args = map[string]any{"action": "remove"}
+	for _, a := range args {
+		switch a {
+		case "--dry-run", "-n":
+			return
+		case "--all", "-a":
+			e.Args["all"] = true
+		default:
+			e.Args["name"] = a
+		}
+	}
+	if cmdErr != nil {
+		e.Message = cmdErr.Error()
+	}
+	commitUndoRecord(rec, &e)
+	oplog.Write(cfgPath, oplog.OpsFile, e) //nolint:errcheck
+}
+
 func targetRemoveDryRun(cfg *config.Config, toRemove []string) error {
 	ui.Warning("Dry run mode - no changes will be made")
 
@@ -40,6 +40,15 @@ func cmdTrash(args []string) error {
 	sub := rest[0]
 	subArgs := rest[1:]
 
+	switch sub {
+	case "restore", "delete", "rm", "empty":
+		unlock, err := lockOperation("trash", mode, cwd, subArgs)
+		if err != nil {
+			return err
+		}
+		defer unlock()
+	}
+
 	switch sub {
 	case "list", "ls":
 		return trashList(mode, cwd)
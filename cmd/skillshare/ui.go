@@ -23,6 +23,7 @@ func cmdUI(args []string) error {
 	port := "19420"
 	host := "127.0.0.1"
 	noOpen := false
+	withDaemon := false
 
 	for i := 0; i < len(rest); i++ {
 		switch rest[i] {
@@ -42,6 +43,8 @@ func cmdUI(args []string) error {
 			}
 		case "--no-open":
 			noOpen = true
+		case "--daemon":
+			withDaemon = true
 		case "--clear-cache":
 			if err := uidist.ClearCache(); err != nil {
 				return fmt.Errorf("failed to clear UI cache: %w", err)
@@ -69,8 +72,20 @@ func cmdUI(args []string) error {
 	url := "http://" + addr
 
 	if mode == modeProject {
+		if withDaemon {
+			return fmt.Errorf("--daemon is only supported in global mode")
+		}
 		return startProjectUI(addr, url, noOpen)
 	}
+
+	if withDaemon {
+		stopDaemon, err := startBackgroundDaemon()
+		if err != nil {
+			return err
+		}
+		defer stopDaemon()
+		ui.Info("Scheduled maintenance running (see 'skillshare daemon status')")
+	}
 	return startGlobalUI(addr, url, noOpen)
 }
 
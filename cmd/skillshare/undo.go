@@ -0,0 +1,380 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"skillshare/internal/config"
+	"skillshare/internal/install"
+	"skillshare/internal/oplog"
+	"skillshare/internal/trash"
+	"skillshare/internal/ui"
+	"skillshare/internal/undo"
+)
+
+// beginUndoRecord snapshots the pre-state (source checksums and config)
+// for the command about to run and returns the recorder the command
+// passes along to whatever modifies skills or targets. Returns nil for
+// dry runs and help output; every Recorder method is a no-op on nil.
+func beginUndoRecord(cmd string, mode runMode, cwd string, args []string) *undo.Recorder {
+	for _, a := range args {
+		switch a {
+		case "--dry-run", "-n", "--help", "-h":
+			return nil
+		}
+	}
+
+	if mode == modeProject {
+		rec := undo.NewRecorder(cmd, config.ProjectConfigPath(cwd), trash.ProjectTrashDir(cwd))
+		rec.TrackSource(filepath.Join(cwd, ".skillshare", "skills"))
+		return rec
+	}
+
+	rec := undo.NewRecorder(cmd, config.ConfigPath(), trash.TrashDir())
+	if cfg, err := config.Load(); err == nil {
+		rec.TrackSource(cfg.Source)
+	}
+	return rec
+}
+
+// preserveUpdatableForUndo snapshots the given skills (relative to
+// sourceDir) before update touches them. A nil list means every tracked
+// repo and every skill with install metadata, as in update --all.
+func preserveUpdatableForUndo(rec *undo.Recorder, sourceDir string, relPaths []string) {
+	if rec == nil {
+		return
+	}
+	if relPaths == nil {
+		repos, _ := install.GetTrackedRepos(sourceDir)
+		skills, _ := install.GetUpdatableSkills(sourceDir)
+		relPaths = append(repos, skills...)
+	}
+	for _, rel := range relPaths {
+		rec.Preserve(filepath.Join(sourceDir, rel))
+	}
+}
+
+// commitUndoRecord finalizes the changeset and attaches its ID to e.
+func commitUndoRecord(rec *undo.Recorder, e *oplog.Entry) {
+	id, err := rec.Commit()
+	if err != nil {
+		ui.Warning("Failed to record undo information: %v", err)
+		return
+	}
+	e.Changeset = id
+}
+
+type undoOptions struct {
+	id     string
+	list   bool
+	dryRun bool
+	force  bool
+	yes    bool
+}
+
+func parseUndoArgs(args []string) (*undoOptions, bool, error) {
+	opts := &undoOptions{}
+	for _, arg := range args {
+		switch {
+		case arg == "--list" || arg == "-l":
+			opts.list = true
+		case arg == "--dry-run" || arg == "-n":
+			opts.dryRun = true
+		case arg == "--force" || arg == "-f":
+			opts.force = true
+		case arg == "--yes" || arg == "-y":
+			opts.yes = true
+		case arg == "--help" || arg == "-h":
+			return nil, true, nil
+		case strings.HasPrefix(arg, "-"):
+			return nil, false, fmt.Errorf("unknown option: %s", arg)
+		default:
+			if opts.id != "" {
+				return nil, false, fmt.Errorf("unexpected argument: %s", arg)
+			}
+			opts.id = arg
+		}
+	}
+	return opts, false, nil
+}
+
+func cmdUndo(args []string) error {
+	start := time.Now()
+
+	mode, rest, err := parseModeArgs(args)
+	if err != nil {
+		return err
+	}
+
+	cwd, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("cannot determine working directory: %w", err)
+	}
+
+	if mode == modeAuto {
+		if projectConfigExists(cwd) {
+			mode = modeProject
+		} else {
+			mode = modeGlobal
+		}
+	}
+
+	applyModeLabel(mode)
+
+	opts, showHelp, err := parseUndoArgs(rest)
+	if showHelp {
+		printUndoHelp()
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	cfgPath := config.ConfigPath()
+	if mode == modeProject {
+		cfgPath = config.ProjectConfigPath(cwd)
+	}
+
+	if opts.list {
+		return undoList(cfgPath)
+	}
+
+	unlock, err := lockOperation("undo", mode, cwd, rest)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	var cs *undo.Changeset
+	if opts.id != "" {
+		cs, err = undo.LoadChangeset(cfgPath, opts.id)
+	} else {
+		cs, err = undo.LatestChangeset(cfgPath)
+	}
+	if err != nil {
+		return err
+	}
+
+	printChangeset(cs)
+
+	conflicts := undo.Conflicts(cfgPath, cs)
+	if len(conflicts) > 0 {
+		fmt.Println()
+		ui.Warning("Conflicts:")
+		for _, c := range conflicts {
+			fmt.Printf("  - %s\n", c)
+		}
+		if !opts.force {
+			return fmt.Errorf("cannot undo %s: later changes conflict (use --force to revert anyway)", cs.ID)
+		}
+	}
+
+	if opts.dryRun {
+		fmt.Println()
+		ui.Info("Dry run - no changes made")
+		return nil
+	}
+
+	if !opts.yes {
+		fmt.Printf("\nUndo this %s? [y/N]: ", cs.Command)
+		input, _ := bufio.NewReader(os.Stdin).ReadString('\n')
+		input = strings.TrimSpace(strings.ToLower(input))
+		if input != "y" && input != "yes" {
+			ui.Info("Cancelled")
+			return nil
+		}
+	}
+
+	result, revertErr := undo.Revert(cfgPath, cs, undo.RevertOptions{Force: opts.force})
+	if result != nil {
+		fmt.Println()
+		for _, name := range result.Removed {
+			ui.Success("Removed %s (moved to trash)", name)
+		}
+		for _, name := range result.Restored {
+			ui.Success("Restored %s", name)
+		}
+		for _, name := range result.Targets {
+			ui.Success("Restored target %s from backup", name)
+		}
+		if result.Config {
+			ui.Success("Restored config")
+		}
+		for _, name := range result.Skipped {
+			ui.Warning("Skipped %s (previous state not available)", name)
+		}
+	}
+
+	e := oplog.NewEntry("undo", statusFromErr(revertErr), time.Since(start))
+	e.Args = map[string]any{"op": cs.ID, "name": cs.Command}
+	if revertErr != nil {
+		e.Message = revertErr.Error()
+	}
+	oplog.Write(cfgPath, oplog.OpsFile, e) //nolint:errcheck
+
+	if revertErr != nil {
+		return revertErr
+	}
+
+	// Backups hold no symlinks: sync the restored targets again to put
+	// back the links the operation had replaced or pruned.
+	if len(result.Targets) > 0 {
+		fmt.Println()
+		return resyncTargets(mode, cwd, result.Targets)
+	}
+
+	fmt.Println()
+	if mode == modeProject {
+		ui.Info("Run 'skillshare sync -p' to update targets")
+	} else {
+		ui.Info("Run 'skillshare sync' to update targets")
+	}
+	return nil
+}
+
+// resyncTargets syncs the named targets of the global or project config.
+func resyncTargets(mode runMode, cwd string, names []string) error {
+	failed := 0
+	if mode == modeProject {
+		runtime, err := loadProjectRuntime(cwd)
+		if err != nil {
+			return err
+		}
+		for _, name := range names {
+			target, ok := runtime.targets[name]
+			if !ok {
+				continue // removed from the config again
+			}
+			if err := syncProjectTarget(name, target, runtime.sourcePath, false, false); err != nil {
+				ui.Error("%s: %v", name, err)
+				failed++
+			}
+		}
+	} else {
+		cfg, err := config.Load()
+		if err != nil {
+			return err
+		}
+		for _, name := range names {
+			target, ok := cfg.Targets[name]
+			if !ok {
+				continue // removed from the config again
+			}
+			if err := syncTarget(name, target, cfg, false, false); err != nil {
+				ui.Error("%s: %v", name, err)
+				failed++
+			}
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("some targets failed to sync")
+	}
+	return nil
+}
+
+func undoList(cfgPath string) error {
+	all, err := undo.ListChangesets(cfgPath)
+	if err != nil {
+		return err
+	}
+	if len(all) == 0 {
+		ui.Info("No reversible operations recorded")
+		return nil
+	}
+
+	ui.Header("Reversible operations")
+	for _, cs := range all {
+		state := ""
+		if cs.UndoneAt != "" {
+			state = " (undone)"
+		}
+		fmt.Printf("  %s  %-10s %s%s\n", cs.ID, cs.Command, summarizeChangeset(cs), state)
+	}
+	return nil
+}
+
+func printChangeset(cs *undo.Changeset) {
+	ui.Header(fmt.Sprintf("Undo %s (%s)", cs.Command, cs.ID))
+	if t, err := time.Parse(time.RFC3339Nano, cs.Timestamp); err == nil {
+		ui.Info("Ran %s ago", formatAge(time.Since(t)))
+	}
+	for _, c := range cs.Skills {
+		switch c.Kind() {
+		case "added":
+			ui.DiffItem("remove", c.Name, "added by "+cs.Command+", will be moved to trash")
+		case "removed":
+			ui.DiffItem("add", c.Name, "will be restored from trash")
+		default:
+			ui.DiffItem("modify", c.Name, "will be restored to previous version")
+		}
+	}
+	for _, b := range cs.Backups {
+		ui.DiffItem("modify", "target "+b.Target, "will be restored from "+b.Backup)
+	}
+	if cs.Config != nil {
+		fmt.Println("  config:")
+		for _, line := range cs.Config.Lines() {
+			// Show the inverse: what undo will do to the current config
+			switch {
+			case strings.HasPrefix(line, "+ "):
+				fmt.Printf("    %s- %s%s\n", ui.Red, line[2:], ui.Reset)
+			case strings.HasPrefix(line, "- "):
+				fmt.Printf("    %s+ %s%s\n", ui.Green, line[2:], ui.Reset)
+			}
+		}
+	}
+}
+
+func summarizeChangeset(cs *undo.Changeset) string {
+	var parts []string
+	counts := map[string]int{}
+	for _, c := range cs.Skills {
+		counts[c.Kind()]++
+	}
+	for _, kind := range []string{"added", "removed", "modified"} {
+		if counts[kind] > 0 {
+			parts = append(parts, fmt.Sprintf("%d %s", counts[kind], kind))
+		}
+	}
+	if len(cs.Backups) > 0 {
+		parts = append(parts, fmt.Sprintf("%d target backup(s)", len(cs.Backups)))
+	}
+	if cs.Config != nil {
+		parts = append(parts, "config")
+	}
+	return strings.Join(parts, ", ")
+}
+
+func printUndoHelp() {
+	fmt.Println(`Usage: skillshare undo [<op-id>] [options]
+
+Revert the last mutating operation (install, uninstall, sync, collect,
+update, target remove), or a specific one by its operation ID.
+
+Each of these commands records a changeset: checksums of the skills it
+touched, the trash entries and target backups it created, and the config
+diff. Undo moves added skills to trash, restores removed or modified skills
+from trash, restores targets from backup and reverts the config.
+
+Undo refuses when a later operation touched the same skills, targets or
+config, or when those were edited by hand since.
+
+Options:
+  --list, -l          List recorded operations and their IDs
+  --dry-run, -n       Show what would be reverted
+  --force, -f         Revert even when later changes conflict
+  --yes, -y           Skip confirmation
+  --project, -p       Use project-level operations
+  --global, -g        Use global operations
+  --help, -h          Show this help
+
+Examples:
+  skillshare undo                             # Undo the last operation
+  skillshare undo --list                      # Show reversible operations
+  skillshare undo 20260118-153045.123-a1b2    # Undo a specific operation
+  skillshare undo -n                          # Preview`)
+}
@@ -14,6 +14,7 @@ import (
 	"skillshare/internal/oplog"
 	"skillshare/internal/trash"
 	"skillshare/internal/ui"
+	"skillshare/internal/undo"
 )
 
 // uninstallOptions holds parsed arguments for uninstall command
@@ -295,7 +296,7 @@ func confirmUninstall(target *uninstallTarget) (bool, error) {
 }
 
 // performUninstall moves the skill to trash and cleans up
-func performUninstall(target *uninstallTarget, cfg *config.Config) error {
+func performUninstall(target *uninstallTarget, cfg *config.Config, rec *undo.Recorder) error {
 	// Read metadata before moving (for reinstall hint)
 	meta, _ := install.ReadMeta(target.path)
 
@@ -312,6 +313,7 @@ func performUninstall(target *uninstallTarget, cfg *config.Config) error {
 	if err != nil {
 		return fmt.Errorf("failed to move to trash: %w", err)
 	}
+	rec.Trashed(target.path, trashPath)
 
 	if target.isTrackedRepo {
 		ui.Success("Uninstalled tracked repository: %s", target.name)
@@ -356,9 +358,16 @@ func cmdUninstall(args []string) error {
 
 	applyModeLabel(mode)
 
+	unlock, err := lockOperation("uninstall", mode, cwd, rest)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	rec := beginUndoRecord("uninstall", mode, cwd, rest)
+
 	if mode == modeProject {
-		err := cmdUninstallProject(rest, cwd)
-		logUninstallOp(config.ProjectConfigPath(cwd), rest, start, err)
+		err := cmdUninstallProject(rest, cwd, rec)
+		logUninstallOp(rec, config.ProjectConfigPath(cwd), rest, start, err)
 		return err
 	}
 
@@ -498,7 +507,7 @@ func cmdUninstall(args []string) error {
 	var succeeded []*uninstallTarget
 	var failed []string
 	for _, t := range targets {
-		if err := performUninstall(t, cfg); err != nil {
+		if err := performUninstall(t, cfg, rec); err != nil {
 			failed = append(failed, fmt.Sprintf("%s: %v", t.name, err))
 			ui.Warning("Failed to uninstall %s: %v", t.name, err)
 		} else {
@@ -544,11 +553,11 @@ func cmdUninstall(args []string) error {
 		// Partial failure: report but exit success (skip & continue)
 	}
 
-	logUninstallOp(config.ConfigPath(), opNames, start, finalErr)
+	logUninstallOp(rec, config.ConfigPath(), opNames, start, finalErr)
 	return finalErr
 }
 
-func logUninstallOp(cfgPath string, names []string, start time.Time, cmdErr error) {
+func logUninstallOp(rec *undo.Recorder, cfgPath string, names []string, start time.Time, cmdErr error) {
 	e := oplog.NewEntry("uninstall", statusFromErr(cmdErr), time.Since(start))
 	if len(names) == 1 {
 		e.Args = map[string]any{"name": names[0]}
@@ -558,6 +567,7 @@ func logUninstallOp(cfgPath string, names []string, start time.Time, cmdErr erro
 	if cmdErr != nil {
 		e.Message = cmdErr.Error()
 	}
+	commitUndoRecord(rec, &e)
 	oplog.Write(cfgPath, oplog.OpsFile, e) //nolint:errcheck
 }
 
@@ -11,6 +11,7 @@ import (
 	"skillshare/internal/install"
 	"skillshare/internal/trash"
 	"skillshare/internal/ui"
+	"skillshare/internal/undo"
 )
 
 // resolveProjectUninstallTarget resolves a skill name to an uninstallTarget
@@ -42,7 +43,7 @@ func resolveProjectUninstallTarget(skillName, sourceDir string) (*uninstallTarge
 	}, nil
 }
 
-func cmdUninstallProject(args []string, root string) error {
+func cmdUninstallProject(args []string, root string, rec *undo.Recorder) error {
 	opts, showHelp, err := parseUninstallArgs(args)
 	if showHelp {
 		printUninstallHelp()
@@ -194,6 +195,7 @@ func cmdUninstallProject(args []string, root string) error {
 			ui.Warning("Failed to uninstall %s: %v", t.name, err)
 			continue
 		}
+		rec.Trashed(t.path, trashPath)
 
 		if t.isTrackedRepo {
 			ui.Success("Uninstalled tracked repository: %s", t.name)
@@ -11,7 +11,9 @@ import (
 	"skillshare/internal/git"
 	"skillshare/internal/install"
 	"skillshare/internal/oplog"
+	"skillshare/internal/parallel"
 	"skillshare/internal/ui"
+	"skillshare/internal/undo"
 )
 
 // updateOptions holds parsed arguments for update command
@@ -21,22 +23,44 @@ type updateOptions struct {
 	all    bool
 	dryRun bool
 	force  bool
+	jobs   int // --jobs/-j
+	pin    repoPinOptions
+}
+
+// repoPinOptions moves tracked repos to another ref during update.
+type repoPinOptions struct {
+	to     string // --to: branch, tag or commit for a single tracked repo
+	latest bool   // --latest: move pinned repos to their newest version tag
 }
 
 // parseUpdateArgs parses command line arguments for the update command.
 // Returns (opts, showHelp, error).
 func parseUpdateArgs(args []string) (*updateOptions, bool, error) {
-	opts := &updateOptions{}
+	opts := &updateOptions{jobs: parallel.DefaultJobs}
 
 	for i := 0; i < len(args); i++ {
 		arg := args[i]
 		switch {
 		case arg == "--all" || arg == "-a":
 			opts.all = true
+		case arg == "--jobs" || arg == "-j" || strings.HasPrefix(arg, "--jobs="):
+			n, next, err := parseJobsFlag(args, i)
+			if err != nil {
+				return nil, false, err
+			}
+			opts.jobs, i = n, next
 		case arg == "--dry-run" || arg == "-n":
 			opts.dryRun = true
 		case arg == "--force" || arg == "-f":
 			opts.force = true
+		case arg == "--to":
+			i++
+			if i >= len(args) {
+				return nil, false, fmt.Errorf("--to requires a value")
+			}
+			opts.pin.to = args[i]
+		case arg == "--latest":
+			opts.pin.latest = true
 		case arg == "--group" || arg == "-G":
 			i++
 			if i >= len(args) {
@@ -56,6 +80,13 @@ func parseUpdateArgs(args []string) (*updateOptions, bool, error) {
 		return nil, false, fmt.Errorf("--all cannot be used with skill names or --group")
 	}
 
+	if opts.pin.to != "" && opts.pin.latest {
+		return nil, false, fmt.Errorf("--to and --latest cannot be used together")
+	}
+	if opts.pin.to != "" && (opts.all || len(opts.groups) > 0 || len(opts.names) != 1) {
+		return nil, false, fmt.Errorf("--to needs exactly one tracked repository")
+	}
+
 	if len(opts.names) == 0 && len(opts.groups) == 0 && !opts.all {
 		return nil, true, fmt.Errorf("specify a skill or repo name, or use --all")
 	}
@@ -136,6 +167,12 @@ func isGroupDir(name, sourceDir string) bool {
 }
 
 func cmdUpdate(args []string) error {
+	post := &commandHooks{}
+	return post.finish(runUpdate(args, post))
+}
+
+// runUpdate is cmdUpdate; post runs the post-update hooks.
+func runUpdate(args []string, post *commandHooks) error {
 	start := time.Now()
 
 	mode, rest, err := parseModeArgs(args)
@@ -158,9 +195,17 @@ func cmdUpdate(args []string) error {
 
 	applyModeLabel(mode)
 
+	unlock, err := lockOperation("update", mode, cwd, rest)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	rec := beginUndoRecord("update", mode, cwd, rest)
+	post.begin(config.HookPostUpdate, mode, cwd, rest)
+
 	if mode == modeProject {
-		err := cmdUpdateProject(rest, cwd)
-		logUpdateOp(config.ProjectConfigPath(cwd), rest, start, err)
+		err := cmdUpdateProject(rest, cwd, rec)
+		logUpdateOp(rec, config.ProjectConfigPath(cwd), rest, start, err)
 		return err
 	}
 
@@ -179,8 +224,10 @@ func cmdUpdate(args []string) error {
 	}
 
 	if opts.all {
-		err = updateAllTrackedRepos(cfg, opts.dryRun, opts.force)
-		logUpdateOp(config.ConfigPath(), []string{"--all"}, start, err)
+		preserveUpdatableForUndo(rec, cfg.Source, nil)
+		err = updateAllTrackedRepos(cfg, opts.jobs, opts.dryRun, opts.force, opts.pin.latest)
+		reconcileGlobalPins(cfg, opts)
+		logUpdateOp(rec, config.ConfigPath(), []string{"--all"}, start, err)
 		return err
 	}
 
@@ -254,17 +301,28 @@ func cmdUpdate(args []string) error {
 		return fmt.Errorf("no skills found")
 	}
 
+	if opts.pin.to != "" && (len(targets) != 1 || !targets[0].isRepo) {
+		return fmt.Errorf("--to needs exactly one tracked repository")
+	}
+
+	relPaths := make([]string, 0, len(targets))
+	for _, t := range targets {
+		relPaths = append(relPaths, t.relPath)
+	}
+	preserveUpdatableForUndo(rec, cfg.Source, relPaths)
+
 	// --- Execute ---
 	if len(targets) == 1 {
 		// Single target: verbose path
 		t := targets[0]
 		var updateErr error
 		if t.isRepo {
-			updateErr = updateTrackedRepo(cfg, t.relPath, opts.dryRun, opts.force)
+			updateErr = updateTrackedRepo(cfg, t.relPath, opts.dryRun, opts.force, opts.pin)
+			reconcileGlobalPins(cfg, opts)
 		} else {
 			updateErr = updateRegularSkill(cfg, t.relPath, opts.dryRun, opts.force)
 		}
-		logUpdateOp(config.ConfigPath(), opts.names, start, updateErr)
+		logUpdateOp(rec, config.ConfigPath(), opts.names, start, updateErr)
 		return updateErr
 	}
 
@@ -274,24 +332,8 @@ func cmdUpdate(args []string) error {
 		fmt.Sprintf("Updating %d skill(s)", total))
 	fmt.Println()
 
-	var result updateResult
-	for i, t := range targets {
-		progress := fmt.Sprintf("[%d/%d]", i+1, total)
-		itemPath := filepath.Join(cfg.Source, t.relPath)
-		if t.isRepo {
-			if updated, _ := updateTrackedRepoQuick(t.relPath, itemPath, progress, opts.dryRun, opts.force); updated {
-				result.updated++
-			} else {
-				result.skipped++
-			}
-		} else {
-			if updateSkillFromMeta(t.relPath, itemPath, progress, opts.dryRun) {
-				result.updated++
-			} else {
-				result.skipped++
-			}
-		}
-	}
+	result := runUpdateBatch(cfg.Source, targets, opts.jobs, opts.dryRun, opts.force, opts.pin.latest)
+	reconcileGlobalPins(cfg, opts)
 
 	if !opts.dryRun {
 		fmt.Println()
@@ -312,12 +354,12 @@ func cmdUpdate(args []string) error {
 	for _, g := range opts.groups {
 		opNames = append(opNames, "--group="+g)
 	}
-	logUpdateOp(config.ConfigPath(), opNames, start, nil)
+	logUpdateOp(rec, config.ConfigPath(), opNames, start, nil)
 
 	return nil
 }
 
-func logUpdateOp(cfgPath string, args []string, start time.Time, cmdErr error) {
+func logUpdateOp(rec *undo.Recorder, cfgPath string, args []string, start time.Time, cmdErr error) {
 	e := oplog.NewEntry("update", statusFromErr(cmdErr), time.Since(start))
 	if len(args) == 1 {
 		e.Args = map[string]any{"name": args[0]}
@@ -327,6 +369,7 @@ func logUpdateOp(cfgPath string, args []string, start time.Time, cmdErr error) {
 	if cmdErr != nil {
 		e.Message = cmdErr.Error()
 	}
+	commitUndoRecord(rec, &e)
 	oplog.Write(cfgPath, oplog.OpsFile, e) //nolint:errcheck
 }
 
@@ -336,75 +379,125 @@ type updateResult struct {
 	skipped int
 }
 
-// updateTrackedRepoQuick updates a single tracked repo (for --all mode)
-func updateTrackedRepoQuick(repo, repoPath, progress string, dryRun, force bool) (updated bool, err error) {
+// updateOutcome is the result of updating one item of a batch.
+type updateOutcome struct {
+	status  string // ui.ListItem status
+	detail  string
+	updated bool
+}
+
+// runUpdateBatch updates targets on a pool of jobs workers, limited per git
+// host, then lists the outcomes in input order.
+func runUpdateBatch(sourceDir string, targets []resolvedMatch, jobs int, dryRun, force, latest bool) updateResult {
+	hosts := make([]string, len(targets))
+	for i, t := range targets {
+		hosts[i] = parallel.HostOf(remoteURLOf(filepath.Join(sourceDir, t.relPath), t.isRepo))
+	}
+
+	var progress *ui.MultiProgress
+	if !dryRun {
+		progress = ui.StartMultiProgress(fmt.Sprintf("Updating %d item(s)", len(targets)), len(targets))
+	}
+
+	outcomes := make([]updateOutcome, len(targets))
+	pool := parallel.NewPool(jobs, parallel.DefaultPerHost)
+	pool.Run(len(targets), func(i int) string { return hosts[i] }, func(i int) {
+		t := targets[i]
+		progress.Begin(t.relPath)
+		defer progress.End(t.relPath)
+		itemPath := filepath.Join(sourceDir, t.relPath)
+		if t.isRepo {
+			outcomes[i] = updateTrackedRepoQuick(itemPath, dryRun, force, latest)
+		} else {
+			outcomes[i] = updateSkillFromMeta(itemPath, dryRun)
+		}
+	})
+
+	if progress != nil {
+		progress.Stop(fmt.Sprintf("Processed %d item(s)", len(targets)))
+		fmt.Println()
+	}
+
+	var result updateResult
+	for i, t := range targets {
+		o := outcomes[i]
+		ui.ListItem(o.status, t.relPath, o.detail)
+		if o.updated {
+			result.updated++
+		} else {
+			result.skipped++
+		}
+	}
+	return result
+}
+
+// updateTrackedRepoQuick pulls a single tracked repo of a batch. Pinned
+// repos stay in place unless latest moves them to their newest tag.
+func updateTrackedRepoQuick(repoPath string, dryRun, force, latest bool) updateOutcome {
+	if _, ref := install.TrackedRepoRefs(repoPath); ref != "" {
+		return updatePinnedRepoQuick(repoPath, ref, dryRun, force, latest)
+	}
+
 	// Check for uncommitted changes
 	if isDirty, _ := git.IsDirty(repoPath); isDirty {
 		if !force {
-			ui.ListItem("warning", repo, "has uncommitted changes (use --force)")
-			return false, nil
+			return updateOutcome{status: "warning", detail: "has uncommitted changes (use --force)"}
 		}
 		if !dryRun {
 			if err := git.Restore(repoPath); err != nil {
-				ui.ListItem("warning", repo, fmt.Sprintf("failed to discard changes: %v", err))
-				return false, nil
+				return updateOutcome{status: "warning", detail: fmt.Sprintf("failed to discard changes: %v", err)}
 			}
 		}
 	}
 
 	if dryRun {
-		ui.ListItem("info", repo, "[dry-run] would git pull")
-		return false, nil
+		return updateOutcome{status: "info", detail: "[dry-run] would git pull"}
 	}
 
-	spinner := ui.StartSpinner(fmt.Sprintf("%s Updating %s...", progress, repo))
-
 	var info *git.UpdateInfo
+	var err error
 	if force {
 		info, err = git.ForcePullWithAuth(repoPath)
 	} else {
 		info, err = git.PullWithAuth(repoPath)
 	}
 	if err != nil {
-		spinner.Warn(fmt.Sprintf("%s %v", repo, err))
-		return false, nil
+		return updateOutcome{status: "warning", detail: err.Error()}
 	}
 
 	if info.UpToDate {
-		spinner.Success(fmt.Sprintf("%s Already up to date", repo))
-	} else {
-		spinner.Success(fmt.Sprintf("%s %d commits, %d files", repo, len(info.Commits), info.Stats.FilesChanged))
+		return updateOutcome{status: "success", detail: "already up to date", updated: true}
+	}
+	return updateOutcome{
+		status:  "success",
+		detail:  fmt.Sprintf("%d commits, %d files", len(info.Commits), info.Stats.FilesChanged),
+		updated: true,
 	}
-	return true, nil
 }
 
-// updateSkillFromMeta updates a skill using its metadata
-func updateSkillFromMeta(skill, skillPath, progress string, dryRun bool) (updated bool) {
+// updateSkillFromMeta reinstalls a single skill of a batch from its metadata.
+func updateSkillFromMeta(skillPath string, dryRun bool) updateOutcome {
 	if dryRun {
-		ui.ListItem("info", skill, "[dry-run] would reinstall from source")
-		return false
+		return updateOutcome{status: "info", detail: "[dry-run] would reinstall from source"}
 	}
 
-	spinner := ui.StartSpinner(fmt.Sprintf("%s Updating %s...", progress, skill))
-
-	meta, _ := install.ReadMeta(skillPath)
+	meta, err := install.ReadMeta(skillPath)
+	if err != nil || meta == nil {
+		return updateOutcome{status: "warning", detail: "no source metadata"}
+	}
 	source, err := install.ParseSource(meta.Source)
 	if err != nil {
-		spinner.Warn(fmt.Sprintf("%s invalid source: %v", skill, err))
-		return false
+		return updateOutcome{status: "warning", detail: fmt.Sprintf("invalid source: %v", err)}
 	}
 
 	opts := install.InstallOptions{Force: true, Update: true}
 	if _, err = install.Install(source, skillPath, opts); err != nil {
-		spinner.Warn(fmt.Sprintf("%s %v", skill, err))
-		return false
+		return updateOutcome{status: "warning", detail: err.Error()}
 	}
-
-	spinner.Success(fmt.Sprintf("%s Reinstalled from source", skill))
-	return true
+	return updateOutcome{status: "success", detail: "reinstalled from source", updated: true}
 }
 
-func updateAllTrackedRepos(cfg *config.Config, dryRun, force bool) error {
+func updateAllTrackedRepos(cfg *config.Config, jobs int, dryRun, force, latest bool) error {
 	repos, err := install.GetTrackedRepos(cfg.Source)
 	if err != nil {
 		return fmt.Errorf("failed to get tracked repos: %w", err)
@@ -426,29 +519,14 @@ func updateAllTrackedRepos(cfg *config.Config, dryRun, force bool) error {
 		fmt.Sprintf("Updating %d tracked repos + %d skills", len(repos), len(skills)))
 	fmt.Println()
 
-	var result updateResult
-
-	// Update tracked repos
-	for i, repo := range repos {
-		repoPath := filepath.Join(cfg.Source, repo)
-		progress := fmt.Sprintf("[%d/%d]", i+1, total)
-		if updated, _ := updateTrackedRepoQuick(repo, repoPath, progress, dryRun, force); updated {
-			result.updated++
-		} else {
-			result.skipped++
-		}
+	targets := make([]resolvedMatch, 0, total)
+	for _, repo := range repos {
+		targets = append(targets, resolvedMatch{relPath: repo, isRepo: true})
 	}
-
-	// Update regular skills
-	for i, skill := range skills {
-		skillPath := filepath.Join(cfg.Source, skill)
-		progress := fmt.Sprintf("[%d/%d]", len(repos)+i+1, total)
-		if updateSkillFromMeta(skill, skillPath, progress, dryRun) {
-			result.updated++
-		} else {
-			result.skipped++
-		}
+	for _, skill := range skills {
+		targets = append(targets, resolvedMatch{relPath: skill})
 	}
+	result := runUpdateBatch(cfg.Source, targets, jobs, dryRun, force, latest)
 
 	if !dryRun {
 		fmt.Println()
@@ -466,7 +544,7 @@ func updateAllTrackedRepos(cfg *config.Config, dryRun, force bool) error {
 	return nil
 }
 
-func updateSkillOrRepo(cfg *config.Config, name string, dryRun, force bool) error {
+func updateSkillOrRepo(cfg *config.Config, name string, dryRun, force bool, pin repoPinOptions) error {
 	// Try tracked repo first (with _ prefix)
 	repoName := name
 	if !strings.HasPrefix(repoName, "_") {
@@ -475,7 +553,7 @@ func updateSkillOrRepo(cfg *config.Config, name string, dryRun, force bool) erro
 	repoPath := filepath.Join(cfg.Source, repoName)
 
 	if install.IsGitRepo(repoPath) {
-		return updateTrackedRepo(cfg, repoName, dryRun, force)
+		return updateTrackedRepo(cfg, repoName, dryRun, force, pin)
 	}
 
 	// Try as regular skill (exact path)
@@ -486,13 +564,13 @@ func updateSkillOrRepo(cfg *config.Config, name string, dryRun, force bool) erro
 
 	// Check if it's a nested path that exists as git repo
 	if install.IsGitRepo(skillPath) {
-		return updateTrackedRepo(cfg, name, dryRun, force)
+		return updateTrackedRepo(cfg, name, dryRun, force, pin)
 	}
 
 	// Fallback: search by basename in nested skills and repos
 	if match, err := resolveByBasename(cfg.Source, name); err == nil {
 		if match.isRepo {
-			return updateTrackedRepo(cfg, match.relPath, dryRun, force)
+			return updateTrackedRepo(cfg, match.relPath, dryRun, force, pin)
 		}
 		return updateRegularSkill(cfg, match.relPath, dryRun, force)
 	} else {
@@ -542,13 +620,17 @@ func resolveByBasename(sourceDir, name string) (resolvedMatch, error) {
 	return resolvedMatch{}, fmt.Errorf("%s", strings.Join(lines, "\n"))
 }
 
-func updateTrackedRepo(cfg *config.Config, repoName string, dryRun, force bool) error {
+func updateTrackedRepo(cfg *config.Config, repoName string, dryRun, force bool, pin repoPinOptions) error {
 	repoPath := filepath.Join(cfg.Source, repoName)
 
 	// Header box
 	ui.HeaderBox("skillshare update", fmt.Sprintf("Updating: %s", repoName))
 	fmt.Println()
 
+	if handled, err := updatePinnedRepo(repoName, repoPath, pin, dryRun, force); handled {
+		return err
+	}
+
 	// Check for uncommitted changes
 	spinner := ui.StartSpinner("Checking repository status...")
 
@@ -710,12 +792,18 @@ Update one or more skills or tracked repositories.
 For tracked repos (_repo-name): runs git pull
 For regular skills: reinstalls from stored source metadata
 
+Tracked repos pinned at a tag or commit (install --ref, repo checkout) are
+left in place; move them with --to <ref> or --latest.
+
 If a positional name matches a group directory (not a repo or skill), it is
 automatically expanded to all updatable skills in that group.
 
 Safety: Tracked repos with uncommitted changes are skipped by default.
 Use --force to discard local changes and update.
 
+When several items are updated they run in parallel, at most 4 at a time
+per git host. Results are listed in the same order either way.
+
 Arguments:
   name...             Skill name(s) or tracked repo name(s)
 
@@ -724,6 +812,9 @@ Options:
   --group, -G <name>  Update all updatable skills in a group (repeatable)
   --force, -f         Discard local changes and force update
   --dry-run, -n       Preview without making changes
+  --jobs, -j <n>      Update up to n items at once (default: 8)
+  --to <ref>          Move a tracked repo to a branch, tag or commit
+  --latest            Move pinned repos to their newest version tag
   --project, -p       Use project-level config in current directory
   --global, -g        Use global config (~/.config/skillshare)
   --help, -h          Show this help
@@ -737,5 +828,8 @@ Examples:
   skillshare update team-skills           # _ prefix is optional for repos
   skillshare update --all                 # Update all tracked repos + skills
   skillshare update --all --dry-run       # Preview updates
-  skillshare update _team --force         # Discard changes and update`)
+  skillshare update --all -j 2            # Update two items at a time
+  skillshare update _team --force         # Discard changes and update
+  skillshare update _team --to v1.5.0     # Move a pinned repo to v1.5.0
+  skillshare update --all --latest        # Move every pin to its newest tag`)
 }
@@ -9,10 +9,11 @@ import (
 	"skillshare/internal/git"
 	"skillshare/internal/install"
 	"skillshare/internal/ui"
+	"skillshare/internal/undo"
 	"skillshare/internal/utils"
 )
 
-func cmdUpdateProject(args []string, root string) error {
+func cmdUpdateProject(args []string, root string, rec *undo.Recorder) error {
 	opts, showHelp, parseErr := parseUpdateArgs(args)
 	if showHelp {
 		printUpdateHelp()
@@ -36,13 +37,18 @@ func cmdUpdateProject(args []string, root string) error {
 	sourcePath := filepath.Join(root, ".skillshare", "skills")
 
 	if opts.all {
-		return updateAllProjectSkills(sourcePath, opts.dryRun, opts.force)
+		preserveUpdatableForUndo(rec, sourcePath, nil)
+		err := updateAllProjectSkills(sourcePath, opts.jobs, opts.dryRun, opts.force, opts.pin.latest)
+		reconcileProjectPins(root, opts)
+		return err
 	}
 
-	return cmdUpdateProjectBatch(sourcePath, opts)
+	err := cmdUpdateProjectBatch(sourcePath, opts, rec)
+	reconcileProjectPins(root, opts)
+	return err
 }
 
-func cmdUpdateProjectBatch(sourcePath string, opts *updateOptions) error {
+func cmdUpdateProjectBatch(sourcePath string, opts *updateOptions, rec *undo.Recorder) error {
 	// --- Resolve targets ---
 	type projectTarget struct {
 		name   string
@@ -145,11 +151,21 @@ func cmdUpdateProjectBatch(sourcePath string, opts *updateOptions) error {
 		return fmt.Errorf("no skills found")
 	}
 
+	if opts.pin.to != "" && (len(targets) != 1 || !targets[0].isRepo) {
+		return fmt.Errorf("--to needs exactly one tracked repository")
+	}
+
+	relPaths := make([]string, 0, len(targets))
+	for _, t := range targets {
+		relPaths = append(relPaths, t.name)
+	}
+	preserveUpdatableForUndo(rec, sourcePath, relPaths)
+
 	// --- Execute ---
 	if len(targets) == 1 {
 		t := targets[0]
 		if t.isRepo {
-			return updateProjectTrackedRepo(t.name, t.path, opts.dryRun, opts.force)
+			return updateProjectTrackedRepo(t.name, t.path, opts.dryRun, opts.force, opts.pin)
 		}
 		return updateSingleProjectSkill(sourcePath, t.name, opts.dryRun, opts.force)
 	}
@@ -159,24 +175,13 @@ func cmdUpdateProjectBatch(sourcePath string, opts *updateOptions) error {
 		ui.Warning("Dry run mode - no changes will be made")
 	}
 
-	updated := 0
-	for _, t := range targets {
-		if t.isRepo {
-			if err := updateProjectTrackedRepo(t.name, t.path, opts.dryRun, opts.force); err != nil {
-				ui.Warning("%s: %v", t.name, err)
-			} else {
-				updated++
-			}
-		} else {
-			if err := updateSingleProjectSkill(sourcePath, t.name, opts.dryRun, opts.force); err != nil {
-				ui.Warning("%s: %v", t.name, err)
-			} else {
-				updated++
-			}
-		}
+	items := make([]resolvedMatch, len(targets))
+	for i, t := range targets {
+		items[i] = resolvedMatch{relPath: t.name, isRepo: t.isRepo}
 	}
+	result := runUpdateBatch(sourcePath, items, opts.jobs, opts.dryRun, opts.force, opts.pin.latest)
 
-	if updated > 0 && !opts.dryRun {
+	if result.updated > 0 && !opts.dryRun {
 		fmt.Println()
 		ui.Info("Run 'skillshare sync' to distribute changes")
 	}
@@ -197,7 +202,7 @@ func updateSingleProjectSkill(sourcePath, name string, dryRun, force bool) error
 
 	// Try as tracked repo first
 	if install.IsGitRepo(repoPath) {
-		return updateProjectTrackedRepo(repoName, repoPath, dryRun, force)
+		return updateProjectTrackedRepo(repoName, repoPath, dryRun, force, repoPinOptions{})
 	}
 
 	// Regular skill with metadata
@@ -233,7 +238,11 @@ func updateSingleProjectSkill(sourcePath, name string, dryRun, force bool) error
 	return nil
 }
 
-func updateProjectTrackedRepo(repoName, repoPath string, dryRun, force bool) error {
+func updateProjectTrackedRepo(repoName, repoPath string, dryRun, force bool, pin repoPinOptions) error {
+	if handled, err := updatePinnedRepo(repoName, repoPath, pin, dryRun, force); handled {
+		return err
+	}
+
 	// Check for uncommitted changes
 	if isDirty, _ := git.IsDirty(repoPath); isDirty {
 		if !force {
@@ -276,7 +285,7 @@ func updateProjectTrackedRepo(repoName, repoPath string, dryRun, force bool) err
 	return nil
 }
 
-func updateAllProjectSkills(sourcePath string, dryRun, force bool) error {
+func updateAllProjectSkills(sourcePath string, jobs int, dryRun, force, latest bool) error {
 	entries, err := os.ReadDir(sourcePath)
 	if err != nil {
 		return fmt.Errorf("failed to read project skills: %w", err)
@@ -286,7 +295,7 @@ func updateAllProjectSkills(sourcePath string, dryRun, force bool) error {
 		ui.Warning("Dry run mode - no changes will be made")
 	}
 
-	updated := 0
+	var targets []resolvedMatch
 	for _, entry := range entries {
 		if !entry.IsDir() || utils.IsHidden(entry.Name()) {
 			continue
@@ -297,41 +306,22 @@ func updateAllProjectSkills(sourcePath string, dryRun, force bool) error {
 
 		// Tracked repo: git pull
 		if install.IsGitRepo(skillPath) {
-			if err := updateProjectTrackedRepo(skillName, skillPath, dryRun, force); err != nil {
-				ui.Warning("%s: %v", skillName, err)
-			} else {
-				updated++
-			}
+			targets = append(targets, resolvedMatch{relPath: skillName, isRepo: true})
 			continue
 		}
 
 		// Regular skill with metadata: reinstall
-		meta, err := install.ReadMeta(skillPath)
-		if err != nil || meta == nil {
-			continue
+		if meta, err := install.ReadMeta(skillPath); err == nil && meta != nil {
+			targets = append(targets, resolvedMatch{relPath: skillName})
 		}
-
-		source, err := install.ParseSource(meta.Source)
-		if err != nil {
-			ui.Warning("%s invalid source: %v", skillName, err)
-			continue
-		}
-
-		if dryRun {
-			ui.Info("[dry-run] would update %s", skillName)
-			continue
-		}
-
-		spinner := ui.StartSpinner(fmt.Sprintf("Updating %s...", skillName))
-		if _, err := install.Install(source, skillPath, install.InstallOptions{Force: true, Update: true}); err != nil {
-			spinner.Fail(fmt.Sprintf("%s failed: %v", skillName, err))
-			continue
-		}
-		spinner.Success(fmt.Sprintf("Updated %s", skillName))
-		updated++
 	}
+	if len(targets) == 0 {
+		return nil
+	}
+
+	result := runUpdateBatch(sourcePath, targets, jobs, dryRun, force, latest)
 
-	if updated > 0 && !dryRun {
+	if result.updated > 0 && !dryRun {
 		fmt.Println()
 		ui.Info("Run 'skillshare sync' to distribute changes")
 	}
@@ -8,7 +8,6 @@ import (
 	"errors"
 	"fmt"
 	"io"
-	"net/http"
 	"os"
 	"os/exec"
 	"path/filepath"
@@ -17,6 +16,7 @@ import (
 
 	"skillshare/internal/config"
 	"skillshare/internal/install"
+	"skillshare/internal/network"
 	"skillshare/internal/ui"
 	"skillshare/internal/uidist"
 	versionpkg "skillshare/internal/version"
@@ -291,7 +291,7 @@ func doSkillDownload(skillshareSkillDir string) error {
 }
 
 func downloadAndReplace(url, destPath string) error {
-	resp, err := http.Get(url)
+	resp, err := network.NewClient(0).Get(url)
 	if err != nil {
 		return err
 	}
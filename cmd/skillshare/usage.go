@@ -0,0 +1,345 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"skillshare/internal/config"
+	"skillshare/internal/sync"
+	"skillshare/internal/ui"
+	"skillshare/internal/usage"
+)
+
+// defaultUsageDays is the window shown by usage, list --verbose and status.
+const defaultUsageDays = 30
+
+type usageOptions struct {
+	action string // "", scan, enable, disable, reset
+	skill  string
+	target string
+	days   int
+	json   bool
+}
+
+func cmdUsage(args []string) error {
+	mode, rest, err := parseModeArgs(args)
+	if err != nil {
+		return err
+	}
+	if mode == modeProject {
+		return fmt.Errorf("usage reads agent transcripts from your home directory and is global only; run it without -p")
+	}
+	applyModeLabel(modeGlobal)
+
+	opts, showHelp, err := parseUsageArgs(rest)
+	if showHelp {
+		printUsageHelp()
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if opts.action == "enable" || opts.action == "disable" {
+		unlock, err := acquireOpLock(config.ConfigPath(), "usage")
+		if err != nil {
+			return err
+		}
+		defer unlock()
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		return err
+	}
+
+	switch opts.action {
+	case "enable", "disable":
+		cfg.Usage.Enabled = opts.action == "enable"
+		if err := cfg.Save(); err != nil {
+			return err
+		}
+		if !cfg.Usage.Enabled {
+			ui.Success("Usage tracking disabled (collected data kept in %s)", usage.Dir())
+			return nil
+		}
+		ui.Success("Usage tracking enabled")
+		printUsageSources(cfg)
+		return scanUsage(cfg, true)
+	case "reset":
+		if err := usage.Reset(usage.Dir()); err != nil {
+			return err
+		}
+		ui.Success("Usage data deleted")
+		return nil
+	}
+
+	if !cfg.Usage.Enabled {
+		ui.Info("Usage tracking is off. Nothing is read until you opt in:")
+		fmt.Println("  skillshare usage enable")
+		return nil
+	}
+
+	if err := scanUsage(cfg, opts.action == "scan"); err != nil {
+		return err
+	}
+	if opts.action == "scan" {
+		return nil
+	}
+
+	discovered, err := sync.DiscoverSourceSkills(cfg.Source)
+	if err != nil {
+		return fmt.Errorf("cannot discover skills: %w", err)
+	}
+	events, err := usage.Load(usage.Dir())
+	if err != nil {
+		return fmt.Errorf("failed to read usage data: %w", err)
+	}
+	if opts.target != "" {
+		filtered := events[:0]
+		for _, e := range events {
+			if e.Target == opts.target {
+				filtered = append(filtered, e)
+			}
+		}
+		events = filtered
+	}
+	summary := usage.Summarize(events, usage.Since(opts.days))
+	unused := usage.Unused(discovered, summary)
+
+	if opts.json {
+		if summary == nil {
+			summary = []usage.SkillUsage{}
+		}
+		if unused == nil {
+			unused = []string{}
+		}
+		out, _ := json.MarshalIndent(map[string]any{
+			"days":   opts.days,
+			"skills": summary,
+			"unused": unused,
+		}, "", "  ")
+		fmt.Println(string(out))
+		return nil
+	}
+
+	if opts.skill != "" {
+		return printSkillUsage(opts, summary)
+	}
+	printUsageReport(opts, summary, unused)
+	return nil
+}
+
+// scanUsage reads new transcript lines into the store.
+func scanUsage(cfg *config.Config, verbose bool) error {
+	sources := usage.Sources(cfg.Targets)
+	if len(sources) == 0 {
+		ui.Warning("No target has known transcript locations; set 'transcripts' on a target")
+		return nil
+	}
+	discovered, err := sync.DiscoverSourceSkills(cfg.Source)
+	if err != nil {
+		return fmt.Errorf("cannot discover skills: %w", err)
+	}
+	n, err := usage.Update(usage.Dir(), sources, usage.NamesFor(discovered))
+	if err != nil {
+		return fmt.Errorf("failed to update usage data: %w", err)
+	}
+	if verbose {
+		ui.Success("Scanned transcripts: %d new invocation(s)", n)
+	}
+	return nil
+}
+
+func printUsageSources(cfg *config.Config) {
+	for _, src := range usage.Sources(cfg.Targets) {
+		fmt.Printf("  %s%-10s%s %s\n", ui.Cyan, src.Target, ui.Reset, strings.Join(src.Patterns, ", "))
+	}
+}
+
+func printUsageReport(opts usageOptions, summary []usage.SkillUsage, unused []string) {
+	window := fmt.Sprintf("last %d days", opts.days)
+	if opts.days <= 0 {
+		window = "all time"
+	}
+	ui.Header(fmt.Sprintf("Skill usage (%s)", window))
+	if len(summary) == 0 {
+		ui.Info("No skill invocations recorded")
+	}
+
+	width := 10
+	for _, u := range summary {
+		if len(u.Skill) > width {
+			width = len(u.Skill)
+		}
+	}
+	for _, u := range summary {
+		fmt.Printf("  %s%-*s%s %5d   %-24s %slast %s%s\n",
+			ui.Cyan, width, u.Skill, ui.Reset, u.Total,
+			formatUsageTargets(u.Targets), ui.Gray, u.LastUsed.Local().Format(usage.DayFormat), ui.Reset)
+	}
+
+	if len(unused) > 0 {
+		fmt.Println()
+		ui.Warning("Unused in the %s (%d): %s", window, len(unused), strings.Join(unused, ", "))
+		fmt.Printf("  %sCandidates for 'skillshare uninstall'%s\n", ui.Gray, ui.Reset)
+	}
+}
+
+func printSkillUsage(opts usageOptions, summary []usage.SkillUsage) error {
+	for _, u := range summary {
+		if u.Skill != opts.skill {
+			continue
+		}
+		ui.Header(fmt.Sprintf("%s: %d invocation(s)", u.Skill, u.Total))
+		fmt.Printf("  %sTargets:%s   %s\n", ui.Gray, ui.Reset, formatUsageTargets(u.Targets))
+		fmt.Printf("  %sLast used:%s %s\n\n", ui.Gray, ui.Reset, u.LastUsed.Local().Format("2006-01-02 15:04"))
+		days := make([]string, 0, len(u.Days))
+		for d := range u.Days {
+			days = append(days, d)
+		}
+		sort.Sort(sort.Reverse(sort.StringSlice(days)))
+		for _, d := range days {
+			fmt.Printf("  %s  %4d  %s\n", d, u.Days[d], strings.Repeat("▇", min(u.Days[d], 40)))
+		}
+		return nil
+	}
+	ui.Info("%s: no invocations recorded", opts.skill)
+	return nil
+}
+
+// formatUsageTargets renders per-target counts, most used first.
+func formatUsageTargets(targets map[string]int) string {
+	names := make([]string, 0, len(targets))
+	for name := range targets {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		if targets[names[i]] != targets[names[j]] {
+			return targets[names[i]] > targets[names[j]]
+		}
+		return names[i] < names[j]
+	})
+	parts := make([]string, len(names))
+	for i, name := range names {
+		parts[i] = fmt.Sprintf("%s %d", name, targets[name])
+	}
+	return strings.Join(parts, ", ")
+}
+
+// loadUsageSummary returns the recorded usage of the last defaultUsageDays
+// keyed by skill, or nil when tracking is off. It does not scan.
+func loadUsageSummary(cfg *config.Config) map[string]usage.SkillUsage {
+	if !cfg.Usage.Enabled {
+		return nil
+	}
+	events, err := usage.Load(usage.Dir())
+	if err != nil {
+		return nil
+	}
+	out := map[string]usage.SkillUsage{}
+	for _, u := range usage.Summarize(events, usage.Since(defaultUsageDays)) {
+		out[u.Skill] = u
+	}
+	return out
+}
+
+// printUsageStatus is the usage section of status.
+func printUsageStatus(cfg *config.Config, discovered []sync.DiscoveredSkill) {
+	summary := loadUsageSummary(cfg)
+	if summary == nil {
+		return
+	}
+	ui.Header(fmt.Sprintf("Usage (last %d days)", defaultUsageDays))
+	used, total := 0, 0
+	for _, d := range discovered {
+		if u, ok := summary[d.FlatName]; ok {
+			used++
+			total += u.Total
+		}
+	}
+	ui.Success("%d of %d skills used, %d invocation(s)", used, len(discovered), total)
+	if unused := len(discovered) - used; unused > 0 {
+		ui.Info("%d unused; run 'skillshare usage' for details", unused)
+	}
+}
+
+func parseUsageArgs(args []string) (usageOptions, bool, error) {
+	opts := usageOptions{days: defaultUsageDays}
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		key, val, hasVal := strings.Cut(arg, "=")
+		switch {
+		case arg == "--help" || arg == "-h":
+			return opts, true, nil
+		case arg == "--json":
+			opts.json = true
+		case key == "--days" || key == "--target" || key == "-t":
+			if !hasVal {
+				if i+1 >= len(args) {
+					return opts, false, fmt.Errorf("%s requires a value", key)
+				}
+				i++
+				val = args[i]
+			}
+			if key == "--days" {
+				n, err := strconv.Atoi(val)
+				if err != nil || n < 0 {
+					return opts, false, fmt.Errorf("invalid --days %q (use a number of days, 0 for all)", val)
+				}
+				opts.days = n
+			} else {
+				opts.target = val
+			}
+		case strings.HasPrefix(arg, "-"):
+			return opts, false, fmt.Errorf("unknown option: %s", arg)
+		case opts.action == "" && opts.skill == "" && (arg == "scan" || arg == "enable" || arg == "disable" || arg == "reset"):
+			opts.action = arg
+		case opts.skill == "" && opts.action == "":
+			opts.skill = arg
+		default:
+			return opts, false, fmt.Errorf("unexpected argument: %s", arg)
+		}
+	}
+	return opts, false, nil
+}
+
+func printUsageHelp() {
+	fmt.Println(`Usage: skillshare usage [skill] [options]
+       skillshare usage scan|enable|disable|reset
+
+Count how often agents invoke each skill, from the session transcripts they
+keep on disk (e.g. ~/.claude/projects, ~/.codex/sessions). Opt-in and local:
+nothing is read until 'usage enable', and results stay in
+~/.local/state/skillshare/usage/.
+
+Actions:
+  enable            Turn tracking on and scan transcripts
+  disable           Turn tracking off (data is kept)
+  scan              Read new transcript lines without reporting
+  reset             Delete all collected usage data
+
+Options:
+  --days <n>        Window to report (default: 30, 0 for all time)
+  --target, -t <t>  Only count invocations from one target
+  --json            Output as JSON
+  --help, -h        Show this help
+
+Examples:
+  skillshare usage enable
+  skillshare usage
+  skillshare usage pdf --days 90
+  skillshare usage --target claude --json`)
+}
+
+// formatUsageLine is the list --verbose summary for one skill.
+func formatUsageLine(summary map[string]usage.SkillUsage, skill string) string {
+	u, ok := summary[skill]
+	if !ok {
+		return fmt.Sprintf("never in %d days", defaultUsageDays)
+	}
+	return fmt.Sprintf("%d× in %d days (last %s)", u.Total, defaultUsageDays, u.LastUsed.Local().Format(usage.DayFormat))
+}
@@ -2,10 +2,10 @@ package backup
 
 import (
 	"fmt"
-	"io"
 	"os"
 	"path/filepath"
 	"sort"
+	"strings"
 	"time"
 
 	"skillshare/internal/config"
@@ -16,8 +16,8 @@ func BackupDir() string {
 	return filepath.Join(config.DataDir(), "backups")
 }
 
-// Create creates a backup of the target directory
-// Returns the backup path
+// Create creates a backup of the target directory.
+// Returns the backup (timestamp) directory holding its snapshot.
 func Create(targetName, targetPath string) (string, error) {
 	backupDir := BackupDir()
 	if backupDir == "" {
@@ -44,29 +44,36 @@ func Create(targetName, targetPath string) (string, error) {
 		return "", nil // Empty, nothing to backup
 	}
 
-	// Create backup directory with timestamp
-	timestamp := time.Now().Format("2006-01-02_15-04-05")
-	backupPath := filepath.Join(backupDir, timestamp, targetName)
+	// Store files and write the snapshot manifest under the timestamp dir
+	now := time.Now()
+	backupPath := filepath.Join(backupDir, now.Format("2006-01-02_15-04-05"))
 
-	if err := os.MkdirAll(backupPath, 0755); err != nil {
-		return "", fmt.Errorf("failed to create backup directory: %w", err)
+	lock, err := lockStore(backupDir)
+	if err != nil {
+		return "", err
 	}
+	defer lock.Close()
 
-	// Copy target contents to backup
-	if err := copyDir(targetPath, backupPath); err != nil {
+	if err := writeSnapshot(backupDir, backupPath, targetName, targetPath, now); err != nil {
 		return "", fmt.Errorf("failed to backup: %w", err)
 	}
 
 	return backupPath, nil
 }
 
-// List returns all backups sorted by date (newest first)
+// List returns all backups sorted by date (newest first).
+// Legacy full-copy backups are migrated to snapshots on first use.
 func List() ([]BackupInfo, error) {
 	backupDir := BackupDir()
 	if backupDir == "" {
 		return nil, fmt.Errorf("cannot determine backup directory: home directory not found")
 	}
+	migrateStore(backupDir)
+	return listBackups(backupDir)
+}
 
+// listBackups reads the backups in backupDir without migrating them.
+func listBackups(backupDir string) ([]BackupInfo, error) {
 	entries, err := os.ReadDir(backupDir)
 	if err != nil {
 		if os.IsNotExist(err) {
@@ -77,30 +84,34 @@ func List() ([]BackupInfo, error) {
 
 	var backups []BackupInfo
 	for _, entry := range entries {
-		if !entry.IsDir() {
+		if !entry.IsDir() || strings.HasPrefix(entry.Name(), ".") {
 			continue
 		}
 
 		backupPath := filepath.Join(backupDir, entry.Name())
-		info, err := entry.Info()
-		if err != nil {
-			continue
-		}
 
-		// List targets in this backup
-		targetEntries, _ := os.ReadDir(backupPath)
+		// List targets in this backup; the date is when the first was taken
 		var targets []string
-		for _, t := range targetEntries {
-			if t.IsDir() {
-				targets = append(targets, t.Name())
+		var date time.Time
+		for _, t := range listSnapshots(backupPath) {
+			snap, err := loadSnapshot(backupPath, t)
+			if err != nil {
+				continue
 			}
+			targets = append(targets, t)
+			if date.IsZero() || snap.Created.Before(date) {
+				date = snap.Created
+			}
+		}
+		if len(targets) == 0 {
+			continue
 		}
 
 		backups = append(backups, BackupInfo{
 			Timestamp: entry.Name(),
 			Path:      backupPath,
 			Targets:   targets,
-			Date:      info.ModTime(),
+			Date:      date,
 		})
 	}
 
@@ -119,69 +130,3 @@ type BackupInfo struct {
 	Targets   []string
 	Date      time.Time
 }
-
-// copyDir copies a directory recursively, skipping symlinks and junctions.
-// Uses os.ReadDir + os.Lstat instead of filepath.Walk to avoid failures
-// when os.Lstat on Windows junctions returns nil info with an error.
-func copyDir(src, dst string) error {
-	if err := os.MkdirAll(dst, 0755); err != nil {
-		return err
-	}
-
-	entries, err := os.ReadDir(src)
-	if err != nil {
-		return err
-	}
-
-	for _, entry := range entries {
-		srcPath := filepath.Join(src, entry.Name())
-		dstPath := filepath.Join(dst, entry.Name())
-
-		// Use Lstat to detect symlinks/junctions without following them
-		info, err := os.Lstat(srcPath)
-		if err != nil {
-			// Cannot stat (e.g. broken junction on Windows) — skip
-			continue
-		}
-
-		// Skip symlinks and junctions — they point to source, not local data
-		if info.Mode()&os.ModeSymlink != 0 {
-			continue
-		}
-
-		if info.IsDir() {
-			if err := copyDir(srcPath, dstPath); err != nil {
-				return err
-			}
-		} else if info.Mode().IsRegular() {
-			if err := copyFile(srcPath, dstPath); err != nil {
-				return err
-			}
-		}
-	}
-
-	return nil
-}
-
-// copyFile copies a single file
-func copyFile(src, dst string) error {
-	srcFile, err := os.Open(src)
-	if err != nil {
-		return err
-	}
-	defer srcFile.Close()
-
-	srcInfo, err := srcFile.Stat()
-	if err != nil {
-		return err
-	}
-
-	dstFile, err := os.OpenFile(dst, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, srcInfo.Mode())
-	if err != nil {
-		return err
-	}
-	defer dstFile.Close()
-
-	_, err = io.Copy(dstFile, srcFile)
-	return err
-}
@@ -4,9 +4,10 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 )
 
-func TestCopyDir_RegularFiles(t *testing.T) {
+func TestSnapshot_RegularFiles(t *testing.T) {
 	src := t.TempDir()
 	dst := t.TempDir()
 
@@ -14,15 +15,15 @@ func TestCopyDir_RegularFiles(t *testing.T) {
 	os.MkdirAll(filepath.Join(src, "subdir"), 0755)
 	writeTestFile(t, filepath.Join(src, "subdir", "file2.txt"), "world")
 
-	if err := copyDir(src, dst); err != nil {
-		t.Fatalf("copyDir failed: %v", err)
+	if err := snapshotRoundTrip(t, src, dst); err != nil {
+		t.Fatalf("snapshot round trip failed: %v", err)
 	}
 
 	assertFileContent(t, filepath.Join(dst, "file1.txt"), "hello")
 	assertFileContent(t, filepath.Join(dst, "subdir", "file2.txt"), "world")
 }
 
-func TestCopyDir_SkipsSymlinks(t *testing.T) {
+func TestSnapshot_SkipsSymlinks(t *testing.T) {
 	src := t.TempDir()
 	dst := t.TempDir()
 
@@ -44,8 +45,8 @@ func TestCopyDir_SkipsSymlinks(t *testing.T) {
 		t.Skipf("symlink not supported: %v", err)
 	}
 
-	if err := copyDir(src, dst); err != nil {
-		t.Fatalf("copyDir failed: %v", err)
+	if err := snapshotRoundTrip(t, src, dst); err != nil {
+		t.Fatalf("snapshot round trip failed: %v", err)
 	}
 
 	assertFileContent(t, filepath.Join(dst, "real.txt"), "keep me")
@@ -58,7 +59,7 @@ func TestCopyDir_SkipsSymlinks(t *testing.T) {
 	}
 }
 
-func TestCopyDir_MixedContent(t *testing.T) {
+func TestSnapshot_MixedContent(t *testing.T) {
 	src := t.TempDir()
 	dst := t.TempDir()
 
@@ -80,8 +81,8 @@ func TestCopyDir_MixedContent(t *testing.T) {
 		t.Skipf("symlink not supported: %v", err)
 	}
 
-	if err := copyDir(src, dst); err != nil {
-		t.Fatalf("copyDir failed: %v", err)
+	if err := snapshotRoundTrip(t, src, dst); err != nil {
+		t.Fatalf("snapshot round trip failed: %v", err)
 	}
 
 	assertFileContent(t, filepath.Join(dst, "my-local-skill", "SKILL.md"), "# Local Skill")
@@ -92,7 +93,7 @@ func TestCopyDir_MixedContent(t *testing.T) {
 	}
 }
 
-func TestCopyDir_BrokenSymlink(t *testing.T) {
+func TestSnapshot_BrokenSymlink(t *testing.T) {
 	src := t.TempDir()
 	dst := t.TempDir()
 
@@ -102,8 +103,8 @@ func TestCopyDir_BrokenSymlink(t *testing.T) {
 		t.Skipf("symlink not supported: %v", err)
 	}
 
-	if err := copyDir(src, dst); err != nil {
-		t.Fatalf("copyDir should not fail on broken symlink: %v", err)
+	if err := snapshotRoundTrip(t, src, dst); err != nil {
+		t.Fatalf("snapshot should not fail on broken symlink: %v", err)
 	}
 
 	assertFileContent(t, filepath.Join(dst, "real.txt"), "safe")
@@ -113,12 +114,12 @@ func TestCopyDir_BrokenSymlink(t *testing.T) {
 	}
 }
 
-func TestCopyDir_EmptyDir(t *testing.T) {
+func TestSnapshot_EmptyDir(t *testing.T) {
 	src := t.TempDir()
 	dst := t.TempDir()
 
-	if err := copyDir(src, dst); err != nil {
-		t.Fatalf("copyDir on empty dir failed: %v", err)
+	if err := snapshotRoundTrip(t, src, dst); err != nil {
+		t.Fatalf("snapshot of empty dir failed: %v", err)
 	}
 
 	entries, _ := os.ReadDir(dst)
@@ -139,6 +140,21 @@ func TestBackupDir_RespectsXDGDataHome(t *testing.T) {
 
 // --- helpers ---
 
+// snapshotRoundTrip stores src in a fresh object store and restores it to dst.
+func snapshotRoundTrip(t *testing.T, src, dst string) error {
+	t.Helper()
+	backupDir := t.TempDir()
+	backupPath := filepath.Join(backupDir, "2024-01-01_00-00-00")
+	if err := writeSnapshot(backupDir, backupPath, "claude", src, time.Now()); err != nil {
+		return err
+	}
+	snap, err := LoadSnapshot(backupPath, "claude")
+	if err != nil {
+		return err
+	}
+	return restoreSnapshot(backupDir, snap, dst)
+}
+
 func writeTestFile(t *testing.T, path, content string) {
 	t.Helper()
 	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
@@ -1,6 +1,7 @@
 package backup
 
 import (
+	"fmt"
 	"os"
 	"path/filepath"
 	"time"
@@ -25,7 +26,21 @@ func DefaultCleanupConfig() CleanupConfig {
 // Cleanup removes old backups based on the configuration.
 // Returns the number of backups removed and any error encountered.
 func Cleanup(cfg CleanupConfig) (int, error) {
-	backups, err := List()
+	backupDir := BackupDir()
+	if backupDir == "" {
+		return 0, fmt.Errorf("cannot determine backup directory: home directory not found")
+	}
+	migrateStore(backupDir)
+
+	// Hold the store lock from listing to pruning, so a backup written in
+	// the meantime can't lose objects it deduplicated against
+	lock, err := lockStore(backupDir)
+	if err != nil {
+		return 0, err
+	}
+	defer lock.Close()
+
+	backups, err := listBackups(backupDir)
 	if err != nil {
 		return 0, err
 	}
@@ -37,6 +52,8 @@ func Cleanup(cfg CleanupConfig) (int, error) {
 	removed := 0
 	now := time.Now()
 	var totalSize int64
+	sizes := IncrementalSizes(backups)
+	var kept []BackupInfo
 
 	// Backups are sorted by date (newest first)
 	for i, backup := range backups {
@@ -53,8 +70,7 @@ func Cleanup(cfg CleanupConfig) (int, error) {
 		}
 
 		// Check size - remove if total exceeds limit (skip if MaxSizeMB is 0)
-		size := dirSize(backup.Path)
-		totalSize += size
+		totalSize += sizes[i]
 		if cfg.MaxSizeMB > 0 && totalSize > cfg.MaxSizeMB*1024*1024 {
 			shouldRemove = true
 		}
@@ -62,18 +78,50 @@ func Cleanup(cfg CleanupConfig) (int, error) {
 		if shouldRemove {
 			if err := os.RemoveAll(backup.Path); err != nil {
 				// Log but continue with other backups
+				kept = append(kept, backup)
 				continue
 			}
 			removed++
+		} else {
+			kept = append(kept, backup)
 		}
 	}
 
+	// Drop objects no remaining snapshot uses
+	if removed > 0 {
+		pruneObjects(backupDir, kept)
+	}
+
 	// Clean up empty timestamp directories
-	cleanEmptyDirs(BackupDir())
+	cleanEmptyDirs(backupDir)
 
 	return removed, nil
 }
 
+// IncrementalSizes returns, for each backup in order (newest first), the
+// bytes of stored objects it adds on top of the newer backups before it.
+// Objects shared with a newer backup count only for the newest one.
+func IncrementalSizes(backups []BackupInfo) []int64 {
+	seen := map[string]bool{}
+	sizes := make([]int64, len(backups))
+	for i, b := range backups {
+		for _, t := range b.Targets {
+			snap, err := loadSnapshot(b.Path, t)
+			if err != nil {
+				continue
+			}
+			for _, f := range snap.Files {
+				if seen[f.Hash] {
+					continue
+				}
+				seen[f.Hash] = true
+				sizes[i] += f.Size
+			}
+		}
+	}
+	return sizes
+}
+
 // CleanupByAge removes backups older than the specified duration.
 // Returns the number of backups removed.
 func CleanupByAge(maxAge time.Duration) (int, error) {
@@ -123,21 +171,26 @@ func cleanEmptyDirs(path string) {
 	}
 }
 
-// Size returns the total size of a backup directory in bytes
+// Size returns the total size of the files in a backup (all of its target
+// snapshots) in bytes, i.e. what a restore would write.
 func Size(path string) int64 {
-	return dirSize(path)
+	var total int64
+	for _, t := range listSnapshots(path) {
+		if snap, err := loadSnapshot(path, t); err == nil {
+			total += snap.Size()
+		}
+	}
+	return total
 }
 
-// TotalSize returns the total size of all backups in bytes
+// TotalSize returns the disk space used by all backups in bytes: the
+// deduplicated object store plus the snapshot manifests.
 func TotalSize() (int64, error) {
-	backups, err := List()
-	if err != nil {
-		return 0, err
-	}
-
-	var total int64
-	for _, b := range backups {
-		total += dirSize(b.Path)
+	backupDir := BackupDir()
+	if backupDir == "" {
+		return 0, fmt.Errorf("cannot determine backup directory: home directory not found")
 	}
-	return total, nil
+	// Migrate legacy copies so they are counted deduplicated
+	migrateStore(backupDir)
+	return dirSize(backupDir), nil
 }
@@ -13,14 +13,9 @@ type RestoreOptions struct {
 
 // ValidateRestore checks if a restore would succeed without modifying the destination.
 func ValidateRestore(backupPath, targetName, destPath string, opts RestoreOptions) error {
-	targetBackupPath := filepath.Join(backupPath, targetName)
-
 	// Verify backup source exists
-	if _, err := os.Stat(targetBackupPath); err != nil {
-		if os.IsNotExist(err) {
-			return fmt.Errorf("target '%s' not found in backup", targetName)
-		}
-		return fmt.Errorf("cannot access backup: %w", err)
+	if _, err := LoadSnapshot(backupPath, targetName); err != nil {
+		return err
 	}
 
 	// Check if destination exists
@@ -57,7 +52,10 @@ func RestoreToPath(backupPath, targetName, destPath string, opts RestoreOptions)
 		return err
 	}
 
-	targetBackupPath := filepath.Join(backupPath, targetName)
+	snap, err := LoadSnapshot(backupPath, targetName)
+	if err != nil {
+		return err
+	}
 
 	// Check if destination exists
 	info, err := os.Stat(destPath)
@@ -79,8 +77,8 @@ func RestoreToPath(backupPath, targetName, destPath string, opts RestoreOptions)
 		return fmt.Errorf("cannot access destination: %w", err)
 	}
 
-	// Copy backup to destination
-	return copyDir(targetBackupPath, destPath)
+	// Materialize the snapshot from the object store
+	return restoreSnapshot(filepath.Dir(backupPath), snap, destPath)
 }
 
 // RestoreLatest restores the most recent backup for a target.
@@ -141,3 +139,62 @@ func GetBackupByTimestamp(timestamp string) (*BackupInfo, error) {
 
 	return nil, fmt.Errorf("backup not found: %s", timestamp)
 }
+
+// ValidateRestoreSkills checks that every skill exists in the backup of
+// targetName and that restoring it into destDir would not overwrite a
+// non-empty directory (unless opts.Force is set).
+func ValidateRestoreSkills(backupPath, targetName, destDir string, skills []string, opts RestoreOptions) error {
+	snap, err := LoadSnapshot(backupPath, targetName)
+	if err != nil {
+		return err
+	}
+
+	for _, name := range skills {
+		if !snap.HasSkill(name) {
+			return fmt.Errorf("skill '%s' not found in backup of '%s'", name, targetName)
+		}
+		if opts.Force {
+			continue
+		}
+		skillPath := filepath.Join(destDir, name)
+		info, err := os.Lstat(skillPath)
+		if err != nil {
+			continue
+		}
+		if info.Mode()&os.ModeSymlink != 0 {
+			continue // links are replaced, they hold no local data
+		}
+		if info.IsDir() {
+			if entries, _ := os.ReadDir(skillPath); len(entries) == 0 {
+				continue
+			}
+		}
+		return fmt.Errorf("skill already exists: %s (use --force to overwrite)", skillPath)
+	}
+	return nil
+}
+
+// RestoreSkills restores only the named skills from the backup of
+// targetName into destDir, leaving every other entry in destDir untouched.
+// destDir can be the target directory or the source directory.
+func RestoreSkills(backupPath, targetName, destDir string, skills []string, opts RestoreOptions) error {
+	if err := ValidateRestoreSkills(backupPath, targetName, destDir, skills, opts); err != nil {
+		return err
+	}
+
+	snap, err := LoadSnapshot(backupPath, targetName)
+	if err != nil {
+		return err
+	}
+
+	for _, name := range skills {
+		skillPath := filepath.Join(destDir, name)
+		if err := os.RemoveAll(skillPath); err != nil {
+			return fmt.Errorf("failed to remove existing %s: %w", name, err)
+		}
+		if err := restoreSnapshot(filepath.Dir(backupPath), snap.Subset(name), destDir); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,468 @@
+package backup
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"skillshare/internal/filelock"
+)
+
+// Backups are stored content-addressed: every file lives once in
+// backups/.objects/<sha256[:2]>/<sha256[2:]>, and each backup of a target is
+// a small manifest at backups/<timestamp>/<target>.json listing the files it
+// contains. Identical files across targets and timestamps share one object,
+// so a new backup only adds the files that changed since the last one.
+
+// objectsDirName is the object store directory inside BackupDir.
+const objectsDirName = ".objects"
+
+// snapshotExt is the file extension of snapshot manifests.
+const snapshotExt = ".json"
+
+// storeLockName is the lock file inside BackupDir. It is held while objects
+// are added or pruned, so a prune never removes an object that a backup
+// being written has just deduplicated against.
+const storeLockName = ".lock"
+
+// storeLockTimeout bounds how long a backup waits for another process.
+const storeLockTimeout = time.Minute
+
+// migratedMarker is created in BackupDir once legacy full-copy backups
+// have been converted, so the conversion is not retried on every read.
+const migratedMarker = ".migrated"
+
+// Snapshot is the manifest of one target backup.
+type Snapshot struct {
+	Target  string      `json:"target"`
+	Created time.Time   `json:"created"`
+	Files   []FileEntry `json:"files"`
+	Dirs    []string    `json:"dirs,omitempty"` // empty directories
+}
+
+// FileEntry is one file in a snapshot. Path is slash-separated and
+// relative to the target directory.
+type FileEntry struct {
+	Path string      `json:"path"`
+	Hash string      `json:"sha256"`
+	Size int64       `json:"size"`
+	Mode os.FileMode `json:"mode"`
+}
+
+// Size returns the total size of the files in the snapshot in bytes.
+func (s *Snapshot) Size() int64 {
+	var total int64
+	for _, f := range s.Files {
+		total += f.Size
+	}
+	return total
+}
+
+// SkillInfo summarizes one top-level entry (normally a skill directory)
+// of a snapshot.
+type SkillInfo struct {
+	Name  string
+	Files int
+	Size  int64
+}
+
+// Skills returns the top-level entries of the snapshot, sorted by name.
+func (s *Snapshot) Skills() []SkillInfo {
+	byName := map[string]*SkillInfo{}
+	add := func(name string) *SkillInfo {
+		if byName[name] == nil {
+			byName[name] = &SkillInfo{Name: name}
+		}
+		return byName[name]
+	}
+	for _, f := range s.Files {
+		info := add(topLevel(f.Path))
+		info.Files++
+		info.Size += f.Size
+	}
+	for _, d := range s.Dirs {
+		add(topLevel(d))
+	}
+
+	out := make([]SkillInfo, 0, len(byName))
+	for _, info := range byName {
+		out = append(out, *info)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Name < out[j].Name })
+	return out
+}
+
+// HasSkill reports whether the snapshot contains the top-level entry name.
+func (s *Snapshot) HasSkill(name string) bool {
+	for _, f := range s.Files {
+		if topLevel(f.Path) == name {
+			return true
+		}
+	}
+	for _, d := range s.Dirs {
+		if topLevel(d) == name {
+			return true
+		}
+	}
+	return false
+}
+
+// Subset returns a copy of the snapshot holding only the top-level entry name.
+func (s *Snapshot) Subset(name string) *Snapshot {
+	sub := &Snapshot{Target: s.Target, Created: s.Created}
+	for _, f := range s.Files {
+		if topLevel(f.Path) == name {
+			sub.Files = append(sub.Files, f)
+		}
+	}
+	for _, d := range s.Dirs {
+		if topLevel(d) == name {
+			sub.Dirs = append(sub.Dirs, d)
+		}
+	}
+	return sub
+}
+
+func topLevel(path string) string {
+	if i := strings.Index(path, "/"); i >= 0 {
+		return path[:i]
+	}
+	return path
+}
+
+// objectsDir returns the object store path for a backup root.
+func objectsDir(backupDir string) string {
+	return filepath.Join(backupDir, objectsDirName)
+}
+
+// objectPath returns where the object with the given hash is stored.
+func objectPath(backupDir, hash string) string {
+	return filepath.Join(objectsDir(backupDir), hash[:2], hash[2:])
+}
+
+// snapshotPath returns the manifest path of a target inside a timestamp dir.
+func snapshotPath(backupPath, targetName string) string {
+	return filepath.Join(backupPath, targetName+snapshotExt)
+}
+
+// LoadSnapshot reads the manifest of targetName in the backup at backupPath
+// (a timestamp directory).
+func LoadSnapshot(backupPath, targetName string) (*Snapshot, error) {
+	migrateStore(filepath.Dir(backupPath))
+	return loadSnapshot(backupPath, targetName)
+}
+
+func loadSnapshot(backupPath, targetName string) (*Snapshot, error) {
+	data, err := os.ReadFile(snapshotPath(backupPath, targetName))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("target '%s' not found in backup", targetName)
+		}
+		return nil, fmt.Errorf("cannot access backup: %w", err)
+	}
+	var snap Snapshot
+	if err := json.Unmarshal(data, &snap); err != nil {
+		return nil, fmt.Errorf("corrupt backup manifest for '%s': %w", targetName, err)
+	}
+	return &snap, nil
+}
+
+// lockStore takes the cross-process lock of the backup store in backupDir.
+// Close the returned file to release it.
+func lockStore(backupDir string) (*os.File, error) {
+	if err := os.MkdirAll(backupDir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create backup directory: %w", err)
+	}
+	f, err := filelock.Lock(filepath.Join(backupDir, storeLockName), storeLockTimeout)
+	if err != nil {
+		return nil, fmt.Errorf("cannot lock backup store: %w", err)
+	}
+	return f, nil
+}
+
+// writeSnapshot stores every regular file under srcDir in the object store
+// and writes the manifest. The caller must hold the store lock. Symlinks and junctions are skipped, as they
+// point to source rather than local data.
+func writeSnapshot(backupDir, backupPath, targetName, srcDir string, created time.Time) error {
+	snap := &Snapshot{Target: targetName, Created: created}
+	if err := storeTree(backupDir, srcDir, "", snap); err != nil {
+		return err
+	}
+	sort.Slice(snap.Files, func(i, j int) bool { return snap.Files[i].Path < snap.Files[j].Path })
+
+	if err := os.MkdirAll(backupPath, 0755); err != nil {
+		return fmt.Errorf("failed to create backup directory: %w", err)
+	}
+	data, err := json.MarshalIndent(snap, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(snapshotPath(backupPath, targetName), data, 0644)
+}
+
+// storeTree walks dir recursively, skipping symlinks and junctions, and
+// adds its files to snap. Uses os.Lstat so broken Windows junctions are
+// skipped rather than failing the backup.
+func storeTree(backupDir, dir, rel string, snap *Snapshot) error {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return err
+	}
+	if len(entries) == 0 && rel != "" {
+		snap.Dirs = append(snap.Dirs, rel)
+		return nil
+	}
+
+	for _, entry := range entries {
+		srcPath := filepath.Join(dir, entry.Name())
+		relPath := entry.Name()
+		if rel != "" {
+			relPath = rel + "/" + entry.Name()
+		}
+
+		info, err := os.Lstat(srcPath)
+		if err != nil {
+			// Cannot stat (e.g. broken junction on Windows) — skip
+			continue
+		}
+		if info.Mode()&os.ModeSymlink != 0 {
+			continue
+		}
+
+		if info.IsDir() {
+			if err := storeTree(backupDir, srcPath, relPath, snap); err != nil {
+				return err
+			}
+		} else if info.Mode().IsRegular() {
+			hash, err := storeObject(backupDir, srcPath)
+			if err != nil {
+				return err
+			}
+			snap.Files = append(snap.Files, FileEntry{
+				Path: relPath,
+				Hash: hash,
+				Size: info.Size(),
+				Mode: info.Mode().Perm(),
+			})
+		}
+	}
+	return nil
+}
+
+// storeObject copies a file into the object store unless an identical
+// object already exists, and returns its hash.
+func storeObject(backupDir, src string) (string, error) {
+	in, err := os.Open(src)
+	if err != nil {
+		return "", err
+	}
+	defer in.Close()
+
+	objDir := objectsDir(backupDir)
+	if err := os.MkdirAll(objDir, 0755); err != nil {
+		return "", err
+	}
+	tmp, err := os.CreateTemp(objDir, "tmp-*")
+	if err != nil {
+		return "", err
+	}
+	tmpName := tmp.Name()
+	defer os.Remove(tmpName)
+
+	h := sha256.New()
+	if _, err := io.Copy(io.MultiWriter(tmp, h), in); err != nil {
+		tmp.Close()
+		return "", err
+	}
+	if err := tmp.Close(); err != nil {
+		return "", err
+	}
+
+	hash := hex.EncodeToString(h.Sum(nil))
+	dst := objectPath(backupDir, hash)
+	if _, err := os.Stat(dst); err == nil {
+		return hash, nil
+	}
+	if err := os.MkdirAll(filepath.Dir(dst), 0755); err != nil {
+		return "", err
+	}
+	if err := os.Rename(tmpName, dst); err != nil {
+		return "", err
+	}
+	return hash, nil
+}
+
+// restoreSnapshot materializes snap into destPath.
+func restoreSnapshot(backupDir string, snap *Snapshot, destPath string) error {
+	if err := os.MkdirAll(destPath, 0755); err != nil {
+		return err
+	}
+	for _, d := range snap.Dirs {
+		if err := os.MkdirAll(filepath.Join(destPath, filepath.FromSlash(d)), 0755); err != nil {
+			return err
+		}
+	}
+	for _, f := range snap.Files {
+		dst := filepath.Join(destPath, filepath.FromSlash(f.Path))
+		if err := os.MkdirAll(filepath.Dir(dst), 0755); err != nil {
+			return err
+		}
+		if err := copyObject(backupDir, f, dst); err != nil {
+			return fmt.Errorf("failed to restore %s: %w", f.Path, err)
+		}
+	}
+	return nil
+}
+
+func copyObject(backupDir string, f FileEntry, dst string) error {
+	in, err := os.Open(objectPath(backupDir, f.Hash))
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	mode := f.Mode
+	if mode == 0 {
+		mode = 0644
+	}
+	out, err := os.OpenFile(dst, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, mode)
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+
+	_, err = io.Copy(out, in)
+	return err
+}
+
+// listSnapshots returns the target names with a manifest in backupPath.
+func listSnapshots(backupPath string) []string {
+	entries, _ := os.ReadDir(backupPath)
+	var targets []string
+	for _, e := range entries {
+		if e.IsDir() || !strings.HasSuffix(e.Name(), snapshotExt) {
+			continue
+		}
+		targets = append(targets, strings.TrimSuffix(e.Name(), snapshotExt))
+	}
+	return targets
+}
+
+// migrateStore converts legacy full-copy backups in backupDir into
+// snapshots, once: the marker file is written when every backup converted,
+// so later calls return after a single stat.
+func migrateStore(backupDir string) {
+	marker := filepath.Join(backupDir, migratedMarker)
+	if _, err := os.Stat(marker); err == nil {
+		return
+	}
+	entries, err := os.ReadDir(backupDir)
+	if err != nil {
+		return
+	}
+
+	lock, err := lockStore(backupDir)
+	if err != nil {
+		return
+	}
+	defer lock.Close()
+
+	ok := true
+	for _, e := range entries {
+		if !e.IsDir() || strings.HasPrefix(e.Name(), ".") {
+			continue
+		}
+		if !migrateBackup(filepath.Join(backupDir, e.Name())) {
+			ok = false
+		}
+	}
+	if ok {
+		os.WriteFile(marker, nil, 0644) //nolint:errcheck
+	}
+}
+
+// migrateBackup converts legacy full-copy backups (backups/<ts>/<target>/)
+// in a timestamp directory into snapshots. The directory's modification
+// time is kept as the creation time and restored afterwards. Returns
+// false when a legacy copy could not be converted.
+func migrateBackup(backupPath string) bool {
+	entries, err := os.ReadDir(backupPath)
+	if err != nil {
+		return false
+	}
+	info, err := os.Stat(backupPath)
+	if err != nil {
+		return false
+	}
+	created := info.ModTime()
+
+	migrated, ok := false, true
+	backupDir := filepath.Dir(backupPath)
+	for _, e := range entries {
+		if !e.IsDir() {
+			continue
+		}
+		legacy := filepath.Join(backupPath, e.Name())
+		if err := writeSnapshot(backupDir, backupPath, e.Name(), legacy, created); err != nil {
+			ok = false // leave it in place; retried next time
+			continue
+		}
+		os.RemoveAll(legacy)
+		migrated = true
+	}
+	if migrated {
+		os.Chtimes(backupPath, created, created) //nolint:errcheck
+	}
+	return ok
+}
+
+// referencedObjects returns the hashes used by any snapshot in backupDir.
+func referencedObjects(backupDir string, backups []BackupInfo) map[string]bool {
+	refs := map[string]bool{}
+	for _, b := range backups {
+		for _, t := range b.Targets {
+			snap, err := loadSnapshot(b.Path, t)
+			if err != nil {
+				continue
+			}
+			for _, f := range snap.Files {
+				refs[f.Hash] = true
+			}
+		}
+	}
+	return refs
+}
+
+// pruneObjects removes objects no snapshot references and returns the
+// number of bytes freed. The caller must hold the store lock.
+func pruneObjects(backupDir string, backups []BackupInfo) int64 {
+	refs := referencedObjects(backupDir, backups)
+	var freed int64
+	root := objectsDir(backupDir)
+	filepath.Walk(root, func(path string, info os.FileInfo, err error) error { //nolint:errcheck
+		if err != nil || info.IsDir() {
+			return nil
+		}
+		rel, relErr := filepath.Rel(root, path)
+		if relErr != nil {
+			return nil
+		}
+		hash := strings.ReplaceAll(filepath.ToSlash(rel), "/", "")
+		if refs[hash] {
+			return nil
+		}
+		if os.Remove(path) == nil {
+			freed += info.Size()
+		}
+		return nil
+	})
+	cleanEmptyDirs(root)
+	return freed
+}
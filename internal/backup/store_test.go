@@ -0,0 +1,242 @@
+package backup
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func countObjects(t *testing.T, backupDir string) int {
+	t.Helper()
+	n := 0
+	filepath.Walk(objectsDir(backupDir), func(_ string, info os.FileInfo, err error) error {
+		if err == nil && !info.IsDir() {
+			n++
+		}
+		return nil
+	})
+	return n
+}
+
+func TestCreate_DeduplicatesAcrossTargets(t *testing.T) {
+	t.Setenv("XDG_DATA_HOME", t.TempDir())
+
+	for _, name := range []string{"claude", "cursor"} {
+		dir := filepath.Join(t.TempDir(), name)
+		os.MkdirAll(filepath.Join(dir, "shared"), 0755)
+		writeTestFile(t, filepath.Join(dir, "shared", "SKILL.md"), "# Shared")
+		writeTestFile(t, filepath.Join(dir, name+".txt"), "unique "+name)
+
+		backupPath, err := Create(name, dir)
+		if err != nil {
+			t.Fatalf("Create(%s) error: %v", name, err)
+		}
+		if filepath.Dir(backupPath) != BackupDir() {
+			t.Errorf("Create(%s) = %q, want a timestamp dir in %s", name, backupPath, BackupDir())
+		}
+		if _, err := LoadSnapshot(backupPath, name); err != nil {
+			t.Errorf("LoadSnapshot(%s) error: %v", name, err)
+		}
+	}
+
+	// shared/SKILL.md stored once + one unique file per target
+	if got := countObjects(t, BackupDir()); got != 3 {
+		t.Errorf("object count = %d, want 3", got)
+	}
+
+	backups, err := List()
+	if err != nil || len(backups) != 1 {
+		t.Fatalf("List() = %v, %v; want one backup", backups, err)
+	}
+	if len(backups[0].Targets) != 2 {
+		t.Errorf("Targets = %v, want claude and cursor", backups[0].Targets)
+	}
+
+	dest := filepath.Join(t.TempDir(), "restored")
+	if err := RestoreToPath(backups[0].Path, "cursor", dest, RestoreOptions{}); err != nil {
+		t.Fatalf("RestoreToPath() error: %v", err)
+	}
+	assertFileContent(t, filepath.Join(dest, "shared", "SKILL.md"), "# Shared")
+	assertFileContent(t, filepath.Join(dest, "cursor.txt"), "unique cursor")
+}
+
+func TestList_MigratesLegacyBackups(t *testing.T) {
+	t.Setenv("XDG_DATA_HOME", t.TempDir())
+
+	tsDir := filepath.Join(BackupDir(), "2024-01-01_00-00-00")
+	legacy := filepath.Join(tsDir, "claude", "old-skill")
+	os.MkdirAll(legacy, 0755)
+	writeTestFile(t, filepath.Join(legacy, "SKILL.md"), "# Old")
+	old := time.Now().Add(-48 * time.Hour)
+	os.Chtimes(tsDir, old, old)
+
+	backups, err := List()
+	if err != nil || len(backups) != 1 {
+		t.Fatalf("List() = %v, %v; want one backup", backups, err)
+	}
+	if !backups[0].Date.Equal(old) {
+		t.Errorf("Date = %v, want legacy mtime %v", backups[0].Date, old)
+	}
+	if _, err := os.Stat(filepath.Join(tsDir, "claude")); !os.IsNotExist(err) {
+		t.Error("legacy directory should be replaced by a snapshot")
+	}
+
+	snap, err := LoadSnapshot(tsDir, "claude")
+	if err != nil {
+		t.Fatalf("LoadSnapshot() error: %v", err)
+	}
+	if len(snap.Files) != 1 || snap.Files[0].Path != "old-skill/SKILL.md" {
+		t.Errorf("Files = %+v, want old-skill/SKILL.md", snap.Files)
+	}
+}
+
+func TestList_MigratesOnce(t *testing.T) {
+	t.Setenv("XDG_DATA_HOME", t.TempDir())
+	os.MkdirAll(BackupDir(), 0755)
+
+	if _, err := List(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(filepath.Join(BackupDir(), migratedMarker)); err != nil {
+		t.Fatalf("migration marker not written: %v", err)
+	}
+
+	// Once marked, timestamp dirs are no longer scanned for legacy copies
+	legacy := filepath.Join(BackupDir(), "2024-01-01_00-00-00", "claude")
+	os.MkdirAll(legacy, 0755)
+	writeTestFile(t, filepath.Join(legacy, "SKILL.md"), "# Old")
+	List()
+	if _, err := os.Stat(legacy); err != nil {
+		t.Error("migration ran again after the marker was written")
+	}
+}
+
+func TestCleanup_WaitsForStoreLock(t *testing.T) {
+	t.Setenv("XDG_DATA_HOME", t.TempDir())
+
+	target := t.TempDir()
+	writeTestFile(t, filepath.Join(target, "a.txt"), "a")
+	if _, err := Create("claude", target); err != nil {
+		t.Fatal(err)
+	}
+
+	lock, err := lockStore(BackupDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	done := make(chan struct{})
+	go func() {
+		CleanupByCount(1) //nolint:errcheck
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		t.Fatal("Cleanup ran while another backup held the store lock")
+	case <-time.After(300 * time.Millisecond):
+	}
+	lock.Close()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Cleanup did not resume after the store lock was released")
+	}
+}
+
+func TestCleanup_PrunesUnreferencedObjects(t *testing.T) {
+	t.Setenv("XDG_DATA_HOME", t.TempDir())
+
+	target := t.TempDir()
+	writeTestFile(t, filepath.Join(target, "keep.txt"), "keep")
+	writeTestFile(t, filepath.Join(target, "old.txt"), "old")
+	oldBackup := filepath.Join(BackupDir(), "2024-01-01_00-00-00")
+	if err := writeSnapshot(BackupDir(), oldBackup, "claude", target, time.Now().Add(-time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+
+	os.Remove(filepath.Join(target, "old.txt"))
+	if _, err := Create("claude", target); err != nil {
+		t.Fatal(err)
+	}
+	if got := countObjects(t, BackupDir()); got != 2 {
+		t.Fatalf("object count = %d, want 2", got)
+	}
+
+	removed, err := CleanupByCount(1)
+	if err != nil || removed != 1 {
+		t.Fatalf("CleanupByCount(1) = %d, %v; want 1", removed, err)
+	}
+	if got := countObjects(t, BackupDir()); got != 1 {
+		t.Errorf("object count after cleanup = %d, want 1", got)
+	}
+}
+
+func TestIncrementalSizes(t *testing.T) {
+	t.Setenv("XDG_DATA_HOME", t.TempDir())
+
+	target := t.TempDir()
+	writeTestFile(t, filepath.Join(target, "a.txt"), "aaaa")
+	older := filepath.Join(BackupDir(), "2024-01-01_00-00-00")
+	writeSnapshot(BackupDir(), older, "claude", target, time.Now().Add(-time.Hour))
+
+	writeTestFile(t, filepath.Join(target, "b.txt"), "bb")
+	newer := filepath.Join(BackupDir(), "2024-01-02_00-00-00")
+	writeSnapshot(BackupDir(), newer, "claude", target, time.Now())
+
+	backups, _ := List()
+	sizes := IncrementalSizes(backups)
+	// Newest owns both files; the older one adds nothing new
+	if len(sizes) != 2 || sizes[0] != 6 || sizes[1] != 0 {
+		t.Errorf("IncrementalSizes() = %v, want [6 0]", sizes)
+	}
+	if got := Size(older); got != 4 {
+		t.Errorf("Size(older) = %d, want 4", got)
+	}
+}
+
+func TestRestoreSkills_LeavesOtherSkillsAlone(t *testing.T) {
+	t.Setenv("XDG_DATA_HOME", t.TempDir())
+
+	target := t.TempDir()
+	os.MkdirAll(filepath.Join(target, "alpha"), 0755)
+	os.MkdirAll(filepath.Join(target, "beta"), 0755)
+	writeTestFile(t, filepath.Join(target, "alpha", "SKILL.md"), "# Alpha v1")
+	writeTestFile(t, filepath.Join(target, "beta", "SKILL.md"), "# Beta v1")
+	backupPath, err := Create("claude", target)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Both skills change after the backup
+	writeTestFile(t, filepath.Join(target, "alpha", "SKILL.md"), "# Alpha v2")
+	writeTestFile(t, filepath.Join(target, "beta", "SKILL.md"), "# Beta v2")
+
+	if err := RestoreSkills(backupPath, "claude", target, []string{"alpha"}, RestoreOptions{}); err == nil {
+		t.Fatal("RestoreSkills() should refuse to overwrite without Force")
+	}
+	if err := RestoreSkills(backupPath, "claude", target, []string{"alpha"}, RestoreOptions{Force: true}); err != nil {
+		t.Fatalf("RestoreSkills() error: %v", err)
+	}
+	assertFileContent(t, filepath.Join(target, "alpha", "SKILL.md"), "# Alpha v1")
+	assertFileContent(t, filepath.Join(target, "beta", "SKILL.md"), "# Beta v2")
+
+	if err := RestoreSkills(backupPath, "claude", target, []string{"missing"}, RestoreOptions{Force: true}); err == nil {
+		t.Error("RestoreSkills() should fail for a skill not in the backup")
+	}
+}
+
+func TestSnapshot_Skills(t *testing.T) {
+	snap := &Snapshot{Files: []FileEntry{
+		{Path: "alpha/SKILL.md", Size: 10},
+		{Path: "alpha/ref/notes.md", Size: 5},
+		{Path: "beta/SKILL.md", Size: 3},
+	}}
+	skills := snap.Skills()
+	if len(skills) != 2 || skills[0].Name != "alpha" || skills[0].Files != 2 || skills[0].Size != 15 {
+		t.Errorf("Skills() = %+v", skills)
+	}
+	if !snap.HasSkill("beta") || snap.HasSkill("gamma") {
+		t.Error("HasSkill() mismatch")
+	}
+}
@@ -0,0 +1,53 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+func TestWriteFileAtomic_ReplacesContent(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.yaml")
+	if err := os.WriteFile(path, []byte("old: true\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := WriteFileAtomic(path, []byte("new: true\n"), 0644); err != nil {
+		t.Fatalf("WriteFileAtomic() error: %v", err)
+	}
+	got, _ := os.ReadFile(path)
+	if string(got) != "new: true\n" {
+		t.Errorf("content = %q, want %q", got, "new: true\n")
+	}
+
+	entries, _ := os.ReadDir(dir)
+	if len(entries) != 1 {
+		t.Errorf("dir has %d entries, want 1 (no temp files left)", len(entries))
+	}
+}
+
+func TestWriteFileAtomic_KeepsSymlink(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("symlinks need privileges on Windows")
+	}
+	dir := t.TempDir()
+	real := filepath.Join(dir, "dotfiles", "config.yaml")
+	link := filepath.Join(dir, "config.yaml")
+	os.MkdirAll(filepath.Dir(real), 0755)
+	os.WriteFile(real, []byte("old\n"), 0644)
+	if err := os.Symlink(real, link); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := WriteFileAtomic(link, []byte("new\n"), 0644); err != nil {
+		t.Fatalf("WriteFileAtomic() error: %v", err)
+	}
+	if info, _ := os.Lstat(link); info.Mode()&os.ModeSymlink == 0 {
+		t.Error("symlink should be preserved")
+	}
+	if got, _ := os.ReadFile(real); string(got) != "new\n" {
+		t.Errorf("link target content = %q, want %q", got, "new\n")
+	}
+}
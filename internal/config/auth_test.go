@@ -0,0 +1,48 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLoad_AuthEntries(t *testing.T) {
+	cfgPath := filepath.Join(t.TempDir(), "config.yaml")
+	t.Setenv("SKILLSHARE_CONFIG", cfgPath)
+
+	raw := `source: /tmp/skills
+targets: {}
+auth:
+  - host: git.example.com
+    platform: gitea
+    username: ci-bot
+    token:
+      command: op read op://vault/gitea/token
+  - host: github.com
+    token:
+      git_credential: true
+`
+	os.WriteFile(cfgPath, []byte(raw), 0644)
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load() error: %v", err)
+	}
+	if len(cfg.Auth) != 2 {
+		t.Fatalf("auth entries = %d, want 2", len(cfg.Auth))
+	}
+	if e := cfg.Auth[0]; e.Platform != "gitea" || e.Username != "ci-bot" || e.Token.Command != "op read op://vault/gitea/token" {
+		t.Errorf("entry = %+v", e)
+	}
+	if !cfg.Auth[1].Token.GitCredential {
+		t.Errorf("entry = %+v, want git_credential", cfg.Auth[1])
+	}
+
+	raw += `  - host: bad.example.com
+    platform: svn
+`
+	os.WriteFile(cfgPath, []byte(raw), 0644)
+	if _, err := Load(); err == nil || !strings.Contains(err.Error(), "invalid auth") {
+		t.Errorf("Load() error = %v, want invalid auth", err)
+	}
+}
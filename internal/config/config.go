@@ -8,6 +8,8 @@ import (
 	"strings"
 
 	"gopkg.in/yaml.v3"
+	"skillshare/internal/credential"
+	"skillshare/internal/network"
 	"skillshare/internal/utils"
 )
 
@@ -17,6 +19,9 @@ type TargetConfig struct {
 	Mode    string   `yaml:"mode,omitempty"` // merge, symlink, or copy
 	Include []string `yaml:"include,omitempty"`
 	Exclude []string `yaml:"exclude,omitempty"`
+	// Transcripts overrides the agent session logs `skillshare usage`
+	// reads for this target (glob patterns; ** matches any depth).
+	Transcripts []string `yaml:"transcripts,omitempty"`
 }
 
 // AuditConfig holds security audit policy settings.
@@ -24,6 +29,53 @@ type AuditConfig struct {
 	BlockThreshold string `yaml:"block_threshold,omitempty"` // CRITICAL/HIGH/MEDIUM/LOW/INFO
 }
 
+// LintConfig tunes `skillshare lint`. Rules maps a rule ID to a severity
+// override: "error", "warning", "info" or "off".
+type LintConfig struct {
+	Rules                map[string]string `yaml:"rules,omitempty"`
+	MaxBodyLines         int               `yaml:"max_body_lines,omitempty"`
+	DescriptionMinLength int               `yaml:"description_min_length,omitempty"`
+	DescriptionMaxLength int               `yaml:"description_max_length,omitempty"`
+}
+
+// StatsConfig tunes `skillshare stats`. Budget is the approximate token
+// budget per skill (frontmatter + body); 0 uses the built-in default.
+type StatsConfig struct {
+	Budget int `yaml:"budget,omitempty"`
+}
+
+// UsageConfig controls `skillshare usage`. Reading agent transcripts is
+// opt-in; nothing is collected until Enabled is set.
+type UsageConfig struct {
+	Enabled bool `yaml:"enabled,omitempty"`
+}
+
+// ReviewConfig sets when skills come due for review. Skills unchanged for
+// longer than MaxAgeMonths are listed as stale; 0 only honours review-by
+// dates in frontmatter.
+type ReviewConfig struct {
+	MaxAgeMonths int `yaml:"max_age_months,omitempty"`
+}
+
+// DefaultCacheMaxSizeMB is the clone cache size kept by 'cache prune'
+// when cache.max_size_mb is not set.
+const DefaultCacheMaxSizeMB = 1024
+
+// CacheConfig limits the shared clone cache. 'skillshare cache prune'
+// removes least recently used repositories until the cache fits.
+type CacheConfig struct {
+	MaxSizeMB int `yaml:"max_size_mb,omitempty"`
+}
+
+// MaxBytes returns the configured limit in bytes, or the default.
+func (c CacheConfig) MaxBytes() int64 {
+	mb := c.MaxSizeMB
+	if mb <= 0 {
+		mb = DefaultCacheMaxSizeMB
+	}
+	return int64(mb) * 1024 * 1024
+}
+
 // HubEntry represents a single saved hub source.
 type HubEntry struct {
 	Label   string `yaml:"label"`
@@ -37,6 +89,16 @@ type HubConfig struct {
 	Hubs    []HubEntry `yaml:"hubs,omitempty"`
 }
 
+// DaemonConfig holds the schedule of `skillshare daemon`. Each value is an
+// interval such as "30m", "6h" or "1d"; an empty value disables the task.
+type DaemonConfig struct {
+	Check   string `yaml:"check,omitempty"`   // check for skill updates
+	Pull    string `yaml:"pull,omitempty"`    // pull from git remote and sync
+	Sync    string `yaml:"sync,omitempty"`    // sync source to targets
+	Audit   string `yaml:"audit,omitempty"`   // re-scan skills with current rules
+	Cleanup string `yaml:"cleanup,omitempty"` // prune expired trash and old backups
+}
+
 // Config holds the application configuration
 type Config struct {
 	Source  string                  `yaml:"source"`
@@ -45,7 +107,16 @@ type Config struct {
 	Skills  []SkillEntry            `yaml:"skills,omitempty"`
 	Ignore  []string                `yaml:"ignore,omitempty"`
 	Audit   AuditConfig             `yaml:"audit,omitempty"`
+	Lint    LintConfig              `yaml:"lint,omitempty"`
+	Stats   StatsConfig             `yaml:"stats,omitempty"`
+	Usage   UsageConfig             `yaml:"usage,omitempty"`
+	Review  ReviewConfig            `yaml:"review,omitempty"`
+	Cache   CacheConfig             `yaml:"cache,omitempty"`
 	Hub     HubConfig               `yaml:"hub,omitempty"`
+	Daemon  DaemonConfig            `yaml:"daemon,omitempty"`
+	Auth    []credential.Entry      `yaml:"auth,omitempty"`
+	Network network.Config          `yaml:"network,omitempty"`
+	Hooks   HooksConfig             `yaml:"hooks,omitempty"`
 }
 
 const defaultAuditBlockThreshold = "CRITICAL"
@@ -168,6 +239,20 @@ func Load() (*Config, error) {
 		}
 	}
 
+	for _, entry := range cfg.Auth {
+		if err := entry.Validate(); err != nil {
+			return nil, fmt.Errorf("invalid auth: %w", err)
+		}
+	}
+
+	if err := cfg.Network.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid network: %w", err)
+	}
+
+	if err := cfg.Hooks.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid hooks: %w", err)
+	}
+
 	// Expand ~ in paths
 	cfg.Source = expandPath(cfg.Source)
 	for name, target := range cfg.Targets {
@@ -195,7 +280,7 @@ func (c *Config) Save() error {
 
 	data = append(schemaComment, data...)
 
-	if err := os.WriteFile(path, data, 0644); err != nil {
+	if err := WriteFileAtomic(path, data, 0644); err != nil {
 		return fmt.Errorf("failed to write config: %w", err)
 	}
 
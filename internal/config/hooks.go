@@ -0,0 +1,133 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Hook events.
+const (
+	HookPreInstall  = "pre-install"
+	HookPostInstall = "post-install"
+	HookPostSync    = "post-sync"
+	HookPostUpdate  = "post-update"
+)
+
+// HookEvents lists every event, in the order they are documented.
+var HookEvents = []string{HookPreInstall, HookPostInstall, HookPostSync, HookPostUpdate}
+
+// DefaultHookTimeout bounds a hook command when no timeout is configured.
+const DefaultHookTimeout = 5 * time.Minute
+
+// Hook is one command run on an event. In YAML it is either the command
+// string itself or a mapping with run and timeout.
+type Hook struct {
+	Run     string `yaml:"run"`
+	Timeout string `yaml:"timeout,omitempty"` // e.g. "30s", "10m"
+}
+
+// UnmarshalYAML accepts the plain string form.
+func (h *Hook) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		h.Run = node.Value
+		return nil
+	}
+	type plain Hook
+	return node.Decode((*plain)(h))
+}
+
+// HookList is one event's hooks: a single command string, or a list of
+// commands and run/timeout mappings.
+type HookList []Hook
+
+// UnmarshalYAML accepts a single command for the list.
+func (l *HookList) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		*l = HookList{{Run: node.Value}}
+		return nil
+	}
+	var hooks []Hook
+	if err := node.Decode(&hooks); err != nil {
+		return err
+	}
+	*l = hooks
+	return nil
+}
+
+// HooksConfig holds the user's hook commands per event. Timeout applies to
+// hooks without their own.
+type HooksConfig struct {
+	PreInstall  HookList `yaml:"pre-install,omitempty"`
+	PostInstall HookList `yaml:"post-install,omitempty"`
+	PostSync    HookList `yaml:"post-sync,omitempty"`
+	PostUpdate  HookList `yaml:"post-update,omitempty"`
+	Timeout     string   `yaml:"timeout,omitempty"`
+}
+
+// For returns the hooks configured for event.
+func (c HooksConfig) For(event string) []Hook {
+	switch event {
+	case HookPreInstall:
+		return c.PreInstall
+	case HookPostInstall:
+		return c.PostInstall
+	case HookPostSync:
+		return c.PostSync
+	case HookPostUpdate:
+		return c.PostUpdate
+	}
+	return nil
+}
+
+// IsEmpty reports whether no hook is configured.
+func (c HooksConfig) IsEmpty() bool {
+	for _, event := range HookEvents {
+		if len(c.For(event)) > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// TimeoutFor returns the timeout of h: its own, else the section default,
+// else DefaultHookTimeout. Invalid values are rejected by Validate.
+func (c HooksConfig) TimeoutFor(h Hook) time.Duration {
+	for _, s := range []string{h.Timeout, c.Timeout} {
+		if d, err := time.ParseDuration(s); err == nil && d > 0 {
+			return d
+		}
+	}
+	return DefaultHookTimeout
+}
+
+// Validate checks for empty commands and unparsable timeouts.
+func (c HooksConfig) Validate() error {
+	if err := validateHookTimeout(c.Timeout); err != nil {
+		return fmt.Errorf("timeout: %w", err)
+	}
+	for _, event := range HookEvents {
+		for i, h := range c.For(event) {
+			if strings.TrimSpace(h.Run) == "" {
+				return fmt.Errorf("%s[%d]: empty command", event, i)
+			}
+			if err := validateHookTimeout(h.Timeout); err != nil {
+				return fmt.Errorf("%s[%d] timeout: %w", event, i, err)
+			}
+		}
+	}
+	return nil
+}
+
+func validateHookTimeout(s string) error {
+	if s == "" {
+		return nil
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil || d <= 0 {
+		return fmt.Errorf("invalid duration %q (want e.g. 30s or 10m)", s)
+	}
+	return nil
+}
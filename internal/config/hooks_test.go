@@ -0,0 +1,57 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestLoad_Hooks(t *testing.T) {
+	cfgPath := filepath.Join(t.TempDir(), "config.yaml")
+	t.Setenv("SKILLSHARE_CONFIG", cfgPath)
+
+	raw := `source: /tmp/skills
+targets: {}
+hooks:
+  timeout: 2m
+  pre-install: ./check-source.sh
+  post-install:
+    - pip install -r "$SKILLSHARE_SKILL_PATH/requirements.txt"
+  post-sync:
+    - run: ./notify.sh
+      timeout: 10s
+`
+	os.WriteFile(cfgPath, []byte(raw), 0644)
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load() error: %v", err)
+	}
+	install := cfg.Hooks.For(HookPostInstall)
+	if len(install) != 1 || !strings.HasPrefix(install[0].Run, "pip install") {
+		t.Fatalf("post-install = %+v", install)
+	}
+	if got := cfg.Hooks.TimeoutFor(install[0]); got != 2*time.Minute {
+		t.Errorf("post-install timeout = %v, want section default 2m", got)
+	}
+	if pre := cfg.Hooks.For(HookPreInstall); len(pre) != 1 || pre[0].Run != "./check-source.sh" {
+		t.Errorf("pre-install = %+v, want the single command form", pre)
+	}
+	sync := cfg.Hooks.For(HookPostSync)
+	if len(sync) != 1 || sync[0].Run != "./notify.sh" || cfg.Hooks.TimeoutFor(sync[0]) != 10*time.Second {
+		t.Errorf("post-sync = %+v", sync)
+	}
+	if (HooksConfig{}).TimeoutFor(Hook{Run: "x"}) != DefaultHookTimeout {
+		t.Error("empty config should use DefaultHookTimeout")
+	}
+
+	raw += `  post-update:
+    - run: make
+      timeout: soon
+`
+	os.WriteFile(cfgPath, []byte(raw), 0644)
+	if _, err := Load(); err == nil || !strings.Contains(err.Error(), "invalid hooks") {
+		t.Errorf("Load() error = %v, want invalid hooks", err)
+	}
+}
@@ -83,6 +83,8 @@ type SkillEntry struct {
 	Source  string `yaml:"source"`
 	Tracked bool   `yaml:"tracked,omitempty"`
 	Group   string `yaml:"group,omitempty"`
+	Branch  string `yaml:"branch,omitempty"` // tracked repos: branch followed instead of the default
+	Ref     string `yaml:"ref,omitempty"`    // tracked repos: tag or commit the repo is pinned at
 }
 
 // FullName returns the full relative path for the skill entry.
@@ -118,6 +120,9 @@ type ProjectConfig struct {
 	Targets []ProjectTargetEntry `yaml:"targets"`
 	Skills  []ProjectSkill       `yaml:"skills,omitempty"`
 	Audit   AuditConfig          `yaml:"audit,omitempty"`
+	Lint    LintConfig           `yaml:"lint,omitempty"`
+	Stats   StatsConfig          `yaml:"stats,omitempty"`
+	Review  ReviewConfig         `yaml:"review,omitempty"`
 	Hub     HubConfig            `yaml:"hub,omitempty"`
 }
 
@@ -180,7 +185,7 @@ func (c *ProjectConfig) Save(projectRoot string) error {
 
 	data = append(projectSchemaComment, data...)
 
-	if err := os.WriteFile(path, data, 0644); err != nil {
+	if err := WriteFileAtomic(path, data, 0644); err != nil {
 		return fmt.Errorf("failed to write project config: %w", err)
 	}
 
@@ -77,6 +77,11 @@ func ReconcileProjectSkills(projectRoot string, projectCfg *ProjectConfig, sourc
 			return nil
 		}
 
+		var branch, ref string
+		if tracked {
+			branch, ref = install.TrackedRepoRefs(path)
+		}
+
 		fullPath := filepath.ToSlash(relPath)
 
 		if existingIdx, ok := index[fullPath]; ok {
@@ -88,10 +93,16 @@ func ReconcileProjectSkills(projectRoot string, projectCfg *ProjectConfig, sourc
 				projectCfg.Skills[existingIdx].Tracked = tracked
 				changed = true
 			}
+			if e := &projectCfg.Skills[existingIdx]; e.Branch != branch || e.Ref != ref {
+				e.Branch, e.Ref = branch, ref
+				changed = true
+			}
 		} else {
 			entry := SkillEntry{
 				Source:  source,
 				Tracked: tracked,
+				Branch:  branch,
+				Ref:     ref,
 			}
 			if idx := strings.LastIndex(fullPath, "/"); idx >= 0 {
 				entry.Group = fullPath[:idx]
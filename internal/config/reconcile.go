@@ -72,6 +72,11 @@ func ReconcileGlobalSkills(cfg *Config) error {
 			return nil
 		}
 
+		var branch, ref string
+		if tracked {
+			branch, ref = install.TrackedRepoRefs(path)
+		}
+
 		fullPath := filepath.ToSlash(relPath)
 
 		if existingIdx, ok := index[fullPath]; ok {
@@ -83,10 +88,16 @@ func ReconcileGlobalSkills(cfg *Config) error {
 				cfg.Skills[existingIdx].Tracked = tracked
 				changed = true
 			}
+			if e := &cfg.Skills[existingIdx]; e.Branch != branch || e.Ref != ref {
+				e.Branch, e.Ref = branch, ref
+				changed = true
+			}
 		} else {
 			entry := SkillEntry{
 				Source:  source,
 				Tracked: tracked,
+				Branch:  branch,
+				Ref:     ref,
 			}
 			if idx := strings.LastIndex(fullPath, "/"); idx >= 0 {
 				entry.Group = fullPath[:idx]
@@ -15,7 +15,8 @@ type targetSpec struct {
 	ProjectName string   `yaml:"project_name"`
 	GlobalPath  string   `yaml:"global_path"`
 	ProjectPath string   `yaml:"project_path"`
-	Aliases     []string `yaml:"aliases,omitempty"` // Deprecated: backward compat for old project_name values. Remove once safe.
+	Aliases     []string `yaml:"aliases,omitempty"`     // Deprecated: backward compat for old project_name values. Remove once safe.
+	Transcripts []string `yaml:"transcripts,omitempty"` // agent session logs read by `skillshare usage`
 }
 
 type targetsFile struct {
@@ -112,6 +113,28 @@ func LookupGlobalTarget(name string) (TargetConfig, bool) {
 	return target, ok
 }
 
+// TranscriptPatterns returns the built-in session log patterns for a
+// target name (global, project or alias), with ~ expanded.
+func TranscriptPatterns(name string) []string {
+	specs, err := loadTargetSpecs()
+	if err != nil {
+		return nil
+	}
+	for _, spec := range specs {
+		names := append([]string{spec.GlobalName, spec.ProjectName}, spec.Aliases...)
+		for _, n := range names {
+			if n != "" && n == name {
+				patterns := make([]string, 0, len(spec.Transcripts))
+				for _, p := range spec.Transcripts {
+					patterns = append(patterns, normalizeTargetPath(p))
+				}
+				return patterns
+			}
+		}
+	}
+	return nil
+}
+
 // GroupedProjectTarget represents a project target, optionally grouped with
 // other targets that share the same project path.
 type GroupedProjectTarget struct {
@@ -0,0 +1,395 @@
+// Package convert turns agent configuration that is not already a skill —
+// Claude slash commands, Cursor rules, Copilot instructions — into skill
+// folders with generated frontmatter.
+package convert
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"skillshare/internal/utils"
+)
+
+// Supported source kinds.
+const (
+	KindClaudeCommands = "claude-commands"
+	KindCursor         = "cursor"
+	KindCopilot        = "copilot"
+)
+
+// Kinds lists the supported source kinds.
+var Kinds = []string{KindClaudeCommands, KindCursor, KindCopilot}
+
+// maxDescriptionLen caps descriptions derived from the body.
+const maxDescriptionLen = 200
+
+// Skill is one converted skill, ready to be written.
+type Skill struct {
+	Name        string
+	Description string
+	Source      string            // file it was converted from
+	Fields      map[string]string // extra top-level frontmatter (allowed-tools, …)
+	Metadata    map[string]string // written under metadata:
+	Body        string
+	Notes       []string // parts that could not be carried over faithfully
+}
+
+// Failure is a file that could not be converted.
+type Failure struct {
+	Path   string
+	Reason string
+}
+
+// Result is the outcome of Scan.
+type Result struct {
+	Skills   []Skill
+	Failures []Failure
+}
+
+// ParseKind validates a --from value. "claude" is accepted for
+// claude-commands.
+func ParseKind(s string) (string, error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	if s == "claude" {
+		return KindClaudeCommands, nil
+	}
+	for _, k := range Kinds {
+		if s == k {
+			return k, nil
+		}
+	}
+	return "", fmt.Errorf("unknown kind %q (supported: %s)", s, strings.Join(Kinds, ", "))
+}
+
+// DefaultPath returns where a kind is usually found: ~/.claude/commands
+// for Claude commands in global mode, otherwise the given directory.
+func DefaultPath(kind, home, dir string, project bool) string {
+	if kind == KindClaudeCommands {
+		if project {
+			return filepath.Join(dir, ".claude", "commands")
+		}
+		return filepath.Join(home, ".claude", "commands")
+	}
+	return dir
+}
+
+// Scan finds and converts every file of the given kind under path. path
+// may be a single file, the directory holding the files, or a project
+// root (where .cursorrules, .cursor/rules and .github are looked up).
+func Scan(kind, path string) (*Result, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+
+	files := []candidate{{root: filepath.Dir(path), path: path}}
+	if info.IsDir() {
+		if files, err = findFiles(kind, path); err != nil {
+			return nil, err
+		}
+	}
+
+	res := &Result{}
+	byName := map[string]string{}
+	for _, f := range files {
+		skill, err := convertFile(kind, f.root, f.path)
+		if err != nil {
+			res.Failures = append(res.Failures, Failure{Path: f.path, Reason: err.Error()})
+			continue
+		}
+		if prev, ok := byName[skill.Name]; ok {
+			res.Failures = append(res.Failures, Failure{
+				Path:   f.path,
+				Reason: fmt.Sprintf("name %q already taken by %s", skill.Name, prev),
+			})
+			continue
+		}
+		byName[skill.Name] = f.path
+		res.Skills = append(res.Skills, *skill)
+	}
+	return res, nil
+}
+
+// candidate is a file to convert and the directory its name is relative to.
+type candidate struct {
+	root string
+	path string
+}
+
+// findFiles lists the candidate files of kind in dir. Every file found is
+// returned, so ones with an unsupported type are reported rather than
+// silently ignored. When dir is not a project root, only Claude commands
+// are looked up recursively (sub-directories are command namespaces).
+func findFiles(kind, dir string) ([]candidate, error) {
+	var roots []string
+	switch kind {
+	case KindCursor:
+		roots = existing(filepath.Join(dir, ".cursorrules"), filepath.Join(dir, ".cursor", "rules"))
+	case KindCopilot:
+		roots = existing(filepath.Join(dir, ".github", "copilot-instructions.md"), filepath.Join(dir, ".github", "instructions"))
+	case KindClaudeCommands:
+		roots = existing(filepath.Join(dir, ".claude", "commands"))
+	}
+	recursive := len(roots) > 0 || kind == KindClaudeCommands
+	if len(roots) == 0 {
+		roots = []string{dir}
+	}
+
+	var files []candidate
+	for _, r := range roots {
+		err := filepath.WalkDir(r, func(p string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if d.IsDir() {
+				if p != r && (!recursive || utils.IsHidden(d.Name())) {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+			if p != r && utils.IsHidden(d.Name()) {
+				return nil
+			}
+			root := r
+			if p == r {
+				root = filepath.Dir(r)
+			}
+			files = append(files, candidate{root: root, path: p})
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	sort.Slice(files, func(i, j int) bool { return files[i].path < files[j].path })
+	return files, nil
+}
+
+func existing(paths ...string) []string {
+	var out []string
+	for _, p := range paths {
+		if _, err := os.Stat(p); err == nil {
+			out = append(out, p)
+		}
+	}
+	return out
+}
+
+// convertFile converts one file. root is used to build names for files in
+// sub-directories (Claude's namespaced commands).
+func convertFile(kind, root, path string) (*Skill, error) {
+	base := filepath.Base(path)
+	var stem string
+	switch {
+	case kind == KindCursor && base == ".cursorrules":
+		// Project-wide files are named after the project.
+		stem = filepath.Base(filepath.Dir(path)) + "-cursorrules"
+	case kind == KindCursor && (strings.HasSuffix(base, ".mdc") || strings.HasSuffix(base, ".md")):
+		stem = strings.TrimSuffix(strings.TrimSuffix(base, ".mdc"), ".md")
+	case kind == KindCopilot && base == "copilot-instructions.md":
+		stem = filepath.Base(filepath.Dir(filepath.Dir(path))) + "-copilot-instructions"
+	case kind == KindCopilot && strings.HasSuffix(base, ".md"):
+		stem = strings.TrimSuffix(strings.TrimSuffix(base, ".md"), ".instructions")
+	case kind == KindClaudeCommands && strings.HasSuffix(base, ".md"):
+		stem = strings.TrimSuffix(base, ".md")
+	default:
+		return nil, fmt.Errorf("unsupported file type for %s", kind)
+	}
+
+	if kind == KindClaudeCommands {
+		// Commands in sub-directories are namespaced (/frontend:review).
+		if rel, err := filepath.Rel(root, filepath.Dir(path)); err == nil && rel != "." && !strings.HasPrefix(rel, "..") {
+			stem = strings.ReplaceAll(filepath.ToSlash(rel), "/", "-") + "-" + stem
+		}
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	if strings.IndexByte(string(data), 0) >= 0 {
+		return nil, fmt.Errorf("binary file")
+	}
+	fields, body := splitFrontmatter(strings.ReplaceAll(string(data), "\r\n", "\n"))
+	body = strings.TrimSpace(body)
+	if body == "" {
+		return nil, fmt.Errorf("no instructions (empty body)")
+	}
+
+	name := Slug(stem)
+	if name == "" {
+		return nil, fmt.Errorf("cannot derive a skill name from %q", base)
+	}
+
+	s := &Skill{
+		Name:     name,
+		Source:   path,
+		Fields:   map[string]string{},
+		Metadata: map[string]string{"converted-from": kind},
+		Body:     body,
+	}
+	s.Description = fields["description"]
+	delete(fields, "description")
+
+	switch kind {
+	case KindClaudeCommands:
+		for _, k := range []string{"allowed-tools", "argument-hint", "model", "disable-model-invocation"} {
+			if v, ok := fields[k]; ok {
+				s.Fields[k] = v
+				delete(fields, k)
+			}
+		}
+		if argPlaceholder.MatchString(body) {
+			s.Notes = append(s.Notes, "uses $ARGUMENTS placeholders; skills receive no arguments")
+		}
+		if bashPreamble.MatchString(body) {
+			s.Notes = append(s.Notes, "runs !`command` before the prompt; skills do not")
+		}
+	case KindCursor:
+		if v := fields["alwaysApply"]; v == "true" {
+			s.Metadata["always-apply"] = v
+		}
+		delete(fields, "alwaysApply")
+		if v := fields["globs"]; v != "" {
+			s.Metadata["globs"] = v
+			s.Notes = append(s.Notes, "glob scoping ("+v+") is kept as metadata only")
+		}
+		delete(fields, "globs")
+	case KindCopilot:
+		if v := fields["applyTo"]; v != "" {
+			s.Metadata["apply-to"] = v
+			s.Notes = append(s.Notes, "applyTo scoping ("+v+") is kept as metadata only")
+		}
+		delete(fields, "applyTo")
+	}
+	for k := range fields {
+		s.Notes = append(s.Notes, fmt.Sprintf("dropped frontmatter field %q", k))
+	}
+	sort.Strings(s.Notes)
+
+	if s.Description == "" {
+		s.Description = describe(body)
+	}
+	if s.Description == "" {
+		s.Description = fmt.Sprintf("Converted from %s.", base)
+	}
+	return s, nil
+}
+
+// splitFrontmatter returns the top-level "key: value" pairs of a leading
+// frontmatter block and the rest of the text. Cursor's .mdc frontmatter is
+// often not valid YAML (globs: *.ts), so it is read line by line.
+func splitFrontmatter(text string) (map[string]string, string) {
+	fields := map[string]string{}
+	if !strings.HasPrefix(text, "---\n") {
+		return fields, text
+	}
+	end := strings.Index(text[4:], "\n---")
+	if end < 0 {
+		return fields, text
+	}
+	block := text[4 : 4+end]
+	rest := text[4+end+4:]
+	if i := strings.IndexByte(rest, '\n'); i >= 0 {
+		rest = rest[i+1:]
+	} else {
+		rest = ""
+	}
+
+	for _, line := range strings.Split(block, "\n") {
+		if line == "" || line[0] == ' ' || line[0] == '\t' || line[0] == '#' {
+			continue
+		}
+		key, val, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		val = strings.TrimSpace(val)
+		if len(val) >= 2 && (val[0] == '"' || val[0] == '\'') && val[len(val)-1] == val[0] {
+			val = val[1 : len(val)-1]
+		}
+		fields[strings.TrimSpace(key)] = val
+	}
+	return fields, rest
+}
+
+// describe uses the first line of prose in body as the description.
+func describe(body string) string {
+	for _, line := range strings.Split(body, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, "```") {
+			continue
+		}
+		line = strings.TrimLeft(line, "-*> ")
+		if len(line) > maxDescriptionLen {
+			line = strings.TrimSpace(line[:maxDescriptionLen]) + "…"
+		}
+		return line
+	}
+	return ""
+}
+
+var (
+	nonSlug        = regexp.MustCompile(`[^a-z0-9]+`)
+	argPlaceholder = regexp.MustCompile(`\$(ARGUMENTS|[1-9])`)
+	bashPreamble   = regexp.MustCompile("!`[^`]+`")
+)
+
+// Slug turns a file name into a skill name: lowercase letters, digits and
+// hyphens.
+func Slug(s string) string {
+	return strings.Trim(nonSlug.ReplaceAllString(strings.ToLower(s), "-"), "-")
+}
+
+// Render returns the SKILL.md content for s.
+func (s *Skill) Render() string {
+	var b strings.Builder
+	b.WriteString("---\n")
+	writeField(&b, "", "name", s.Name)
+	writeField(&b, "", "description", s.Description)
+	for _, k := range sortedKeys(s.Fields) {
+		writeField(&b, "", k, s.Fields[k])
+	}
+	if len(s.Metadata) > 0 {
+		b.WriteString("metadata:\n")
+		for _, k := range sortedKeys(s.Metadata) {
+			writeField(&b, "  ", k, s.Metadata[k])
+		}
+	}
+	b.WriteString("---\n\n")
+	b.WriteString(s.Body)
+	b.WriteString("\n")
+	return b.String()
+}
+
+// Write creates the skill folder at dir.
+func (s *Skill) Write(dir string) error {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(dir, "SKILL.md"), []byte(s.Render()), 0644)
+}
+
+func writeField(b *strings.Builder, indent, key, val string) {
+	out, err := yaml.Marshal(val)
+	if err != nil {
+		out = []byte(fmt.Sprintf("%q\n", val))
+	}
+	b.WriteString(indent + key + ": " + string(out))
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
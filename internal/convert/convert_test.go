@@ -0,0 +1,117 @@
+package convert
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func names(res *Result) string {
+	var out []string
+	for _, s := range res.Skills {
+		out = append(out, s.Name)
+	}
+	return strings.Join(out, ",")
+}
+
+func TestScan_ClaudeCommands(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "commands")
+	writeFile(t, filepath.Join(dir, "review.md"),
+		"---\ndescription: Review the staged diff\nallowed-tools: Bash(git diff:*)\ncolor: blue\n---\n\nReview $ARGUMENTS.\n")
+	writeFile(t, filepath.Join(dir, "frontend", "Lint Check.md"), "# Lint\n\nRun the linter and fix errors.\n")
+	writeFile(t, filepath.Join(dir, "empty.md"), "---\ndescription: nothing\n---\n")
+	writeFile(t, filepath.Join(dir, "notes.txt"), "not a command")
+
+	res, err := Scan(KindClaudeCommands, dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := names(res); got != "frontend-lint-check,review" {
+		t.Fatalf("skills = %s", got)
+	}
+	if len(res.Failures) != 2 {
+		t.Fatalf("failures = %+v, want empty.md and notes.txt", res.Failures)
+	}
+
+	lint, review := res.Skills[0], res.Skills[1]
+	if lint.Description != "Run the linter and fix errors." {
+		t.Errorf("derived description = %q", lint.Description)
+	}
+	if review.Description != "Review the staged diff" || review.Fields["allowed-tools"] != "Bash(git diff:*)" {
+		t.Errorf("review = %+v", review)
+	}
+	notes := strings.Join(review.Notes, "\n")
+	if !strings.Contains(notes, "$ARGUMENTS") || !strings.Contains(notes, `"color"`) {
+		t.Errorf("notes = %q", notes)
+	}
+}
+
+func TestScan_CursorProject(t *testing.T) {
+	root := filepath.Join(t.TempDir(), "webapp")
+	writeFile(t, filepath.Join(root, ".cursorrules"), "Prefer tabs.\n")
+	writeFile(t, filepath.Join(root, ".cursor", "rules", "ts.mdc"),
+		"---\ndescription:\nglobs: *.ts\nalwaysApply: false\n---\n# TS\n\nUse strict TypeScript.\n")
+	writeFile(t, filepath.Join(root, "src", "main.ts"), "ignored")
+
+	res, err := Scan(KindCursor, root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := names(res); got != "ts,webapp-cursorrules" || len(res.Failures) != 0 {
+		t.Fatalf("skills = %s, failures = %+v", got, res.Failures)
+	}
+	if ts := res.Skills[0]; ts.Metadata["globs"] != "*.ts" || ts.Description != "Use strict TypeScript." {
+		t.Errorf("ts = %+v", ts)
+	}
+}
+
+func TestScan_CopilotNameClash(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "go.instructions.md"), "---\napplyTo: \"**/*.go\"\n---\nUse gofmt.\n")
+	writeFile(t, filepath.Join(dir, "go.md"), "Use go vet.\n")
+
+	res, err := Scan(KindCopilot, dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res.Skills) != 1 || len(res.Failures) != 1 || !strings.Contains(res.Failures[0].Reason, "already taken") {
+		t.Fatalf("skills = %s, failures = %+v", names(res), res.Failures)
+	}
+	if res.Skills[0].Metadata["apply-to"] != "**/*.go" {
+		t.Errorf("metadata = %+v", res.Skills[0].Metadata)
+	}
+}
+
+func TestRender(t *testing.T) {
+	s := &Skill{
+		Name:        "ts",
+		Description: "Rules: strict",
+		Fields:      map[string]string{"model": "sonnet"},
+		Metadata:    map[string]string{"globs": "*.ts"},
+		Body:        "Body.",
+	}
+	want := "---\nname: ts\ndescription: 'Rules: strict'\nmodel: sonnet\nmetadata:\n  globs: '*.ts'\n---\n\nBody.\n"
+	if got := s.Render(); got != want {
+		t.Errorf("Render() = %q, want %q", got, want)
+	}
+}
+
+func TestParseKind(t *testing.T) {
+	if k, err := ParseKind("Claude"); err != nil || k != KindClaudeCommands {
+		t.Errorf("ParseKind(Claude) = %q, %v", k, err)
+	}
+	if _, err := ParseKind("windsurf"); err == nil {
+		t.Error("ParseKind(windsurf) should fail")
+	}
+}
@@ -0,0 +1,397 @@
+// Package credential resolves per-host credentials for private skill
+// sources. Hosts are configured under `auth:` in config.yaml, each with a
+// token source: an environment variable, a command, a file, or git's own
+// credential helpers. Git operations, GitHub search and hub downloads all
+// look tokens up here before falling back to the standard env vars.
+package credential
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+
+	"skillshare/internal/utils"
+)
+
+// Platforms that can be set explicitly for self-hosted servers.
+const (
+	PlatformGitHub    = "github"
+	PlatformGitLab    = "gitlab"
+	PlatformBitbucket = "bitbucket"
+	PlatformGitea     = "gitea"
+	PlatformAzure     = "azure"
+)
+
+var platforms = []string{PlatformGitHub, PlatformGitLab, PlatformBitbucket, PlatformGitea, PlatformAzure}
+
+// commandTimeout bounds a token command or git credential helper.
+const commandTimeout = 30 * time.Second
+
+// Entry configures authentication for one host.
+type Entry struct {
+	Host     string      `yaml:"host"`
+	Platform string      `yaml:"platform,omitempty"`
+	Username string      `yaml:"username,omitempty"`
+	Token    TokenSource `yaml:"token,omitempty"`
+}
+
+// TokenSource says where a host's token comes from. Exactly one field is
+// set, except for entries that only declare a platform.
+type TokenSource struct {
+	Env           string `yaml:"env,omitempty"`
+	Command       string `yaml:"command,omitempty"`
+	File          string `yaml:"file,omitempty"`
+	GitCredential bool   `yaml:"git_credential,omitempty"`
+}
+
+// Credential is a resolved username and token for a host.
+type Credential struct {
+	Host     string
+	Platform string // From config; empty when not set
+	Username string // From config or the git credential helper; may be empty
+	Token    string
+}
+
+// Validate checks an entry for a missing host, an unknown platform or
+// more than one token source.
+func (e Entry) Validate() error {
+	if strings.TrimSpace(e.Host) == "" {
+		return fmt.Errorf("auth entry has empty host")
+	}
+	if strings.Contains(e.Host, "/") {
+		return fmt.Errorf("auth host %q: use a hostname, not a URL", e.Host)
+	}
+	if e.Platform != "" && !validPlatform(e.Platform) {
+		return fmt.Errorf("auth host %s: unknown platform %q (want %s)", e.Host, e.Platform, strings.Join(platforms, ", "))
+	}
+	if n := e.Token.count(); n > 1 {
+		return fmt.Errorf("auth host %s: set only one of token.env, token.command, token.file, token.git_credential", e.Host)
+	}
+	return nil
+}
+
+func (s TokenSource) count() int {
+	n := 0
+	for _, set := range []bool{s.Env != "", s.Command != "", s.File != "", s.GitCredential} {
+		if set {
+			n++
+		}
+	}
+	return n
+}
+
+func validPlatform(p string) bool {
+	for _, known := range platforms {
+		if p == known {
+			return true
+		}
+	}
+	return false
+}
+
+// result is a resolved credential or the error resolving it, cached so a
+// failing token command is not rerun for every git operation. done is
+// closed once cred and err are set; concurrent lookups of the same host
+// wait on it instead of running the token source again.
+type result struct {
+	done chan struct{}
+	cred *Credential
+	err  error
+}
+
+var (
+	mu       sync.Mutex
+	entries  []Entry
+	resolved = map[string]*result{} // entry host → result
+	secrets  []string
+)
+
+// Configure replaces the configured entries. The CLI calls it with the
+// `auth:` section of config.yaml at startup; with no entries, lookups
+// always miss and callers use their env var fallbacks.
+func Configure(list []Entry) {
+	mu.Lock()
+	defer mu.Unlock()
+	entries = append([]Entry(nil), list...)
+	resolved = map[string]*result{}
+}
+
+// Platform returns the platform configured for host, or "".
+func Platform(host string) string {
+	if e := find(host); e != nil {
+		return e.Platform
+	}
+	return ""
+}
+
+// configuredPlatforms maps the platform names of auth entries.
+var configuredPlatforms = map[string]utils.Platform{
+	PlatformGitHub:    utils.PlatformGitHub,
+	PlatformGitLab:    utils.PlatformGitLab,
+	PlatformBitbucket: utils.PlatformBitbucket,
+	PlatformGitea:     utils.PlatformGitea,
+	PlatformAzure:     utils.PlatformAzure,
+}
+
+// DetectPlatform identifies the git hosting platform from a clone URL.
+// A platform set for the host in config.yaml wins over the hostname.
+func DetectPlatform(cloneURL string) utils.Platform {
+	if p, ok := configuredPlatforms[Platform(strings.ToLower(utils.URLHost(cloneURL)))]; ok {
+		return p
+	}
+	return utils.DetectPlatform(cloneURL)
+}
+
+// Lookup resolves the credential configured for host (a hostname, or
+// host:port). Tokens are resolved once per process, so a command such as
+// `op read` runs at most once. Returns nil, nil when host has no entry or
+// its token source yields nothing.
+func Lookup(host string) (*Credential, error) {
+	e := find(host)
+	if e == nil || e.Token.count() == 0 {
+		return nil, nil
+	}
+
+	mu.Lock()
+	r, ok := resolved[e.Host]
+	if !ok {
+		r = &result{done: make(chan struct{})}
+		resolved[e.Host] = r
+	}
+	mu.Unlock()
+	if ok {
+		<-r.done
+		return r.cred, r.err
+	}
+
+	defer close(r.done)
+	r.cred, r.err = resolve(*e)
+	if r.cred != nil {
+		mu.Lock()
+		secrets = append(secrets, r.cred.Token)
+		mu.Unlock()
+	}
+	return r.cred, r.err
+}
+
+// Errors returns the token sources that failed to resolve so far, for
+// authentication error hints.
+func Errors() []error {
+	mu.Lock()
+	defer mu.Unlock()
+	var errs []error
+	for _, r := range resolved {
+		select {
+		case <-r.done:
+			if r.err != nil {
+				errs = append(errs, r.err)
+			}
+		default: // still resolving
+		}
+	}
+	return errs
+}
+
+// LookupURL is Lookup for the host of an http(s) URL.
+func LookupURL(rawURL string) (*Credential, error) {
+	u, err := url.Parse(strings.TrimSpace(rawURL))
+	if err != nil || u.Host == "" {
+		return nil, nil
+	}
+	return Lookup(u.Host)
+}
+
+// Authorize adds the Authorization header for the request's host, if one
+// is configured: basic auth for Azure DevOps and Bitbucket (which reject
+// bearer tokens for git content), a bearer token otherwise.
+func Authorize(req *http.Request) error {
+	c, err := Lookup(req.URL.Host)
+	if err != nil || c == nil {
+		return err
+	}
+	switch c.Platform {
+	case PlatformAzure, PlatformBitbucket:
+		user := c.Username
+		if user == "" {
+			user = "x-token-auth"
+		}
+		req.SetBasicAuth(user, c.Token)
+	default:
+		req.Header.Set("Authorization", "Bearer "+c.Token)
+	}
+	return nil
+}
+
+// Redact replaces every token resolved so far with [REDACTED].
+func Redact(text string) string {
+	mu.Lock()
+	defer mu.Unlock()
+	for _, s := range secrets {
+		if s != "" {
+			text = strings.ReplaceAll(text, s, "[REDACTED]")
+		}
+	}
+	return text
+}
+
+// hostAliases maps API and raw-content hosts to the host users configure.
+var hostAliases = map[string]string{
+	"api.github.com":            "github.com",
+	"raw.githubusercontent.com": "github.com",
+	"codeload.github.com":       "github.com",
+}
+
+// find returns the entry for host: an exact host:port match, then the
+// bare hostname, then the host it is an alias of.
+func find(host string) *Entry {
+	host = strings.ToLower(strings.TrimSpace(host))
+	if host == "" {
+		return nil
+	}
+	candidates := []string{host}
+	if u, err := url.Parse("//" + host); err == nil && u.Hostname() != host {
+		candidates = append(candidates, u.Hostname())
+	}
+	if alias, ok := hostAliases[candidates[len(candidates)-1]]; ok {
+		candidates = append(candidates, alias)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	for _, c := range candidates {
+		for i := range entries {
+			if strings.EqualFold(entries[i].Host, c) {
+				e := entries[i]
+				return &e
+			}
+		}
+	}
+	return nil
+}
+
+func resolve(e Entry) (*Credential, error) {
+	c := &Credential{Host: e.Host, Platform: e.Platform, Username: e.Username}
+	src := e.Token
+	switch {
+	case src.Env != "":
+		c.Token = os.Getenv(src.Env)
+	case src.Command != "":
+		out, err := runCommand(src.Command)
+		if err != nil {
+			return nil, fmt.Errorf("auth for %s: token command failed: %w", e.Host, err)
+		}
+		c.Token = out
+	case src.File != "":
+		data, err := os.ReadFile(expandHome(src.File))
+		if err != nil {
+			return nil, fmt.Errorf("auth for %s: %w", e.Host, err)
+		}
+		c.Token = strings.TrimSpace(string(data))
+	case src.GitCredential:
+		user, pass, err := gitCredentialFill(e.Host, e.Username)
+		if err != nil {
+			return nil, fmt.Errorf("auth for %s: git credential fill: %w", e.Host, err)
+		}
+		if c.Username == "" {
+			c.Username = user
+		}
+		c.Token = pass
+	}
+	if c.Token == "" {
+		return nil, nil
+	}
+	return c, nil
+}
+
+// runCommand runs a token command through the shell and returns its
+// trimmed stdout.
+func runCommand(command string) (string, error) {
+	var cmd *exec.Cmd
+	if runtime.GOOS == "windows" {
+		cmd = exec.Command("cmd", "/C", command)
+	} else {
+		cmd = exec.Command("sh", "-c", command)
+	}
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := runWithTimeout(cmd); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return "", fmt.Errorf("%v: %s", err, msg)
+		}
+		return "", err
+	}
+	return strings.TrimSpace(stdout.String()), nil
+}
+
+// gitCredentialFill asks git's configured credential helpers for host,
+// without prompting.
+func gitCredentialFill(host, username string) (string, string, error) {
+	input := "protocol=https\nhost=" + host + "\n"
+	if username != "" {
+		input += "username=" + username + "\n"
+	}
+	cmd := exec.Command("git", "credential", "fill")
+	cmd.Stdin = strings.NewReader(input + "\n")
+	cmd.Env = append(os.Environ(), "GIT_TERMINAL_PROMPT=0", "GIT_ASKPASS=", "SSH_ASKPASS=")
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := runWithTimeout(cmd); err != nil {
+		msg := strings.TrimSpace(stderr.String())
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && strings.Contains(msg, "terminal prompts disabled") {
+			// No helper had it and git wanted to prompt: no credential.
+			return "", "", nil
+		}
+		if msg != "" {
+			return "", "", fmt.Errorf("%v: %s", err, msg)
+		}
+		return "", "", err
+	}
+	var user, pass string
+	for _, line := range strings.Split(stdout.String(), "\n") {
+		key, val, _ := strings.Cut(line, "=")
+		switch key {
+		case "username":
+			user = val
+		case "password":
+			pass = val
+		}
+	}
+	return user, pass, nil
+}
+
+func runWithTimeout(cmd *exec.Cmd) error {
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+	done := make(chan error, 1)
+	go func() { done <- cmd.Wait() }()
+	select {
+	case err := <-done:
+		return err
+	case <-time.After(commandTimeout):
+		cmd.Process.Kill() //nolint:errcheck
+		<-done
+		return fmt.Errorf("timed out after %s", commandTimeout)
+	}
+}
+
+func expandHome(path string) string {
+	if utils.HasTildePrefix(path) {
+		if home, err := os.UserHomeDir(); err == nil {
+			return filepath.Join(home, path[1:])
+		}
+	}
+	return path
+}
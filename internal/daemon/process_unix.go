@@ -0,0 +1,26 @@
+//go:build !windows
+
+package daemon
+
+import (
+	"os"
+	"syscall"
+)
+
+// processAlive reports whether a process with the given PID exists.
+func processAlive(pid int) bool {
+	if pid <= 0 {
+		return false
+	}
+	proc, err := os.FindProcess(pid)
+	if err != nil {
+		return false
+	}
+	err = proc.Signal(syscall.Signal(0))
+	return err == nil || err == syscall.EPERM
+}
+
+// stopProcess asks the daemon to shut down gracefully.
+func stopProcess(proc *os.Process) error {
+	return proc.Signal(syscall.SIGTERM)
+}
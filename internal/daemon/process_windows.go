@@ -0,0 +1,26 @@
+//go:build windows
+
+package daemon
+
+import (
+	"os"
+)
+
+// processAlive reports whether a process with the given PID exists.
+// On Windows, FindProcess opens a handle and fails for unknown PIDs.
+func processAlive(pid int) bool {
+	if pid <= 0 {
+		return false
+	}
+	proc, err := os.FindProcess(pid)
+	if err != nil {
+		return false
+	}
+	proc.Release()
+	return true
+}
+
+// stopProcess terminates the daemon; Windows has no SIGTERM.
+func stopProcess(proc *os.Process) error {
+	return proc.Kill()
+}
@@ -0,0 +1,222 @@
+// Package daemon runs skillshare's periodic maintenance tasks (update
+// checks, pull, sync, audit re-scans and cleanup) on a fixed schedule.
+package daemon
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Task is one scheduled job. Run returns a short summary for the status
+// display; a non-nil error marks the run as failed.
+type Task struct {
+	Name     string
+	Interval time.Duration
+	Run      func(ctx context.Context) (string, error)
+}
+
+// RunFunc is called after every task run, e.g. to write the oplog.
+type RunFunc func(task string, status TaskStatus, duration time.Duration)
+
+// Scheduler runs tasks one at a time whenever they are due and persists
+// their status so other processes (status command, web UI) can read it.
+type Scheduler struct {
+	tasks []Task
+	onRun RunFunc
+	now   func() time.Time
+
+	mu    sync.Mutex
+	state State
+}
+
+// NewScheduler creates a scheduler. Last-run times are picked up from the
+// persisted state, so a restarted daemon does not re-run everything at once.
+func NewScheduler(tasks []Task, onRun RunFunc) *Scheduler {
+	s := &Scheduler{tasks: tasks, onRun: onRun, now: time.Now}
+
+	prev, _ := ReadState()
+	lastRuns := map[string]TaskStatus{}
+	if prev != nil {
+		for _, t := range prev.Tasks {
+			lastRuns[t.Name] = t
+		}
+	}
+
+	now := s.now()
+	for _, t := range tasks {
+		st := lastRuns[t.Name]
+		st.Name = t.Name
+		st.Interval = FormatInterval(t.Interval)
+		st.NextRun = now
+		if !st.LastRun.IsZero() && st.LastRun.Add(t.Interval).After(now) {
+			st.NextRun = st.LastRun.Add(t.Interval)
+		}
+		s.state.Tasks = append(s.state.Tasks, st)
+	}
+	return s
+}
+
+// State returns a copy of the current schedule status.
+func (s *Scheduler) State() State {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	st := s.state
+	st.Tasks = append([]TaskStatus(nil), s.state.Tasks...)
+	return st
+}
+
+// Run executes due tasks until ctx is cancelled.
+func (s *Scheduler) Run(ctx context.Context, pid int) error {
+	s.mu.Lock()
+	s.state.PID = pid
+	s.state.StartedAt = s.now()
+	s.mu.Unlock()
+	s.save()
+	defer func() {
+		s.mu.Lock()
+		s.state.PID = 0
+		s.mu.Unlock()
+		s.save()
+	}()
+
+	for {
+		if i := s.nextDue(); i >= 0 {
+			s.runTask(ctx, i)
+			if ctx.Err() != nil {
+				return nil
+			}
+			continue
+		}
+
+		wait := s.untilNext()
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(wait):
+		}
+	}
+}
+
+// RunOnce runs every task immediately, in order, regardless of schedule.
+func (s *Scheduler) RunOnce(ctx context.Context) {
+	for i := range s.tasks {
+		if ctx.Err() != nil {
+			return
+		}
+		s.runTask(ctx, i)
+	}
+}
+
+// nextDue returns the index of the most overdue task, or -1.
+func (s *Scheduler) nextDue() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := s.now()
+	due := -1
+	for i, t := range s.state.Tasks {
+		if t.NextRun.After(now) {
+			continue
+		}
+		if due < 0 || t.NextRun.Before(s.state.Tasks[due].NextRun) {
+			due = i
+		}
+	}
+	return due
+}
+
+// untilNext returns how long until the next task is due.
+func (s *Scheduler) untilNext() time.Duration {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := s.now()
+	wait := time.Hour
+	for _, t := range s.state.Tasks {
+		if d := t.NextRun.Sub(now); d < wait {
+			wait = d
+		}
+	}
+	if wait < time.Second {
+		wait = time.Second
+	}
+	return wait
+}
+
+func (s *Scheduler) runTask(ctx context.Context, i int) {
+	task := s.tasks[i]
+	start := s.now()
+
+	s.mu.Lock()
+	s.state.Tasks[i].Running = true
+	s.mu.Unlock()
+	s.save()
+
+	msg, err := task.Run(ctx)
+	end := s.now()
+
+	s.mu.Lock()
+	st := &s.state.Tasks[i]
+	st.Running = false
+	st.LastRun = start
+	st.LastStatus = "ok"
+	st.LastMessage = msg
+	if err != nil {
+		st.LastStatus = "error"
+		st.LastMessage = err.Error()
+	}
+	st.NextRun = end.Add(task.Interval)
+	snapshot := *st
+	s.mu.Unlock()
+	s.save()
+
+	if s.onRun != nil {
+		s.onRun(task.Name, snapshot, end.Sub(start))
+	}
+}
+
+func (s *Scheduler) save() {
+	st := s.State()
+	WriteState(&st) //nolint:errcheck
+}
+
+// ParseInterval parses a schedule interval: a Go duration ("30m", "6h")
+// or a number of days ("1d", "7d"). Empty means disabled and returns 0.
+func ParseInterval(v string) (time.Duration, error) {
+	v = strings.TrimSpace(v)
+	if v == "" {
+		return 0, nil
+	}
+	if days, ok := strings.CutSuffix(v, "d"); ok {
+		n, err := strconv.Atoi(days)
+		if err != nil || n <= 0 {
+			return 0, fmt.Errorf("invalid interval %q", v)
+		}
+		return time.Duration(n) * 24 * time.Hour, nil
+	}
+	d, err := time.ParseDuration(v)
+	if err != nil {
+		return 0, fmt.Errorf("invalid interval %q", v)
+	}
+	if d < time.Minute {
+		return 0, fmt.Errorf("interval %q is too short (minimum 1m)", v)
+	}
+	return d, nil
+}
+
+// FormatInterval renders an interval the way ParseInterval accepts it.
+func FormatInterval(d time.Duration) string {
+	if d >= 24*time.Hour && d%(24*time.Hour) == 0 {
+		return fmt.Sprintf("%dd", d/(24*time.Hour))
+	}
+	s := d.String()
+	if strings.HasSuffix(s, "m0s") {
+		s = strings.TrimSuffix(s, "0s")
+	}
+	if strings.HasSuffix(s, "h0m") {
+		s = strings.TrimSuffix(s, "0m")
+	}
+	return s
+}
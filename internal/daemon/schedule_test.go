@@ -0,0 +1,167 @@
+package daemon
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseInterval(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    time.Duration
+		wantErr bool
+	}{
+		{"", 0, false},
+		{"30m", 30 * time.Minute, false},
+		{"6h", 6 * time.Hour, false},
+		{"1d", 24 * time.Hour, false},
+		{"7d", 7 * 24 * time.Hour, false},
+		{"30s", 0, true},
+		{"0d", 0, true},
+		{"soon", 0, true},
+	}
+	for _, tt := range tests {
+		got, err := ParseInterval(tt.in)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseInterval(%q) error = %v, wantErr %v", tt.in, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseInterval(%q) = %v, want %v", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestFormatInterval(t *testing.T) {
+	tests := map[time.Duration]string{
+		10 * time.Minute:   "10m",
+		6 * time.Hour:      "6h",
+		90 * time.Minute:   "1h30m",
+		24 * time.Hour:     "1d",
+		7 * 24 * time.Hour: "7d",
+		36 * time.Hour:     "36h",
+	}
+	for d, want := range tests {
+		if got := FormatInterval(d); got != want {
+			t.Errorf("FormatInterval(%v) = %q, want %q", d, got, want)
+		}
+	}
+}
+
+func TestScheduler_RunOncePersistsState(t *testing.T) {
+	t.Setenv("XDG_STATE_HOME", t.TempDir())
+
+	var ran []string
+	tasks := []Task{
+		{Name: "sync", Interval: time.Hour, Run: func(context.Context) (string, error) {
+			return "synced", nil
+		}},
+		{Name: "audit", Interval: 24 * time.Hour, Run: func(context.Context) (string, error) {
+			return "", errors.New("scan failed")
+		}},
+	}
+	s := NewScheduler(tasks, func(task string, _ TaskStatus, _ time.Duration) {
+		ran = append(ran, task)
+	})
+	s.RunOnce(context.Background())
+
+	if len(ran) != 2 || ran[0] != "sync" || ran[1] != "audit" {
+		t.Fatalf("ran = %v, want [sync audit]", ran)
+	}
+
+	st, err := ReadState()
+	if err != nil || st == nil {
+		t.Fatalf("ReadState() = (%v, %v)", st, err)
+	}
+	if len(st.Tasks) != 2 {
+		t.Fatalf("Tasks = %+v, want 2", st.Tasks)
+	}
+	if st.Tasks[0].LastStatus != "ok" || st.Tasks[0].LastMessage != "synced" {
+		t.Errorf("sync status = %+v", st.Tasks[0])
+	}
+	if st.Tasks[1].LastStatus != "error" || st.Tasks[1].LastMessage != "scan failed" {
+		t.Errorf("audit status = %+v", st.Tasks[1])
+	}
+
+	// A new scheduler picks up last-run times instead of running at once
+	s2 := NewScheduler(tasks, nil)
+	for _, ts := range s2.State().Tasks {
+		if !ts.NextRun.After(time.Now()) {
+			t.Errorf("%s NextRun = %v, want in the future", ts.Name, ts.NextRun)
+		}
+	}
+}
+
+func TestLock(t *testing.T) {
+	t.Setenv("XDG_STATE_HOME", t.TempDir())
+
+	if err := Lock(); err != nil {
+		t.Fatalf("Lock() error: %v", err)
+	}
+	if pid, running := RunningPID(); !running || pid != os.Getpid() {
+		t.Errorf("RunningPID() = (%d, %v), want (%d, true)", pid, running, os.Getpid())
+	}
+	if err := Lock(); err == nil {
+		t.Error("second Lock() should fail while the daemon is running")
+	}
+
+	Unlock()
+	if _, err := os.Stat(PIDPath()); !os.IsNotExist(err) {
+		t.Error("Unlock() should remove the pid file")
+	}
+}
+
+func TestLock_ReplacesStale(t *testing.T) {
+	t.Setenv("XDG_STATE_HOME", t.TempDir())
+
+	if err := os.MkdirAll(Dir(), 0755); err != nil {
+		t.Fatal(err)
+	}
+	// PIDs this large are not in use
+	if err := os.WriteFile(PIDPath(), []byte("999999999\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, running := RunningPID(); running {
+		t.Fatal("stale pid should not be reported as running")
+	}
+	if err := Lock(); err != nil {
+		t.Fatalf("Lock() over stale pid file error: %v", err)
+	}
+	Unlock()
+}
+
+func TestLock_KeepsLiveLock(t *testing.T) {
+	t.Setenv("XDG_STATE_HOME", t.TempDir())
+
+	if err := os.MkdirAll(Dir(), 0755); err != nil {
+		t.Fatal(err)
+	}
+	// Another daemon that won the race: its (live) parent process
+	live := []byte(strconv.Itoa(os.Getppid()) + "\n")
+	if err := os.WriteFile(PIDPath(), live, 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := Lock(); err == nil || !strings.Contains(err.Error(), "already running") {
+		t.Fatalf("Lock() error = %v, want already running", err)
+	}
+	if data, _ := os.ReadFile(PIDPath()); string(data) != string(live) {
+		t.Errorf("pid file = %q, want the live daemon's %q", data, live)
+	}
+}
+
+func TestTaskStatus_JSON(t *testing.T) {
+	data, err := json.Marshal(TaskStatus{Name: "sync", Interval: "1h", LastRun: time.Unix(0, 0).UTC()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := string(data)
+	if !strings.Contains(got, `"lastRun":"1970-01-01T00:00:00Z"`) || strings.Contains(got, "nextRun") {
+		t.Errorf("TaskStatus JSON = %s, want camelCase keys without zero times", got)
+	}
+}
@@ -0,0 +1,157 @@
+package daemon
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"skillshare/internal/config"
+)
+
+// State is the persisted daemon status, shared with `daemon status` and
+// the web UI through the state file.
+type State struct {
+	PID       int          `json:"pid,omitempty"`
+	StartedAt time.Time    `json:"startedAt,omitzero"`
+	Tasks     []TaskStatus `json:"tasks"`
+}
+
+// TaskStatus describes the schedule and last outcome of one task.
+type TaskStatus struct {
+	Name        string    `json:"name"`
+	Interval    string    `json:"interval"`
+	Running     bool      `json:"running,omitempty"`
+	LastRun     time.Time `json:"lastRun,omitzero"`
+	LastStatus  string    `json:"lastStatus,omitempty"` // ok, error
+	LastMessage string    `json:"lastMessage,omitempty"`
+	NextRun     time.Time `json:"nextRun,omitzero"`
+}
+
+// Dir returns the daemon state directory.
+func Dir() string {
+	return filepath.Join(config.StateDir(), "daemon")
+}
+
+// StatePath returns the path of the persisted schedule status.
+func StatePath() string {
+	return filepath.Join(Dir(), "state.json")
+}
+
+// PIDPath returns the path of the daemon PID/lock file.
+func PIDPath() string {
+	return filepath.Join(Dir(), "daemon.pid")
+}
+
+// ReadState loads the persisted status. Returns nil, nil if the daemon
+// never ran.
+func ReadState() (*State, error) {
+	data, err := os.ReadFile(StatePath())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var st State
+	if err := json.Unmarshal(data, &st); err != nil {
+		return nil, err
+	}
+	return &st, nil
+}
+
+// WriteState persists st atomically.
+func WriteState(st *State) error {
+	if err := os.MkdirAll(Dir(), 0755); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(st, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp := StatePath() + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, StatePath())
+}
+
+// Lock writes the PID file for the current process. It fails if another
+// live daemon holds it; a file left by a dead process is replaced.
+func Lock() error {
+	if err := os.MkdirAll(Dir(), 0755); err != nil {
+		return err
+	}
+	for stale := false; ; stale = true {
+		err := createPIDFile()
+		if err == nil {
+			return nil
+		}
+		if !os.IsExist(err) {
+			return fmt.Errorf("failed to create pid file: %w", err)
+		}
+		pid, running := RunningPID()
+		if running {
+			return fmt.Errorf("daemon already running (pid %d)", pid)
+		}
+		if stale {
+			// Replaced by another daemon starting at the same time
+			return fmt.Errorf("daemon already running (pid file %s)", PIDPath())
+		}
+		// Stale lock left by a crashed daemon
+		os.Remove(PIDPath())
+	}
+}
+
+// createPIDFile creates the PID file with the current PID, failing if it
+// exists. The PID is written to a temporary file first and linked into
+// place, so another process never reads a half-written file.
+func createPIDFile() error {
+	tmp := fmt.Sprintf("%s.%d.tmp", PIDPath(), os.Getpid())
+	if err := os.WriteFile(tmp, []byte(strconv.Itoa(os.Getpid())+"\n"), 0644); err != nil {
+		return err
+	}
+	defer os.Remove(tmp)
+	return os.Link(tmp, PIDPath())
+}
+
+// Unlock removes the PID file if it belongs to the current process.
+func Unlock() {
+	if pid, err := readPID(); err == nil && pid == os.Getpid() {
+		os.Remove(PIDPath())
+	}
+}
+
+// RunningPID returns the PID from the lock file and whether that process
+// is alive.
+func RunningPID() (int, bool) {
+	pid, err := readPID()
+	if err != nil {
+		return 0, false
+	}
+	return pid, processAlive(pid)
+}
+
+func readPID() (int, error) {
+	data, err := os.ReadFile(PIDPath())
+	if err != nil {
+		return 0, err
+	}
+	return strconv.Atoi(strings.TrimSpace(string(data)))
+}
+
+// Stop signals the running daemon to exit.
+func Stop() (int, error) {
+	pid, running := RunningPID()
+	if !running {
+		return 0, fmt.Errorf("daemon is not running")
+	}
+	proc, err := os.FindProcess(pid)
+	if err != nil {
+		return pid, err
+	}
+	return pid, stopProcess(proc)
+}
@@ -0,0 +1,39 @@
+package daemon
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// SystemdUnitName is the file name of the generated systemd user unit.
+const SystemdUnitName = "skillshare-daemon.service"
+
+// SystemdUnit returns a systemd user unit that runs `skillshare daemon`
+// with the given executable.
+func SystemdUnit(exe string) string {
+	return fmt.Sprintf(`[Unit]
+Description=skillshare background maintenance
+After=network-online.target
+
+[Service]
+Type=simple
+ExecStart=%s daemon run
+Restart=on-failure
+RestartSec=30
+
+[Install]
+WantedBy=default.target
+`, exe)
+}
+
+// SystemdUnitPath returns where the user unit is installed
+// ($XDG_CONFIG_HOME/systemd/user or ~/.config/systemd/user).
+func SystemdUnitPath() string {
+	base := os.Getenv("XDG_CONFIG_HOME")
+	if base == "" {
+		home, _ := os.UserHomeDir()
+		base = filepath.Join(home, ".config")
+	}
+	return filepath.Join(base, "systemd", "user", SystemdUnitName)
+}
@@ -0,0 +1,121 @@
+package dedupe
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"time"
+
+	"skillshare/internal/config"
+)
+
+// DecisionsFile is the name of the file recording resolved pairs.
+const DecisionsFile = "dedupe.json"
+
+// Decision actions.
+const (
+	ActionIgnore = "ignore" // the pair is intentional; stop reporting it
+	ActionKeep   = "keep"   // Kept stays, Dropped went to trash
+	ActionMerge  = "merge"  // Dropped's extra files merged into Kept, then trashed
+)
+
+// Decision records how one pair was resolved.
+type Decision struct {
+	A       string    `json:"a"`
+	B       string    `json:"b"`
+	Action  string    `json:"action"`
+	Kept    string    `json:"kept,omitempty"`
+	Dropped string    `json:"dropped,omitempty"`
+	Time    time.Time `json:"time"`
+}
+
+// Decisions is the persisted list of resolved pairs.
+type Decisions struct {
+	path  string
+	Items []Decision `json:"decisions"`
+}
+
+// DecisionsPath returns the decisions file for the scope of configPath:
+// next to the project config (.skillshare/dedupe.json) in project mode,
+// or in the state directory in global mode.
+func DecisionsPath(configPath string) string {
+	configDir := filepath.Dir(configPath)
+	if filepath.Base(configDir) == ".skillshare" {
+		return filepath.Join(configDir, DecisionsFile)
+	}
+	return filepath.Join(config.StateDir(), DecisionsFile)
+}
+
+// LoadDecisions reads the decisions file. A missing file yields an empty list.
+func LoadDecisions(path string) (*Decisions, error) {
+	d := &Decisions{path: path}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return d, nil
+		}
+		return nil, err
+	}
+	if err := json.Unmarshal(data, d); err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+// Ignored reports whether the pair a/b was marked as ignored.
+func (d *Decisions) Ignored(a, b string) bool {
+	key := PairKey(a, b)
+	for _, item := range d.Items {
+		if item.Action == ActionIgnore && PairKey(item.A, item.B) == key {
+			return true
+		}
+	}
+	return false
+}
+
+// Record adds a decision, replacing any earlier one for the same pair.
+func (d *Decisions) Record(dec Decision) {
+	if dec.Time.IsZero() {
+		dec.Time = time.Now().UTC()
+	}
+	key := PairKey(dec.A, dec.B)
+	items := d.Items[:0]
+	for _, item := range d.Items {
+		if PairKey(item.A, item.B) != key {
+			items = append(items, item)
+		}
+	}
+	d.Items = append(items, dec)
+}
+
+// Forget removes any decision for the pair. It returns false if none existed.
+func (d *Decisions) Forget(a, b string) bool {
+	key := PairKey(a, b)
+	items := d.Items[:0]
+	found := false
+	for _, item := range d.Items {
+		if PairKey(item.A, item.B) == key {
+			found = true
+			continue
+		}
+		items = append(items, item)
+	}
+	d.Items = items
+	return found
+}
+
+// Save writes the decisions file atomically.
+func (d *Decisions) Save() error {
+	if err := os.MkdirAll(filepath.Dir(d.path), 0755); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(d, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp := d.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, d.path)
+}
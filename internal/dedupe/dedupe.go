@@ -0,0 +1,169 @@
+// Package dedupe finds skills in the source that are exact copies or close
+// variants of each other, typically collected from several tools.
+package dedupe
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"unicode"
+
+	"skillshare/internal/sync"
+)
+
+// DefaultThreshold is the minimum similarity for a near-duplicate.
+const DefaultThreshold = 0.8
+
+// shingleSize is the number of consecutive words hashed into one shingle.
+const shingleSize = 3
+
+const (
+	KindExact   = "exact"   // identical directory contents
+	KindSimilar = "similar" // SKILL.md description and body mostly the same
+)
+
+// Pair is two skills reported as duplicates. A and B are source-relative
+// paths with A < B.
+type Pair struct {
+	A          string  `json:"a"`
+	B          string  `json:"b"`
+	Kind       string  `json:"kind"`
+	Similarity float64 `json:"similarity"` // 0..1; 1 for exact duplicates
+}
+
+// Key identifies the pair independent of order.
+func (p Pair) Key() string {
+	return PairKey(p.A, p.B)
+}
+
+// PairKey returns the order-independent key of two skill paths.
+func PairKey(a, b string) string {
+	if b < a {
+		a, b = b, a
+	}
+	return a + "\x00" + b
+}
+
+// Skill is the comparable content of one source skill.
+type Skill struct {
+	RelPath  string
+	Path     string
+	Checksum string
+	shingles map[string]struct{}
+}
+
+// Load reads the checksum and text shingles of discovered skills.
+func Load(discovered []sync.DiscoveredSkill) []Skill {
+	skills := make([]Skill, 0, len(discovered))
+	for _, d := range discovered {
+		s := Skill{RelPath: d.RelPath, Path: d.SourcePath}
+		s.Checksum, _ = sync.DirChecksum(d.SourcePath)
+		if data, err := os.ReadFile(filepath.Join(d.SourcePath, "SKILL.md")); err == nil {
+			s.shingles = shingles(comparableText(string(data)))
+		}
+		skills = append(skills, s)
+	}
+	sort.Slice(skills, func(i, j int) bool { return skills[i].RelPath < skills[j].RelPath })
+	return skills
+}
+
+// Find returns exact duplicates followed by near-duplicates whose
+// similarity is at least threshold, most similar first. Pairs for which
+// skip returns true (e.g. ignored pairs) are left out.
+func Find(skills []Skill, threshold float64, skip func(a, b string) bool) []Pair {
+	if threshold <= 0 {
+		threshold = DefaultThreshold
+	}
+
+	var exact, similar []Pair
+	for i := 0; i < len(skills); i++ {
+		for j := i + 1; j < len(skills); j++ {
+			a, b := skills[i], skills[j]
+			if nested(a.RelPath, b.RelPath) || (skip != nil && skip(a.RelPath, b.RelPath)) {
+				continue
+			}
+			if a.Checksum != "" && a.Checksum == b.Checksum {
+				exact = append(exact, Pair{A: a.RelPath, B: b.RelPath, Kind: KindExact, Similarity: 1})
+				continue
+			}
+			if sim := jaccard(a.shingles, b.shingles, threshold); sim >= threshold {
+				similar = append(similar, Pair{A: a.RelPath, B: b.RelPath, Kind: KindSimilar, Similarity: sim})
+			}
+		}
+	}
+
+	sort.SliceStable(similar, func(i, j int) bool { return similar[i].Similarity > similar[j].Similarity })
+	return append(exact, similar...)
+}
+
+// Similarity returns the shingle similarity of two SKILL.md contents.
+func Similarity(a, b string) float64 {
+	return jaccard(shingles(comparableText(a)), shingles(comparableText(b)), 0)
+}
+
+// comparableText drops the frontmatter name line: copies usually differ
+// exactly there. Description, other metadata and body are kept.
+func comparableText(content string) string {
+	lines := strings.Split(strings.ReplaceAll(content, "\r\n", "\n"), "\n")
+	if len(lines) == 0 || strings.TrimSpace(lines[0]) != "---" {
+		return content
+	}
+	kept := make([]string, 0, len(lines))
+	for i, line := range lines {
+		if i > 0 && strings.TrimSpace(line) == "---" {
+			kept = append(kept, lines[i+1:]...)
+			break
+		}
+		if !strings.HasPrefix(line, "name:") {
+			kept = append(kept, line)
+		}
+	}
+	return strings.Join(kept, "\n")
+}
+
+// shingles returns the set of overlapping word n-grams in text.
+func shingles(text string) map[string]struct{} {
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	set := make(map[string]struct{})
+	if len(words) < shingleSize {
+		if len(words) > 0 {
+			set[strings.Join(words, " ")] = struct{}{}
+		}
+		return set
+	}
+	for i := 0; i+shingleSize <= len(words); i++ {
+		set[strings.Join(words[i:i+shingleSize], " ")] = struct{}{}
+	}
+	return set
+}
+
+// jaccard returns |a∩b| / |a∪b|. Pairs whose size ratio already rules out
+// reaching minSim return 0 without comparing members.
+func jaccard(a, b map[string]struct{}, minSim float64) float64 {
+	if len(a) == 0 || len(b) == 0 {
+		return 0
+	}
+	small, large := a, b
+	if len(small) > len(large) {
+		small, large = large, small
+	}
+	if float64(len(small))/float64(len(large)) < minSim {
+		return 0
+	}
+	inter := 0
+	for s := range small {
+		if _, ok := large[s]; ok {
+			inter++
+		}
+	}
+	return float64(inter) / float64(len(a)+len(b)-inter)
+}
+
+// nested reports whether one skill lives inside the other; those are
+// parent/child skills, not copies.
+func nested(a, b string) bool {
+	return strings.HasPrefix(b, a+"/") || strings.HasPrefix(a, b+"/")
+}
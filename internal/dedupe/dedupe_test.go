@@ -0,0 +1,148 @@
+package dedupe
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"skillshare/internal/sync"
+)
+
+const pdfBody = `
+# PDF
+
+Extract text and tables from PDF files using pdfplumber. Prefer pypdf for
+simple page text. For scanned documents run OCR first, then post-process
+the output to fix hyphenation and broken lines.
+`
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func skillFile(name, desc, body string) string {
+	return "---\nname: " + name + "\ndescription: " + desc + "\n---\n" + body
+}
+
+func TestFind(t *testing.T) {
+	src := t.TempDir()
+	writeFile(t, filepath.Join(src, "pdf", "SKILL.md"), skillFile("pdf", "Work with PDF files.", pdfBody))
+	writeFile(t, filepath.Join(src, "pdf-copy", "SKILL.md"), skillFile("pdf", "Work with PDF files.", pdfBody))
+	writeFile(t, filepath.Join(src, "claude-pdf", "SKILL.md"),
+		skillFile("claude-pdf", "Work with PDF files.", pdfBody+"\nAlways cite page numbers.\n"))
+	writeFile(t, filepath.Join(src, "docx", "SKILL.md"), skillFile("docx", "Edit Word documents.", "# DOCX\n\nUse python-docx.\n"))
+
+	discovered, err := sync.DiscoverSourceSkills(src)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pairs := Find(Load(discovered), DefaultThreshold, nil)
+
+	if len(pairs) != 3 {
+		t.Fatalf("pairs = %+v, want 3", pairs)
+	}
+	if pairs[0].Kind != KindExact || pairs[0].A != "pdf" || pairs[0].B != "pdf-copy" {
+		t.Errorf("first pair = %+v, want exact pdf/pdf-copy", pairs[0])
+	}
+	for _, p := range pairs[1:] {
+		if p.Kind != KindSimilar || p.A != "claude-pdf" || p.Similarity < DefaultThreshold || p.Similarity >= 1 {
+			t.Errorf("pair = %+v, want similar claude-pdf", p)
+		}
+		if p.A == "docx" || p.B == "docx" {
+			t.Errorf("docx should not be a duplicate: %+v", p)
+		}
+	}
+
+	skip := func(a, b string) bool { return PairKey(a, b) == PairKey("pdf-copy", "pdf") }
+	if got := Find(Load(discovered), DefaultThreshold, skip); len(got) != 2 {
+		t.Errorf("with skip: %d pairs, want 2", len(got))
+	}
+}
+
+func TestSimilarity_IgnoresName(t *testing.T) {
+	a := skillFile("one", "Same description.", pdfBody)
+	b := skillFile("two", "Same description.", pdfBody)
+	if got := Similarity(a, b); got != 1 {
+		t.Errorf("Similarity() = %v, want 1", got)
+	}
+	if got := Similarity(a, skillFile("one", "Other.", "Completely unrelated text about git rebasing.")); got > 0.1 {
+		t.Errorf("Similarity() = %v, want ~0", got)
+	}
+}
+
+func TestSideBySide(t *testing.T) {
+	rows := SideBySide("a\nb\nc\nd\n", "a\nB\nc\nd\ne\n")
+	var ops []string
+	for _, r := range rows {
+		ops = append(ops, string(r.Op))
+	}
+	if got := strings.Join(ops, ""); got != " ~  +" {
+		t.Fatalf("ops = %q, want %q", got, " ~  +")
+	}
+	if rows[1].Left != "b" || rows[1].Right != "B" || rows[4].Right != "e" {
+		t.Errorf("rows = %+v", rows)
+	}
+	if Changed(SideBySide("x\n", "x")) {
+		t.Error("identical content should not be changed")
+	}
+}
+
+func TestMerge(t *testing.T) {
+	keep := filepath.Join(t.TempDir(), "keep")
+	from := filepath.Join(t.TempDir(), "from")
+	writeFile(t, filepath.Join(keep, "SKILL.md"), "keep")
+	writeFile(t, filepath.Join(keep, "references", "same.md"), "same")
+	writeFile(t, filepath.Join(from, "SKILL.md"), "from")
+	writeFile(t, filepath.Join(from, "references", "same.md"), "same")
+	writeFile(t, filepath.Join(from, "references", "extra.md"), "extra")
+	writeFile(t, filepath.Join(from, ".skillshare-meta.json"), "{}")
+
+	res, err := Merge(keep, from)
+	if err != nil {
+		t.Fatalf("Merge() error: %v", err)
+	}
+	if strings.Join(res.Added, ",") != "references/extra.md" || strings.Join(res.Conflicts, ",") != "SKILL.md" {
+		t.Errorf("Merge() = %+v", res)
+	}
+	if data, _ := os.ReadFile(filepath.Join(keep, "SKILL.md")); string(data) != "keep" {
+		t.Errorf("SKILL.md overwritten: %q", data)
+	}
+	if _, err := os.Stat(filepath.Join(keep, ".skillshare-meta.json")); !os.IsNotExist(err) {
+		t.Error("install metadata should not be merged")
+	}
+}
+
+func TestDecisions(t *testing.T) {
+	path := filepath.Join(t.TempDir(), DecisionsFile)
+	d, err := LoadDecisions(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	d.Record(Decision{A: "b", B: "a", Action: ActionIgnore})
+	d.Record(Decision{A: "c", B: "d", Action: ActionKeep, Kept: "c", Dropped: "d"})
+	if err := d.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	d, err = LoadDecisions(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !d.Ignored("a", "b") || d.Ignored("c", "d") {
+		t.Errorf("Ignored() wrong after reload: %+v", d.Items)
+	}
+	d.Record(Decision{A: "a", B: "b", Action: ActionKeep, Kept: "a", Dropped: "b"})
+	if d.Ignored("a", "b") || len(d.Items) != 2 {
+		t.Errorf("Record() should replace the earlier decision: %+v", d.Items)
+	}
+	if !d.Forget("b", "a") || d.Forget("b", "a") {
+		t.Error("Forget() should remove the decision once")
+	}
+}
@@ -0,0 +1,96 @@
+package dedupe
+
+import "strings"
+
+// Row operations of a side-by-side diff.
+const (
+	RowSame    = ' '
+	RowChanged = '~'
+	RowLeft    = '-' // line only in the left file
+	RowRight   = '+' // line only in the right file
+)
+
+// Row is one line of a side-by-side diff.
+type Row struct {
+	Op    byte
+	Left  string
+	Right string
+}
+
+// SideBySide aligns the lines of a and b. Runs of removed and added lines
+// at the same position are paired into RowChanged rows.
+func SideBySide(a, b string) []Row {
+	left := splitLines(a)
+	right := splitLines(b)
+
+	// LCS table; SKILL.md files are small enough for the quadratic version.
+	lcs := make([][]int, len(left)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(right)+1)
+	}
+	for i := len(left) - 1; i >= 0; i-- {
+		for j := len(right) - 1; j >= 0; j-- {
+			if left[i] == right[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else if lcs[i+1][j] >= lcs[i][j+1] {
+				lcs[i][j] = lcs[i+1][j]
+			} else {
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+
+	var rows []Row
+	var removed, added []string
+	flush := func() {
+		n := max(len(removed), len(added))
+		for k := 0; k < n; k++ {
+			switch {
+			case k < len(removed) && k < len(added):
+				rows = append(rows, Row{Op: RowChanged, Left: removed[k], Right: added[k]})
+			case k < len(removed):
+				rows = append(rows, Row{Op: RowLeft, Left: removed[k]})
+			default:
+				rows = append(rows, Row{Op: RowRight, Right: added[k]})
+			}
+		}
+		removed, added = removed[:0], added[:0]
+	}
+
+	i, j := 0, 0
+	for i < len(left) || j < len(right) {
+		switch {
+		case i < len(left) && j < len(right) && left[i] == right[j]:
+			flush()
+			rows = append(rows, Row{Op: RowSame, Left: left[i], Right: right[j]})
+			i++
+			j++
+		case j >= len(right) || (i < len(left) && lcs[i+1][j] >= lcs[i][j+1]):
+			removed = append(removed, left[i])
+			i++
+		default:
+			added = append(added, right[j])
+			j++
+		}
+	}
+	flush()
+	return rows
+}
+
+// Changed reports whether any row differs.
+func Changed(rows []Row) bool {
+	for _, r := range rows {
+		if r.Op != RowSame {
+			return true
+		}
+	}
+	return false
+}
+
+func splitLines(s string) []string {
+	s = strings.TrimRight(strings.ReplaceAll(s, "\r\n", "\n"), "\n")
+	if s == "" {
+		return nil
+	}
+	return strings.Split(s, "\n")
+}
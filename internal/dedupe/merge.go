@@ -0,0 +1,88 @@
+package dedupe
+
+import (
+	"bytes"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+// MergeResult lists what Merge did with the files of the dropped skill.
+type MergeResult struct {
+	Added     []string `json:"added"`     // copied into the kept skill
+	Conflicts []string `json:"conflicts"` // differ in both; kept skill's version wins
+}
+
+// Files returns the source-relative files of a skill directory, skipping
+// .git and install metadata.
+func Files(dir string) ([]string, error) {
+	var files []string
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if d.Name() == ".git" {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if d.Name() == ".skillshare-meta.json" {
+			return nil
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		files = append(files, filepath.ToSlash(rel))
+		return nil
+	})
+	sort.Strings(files)
+	return files, err
+}
+
+// Merge copies files that exist only in from into keep. Files present in
+// both with different content are reported as conflicts and left as they
+// are in keep. from itself is not modified.
+func Merge(keep, from string) (*MergeResult, error) {
+	files, err := Files(from)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &MergeResult{Added: []string{}, Conflicts: []string{}}
+	for _, rel := range files {
+		src := filepath.Join(from, filepath.FromSlash(rel))
+		dst := filepath.Join(keep, filepath.FromSlash(rel))
+
+		srcData, err := os.ReadFile(src)
+		if err != nil {
+			return res, err
+		}
+		dstData, err := os.ReadFile(dst)
+		switch {
+		case err == nil:
+			if !bytes.Equal(srcData, dstData) {
+				res.Conflicts = append(res.Conflicts, rel)
+			}
+			continue
+		case !os.IsNotExist(err):
+			return res, err
+		}
+
+		info, err := os.Stat(src)
+		if err != nil {
+			return res, err
+		}
+		if err := os.MkdirAll(filepath.Dir(dst), 0755); err != nil {
+			return res, err
+		}
+		if err := os.WriteFile(dst, srcData, info.Mode().Perm()); err != nil {
+			return res, fmt.Errorf("failed to copy %s: %w", rel, err)
+		}
+		res.Added = append(res.Added, rel)
+	}
+	return res, nil
+}
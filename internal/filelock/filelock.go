@@ -0,0 +1,42 @@
+// Package filelock provides exclusive advisory locks on open files: flock
+// on Unix, LockFileEx on Windows. Locks exclude other open files of the
+// same path, in this process or another.
+package filelock
+
+import (
+	"errors"
+	"os"
+	"time"
+)
+
+// ErrTimeout is returned by Lock when the file stays locked past the
+// timeout.
+var ErrTimeout = errors.New("timed out waiting for file lock")
+
+// pollInterval is how often Lock retries.
+const pollInterval = 100 * time.Millisecond
+
+// Lock opens path, creating it if needed, and waits up to timeout for its
+// lock. Close the returned file to release it.
+func Lock(path string, timeout time.Duration) (*os.File, error) {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_RDWR, 0644)
+	if err != nil {
+		return nil, err
+	}
+	deadline := time.Now().Add(timeout)
+	for {
+		ok, err := TryLock(f)
+		if err != nil {
+			f.Close()
+			return nil, err
+		}
+		if ok {
+			return f, nil
+		}
+		if !time.Now().Before(deadline) {
+			f.Close()
+			return nil, ErrTimeout
+		}
+		time.Sleep(pollInterval)
+	}
+}
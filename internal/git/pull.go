@@ -0,0 +1,439 @@
+package git
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Pull strategies for SyncPull.
+const (
+	StrategyRebase = "rebase"
+	StrategyMerge  = "merge"
+)
+
+// Conflict stages reported in PullResult.Stage.
+const (
+	StageRebase = "rebase" // replaying local commits onto the remote
+	StageMerge  = "merge"  // merging the remote into local commits
+	StageStash  = "stash"  // restoring auto-stashed local changes
+)
+
+// Resolution sides for ResolveSkill.
+const (
+	SideOurs   = "ours"   // keep the local version
+	SideTheirs = "theirs" // take the remote version
+)
+
+// autostashMessage marks the stash entry created by SyncPull.
+const autostashMessage = "skillshare pull autostash"
+
+// pullStateFile lives in the git dir and carries an interrupted pull
+// across invocations (pull, resolve, --continue / --abort).
+const pullStateFile = "skillshare-pull.json"
+
+// PullOptions configures SyncPull.
+type PullOptions struct {
+	Strategy string   // StrategyRebase (default) or StrategyMerge
+	Env      []string // extra environment, e.g. auth for fetch
+}
+
+// Conflict lists the conflicted files of one skill.
+type Conflict struct {
+	Skill string   `json:"skill"`
+	Files []string `json:"files"`
+}
+
+// PullResult describes the outcome of SyncPull and ContinuePull. When
+// Conflicts is non-empty the pull stopped at Stage and needs resolution.
+type PullResult struct {
+	UpdateInfo
+	Stashed   bool
+	Stage     string
+	Conflicts []Conflict
+}
+
+// Clean reports whether the pull finished without open conflicts.
+func (r *PullResult) Clean() bool {
+	return len(r.Conflicts) == 0
+}
+
+type pullState struct {
+	Before   string `json:"before"`
+	Strategy string `json:"strategy"`
+	Stash    string `json:"stash,omitempty"`   // autostash commit, if any
+	Popping  bool   `json:"popping,omitempty"` // pulled; restoring the stash
+}
+
+// SyncPull brings the current branch up to date with its upstream without
+// refusing on local changes: uncommitted work is stashed, the remote is
+// rebased onto (or merged), and the stash is restored. On conflict it
+// stops and reports the conflicting skills; resolve them with ResolveSkill
+// and finish with ContinuePull, or roll back with AbortPull.
+func SyncPull(repoPath string, opts PullOptions) (*PullResult, error) {
+	if InPullProgress(repoPath) {
+		return nil, fmt.Errorf("a previous pull is still in progress; continue or abort it first")
+	}
+	strategy := opts.Strategy
+	if strategy == "" {
+		strategy = StrategyRebase
+	}
+	if strategy != StrategyRebase && strategy != StrategyMerge {
+		return nil, fmt.Errorf("unknown pull strategy %q (use rebase or merge)", strategy)
+	}
+
+	before, err := GetCurrentHash(repoPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if out, err := runGitEnv(repoPath, opts.Env, "fetch", "--quiet"); err != nil {
+		return nil, fmt.Errorf("git fetch failed: %s", out)
+	}
+
+	st := &pullState{Before: before, Strategy: strategy}
+	dirty, err := IsDirty(repoPath)
+	if err != nil {
+		return nil, err
+	}
+	if dirty {
+		if out, err := runGitEnv(repoPath, nil, "stash", "push", "--include-untracked", "-m", autostashMessage); err != nil {
+			return nil, fmt.Errorf("failed to stash local changes: %s", out)
+		}
+		st.Stash, _ = runGitEnv(repoPath, nil, "rev-parse", "refs/stash")
+	}
+	if err := writePullState(repoPath, st); err != nil {
+		return nil, err
+	}
+
+	var out string
+	if strategy == StrategyRebase {
+		out, err = runGitEnv(repoPath, nil, "rebase", "@{u}")
+	} else {
+		out, err = runGitEnv(repoPath, nil, "merge", "--no-edit", "@{u}")
+	}
+	if err != nil {
+		conflicts, _ := conflictedSkills(repoPath)
+		if len(conflicts) > 0 {
+			return &PullResult{Stashed: st.Stash != "", Stage: strategy, Conflicts: conflicts}, nil
+		}
+		if abortErr := AbortPull(repoPath); abortErr != nil {
+			return nil, fmt.Errorf("git %s failed: %s; %w", strategy, out, abortErr)
+		}
+		return nil, fmt.Errorf("git %s failed: %s", strategy, out)
+	}
+
+	return finishPull(repoPath, st)
+}
+
+// ContinuePull resumes a pull stopped on conflicts once every conflicted
+// file has been resolved (ResolveSkill, or edited and staged by hand).
+func ContinuePull(repoPath string) (*PullResult, error) {
+	st, err := readPullState(repoPath)
+	if err != nil {
+		return nil, err
+	}
+	stage := pullStage(repoPath, st)
+
+	conflicts, err := conflictedSkills(repoPath)
+	if err != nil {
+		return nil, err
+	}
+	if len(conflicts) > 0 {
+		return &PullResult{Stashed: st.Stash != "", Stage: stage, Conflicts: conflicts}, nil
+	}
+
+	switch stage {
+	case StageRebase:
+		out, err := runGitEnv(repoPath, []string{"GIT_EDITOR=true"}, "rebase", "--continue")
+		if err != nil {
+			if conflicts, _ := conflictedSkills(repoPath); len(conflicts) > 0 {
+				// The next local commit conflicts too
+				return &PullResult{Stashed: st.Stash != "", Stage: stage, Conflicts: conflicts}, nil
+			}
+			return nil, fmt.Errorf("git rebase --continue failed: %s", out)
+		}
+	case StageMerge:
+		if out, err := runGitEnv(repoPath, nil, "commit", "--no-edit"); err != nil {
+			return nil, fmt.Errorf("git commit failed: %s", out)
+		}
+	case StageStash:
+		// Resolved stash: leave the changes unstaged, as a clean pop would
+		runGitEnv(repoPath, nil, "reset", "--quiet") //nolint:errcheck
+		if ref := stashRef(repoPath, st.Stash); ref != "" {
+			runGitEnv(repoPath, nil, "stash", "drop", "--quiet", ref) //nolint:errcheck
+		}
+		st.Stash = ""
+	}
+
+	return finishPull(repoPath, st)
+}
+
+// AbortPull rolls back an interrupted pull: the rebase or merge is
+// aborted and auto-stashed local changes are restored. If the conflict
+// happened while restoring the stash, the pulled commits are kept and the
+// local changes stay in the stash.
+func AbortPull(repoPath string) error {
+	st, err := readPullState(repoPath)
+	if err != nil {
+		return err
+	}
+	defer clearPullState(repoPath)
+
+	switch pullStage(repoPath, st) {
+	case StageRebase:
+		if out, err := runGitEnv(repoPath, nil, "rebase", "--abort"); err != nil {
+			return fmt.Errorf("git rebase --abort failed: %s", out)
+		}
+	case StageMerge:
+		if out, err := runGitEnv(repoPath, nil, "merge", "--abort"); err != nil {
+			return fmt.Errorf("git merge --abort failed: %s", out)
+		}
+	case StageStash:
+		if out, err := runGitEnv(repoPath, nil, "reset", "--hard", "--quiet"); err != nil {
+			return fmt.Errorf("git reset failed: %s", out)
+		}
+		return nil
+	}
+
+	if ref := stashRef(repoPath, st.Stash); ref != "" {
+		if out, err := runGitEnv(repoPath, nil, "stash", "pop", "--quiet", ref); err != nil {
+			return fmt.Errorf("failed to restore local changes (kept in %s): %s", ref, out)
+		}
+	}
+	return nil
+}
+
+// InPullProgress reports whether a pull started by SyncPull is waiting
+// for conflict resolution.
+func InPullProgress(repoPath string) bool {
+	_, err := os.Stat(gitPath(repoPath, pullStateFile))
+	return err == nil
+}
+
+// PullConflicts returns the open conflicts and stage of an interrupted pull.
+func PullConflicts(repoPath string) (string, []Conflict, error) {
+	st, err := readPullState(repoPath)
+	if err != nil {
+		return "", nil, err
+	}
+	conflicts, err := conflictedSkills(repoPath)
+	return pullStage(repoPath, st), conflicts, err
+}
+
+// ResolveSkill resolves every conflicted file of skill by taking one side:
+// SideOurs keeps the local version, SideTheirs takes the remote version.
+func ResolveSkill(repoPath, skill, side string) error {
+	st, err := readPullState(repoPath)
+	if err != nil {
+		return err
+	}
+	if side != SideOurs && side != SideTheirs {
+		return fmt.Errorf("unknown resolution %q (use ours or theirs)", side)
+	}
+
+	conflicts, err := conflictedSkills(repoPath)
+	if err != nil {
+		return err
+	}
+	var files []string
+	for _, c := range conflicts {
+		if c.Skill == skill {
+			files = c.Files
+		}
+	}
+	if len(files) == 0 {
+		return fmt.Errorf("skill '%s' has no conflicts", skill)
+	}
+
+	// git's --ours is the branch being updated, which is the remote side
+	// while rebasing or restoring the stash, and the local side in a merge.
+	flag := "--theirs"
+	if (side == SideOurs) == (pullStage(repoPath, st) == StageMerge) {
+		flag = "--ours"
+	}
+
+	for _, f := range files {
+		if _, err := runGitEnv(repoPath, nil, "checkout", flag, "--", f); err != nil {
+			// The chosen side deleted the file
+			if out, err := runGitEnv(repoPath, nil, "rm", "--quiet", "--", f); err != nil {
+				return fmt.Errorf("failed to resolve %s: %s", f, out)
+			}
+			continue
+		}
+		if out, err := runGitEnv(repoPath, nil, "add", "--", f); err != nil {
+			return fmt.Errorf("failed to resolve %s: %s", f, out)
+		}
+	}
+	return nil
+}
+
+// finishPull restores the autostash and collects the pulled commits.
+func finishPull(repoPath string, st *pullState) (*PullResult, error) {
+	res := &PullResult{Stashed: st.Stash != ""}
+
+	if ref := stashRef(repoPath, st.Stash); ref != "" {
+		st.Popping = true
+		if err := writePullState(repoPath, st); err != nil {
+			return nil, err
+		}
+		out, err := runGitEnv(repoPath, nil, "stash", "pop", "--quiet", ref)
+		if err != nil {
+			conflicts, _ := conflictedSkills(repoPath)
+			if len(conflicts) == 0 {
+				clearPullState(repoPath)
+				return nil, fmt.Errorf("pulled, but failed to restore local changes (kept in %s): %s", ref, out)
+			}
+			res.Stage = StageStash
+			res.Conflicts = conflicts
+			return res, nil
+		}
+	}
+	clearPullState(repoPath)
+
+	after, err := GetCurrentHash(repoPath)
+	if err != nil {
+		return nil, err
+	}
+	res.BeforeHash = st.Before
+	res.AfterHash = after
+	if st.Before == after {
+		res.UpToDate = true
+		return res, nil
+	}
+	res.Commits, _ = GetCommitsBetween(repoPath, st.Before, after)
+	res.Stats, _ = GetDiffStats(repoPath, st.Before, after)
+	return res, nil
+}
+
+// pullStage tells which step an interrupted pull stopped at.
+func pullStage(repoPath string, st *pullState) string {
+	if exists(gitPath(repoPath, "rebase-merge")) || exists(gitPath(repoPath, "rebase-apply")) {
+		return StageRebase
+	}
+	if exists(gitPath(repoPath, "MERGE_HEAD")) {
+		return StageMerge
+	}
+	if st.Popping {
+		return StageStash
+	}
+	return ""
+}
+
+// conflictedSkills groups unmerged files by the skill that contains them.
+func conflictedSkills(repoPath string) ([]Conflict, error) {
+	out, err := runGitEnv(repoPath, nil, "diff", "--name-only", "--diff-filter=U")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list conflicts: %s", out)
+	}
+
+	bySkill := map[string][]string{}
+	for _, f := range strings.Split(out, "\n") {
+		if f = strings.TrimSpace(f); f != "" {
+			skill := skillOfPath(repoPath, f)
+			bySkill[skill] = append(bySkill[skill], f)
+		}
+	}
+
+	conflicts := make([]Conflict, 0, len(bySkill))
+	for skill, files := range bySkill {
+		sort.Strings(files)
+		conflicts = append(conflicts, Conflict{Skill: skill, Files: files})
+	}
+	sort.Slice(conflicts, func(i, j int) bool { return conflicts[i].Skill < conflicts[j].Skill })
+	return conflicts, nil
+}
+
+// skillOfPath returns the skill directory (relative to the repo) that
+// holds file: the nearest parent with a SKILL.md, else its top-level dir.
+func skillOfPath(repoPath, file string) string {
+	dir := path.Dir(file)
+	for dir != "." && dir != "/" {
+		if exists(filepath.Join(repoPath, filepath.FromSlash(dir), "SKILL.md")) {
+			return dir
+		}
+		dir = path.Dir(dir)
+	}
+	if i := strings.Index(file, "/"); i >= 0 {
+		return file[:i]
+	}
+	return file
+}
+
+// stashRef returns the stash@{n} reference of the stash commit, or "" if
+// it is no longer in the stash list.
+func stashRef(repoPath, commit string) string {
+	if commit == "" {
+		return ""
+	}
+	out, err := runGitEnv(repoPath, nil, "stash", "list", "--format=%H")
+	if err != nil {
+		return ""
+	}
+	for i, h := range strings.Split(out, "\n") {
+		if strings.TrimSpace(h) == commit {
+			return fmt.Sprintf("stash@{%d}", i)
+		}
+	}
+	return ""
+}
+
+func readPullState(repoPath string) (*pullState, error) {
+	data, err := os.ReadFile(gitPath(repoPath, pullStateFile))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("no pull in progress")
+		}
+		return nil, err
+	}
+	var st pullState
+	if err := json.Unmarshal(data, &st); err != nil {
+		return nil, fmt.Errorf("corrupt pull state: %w", err)
+	}
+	return &st, nil
+}
+
+func writePullState(repoPath string, st *pullState) error {
+	data, err := json.Marshal(st)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(gitPath(repoPath, pullStateFile), data, 0644)
+}
+
+func clearPullState(repoPath string) {
+	os.Remove(gitPath(repoPath, pullStateFile))
+}
+
+// gitPath resolves a path inside the repository's git directory.
+func gitPath(repoPath, name string) string {
+	out, err := runGitEnv(repoPath, nil, "rev-parse", "--git-path", name)
+	if err != nil {
+		return filepath.Join(repoPath, ".git", name)
+	}
+	if !filepath.IsAbs(out) {
+		out = filepath.Join(repoPath, out)
+	}
+	return out
+}
+
+func exists(p string) bool {
+	_, err := os.Stat(p)
+	return err == nil
+}
+
+// runGitEnv runs git in repoPath and returns its trimmed combined output.
+func runGitEnv(repoPath string, extraEnv []string, args ...string) (string, error) {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = repoPath
+	if len(extraEnv) > 0 {
+		cmd.Env = append(os.Environ(), extraEnv...)
+	}
+	out, err := cmd.CombinedOutput()
+	return strings.TrimSpace(string(out)), err
+}
@@ -0,0 +1,211 @@
+package git
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// setupPullRepos returns a local clone and a second clone ("other laptop")
+// of a remote seeded with one skill.
+func setupPullRepos(t *testing.T) (local, other string) {
+	t.Helper()
+	remote := createBareRemoteWithBranch(t, "main", map[string]string{
+		"alpha/SKILL.md": "# Alpha v1\n",
+		"beta/SKILL.md":  "# Beta v1\n",
+	})
+	local = cloneRepo(t, remote)
+	other = filepath.Join(t.TempDir(), "other")
+	runGit(t, "", "clone", remote, other)
+	for _, dir := range []string{local, other} {
+		runGit(t, dir, "config", "user.email", "test@test.com")
+		runGit(t, dir, "config", "user.name", "test")
+	}
+	return local, other
+}
+
+func commitAndPush(t *testing.T, repo, rel, content string) {
+	t.Helper()
+	writeTestFile(t, repo, rel, content)
+	runGit(t, repo, "add", "-A")
+	runGit(t, repo, "commit", "-m", "edit "+rel)
+	runGit(t, repo, "push", "origin", "HEAD")
+}
+
+func writeTestFile(t *testing.T, repo, rel, content string) {
+	t.Helper()
+	if err := os.WriteFile(filepath.Join(repo, filepath.FromSlash(rel)), []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func readTestFile(t *testing.T, repo, rel string) string {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join(repo, filepath.FromSlash(rel)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
+
+func TestSyncPull_StashesLocalChanges(t *testing.T) {
+	local, other := setupPullRepos(t)
+	commitAndPush(t, other, "alpha/SKILL.md", "# Alpha v2\n")
+	writeTestFile(t, local, "beta/SKILL.md", "# Beta local\n")
+
+	res, err := SyncPull(local, PullOptions{})
+	if err != nil {
+		t.Fatalf("SyncPull() error: %v", err)
+	}
+	if !res.Clean() || !res.Stashed || res.UpToDate {
+		t.Fatalf("result = %+v, want clean stashed update", res)
+	}
+	if len(res.Commits) != 1 {
+		t.Errorf("Commits = %v, want 1", res.Commits)
+	}
+	if got := readTestFile(t, local, "alpha/SKILL.md"); got != "# Alpha v2\n" {
+		t.Errorf("alpha = %q, want remote change", got)
+	}
+	if got := readTestFile(t, local, "beta/SKILL.md"); got != "# Beta local\n" {
+		t.Errorf("beta = %q, want local change restored", got)
+	}
+	if out := runGit(t, local, "stash", "list"); out != "" {
+		t.Errorf("stash list = %q, want empty", out)
+	}
+	if InPullProgress(local) {
+		t.Error("pull should not be in progress after a clean pull")
+	}
+}
+
+func TestSyncPull_RebaseConflictTakeTheirs(t *testing.T) {
+	local, other := setupPullRepos(t)
+	commitAndPush(t, other, "alpha/SKILL.md", "# Alpha remote\n")
+	writeTestFile(t, local, "alpha/SKILL.md", "# Alpha local\n")
+	runGit(t, local, "commit", "-am", "local edit")
+
+	res, err := SyncPull(local, PullOptions{})
+	if err != nil {
+		t.Fatalf("SyncPull() error: %v", err)
+	}
+	if res.Clean() || res.Stage != StageRebase {
+		t.Fatalf("result = %+v, want rebase conflict", res)
+	}
+	if len(res.Conflicts) != 1 || res.Conflicts[0].Skill != "alpha" {
+		t.Fatalf("Conflicts = %+v, want alpha", res.Conflicts)
+	}
+
+	if err := ResolveSkill(local, "alpha", SideTheirs); err != nil {
+		t.Fatalf("ResolveSkill() error: %v", err)
+	}
+	res, err = ContinuePull(local)
+	if err != nil {
+		t.Fatalf("ContinuePull() error: %v", err)
+	}
+	if !res.Clean() {
+		t.Fatalf("ContinuePull() conflicts = %+v", res.Conflicts)
+	}
+	if got := readTestFile(t, local, "alpha/SKILL.md"); got != "# Alpha remote\n" {
+		t.Errorf("alpha = %q, want remote version", got)
+	}
+}
+
+func TestSyncPull_MergeConflictKeepOurs(t *testing.T) {
+	local, other := setupPullRepos(t)
+	commitAndPush(t, other, "alpha/SKILL.md", "# Alpha remote\n")
+	writeTestFile(t, local, "alpha/SKILL.md", "# Alpha local\n")
+	runGit(t, local, "commit", "-am", "local edit")
+
+	res, err := SyncPull(local, PullOptions{Strategy: StrategyMerge})
+	if err != nil {
+		t.Fatalf("SyncPull() error: %v", err)
+	}
+	if res.Stage != StageMerge {
+		t.Fatalf("Stage = %q, want merge", res.Stage)
+	}
+	if err := ResolveSkill(local, "alpha", SideOurs); err != nil {
+		t.Fatal(err)
+	}
+	if res, err = ContinuePull(local); err != nil || !res.Clean() {
+		t.Fatalf("ContinuePull() = (%+v, %v)", res, err)
+	}
+	if got := readTestFile(t, local, "alpha/SKILL.md"); got != "# Alpha local\n" {
+		t.Errorf("alpha = %q, want local version", got)
+	}
+}
+
+func TestSyncPull_StashConflictKeepOurs(t *testing.T) {
+	local, other := setupPullRepos(t)
+	commitAndPush(t, other, "alpha/SKILL.md", "# Alpha remote\n")
+	writeTestFile(t, local, "alpha/SKILL.md", "# Alpha uncommitted\n")
+
+	res, err := SyncPull(local, PullOptions{})
+	if err != nil {
+		t.Fatalf("SyncPull() error: %v", err)
+	}
+	if res.Stage != StageStash || len(res.Conflicts) != 1 {
+		t.Fatalf("result = %+v, want stash conflict on alpha", res)
+	}
+
+	if err := ResolveSkill(local, "alpha", SideOurs); err != nil {
+		t.Fatal(err)
+	}
+	if res, err = ContinuePull(local); err != nil || !res.Clean() {
+		t.Fatalf("ContinuePull() = (%+v, %v)", res, err)
+	}
+	if got := readTestFile(t, local, "alpha/SKILL.md"); got != "# Alpha uncommitted\n" {
+		t.Errorf("alpha = %q, want local uncommitted version", got)
+	}
+	if out := runGit(t, local, "stash", "list"); out != "" {
+		t.Errorf("stash list = %q, want autostash dropped", out)
+	}
+	if out := runGit(t, local, "diff", "--cached", "--name-only"); out != "" {
+		t.Errorf("staged = %q, want local changes left unstaged", out)
+	}
+}
+
+func TestAbortPull_RestoresLocalState(t *testing.T) {
+	local, other := setupPullRepos(t)
+	commitAndPush(t, other, "alpha/SKILL.md", "# Alpha remote\n")
+	writeTestFile(t, local, "alpha/SKILL.md", "# Alpha local\n")
+	runGit(t, local, "commit", "-am", "local edit")
+	writeTestFile(t, local, "beta/SKILL.md", "# Beta wip\n")
+	head := runGit(t, local, "rev-parse", "HEAD")
+
+	res, err := SyncPull(local, PullOptions{})
+	if err != nil || res.Clean() {
+		t.Fatalf("SyncPull() = (%+v, %v), want conflict", res, err)
+	}
+	if err := AbortPull(local); err != nil {
+		t.Fatalf("AbortPull() error: %v", err)
+	}
+
+	if got := runGit(t, local, "rev-parse", "HEAD"); got != head {
+		t.Errorf("HEAD = %s, want %s", got, head)
+	}
+	if got := readTestFile(t, local, "beta/SKILL.md"); got != "# Beta wip\n" {
+		t.Errorf("beta = %q, want uncommitted change restored", got)
+	}
+	if InPullProgress(local) {
+		t.Error("pull should not be in progress after abort")
+	}
+}
+
+func TestSyncPull_FailureRestoresStash(t *testing.T) {
+	local, _ := setupPullRepos(t)
+	writeTestFile(t, local, "beta/SKILL.md", "# Beta wip\n")
+	// No upstream: the rebase fails before it starts, without conflicts
+	runGit(t, local, "branch", "--unset-upstream")
+
+	if _, err := SyncPull(local, PullOptions{}); err == nil {
+		t.Fatal("SyncPull() should fail without an upstream")
+	}
+	if got := readTestFile(t, local, "beta/SKILL.md"); got != "# Beta wip\n" {
+		t.Errorf("beta = %q, want uncommitted change restored", got)
+	}
+	if out := runGit(t, local, "stash", "list"); out != "" {
+		t.Errorf("stash list = %q, want the autostash popped", out)
+	}
+	if InPullProgress(local) {
+		t.Error("pull should not be in progress after a failed pull")
+	}
+}
@@ -0,0 +1,304 @@
+package git
+
+import (
+	"fmt"
+	"net/url"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"skillshare/internal/credential"
+	"skillshare/internal/utils"
+)
+
+// Change kinds reported in SkillChange.Kind.
+const (
+	ChangeAdded   = "added"
+	ChangeUpdated = "updated"
+	ChangeRemoved = "removed"
+)
+
+// SkillChange lists the uncommitted files of one skill. Skill is empty for
+// files that do not belong to any skill (README, .gitignore, ...).
+type SkillChange struct {
+	Skill string
+	Kind  string
+	Files []string
+}
+
+// ChangedSkills groups the uncommitted changes in repoPath (staged,
+// unstaged and untracked) by the skill that contains them. A skill counts
+// as added when its SKILL.md is not in HEAD and as removed when its
+// SKILL.md is gone from the working tree.
+func ChangedSkills(repoPath string) ([]SkillChange, error) {
+	// Not runGitEnv: trimming would eat the leading status column.
+	cmd := exec.Command("git", "status", "--porcelain", "-z", "-uall", "--no-renames")
+	cmd.Dir = repoPath
+	raw, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("git status failed: %w", err)
+	}
+	out := string(raw)
+	if out == "" {
+		return nil, nil
+	}
+
+	inHead := headSkillDirs(repoPath)
+	groups := map[string]*SkillChange{}
+	for _, entry := range strings.Split(out, "\x00") {
+		if len(entry) < 4 {
+			continue
+		}
+		file := entry[3:]
+		skill := changedSkillOf(repoPath, file, inHead)
+		g, ok := groups[skill]
+		if !ok {
+			g = &SkillChange{Skill: skill, Kind: ChangeUpdated}
+			if skill != "" {
+				onDisk := exists(filepath.Join(repoPath, filepath.FromSlash(skill), "SKILL.md"))
+				switch {
+				case !inHead[skill] && onDisk:
+					g.Kind = ChangeAdded
+				case inHead[skill] && !onDisk:
+					g.Kind = ChangeRemoved
+				}
+			}
+			groups[skill] = g
+		}
+		g.Files = append(g.Files, file)
+	}
+
+	changes := make([]SkillChange, 0, len(groups))
+	for _, g := range groups {
+		sort.Strings(g.Files)
+		changes = append(changes, *g)
+	}
+	// Skills first in name order, loose repository files last.
+	sort.Slice(changes, func(i, j int) bool {
+		if (changes[i].Skill == "") != (changes[j].Skill == "") {
+			return changes[j].Skill == ""
+		}
+		return changes[i].Skill < changes[j].Skill
+	})
+	return changes, nil
+}
+
+// CommitSkillChange commits only the files of c with a generated subject
+// such as "Add pdf (2 files, +40)". body, when set, becomes the commit
+// body. It returns the subject line.
+func CommitSkillChange(repoPath string, c SkillChange, body string) (string, error) {
+	args := append([]string{"add", "-A", "--"}, c.Files...)
+	if out, err := runGitEnv(repoPath, nil, args...); err != nil {
+		return "", fmt.Errorf("git add failed: %s", out)
+	}
+
+	statArgs := append([]string{"diff", "--cached", "--shortstat", "--"}, c.Files...)
+	statOut, _ := runGitEnv(repoPath, nil, statArgs...)
+	subject := SkillCommitMessage(c, parseDiffStats(statOut))
+
+	args = []string{"commit", "-m", subject}
+	if body != "" {
+		args = append(args, "-m", body)
+	}
+	args = append(args, "--only", "--")
+	args = append(args, c.Files...)
+	if out, err := runGitEnv(repoPath, nil, args...); err != nil {
+		return "", fmt.Errorf("git commit failed: %s", out)
+	}
+	return subject, nil
+}
+
+// CheckBranchName validates name as a new branch name by git's rules
+// (git check-ref-format --branch). Names starting with "-" are rejected
+// so they can't be taken for an option.
+func CheckBranchName(name string) error {
+	if strings.HasPrefix(name, "-") {
+		return fmt.Errorf("invalid branch name %q: must not start with '-'", name)
+	}
+	out, err := exec.Command("git", "check-ref-format", "--branch", name).Output()
+	// --branch expands @{-N}; only accept names that are taken literally
+	if err != nil || strings.TrimSpace(string(out)) != name {
+		return fmt.Errorf("invalid branch name %q", name)
+	}
+	return nil
+}
+
+// ResetBranch moves the current branch back to rev and unstages
+// everything, keeping the working tree: commits made since rev become
+// uncommitted changes again. An empty rev means the branch was unborn.
+func ResetBranch(repoPath, rev string) error {
+	if rev == "" {
+		if out, err := runGitEnv(repoPath, nil, "update-ref", "-d", "HEAD"); err != nil {
+			return fmt.Errorf("git update-ref failed: %s", out)
+		}
+		if out, err := runGitEnv(repoPath, nil, "reset", "--quiet"); err != nil {
+			return fmt.Errorf("git reset failed: %s", out)
+		}
+		return nil
+	}
+	if out, err := runGitEnv(repoPath, nil, "reset", "--quiet", "--mixed", rev); err != nil {
+		return fmt.Errorf("git reset failed: %s", out)
+	}
+	return nil
+}
+
+// StartBranch creates branch name at HEAD and checks it out, carrying
+// uncommitted changes over to it.
+func StartBranch(repoPath, name string) error {
+	if out, err := runGitEnv(repoPath, nil, "checkout", "--quiet", "-b", name); err != nil {
+		return fmt.Errorf("git checkout failed: %s", out)
+	}
+	return nil
+}
+
+// CheckoutBack checks out branch again, or detaches at rev when branch is
+// "" (HEAD was detached).
+func CheckoutBack(repoPath, branch, rev string) error {
+	args := []string{"checkout", "--quiet", branch}
+	if branch == "" {
+		args = []string{"checkout", "--quiet", "--detach", rev}
+	}
+	if out, err := runGitEnv(repoPath, nil, args...); err != nil {
+		return fmt.Errorf("git checkout failed: %s", out)
+	}
+	return nil
+}
+
+// DeleteBranch deletes local branch name, merged or not.
+func DeleteBranch(repoPath, name string) error {
+	if out, err := runGitEnv(repoPath, nil, "branch", "--quiet", "-D", name); err != nil {
+		return fmt.Errorf("git branch failed: %s", out)
+	}
+	return nil
+}
+
+// SkillCommitMessage builds the subject line for a per-skill commit.
+func SkillCommitMessage(c SkillChange, stats DiffStats) string {
+	var subject string
+	switch {
+	case c.Skill == "":
+		subject = "Update repository files"
+	case c.Kind == ChangeAdded:
+		subject = "Add " + c.Skill
+	case c.Kind == ChangeRemoved:
+		subject = "Remove " + c.Skill
+	default:
+		subject = "Update " + c.Skill
+	}
+
+	if stats.FilesChanged == 0 {
+		return subject
+	}
+	noun := "files"
+	if stats.FilesChanged == 1 {
+		noun = "file"
+	}
+	detail := fmt.Sprintf("%d %s", stats.FilesChanged, noun)
+	if stats.Insertions > 0 {
+		detail += fmt.Sprintf(", +%d", stats.Insertions)
+	}
+	if stats.Deletions > 0 {
+		if stats.Insertions > 0 {
+			detail += fmt.Sprintf(" -%d", stats.Deletions)
+		} else {
+			detail += fmt.Sprintf(", -%d", stats.Deletions)
+		}
+	}
+	return fmt.Sprintf("%s (%s)", subject, detail)
+}
+
+// GetRemoteURL returns the URL of the origin remote, or "".
+func GetRemoteURL(repoPath string) string {
+	return getRemoteURL(repoPath)
+}
+
+// CompareURL returns the web page for opening a pull/merge request from
+// branch into base on the host of remoteURL. It supports GitHub, GitLab and
+// Gitea/Forgejo (including Codeberg) and returns "" for other hosts.
+func CompareURL(remoteURL, base, branch string) string {
+	web := webURL(remoteURL)
+	if web == "" || branch == "" {
+		return ""
+	}
+	switch credential.DetectPlatform(remoteURL) {
+	case utils.PlatformGitHub:
+		if base == "" {
+			return web + "/pull/new/" + branch
+		}
+		return fmt.Sprintf("%s/compare/%s...%s?expand=1", web, base, branch)
+	case utils.PlatformGitLab:
+		q := url.Values{}
+		q.Set("merge_request[source_branch]", branch)
+		if base != "" {
+			q.Set("merge_request[target_branch]", base)
+		}
+		return web + "/-/merge_requests/new?" + q.Encode()
+	case utils.PlatformGitea:
+		if base == "" {
+			return web + "/compare/" + branch
+		}
+		return fmt.Sprintf("%s/compare/%s...%s", web, base, branch)
+	}
+	return ""
+}
+
+// webURL converts an SSH or HTTPS remote URL into the repository's https
+// page, dropping credentials, ports of SSH remotes and the .git suffix.
+func webURL(remoteURL string) string {
+	s := strings.TrimSpace(remoteURL)
+	var host, repo string
+	if strings.Contains(s, "@") && strings.Contains(s, ":") && !strings.Contains(s, "://") {
+		// scp-like: git@host:owner/repo.git
+		rest := s[strings.Index(s, "@")+1:]
+		colon := strings.Index(rest, ":")
+		host, repo = rest[:colon], rest[colon+1:]
+	} else {
+		u, err := url.Parse(s)
+		if err != nil || u.Host == "" {
+			return ""
+		}
+		host = u.Host
+		if u.Scheme == "ssh" || u.Scheme == "git" {
+			host = u.Hostname()
+		} else if u.Scheme != "http" && u.Scheme != "https" {
+			return ""
+		}
+		repo = u.Path
+	}
+	repo = strings.TrimSuffix(strings.Trim(repo, "/"), ".git")
+	if host == "" || repo == "" {
+		return ""
+	}
+	return "https://" + host + "/" + repo
+}
+
+// headSkillDirs returns the directories that hold a SKILL.md in HEAD.
+func headSkillDirs(repoPath string) map[string]bool {
+	dirs := map[string]bool{}
+	out, err := runGitEnv(repoPath, nil, "ls-tree", "-r", "--name-only", "-z", "HEAD")
+	if err != nil {
+		return dirs
+	}
+	for _, f := range strings.Split(out, "\x00") {
+		if path.Base(f) == "SKILL.md" && strings.Contains(f, "/") {
+			dirs[path.Dir(f)] = true
+		}
+	}
+	return dirs
+}
+
+// changedSkillOf is skillOfPath that also recognizes skills removed from
+// the working tree, and returns "" for files at the repository root.
+func changedSkillOf(repoPath, file string, inHead map[string]bool) string {
+	for dir := path.Dir(file); dir != "." && dir != "/"; dir = path.Dir(dir) {
+		if inHead[dir] {
+			return dir
+		}
+	}
+	if !strings.Contains(file, "/") {
+		return ""
+	}
+	return skillOfPath(repoPath, file)
+}
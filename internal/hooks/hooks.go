@@ -0,0 +1,218 @@
+// Package hooks runs commands around install, sync and update. User hooks
+// come from the `hooks:` section of config.yaml; skills may also declare
+// post-install and post-update hooks in their frontmatter, which only run
+// once the user has trusted that exact version of the skill. Every hook
+// run is recorded in the operation log with its output.
+package hooks
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"skillshare/internal/config"
+	"skillshare/internal/install"
+	"skillshare/internal/oplog"
+	"skillshare/internal/sync"
+)
+
+// maxLoggedOutput caps the hook output kept for the operation log; the
+// tail is kept, since errors usually come last.
+const maxLoggedOutput = 4096
+
+// Scope describes where the command that fires hooks runs.
+type Scope struct {
+	Mode       string // "global" or "project"
+	ConfigPath string // config of the scope; hook runs are logged next to it
+	SourceDir  string
+}
+
+// Result is the outcome of one hook.
+type Result struct {
+	Event    string
+	Skill    string // RelPath of the skill; empty for hooks not tied to one
+	Declared bool   // declared by the skill rather than config.yaml
+	Command  string
+	Output   string
+	Duration time.Duration
+	Err      error
+	Skipped  string // why a declared hook did not run
+}
+
+// Runner runs the hooks of one command.
+type Runner struct {
+	Hooks config.HooksConfig
+	Scope Scope
+	Trust *Trust       // nil: declared hooks never run
+	Out   io.Writer    // hook output is copied here as it runs; nil discards it
+	Start func(Result) // called before each hook runs
+}
+
+// Run runs the user hooks for an event that is not tied to a skill
+// (pre-install, post-sync), in the source directory. extra is added to
+// the hook environment. It stops at the first failing hook.
+func (r *Runner) Run(event string, extra map[string]string) []Result {
+	var results []Result
+	for _, h := range r.Hooks.For(event) {
+		res := r.exec(Result{Event: event, Command: h.Run}, h, r.Scope.SourceDir, r.env(event, nil, extra))
+		results = append(results, res)
+		if res.Err != nil {
+			break
+		}
+	}
+	return results
+}
+
+// RunSkills runs, for each skill, the user hooks for event and then the
+// hooks the skill declares for it, in the skill directory. Declared hooks
+// of untrusted skills are reported as skipped.
+func (r *Runner) RunSkills(event string, skills []sync.DiscoveredSkill) []Result {
+	var results []Result
+	for _, skill := range skills {
+		env := r.env(event, &skill, nil)
+		for _, h := range r.Hooks.For(event) {
+			results = append(results, r.exec(Result{Event: event, Skill: skill.RelPath, Command: h.Run}, h, skill.SourcePath, env))
+		}
+		results = append(results, r.RunDeclared(event, skill)...)
+	}
+	return results
+}
+
+// RunDeclared runs the hooks skill declares for event, if it is trusted.
+func (r *Runner) RunDeclared(event string, skill sync.DiscoveredSkill) []Result {
+	declared, err := SkillHooks(skill.SourcePath)
+	if err != nil {
+		return []Result{{Event: event, Skill: skill.RelPath, Declared: true, Err: fmt.Errorf("invalid hooks in SKILL.md: %w", err)}}
+	}
+	hooks := declared[event]
+	if len(hooks) == 0 {
+		return nil
+	}
+
+	state := Untrusted
+	if r.Trust != nil {
+		state = r.Trust.State(skill.SourcePath)
+	}
+	var results []Result
+	env := r.env(event, &skill, nil)
+	for _, h := range hooks {
+		res := Result{Event: event, Skill: skill.RelPath, Declared: true, Command: h.Run}
+		if state != Trusted {
+			res.Skipped = state.String()
+			results = append(results, res)
+			continue
+		}
+		res = r.exec(res, h, skill.SourcePath, env)
+		results = append(results, res)
+		if res.Err != nil {
+			break
+		}
+	}
+	return results
+}
+
+// FirstError returns the first failure among results, naming the hook.
+func FirstError(results []Result) error {
+	for _, res := range results {
+		if res.Err != nil {
+			return fmt.Errorf("%s hook %q failed: %w", res.Event, res.Command, res.Err)
+		}
+	}
+	return nil
+}
+
+// env builds the hook environment: event and scope, plus the skill when
+// the hook runs for one.
+func (r *Runner) env(event string, skill *sync.DiscoveredSkill, extra map[string]string) []string {
+	env := []string{
+		"SKILLSHARE_EVENT=" + event,
+		"SKILLSHARE_MODE=" + r.Scope.Mode,
+		"SKILLSHARE_SOURCE_DIR=" + r.Scope.SourceDir,
+		"SKILLSHARE_CONFIG=" + r.Scope.ConfigPath,
+	}
+	if skill != nil {
+		env = append(env,
+			"SKILLSHARE_SKILL_NAME="+filepath.Base(skill.SourcePath),
+			"SKILLSHARE_SKILL_PATH="+skill.SourcePath,
+			"SKILLSHARE_SKILL_REL_PATH="+skill.RelPath,
+		)
+		if meta, err := install.ReadMeta(skill.SourcePath); err == nil && meta != nil {
+			env = append(env, "SKILLSHARE_SKILL_SOURCE="+meta.Source)
+		}
+	}
+	for k, v := range extra {
+		env = append(env, k+"="+v)
+	}
+	return env
+}
+
+func (r *Runner) exec(res Result, h config.Hook, dir string, env []string) Result {
+	if r.Start != nil {
+		r.Start(res)
+	}
+	timeout := r.Hooks.TimeoutFor(h)
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	cmd := shellCommand(ctx, h.Run)
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), env...)
+	cmd.WaitDelay = 2 * time.Second // don't wait on children holding the output pipe
+	var buf bytes.Buffer
+	var w io.Writer = &buf
+	if r.Out != nil {
+		w = io.MultiWriter(&buf, r.Out)
+	}
+	cmd.Stdout, cmd.Stderr = w, w
+
+	start := time.Now()
+	err := cmd.Run()
+	res.Duration = time.Since(start)
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		err = fmt.Errorf("timed out after %s", timeout)
+	}
+	res.Err = err
+	res.Output = strings.TrimSpace(buf.String())
+	r.log(res)
+	return res
+}
+
+// log records a hook run in the operation log of the scope.
+func (r *Runner) log(res Result) {
+	if r.Scope.ConfigPath == "" {
+		return
+	}
+	status := "ok"
+	if res.Err != nil {
+		status = "error"
+	}
+	e := oplog.NewEntry("hook", status, res.Duration)
+	e.Args = map[string]any{
+		"event":   res.Event,
+		"command": res.Command,
+		"mode":    r.Scope.Mode,
+	}
+	if res.Skill != "" {
+		e.Args["skill"] = res.Skill
+	}
+	if res.Declared {
+		e.Args["declared_by_skill"] = true
+	}
+	if res.Output != "" {
+		out := res.Output
+		if len(out) > maxLoggedOutput {
+			out = "..." + out[len(out)-maxLoggedOutput:]
+		}
+		e.Args["output"] = out
+	}
+	if res.Err != nil {
+		e.Message = res.Err.Error()
+	}
+	oplog.Write(r.Scope.ConfigPath, oplog.OpsFile, e) //nolint:errcheck
+}
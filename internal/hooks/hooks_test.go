@@ -0,0 +1,219 @@
+package hooks
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"skillshare/internal/config"
+	"skillshare/internal/oplog"
+	"skillshare/internal/sync"
+)
+
+func writeSkill(t *testing.T, root, name, frontmatter string) sync.DiscoveredSkill {
+	t.Helper()
+	dir := filepath.Join(root, name)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	content := "---\nname: " + name + "\n" + frontmatter + "---\nBody.\n"
+	if err := os.WriteFile(filepath.Join(dir, "SKILL.md"), []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return sync.DiscoveredSkill{SourcePath: dir, RelPath: name, FlatName: name}
+}
+
+func newRunner(t *testing.T, source string, hooks config.HooksConfig) *Runner {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("hook commands use sh")
+	}
+	trust, err := LoadTrust(filepath.Join(t.TempDir(), TrustFile))
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfgDir := filepath.Join(t.TempDir(), ".skillshare")
+	return &Runner{
+		Hooks: hooks,
+		Scope: Scope{Mode: "project", ConfigPath: filepath.Join(cfgDir, "config.yaml"), SourceDir: source},
+		Trust: trust,
+	}
+}
+
+func TestRunSkills_UserHookEnv(t *testing.T) {
+	source := t.TempDir()
+	skill := writeSkill(t, source, "pdf", "")
+	r := newRunner(t, source, config.HooksConfig{
+		PostInstall: []config.Hook{{Run: `echo "$SKILLSHARE_EVENT $SKILLSHARE_SKILL_NAME $SKILLSHARE_MODE" && pwd`}},
+	})
+
+	results := r.RunSkills(config.HookPostInstall, []sync.DiscoveredSkill{skill})
+	if len(results) != 1 || results[0].Err != nil {
+		t.Fatalf("results = %+v", results)
+	}
+	lines := strings.Split(results[0].Output, "\n")
+	if lines[0] != "post-install pdf project" {
+		t.Errorf("env line = %q", lines[0])
+	}
+	if got, _ := filepath.EvalSymlinks(lines[1]); got != mustEval(t, skill.SourcePath) {
+		t.Errorf("hook ran in %q, want the skill directory", lines[1])
+	}
+
+	entries := readOps(t, r.Scope.ConfigPath)
+	if len(entries) != 1 || entries[0].Command != "hook" || entries[0].Args["skill"] != "pdf" {
+		t.Fatalf("oplog = %+v", entries)
+	}
+	if out, _ := entries[0].Args["output"].(string); !strings.Contains(out, "post-install pdf") {
+		t.Errorf("logged output = %q", out)
+	}
+}
+
+func TestRunDeclared_RequiresTrust(t *testing.T) {
+	source := t.TempDir()
+	skill := writeSkill(t, source, "setup", "hooks:\n  post-install: echo ran > marker\n")
+	r := newRunner(t, source, config.HooksConfig{})
+	marker := filepath.Join(skill.SourcePath, "marker")
+
+	results := r.RunDeclared(config.HookPostInstall, skill)
+	if len(results) != 1 || results[0].Skipped != "untrusted" {
+		t.Fatalf("untrusted results = %+v", results)
+	}
+	if _, err := os.Stat(marker); err == nil {
+		t.Fatal("untrusted hook must not run")
+	}
+
+	if err := r.Trust.Grant(skill.SourcePath); err != nil {
+		t.Fatal(err)
+	}
+	results = r.RunDeclared(config.HookPostInstall, skill)
+	if len(results) != 1 || results[0].Skipped != "" || results[0].Err != nil {
+		t.Fatalf("trusted results = %+v", results)
+	}
+	if _, err := os.Stat(marker); err != nil {
+		t.Fatal("trusted hook should have run")
+	}
+
+	// The hook's own output counts as a change: trust covers one version.
+	if state := r.Trust.State(skill.SourcePath); state != Changed {
+		t.Errorf("state after change = %v, want changed", state)
+	}
+	if results := r.RunDeclared(config.HookPostUpdate, skill); len(results) != 0 {
+		t.Errorf("no post-update hook declared, got %+v", results)
+	}
+}
+
+func TestRun_StopsAtFailureAndTimesOut(t *testing.T) {
+	source := t.TempDir()
+	r := newRunner(t, source, config.HooksConfig{
+		PreInstall: []config.Hook{
+			{Run: "sleep 5", Timeout: "100ms"},
+			{Run: "echo never"},
+		},
+	})
+	results := r.Run(config.HookPreInstall, map[string]string{"SKILLSHARE_INSTALL_SOURCE": "org/repo"})
+	if len(results) != 1 {
+		t.Fatalf("results = %+v, want to stop after the first failure", results)
+	}
+	err := FirstError(results)
+	if err == nil || !strings.Contains(err.Error(), "timed out") {
+		t.Errorf("FirstError = %v, want timeout", err)
+	}
+}
+
+func TestSkillHooks_Forms(t *testing.T) {
+	source := t.TempDir()
+	skill := writeSkill(t, source, "multi", `hooks:
+  post-install: make setup
+  post-update:
+    - make build
+    - run: make test
+      timeout: 10m
+  post-sync: ignored
+`)
+	got, err := SkillHooks(skill.SourcePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got[config.HookPostInstall]) != 1 || got[config.HookPostInstall][0].Run != "make setup" {
+		t.Errorf("post-install = %+v", got[config.HookPostInstall])
+	}
+	if up := got[config.HookPostUpdate]; len(up) != 2 || up[1].Timeout != "10m" {
+		t.Errorf("post-update = %+v", up)
+	}
+	if _, ok := got[config.HookPostSync]; ok {
+		t.Error("skills cannot declare post-sync hooks")
+	}
+}
+
+func TestSnapshot_Changed(t *testing.T) {
+	source := t.TempDir()
+	writeSkill(t, source, "kept", "")
+	edited := writeSkill(t, source, "edited", "")
+	snap := TakeSnapshot(source)
+
+	writeSkill(t, source, "added", "")
+	os.WriteFile(filepath.Join(edited.SourcePath, "extra.md"), []byte("x"), 0644) //nolint:errcheck
+
+	var names []string
+	for _, s := range snap.Changed(source) {
+		names = append(names, s.RelPath)
+	}
+	if strings.Join(names, ",") != "added,edited" {
+		t.Errorf("changed = %v, want added,edited", names)
+	}
+}
+
+func TestTrust_SaveLoad(t *testing.T) {
+	source := t.TempDir()
+	skill := writeSkill(t, source, "pdf", "")
+	path := filepath.Join(t.TempDir(), TrustFile)
+
+	trust, _ := LoadTrust(path)
+	if err := trust.Grant(skill.SourcePath); err != nil {
+		t.Fatal(err)
+	}
+	if err := trust.Save(); err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := LoadTrust(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if loaded.State(skill.SourcePath) != Trusted {
+		t.Error("trust should survive a reload")
+	}
+	if !loaded.Revoke(skill.SourcePath) || loaded.State(skill.SourcePath) != Untrusted {
+		t.Error("Revoke should untrust the skill")
+	}
+}
+
+func mustEval(t *testing.T, path string) string {
+	t.Helper()
+	p, err := filepath.EvalSymlinks(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return p
+}
+
+func readOps(t *testing.T, configPath string) []oplog.Entry {
+	t.Helper()
+	f, err := os.Open(filepath.Join(oplog.LogDir(configPath), oplog.OpsFile))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	var entries []oplog.Entry
+	sc := bufio.NewScanner(f)
+	for sc.Scan() {
+		var e oplog.Entry
+		if err := json.Unmarshal(sc.Bytes(), &e); err == nil {
+			entries = append(entries, e)
+		}
+	}
+	return entries
+}
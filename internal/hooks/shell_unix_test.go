@@ -0,0 +1,37 @@
+//go:build !windows
+
+package hooks
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"skillshare/internal/config"
+)
+
+func TestRun_TimeoutKillsChildren(t *testing.T) {
+	source := t.TempDir()
+	marker := filepath.Join(t.TempDir(), "marker")
+	r := newRunner(t, source, config.HooksConfig{
+		PostSync: []config.Hook{
+			{Run: "(sleep 1; touch " + marker + ") & wait", Timeout: "200ms"},
+		},
+	})
+
+	start := time.Now()
+	results := r.Run(config.HookPostSync, nil)
+	if elapsed := time.Since(start); elapsed > 900*time.Millisecond {
+		t.Errorf("Run took %s, want it to return soon after the timeout", elapsed)
+	}
+	if err := FirstError(results); err == nil || !strings.Contains(err.Error(), "timed out") {
+		t.Fatalf("FirstError = %v, want timeout", err)
+	}
+
+	time.Sleep(1500 * time.Millisecond)
+	if _, err := os.Stat(marker); err == nil {
+		t.Error("child of the timed-out hook kept running")
+	}
+}
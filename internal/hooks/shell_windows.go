@@ -0,0 +1,14 @@
+//go:build windows
+
+package hooks
+
+import (
+	"context"
+	"os/exec"
+)
+
+// shellCommand runs command with cmd.exe. On timeout only cmd.exe is
+// killed; WaitDelay keeps its children from blocking the hook.
+func shellCommand(ctx context.Context, command string) *exec.Cmd {
+	return exec.CommandContext(ctx, "cmd", "/C", command)
+}
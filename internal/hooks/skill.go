@@ -0,0 +1,39 @@
+package hooks
+
+import (
+	"path/filepath"
+
+	"skillshare/internal/config"
+	"skillshare/internal/utils"
+)
+
+// SkillEvents are the events a skill may declare hooks for.
+var SkillEvents = []string{config.HookPostInstall, config.HookPostUpdate}
+
+// SkillHooks returns the hooks a skill declares in its SKILL.md
+// frontmatter, by event:
+//
+//	hooks:
+//	  post-install: pip install -r scripts/requirements.txt
+//	  post-update:
+//	    - run: make -C scripts
+//	      timeout: 10m
+//
+// Events other than SkillEvents and empty commands are dropped.
+func SkillHooks(skillDir string) (map[string][]config.Hook, error) {
+	var fm struct {
+		Hooks map[string]config.HookList `yaml:"hooks"`
+	}
+	if err := utils.UnmarshalFrontmatter(filepath.Join(skillDir, "SKILL.md"), &fm); err != nil {
+		return nil, err
+	}
+	out := map[string][]config.Hook{}
+	for _, event := range SkillEvents {
+		for _, h := range fm.Hooks[event] {
+			if h.Run != "" {
+				out[event] = append(out[event], h)
+			}
+		}
+	}
+	return out, nil
+}
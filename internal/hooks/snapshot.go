@@ -0,0 +1,67 @@
+package hooks
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"skillshare/internal/sync"
+)
+
+// Snapshot stamps every skill in a source directory, so the skills a
+// command installed or changed can be found afterwards. Stamps come from
+// file sizes and modification times only, which keeps taking a snapshot
+// cheap on large sources.
+type Snapshot map[string]string // RelPath → stamp
+
+// TakeSnapshot stamps the skills under sourceDir. A missing or unreadable
+// source yields an empty snapshot.
+func TakeSnapshot(sourceDir string) Snapshot {
+	snap := Snapshot{}
+	skills, err := sync.DiscoverSourceSkills(sourceDir)
+	if err != nil {
+		return snap
+	}
+	for _, skill := range skills {
+		snap[skill.RelPath] = stamp(skill.SourcePath)
+	}
+	return snap
+}
+
+// Changed returns the skills under sourceDir that are new or whose stamp
+// differs from the snapshot.
+func (s Snapshot) Changed(sourceDir string) []sync.DiscoveredSkill {
+	skills, err := sync.DiscoverSourceSkills(sourceDir)
+	if err != nil {
+		return nil
+	}
+	var changed []sync.DiscoveredSkill
+	for _, skill := range skills {
+		if before, ok := s[skill.RelPath]; !ok || before != stamp(skill.SourcePath) {
+			changed = append(changed, skill)
+		}
+	}
+	return changed
+}
+
+func stamp(dir string) string {
+	var files, size, latest int64
+	filepath.Walk(dir, func(_ string, info os.FileInfo, err error) error { //nolint:errcheck
+		if err != nil {
+			return nil
+		}
+		if info.IsDir() {
+			if info.Name() == ".git" {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		files++
+		size += info.Size()
+		if t := info.ModTime().UnixNano(); t > latest {
+			latest = t
+		}
+		return nil
+	})
+	return fmt.Sprintf("%d:%d:%d", files, size, latest)
+}
@@ -0,0 +1,183 @@
+package hooks
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"skillshare/internal/config"
+	"skillshare/internal/install"
+)
+
+// TrustFile is the name of the file recording which skills may run their
+// declared hooks.
+const TrustFile = "hook-trust.json"
+
+// metaFile is install metadata, rewritten on every install; it does not
+// count towards the content a user trusts.
+const metaFile = ".skillshare-meta.json"
+
+// TrustPath returns the trust record. It lives in the user's state
+// directory for project skills too, so trust is never shared through a
+// project repository.
+func TrustPath() string {
+	return filepath.Join(config.StateDir(), TrustFile)
+}
+
+// TrustState says whether a skill's declared hooks may run.
+type TrustState int
+
+const (
+	Untrusted TrustState = iota
+	Trusted
+	Changed // trusted once, but the skill's content changed since
+)
+
+func (s TrustState) String() string {
+	switch s {
+	case Trusted:
+		return "trusted"
+	case Changed:
+		return "changed since trusted"
+	default:
+		return "untrusted"
+	}
+}
+
+// TrustEntry is the trusted content hash of one source.
+type TrustEntry struct {
+	Hash      string    `json:"hash"`
+	TrustedAt time.Time `json:"trustedAt"`
+}
+
+// Trust is the persisted record of trusted skills, keyed by source.
+type Trust struct {
+	path  string
+	Items map[string]TrustEntry `json:"trusted"`
+}
+
+// LoadTrust reads the trust file. A missing file yields an empty record.
+func LoadTrust(path string) (*Trust, error) {
+	t := &Trust{path: path, Items: map[string]TrustEntry{}}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return t, nil
+		}
+		return nil, err
+	}
+	if err := json.Unmarshal(data, t); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	if t.Items == nil {
+		t.Items = map[string]TrustEntry{}
+	}
+	return t, nil
+}
+
+// Save writes the trust file atomically.
+func (t *Trust) Save() error {
+	if err := os.MkdirAll(filepath.Dir(t.path), 0755); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(t, "", "  ")
+	if err != nil {
+		return err
+	}
+	return config.WriteFileAtomic(t.path, data, 0600)
+}
+
+// State reports whether the skill in skillDir is trusted as it is now.
+func (t *Trust) State(skillDir string) TrustState {
+	entry, ok := t.Items[SourceKey(skillDir)]
+	if !ok {
+		return Untrusted
+	}
+	if hash, err := ContentHash(skillDir); err != nil || hash != entry.Hash {
+		return Changed
+	}
+	return Trusted
+}
+
+// Grant trusts the current content of the skill in skillDir.
+func (t *Trust) Grant(skillDir string) error {
+	hash, err := ContentHash(skillDir)
+	if err != nil {
+		return err
+	}
+	t.Items[SourceKey(skillDir)] = TrustEntry{Hash: hash, TrustedAt: time.Now().UTC()}
+	return nil
+}
+
+// Revoke removes the skill's trust. Returns false if it was not trusted.
+func (t *Trust) Revoke(skillDir string) bool {
+	key := SourceKey(skillDir)
+	if _, ok := t.Items[key]; !ok {
+		return false
+	}
+	delete(t.Items, key)
+	return true
+}
+
+// SourceKey identifies a skill in the trust record: the source it was
+// installed from, or its absolute path for skills without install
+// metadata (local or inside a tracked repo).
+func SourceKey(skillDir string) string {
+	if meta, err := install.ReadMeta(skillDir); err == nil && meta != nil && meta.Source != "" {
+		return meta.Source
+	}
+	if abs, err := filepath.Abs(skillDir); err == nil {
+		skillDir = abs
+	}
+	return "path:" + filepath.ToSlash(skillDir)
+}
+
+// ContentHash hashes the files of a skill (sorted paths and contents),
+// skipping .git and the install metadata.
+func ContentHash(skillDir string) (string, error) {
+	var files []string
+	err := filepath.Walk(skillDir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			if info.Name() == ".git" {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if info.Name() == metaFile {
+			return nil
+		}
+		files = append(files, path)
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+	sort.Strings(files)
+
+	h := sha256.New()
+	for _, path := range files {
+		rel, _ := filepath.Rel(skillDir, path)
+		io.WriteString(h, strings.ReplaceAll(rel, "\\", "/")) //nolint:errcheck
+		h.Write([]byte{0})
+		f, err := os.Open(path)
+		if err != nil {
+			return "", err
+		}
+		_, err = io.Copy(h, f)
+		f.Close()
+		if err != nil {
+			return "", err
+		}
+		h.Write([]byte{0})
+	}
+	return fmt.Sprintf("%x", h.Sum(nil)), nil
+}
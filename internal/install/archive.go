@@ -0,0 +1,517 @@
+package install
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"skillshare/internal/credential"
+	"skillshare/internal/network"
+)
+
+const (
+	// archiveTimeout bounds an archive download or check request.
+	archiveTimeout = 5 * time.Minute
+)
+
+// maxArchiveExtract caps the total size of extracted files, hardlink
+// copies included, so a decompression bomb cannot fill the disk. A
+// variable so tests can lower it.
+var maxArchiveExtract int64 = 1 << 30
+
+// archiveFetch describes a downloaded and extracted archive.
+type archiveFetch struct {
+	Version    string // First 12 hex digits of the archive's sha256
+	ETag       string // ETag returned by the server, if any
+	Downloaded int64  // Archive size in bytes
+}
+
+// fetchArchive downloads the archive of source, verifies its pinned
+// checksum and extracts it into destPath. A single top-level directory
+// (as in GitHub release tarballs) is stripped, so destPath is the root
+// that subdirectories are relative to.
+func fetchArchive(source *Source, destPath string) (*archiveFetch, error) {
+	tempDir, err := os.MkdirTemp("", "skillshare-archive-*")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create temp directory: %w", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	archivePath := filepath.Join(tempDir, "archive")
+	f, err := os.Create(archivePath)
+	if err != nil {
+		return nil, err
+	}
+	hash := sha256.New()
+	etag, n, err := downloadArchive(source.ArchiveURL, io.MultiWriter(f, hash), "")
+	f.Close()
+	if err != nil {
+		return nil, err
+	}
+	sum := hex.EncodeToString(hash.Sum(nil))
+	if source.SHA256 != "" && sum != source.SHA256 {
+		return nil, fmt.Errorf("checksum mismatch for %s\n       expected sha256 %s\n       got      sha256 %s",
+			source.ArchiveURL, source.SHA256, sum)
+	}
+
+	extractDir := filepath.Join(tempDir, "extract")
+	if err := extractArchiveFile(archivePath, source.ArchiveURL, extractDir); err != nil {
+		return nil, fmt.Errorf("failed to extract %s: %w", source.ArchiveURL, err)
+	}
+	if err := os.Rename(archiveRoot(extractDir), destPath); err != nil {
+		return nil, err
+	}
+	return &archiveFetch{Version: sum[:12], ETag: etag, Downloaded: n}, nil
+}
+
+// downloadArchive copies the archive at url into w. With ifNoneMatch set,
+// a 304 response returns errNotModified without a body.
+func downloadArchive(url string, w io.Writer, ifNoneMatch string) (etag string, n int64, err error) {
+	if strings.HasPrefix(url, "file://") {
+		f, err := os.Open(strings.TrimPrefix(url, "file://"))
+		if err != nil {
+			return "", 0, fmt.Errorf("failed to open archive: %w", err)
+		}
+		defer f.Close()
+		if w == nil {
+			return "", 0, nil
+		}
+		n, err := io.Copy(w, f)
+		return "", n, err
+	}
+
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return "", 0, err
+	}
+	if ifNoneMatch != "" {
+		req.Header.Set("If-None-Match", ifNoneMatch)
+	}
+	if err := credential.Authorize(req); err != nil {
+		return "", 0, err
+	}
+	resp, err := network.NewClient(archiveTimeout).Do(req)
+	if err != nil {
+		return "", 0, fmt.Errorf("failed to download archive: %w", err)
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusNotModified && ifNoneMatch != "":
+		return ifNoneMatch, 0, errNotModified
+	case resp.StatusCode != http.StatusOK:
+		return "", 0, fmt.Errorf("failed to download archive: %s returned %s", url, resp.Status)
+	}
+	etag = resp.Header.Get("ETag")
+	if w == nil {
+		return etag, 0, nil
+	}
+	n, err = io.Copy(w, resp.Body)
+	if err != nil {
+		return "", n, fmt.Errorf("failed to download archive: %w", err)
+	}
+	return etag, n, nil
+}
+
+var errNotModified = errors.New("not modified")
+
+// archiveRoot returns the directory to treat as the archive root: dir
+// itself, or its only entry when that is a directory.
+func archiveRoot(dir string) string {
+	entries, err := os.ReadDir(dir)
+	if err != nil || len(entries) != 1 || !entries[0].IsDir() {
+		return dir
+	}
+	return filepath.Join(dir, entries[0].Name())
+}
+
+// extractArchiveFile extracts the archive at archivePath, whose format is
+// taken from the extension of url, into destPath.
+func extractArchiveFile(archivePath, url, destPath string) error {
+	if err := os.MkdirAll(destPath, 0755); err != nil {
+		return err
+	}
+	if strings.HasSuffix(url, ".zip") {
+		return extractZip(archivePath, destPath)
+	}
+
+	f, err := os.Open(archivePath)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	var r io.Reader = f
+	if !strings.HasSuffix(url, ".tar") {
+		gz, err := gzip.NewReader(f)
+		if err != nil {
+			return err
+		}
+		defer gz.Close()
+		r = gz
+	}
+	return extractTar(r, destPath)
+}
+
+// extractTar extracts a tar stream into destPath. Entries that would land
+// outside destPath, links pointing outside it, and entries written through a
+// symlink an earlier entry created are rejected.
+func extractTar(r io.Reader, destPath string) error {
+	tr := tar.NewReader(r)
+	var budget int64 = maxArchiveExtract
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		target, err := archiveTarget(destPath, hdr.Name)
+		if err != nil {
+			return err
+		}
+		if target == "" {
+			continue
+		}
+
+		switch hdr.Typeflag {
+		case tar.TypeDir:
+			if err := os.MkdirAll(target, 0755); err != nil {
+				return err
+			}
+		case tar.TypeReg:
+			if err := writeArchiveFile(target, tr, os.FileMode(hdr.Mode), &budget); err != nil {
+				return err
+			}
+		case tar.TypeSymlink:
+			if err := writeArchiveSymlink(destPath, target, hdr.Name, hdr.Linkname); err != nil {
+				return err
+			}
+		case tar.TypeLink:
+			src, err := archiveTarget(destPath, hdr.Linkname)
+			if err != nil || src == "" {
+				return fmt.Errorf("unsafe link %s -> %s", hdr.Name, hdr.Linkname)
+			}
+			// Hardlinks are extracted as copies, charged to the budget
+			// like any other file.
+			if err := copyArchiveFile(src, target, &budget); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// extractZip extracts a zip file into destPath with the same checks as
+// extractTar.
+func extractZip(archivePath, destPath string) error {
+	zr, err := zip.OpenReader(archivePath)
+	if err != nil {
+		return err
+	}
+	defer zr.Close()
+
+	var budget int64 = maxArchiveExtract
+	for _, zf := range zr.File {
+		target, err := archiveTarget(destPath, zf.Name)
+		if err != nil {
+			return err
+		}
+		if target == "" {
+			continue
+		}
+		mode := zf.Mode()
+		if mode.IsDir() {
+			if err := os.MkdirAll(target, 0755); err != nil {
+				return err
+			}
+			continue
+		}
+
+		rc, err := zf.Open()
+		if err != nil {
+			return err
+		}
+		if mode&os.ModeSymlink != 0 {
+			link, err := io.ReadAll(io.LimitReader(rc, 4096))
+			rc.Close()
+			if err != nil {
+				return err
+			}
+			if err := writeArchiveSymlink(destPath, target, zf.Name, string(link)); err != nil {
+				return err
+			}
+			continue
+		}
+		err = writeArchiveFile(target, rc, mode, &budget)
+		rc.Close()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// archiveTarget returns where an archive entry extracts to, "" for the
+// root entry, or an error for names that escape destPath, directly or
+// through a symlink extracted earlier.
+func archiveTarget(destPath, name string) (string, error) {
+	name = strings.ReplaceAll(name, "\\", "/")
+	clean := path.Clean(name)
+	if clean == "." || clean == "/" {
+		return "", nil
+	}
+	if path.IsAbs(clean) || clean == ".." || strings.HasPrefix(clean, "../") || filepath.VolumeName(clean) != "" {
+		return "", fmt.Errorf("unsafe path in archive: %s", name)
+	}
+	target := filepath.Join(destPath, filepath.FromSlash(clean))
+	if err := checkArchiveTarget(destPath, target, name); err != nil {
+		return "", err
+	}
+	return target, nil
+}
+
+// checkArchiveTarget refuses a target when it, or any directory between
+// destPath and it, is a symlink: checking link targets by name alone lets a
+// chain such as d -> . then d/e -> .. point outside destPath.
+func checkArchiveTarget(destPath, target, name string) error {
+	rel, err := filepath.Rel(destPath, target)
+	if err != nil {
+		return fmt.Errorf("unsafe path in archive: %s", name)
+	}
+	current := destPath
+	for _, part := range strings.Split(rel, string(filepath.Separator)) {
+		current = filepath.Join(current, part)
+		info, err := os.Lstat(current)
+		if os.IsNotExist(err) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if info.Mode()&os.ModeSymlink != 0 {
+			return fmt.Errorf("unsafe path in archive: %s (through symlink %s)", name, filepath.ToSlash(strings.TrimPrefix(current, destPath+string(filepath.Separator))))
+		}
+	}
+	return nil
+}
+
+func writeArchiveFile(target string, r io.Reader, mode os.FileMode, budget *int64) error {
+	if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(target, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, mode.Perm()&0755|0644)
+	if err != nil {
+		return err
+	}
+	n, err := io.Copy(f, io.LimitReader(r, *budget+1))
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return err
+	}
+	*budget -= n
+	if *budget < 0 {
+		return fmt.Errorf("archive expands beyond %d MB", maxArchiveExtract>>20)
+	}
+	return nil
+}
+
+// copyArchiveFile copies a regular file extracted earlier to target,
+// charging its size to budget.
+func copyArchiveFile(src, target string, budget *int64) error {
+	f, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil {
+		return err
+	}
+	if !info.Mode().IsRegular() {
+		return fmt.Errorf("unsafe link %s: not a regular file", src)
+	}
+	return writeArchiveFile(target, f, info.Mode(), budget)
+}
+
+// writeArchiveSymlink creates a symlink whose target must be relative and
+// stay inside destPath.
+func writeArchiveSymlink(destPath, target, name, link string) error {
+	resolved := filepath.Join(filepath.Dir(target), filepath.FromSlash(link))
+	rel, err := filepath.Rel(destPath, resolved)
+	if filepath.IsAbs(link) || err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return fmt.Errorf("unsafe symlink in archive: %s -> %s", name, link)
+	}
+	if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+		return err
+	}
+	return os.Symlink(link, target)
+}
+
+// listFiles returns the slash-separated paths of all files under root.
+func listFiles(root string) []string {
+	var paths []string
+	filepath.Walk(root, func(p string, info os.FileInfo, err error) error { //nolint:errcheck
+		if err != nil || info.IsDir() {
+			return nil
+		}
+		if rel, err := filepath.Rel(root, p); err == nil {
+			paths = append(paths, filepath.ToSlash(rel))
+		}
+		return nil
+	})
+	return paths
+}
+
+// DiscoverFromArchive downloads an archive and discovers the skills in it,
+// or in its subdirectory when the source has one.
+func DiscoverFromArchive(source *Source) (*DiscoveryResult, error) {
+	tempDir, err := os.MkdirTemp("", "skillshare-discover-*")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create temp directory: %w", err)
+	}
+
+	repoPath := filepath.Join(tempDir, "repo")
+	fetched, err := fetchArchive(source, repoPath)
+	if err != nil {
+		os.RemoveAll(tempDir)
+		return nil, err
+	}
+
+	var skills []SkillInfo
+	if source.HasSubdir() {
+		resolved, err := resolveSubdirInTree(listFiles(repoPath), source.Subdir, readSkillIgnore(repoPath))
+		if err != nil {
+			os.RemoveAll(tempDir)
+			return nil, err
+		}
+		if resolved != source.Subdir {
+			source.Subdir = resolved
+			source.Name = filepath.Base(resolved)
+		}
+		skills = discoverSkills(filepath.Join(repoPath, resolved), true)
+	} else {
+		skills = discoverSkills(repoPath, true)
+		for i := range skills {
+			if skills[i].Path == "." {
+				skills[i].Name = source.Name
+				break
+			}
+		}
+	}
+
+	return &DiscoveryResult{
+		RepoPath:   tempDir,
+		Skills:     skills,
+		Source:     source,
+		Commit:     fetched.Version,
+		ETag:       fetched.ETag,
+		Downloaded: fetched.Downloaded,
+	}, nil
+}
+
+func installFromArchive(source *Source, destPath string, result *InstallResult, opts InstallOptions) (*InstallResult, error) {
+	if opts.DryRun {
+		result.Action = "would download and extract"
+		return result, nil
+	}
+
+	tempDir, err := os.MkdirTemp("", "skillshare-install-*")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create temp directory: %w", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	root := filepath.Join(tempDir, "repo")
+	fetched, err := fetchArchive(source, root)
+	if err != nil {
+		return nil, err
+	}
+	result.Downloaded = fetched.Downloaded
+
+	srcPath := root
+	if source.HasSubdir() {
+		resolved, err := resolveSubdirInTree(listFiles(root), source.Subdir, readSkillIgnore(root))
+		if err != nil {
+			return nil, err
+		}
+		if resolved != source.Subdir {
+			source.Subdir = resolved
+			source.Name = filepath.Base(resolved)
+			source.Raw = source.WithSubdir(resolved)
+			result.SkillName = source.Name
+		}
+		srcPath = filepath.Join(root, resolved)
+	}
+
+	if err := copyDir(srcPath, destPath); err != nil {
+		return nil, fmt.Errorf("failed to copy skill: %w", err)
+	}
+
+	// Security audit
+	if err := auditInstalledSkill(destPath, result, opts); err != nil {
+		return nil, err
+	}
+
+	// Write metadata
+	meta := NewMetaFromSource(source)
+	meta.Version = fetched.Version
+	meta.ETag = fetched.ETag
+	if err := WriteMeta(destPath, meta); err != nil {
+		result.Warnings = append(result.Warnings, fmt.Sprintf("failed to write metadata: %v", err))
+	}
+
+	// Check for SKILL.md
+	checkSkillFile(destPath, result)
+
+	result.Action = "downloaded and extracted"
+	return result, nil
+}
+
+// CheckArchiveUpdate reports whether the archive a skill was installed from
+// has changed. Servers that send an ETag are asked with If-None-Match;
+// otherwise the archive is downloaded and its checksum compared with the
+// installed version. Sources pinned with #sha256= never change.
+func CheckArchiveUpdate(meta *SkillMeta) (bool, error) {
+	source, err := ParseSource(meta.Source)
+	if err != nil {
+		return false, err
+	}
+	if !source.IsArchive() {
+		return false, fmt.Errorf("not an archive source: %s", meta.Source)
+	}
+	if source.SHA256 != "" {
+		return false, nil
+	}
+
+	if meta.ETag != "" {
+		etag, _, err := downloadArchive(source.ArchiveURL, nil, meta.ETag)
+		if err == errNotModified {
+			return false, nil
+		}
+		if err != nil {
+			return false, err
+		}
+		if etag != "" {
+			return etag != meta.ETag, nil
+		}
+	}
+
+	hash := sha256.New()
+	if _, _, err := downloadArchive(source.ArchiveURL, hash, ""); err != nil {
+		return false, err
+	}
+	return hex.EncodeToString(hash.Sum(nil))[:12] != meta.Version, nil
+}
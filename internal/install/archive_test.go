@@ -0,0 +1,224 @@
+package install
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+type archiveEntry struct {
+	name, body, link string
+}
+
+func writeTarGz(t *testing.T, path string, entries []archiveEntry) {
+	t.Helper()
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	for _, e := range entries {
+		hdr := &tar.Header{Name: e.name, Mode: 0644, Size: int64(len(e.body)), Typeflag: tar.TypeReg}
+		if e.link != "" {
+			hdr = &tar.Header{Name: e.name, Linkname: e.link, Typeflag: tar.TypeSymlink}
+		}
+		if err := tw.WriteHeader(hdr); err != nil {
+			t.Fatal(err)
+		}
+		if e.link == "" {
+			tw.Write([]byte(e.body))
+		}
+	}
+	tw.Close()
+	gz.Close()
+	if err := os.WriteFile(path, buf.Bytes(), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func writeZip(t *testing.T, path string, entries []archiveEntry) {
+	t.Helper()
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for _, e := range entries {
+		w, err := zw.Create(e.name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		w.Write([]byte(e.body))
+	}
+	zw.Close()
+	if err := os.WriteFile(path, buf.Bytes(), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func fileSHA256(t *testing.T, path string) string {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func TestFetchArchive_StripsWrapperAndVerifiesChecksum(t *testing.T) {
+	dir := t.TempDir()
+	archive := filepath.Join(dir, "skills-1.0.tar.gz")
+	writeTarGz(t, archive, []archiveEntry{
+		{name: "skills-1.0/pdf/SKILL.md", body: "---\nname: pdf\n---"},
+		{name: "skills-1.0/pdf/ref.md", link: "../README.md"},
+		{name: "skills-1.0/README.md", body: "readme"},
+	})
+	sum := fileSHA256(t, archive)
+
+	source, err := ParseSource("file://" + archive + "#sha256=" + sum)
+	if err != nil {
+		t.Fatal(err)
+	}
+	dest := filepath.Join(dir, "out")
+	fetched, err := fetchArchive(source, dest)
+	if err != nil {
+		t.Fatalf("fetchArchive: %v", err)
+	}
+	if fetched.Version != sum[:12] {
+		t.Errorf("Version = %q, want %q", fetched.Version, sum[:12])
+	}
+	if _, err := os.Stat(filepath.Join(dest, "pdf", "SKILL.md")); err != nil {
+		t.Errorf("wrapper directory not stripped: %v", err)
+	}
+
+	bad, _ := ParseSource("file://" + archive + "#sha256=" + strings.Repeat("0", 64))
+	if _, err := fetchArchive(bad, filepath.Join(dir, "bad")); err == nil || !strings.Contains(err.Error(), "checksum mismatch") {
+		t.Errorf("err = %v, want checksum mismatch", err)
+	}
+}
+
+func TestExtractArchive_RejectsEscapes(t *testing.T) {
+	tests := []struct {
+		name    string
+		file    string
+		entries []archiveEntry
+		want    string
+	}{
+		{"tar path traversal", "a.tar.gz", []archiveEntry{{name: "../evil", body: "x"}}, "unsafe path"},
+		{"tar absolute path", "a.tar.gz", []archiveEntry{{name: "/etc/evil", body: "x"}}, "unsafe path"},
+		{"tar symlink escape", "a.tar.gz", []archiveEntry{{name: "skill/link", link: "../../outside"}}, "unsafe symlink"},
+		{"tar absolute symlink", "a.tar.gz", []archiveEntry{{name: "skill/link", link: "/etc/passwd"}}, "unsafe symlink"},
+		{"tar symlink chain", "a.tar.gz", []archiveEntry{{name: "d", link: "."}, {name: "d/e", link: ".."}, {name: "e/evil", body: "x"}}, "through symlink"},
+		{"zip path traversal", "a.zip", []archiveEntry{{name: "skill/../../evil", body: "x"}}, "unsafe path"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			archive := filepath.Join(dir, tt.file)
+			if strings.HasSuffix(tt.file, ".zip") {
+				writeZip(t, archive, tt.entries)
+			} else {
+				writeTarGz(t, archive, tt.entries)
+			}
+			err := extractArchiveFile(archive, tt.file, filepath.Join(dir, "out"))
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Fatalf("err = %v, want %q", err, tt.want)
+			}
+			if _, err := os.Stat(filepath.Join(dir, "evil")); err == nil {
+				t.Error("file written outside destination")
+			}
+		})
+	}
+}
+
+func TestExtractTar_HardlinksCountTowardLimit(t *testing.T) {
+	old := maxArchiveExtract
+	maxArchiveExtract = 1 << 20
+	t.Cleanup(func() { maxArchiveExtract = old })
+
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	body := bytes.Repeat([]byte("x"), 256<<10)
+	tw.WriteHeader(&tar.Header{Name: "big", Mode: 0644, Size: int64(len(body)), Typeflag: tar.TypeReg})
+	tw.Write(body)
+	for i := 0; i < 16; i++ {
+		tw.WriteHeader(&tar.Header{Name: "link" + string(rune('a'+i)), Linkname: "big", Typeflag: tar.TypeLink})
+	}
+	tw.Close()
+
+	dest := t.TempDir()
+	err := extractTar(&buf, dest)
+	if err == nil || !strings.Contains(err.Error(), "archive expands beyond") {
+		t.Fatalf("err = %v, want the extract limit", err)
+	}
+	entries, _ := os.ReadDir(dest)
+	if len(entries) > 5 {
+		t.Errorf("extracted %d entries, want extraction stopped at the limit", len(entries))
+	}
+}
+
+func TestDiscoverFromArchive_ZipWithSkillIgnore(t *testing.T) {
+	dir := t.TempDir()
+	archive := filepath.Join(dir, "bundle.zip")
+	writeZip(t, archive, []archiveEntry{
+		{name: ".skillignore", body: "legacy\n"},
+		{name: "skills/vue/SKILL.md", body: "# vue"},
+		{name: "legacy/vue/SKILL.md", body: "# old vue"},
+		{name: "skills/react/SKILL.md", body: "# react"},
+	})
+
+	source, _ := ParseSource("file://" + archive)
+	discovery, err := DiscoverFromArchive(source)
+	if err != nil {
+		t.Fatalf("DiscoverFromArchive: %v", err)
+	}
+	defer CleanupDiscovery(discovery)
+	if len(discovery.Skills) != 2 {
+		t.Errorf("skills = %+v, want vue and react", discovery.Skills)
+	}
+
+	sub, _ := ParseSource("file://" + archive + "//vue")
+	subDiscovery, err := DiscoverFromArchive(sub)
+	if err != nil {
+		t.Fatalf("DiscoverFromArchive(subdir): %v", err)
+	}
+	defer CleanupDiscovery(subDiscovery)
+	if sub.Subdir != "skills/vue" || len(subDiscovery.Skills) != 1 {
+		t.Errorf("subdir = %q, skills = %+v", sub.Subdir, subDiscovery.Skills)
+	}
+}
+
+func TestCheckArchiveUpdate(t *testing.T) {
+	etag := `"v1"`
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("If-None-Match") == etag {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", etag)
+		w.Write([]byte("archive"))
+	}))
+	defer srv.Close()
+
+	meta := &SkillMeta{Source: srv.URL + "/skills.tar.gz", Type: "archive", ETag: `"v1"`}
+	if changed, err := CheckArchiveUpdate(meta); err != nil || changed {
+		t.Errorf("same ETag: changed = %v, err = %v", changed, err)
+	}
+
+	etag = `"v2"`
+	if changed, err := CheckArchiveUpdate(meta); err != nil || !changed {
+		t.Errorf("new ETag: changed = %v, err = %v", changed, err)
+	}
+
+	// Without a stored ETag the checksum decides.
+	sum := sha256.Sum256([]byte("archive"))
+	meta = &SkillMeta{Source: srv.URL + "/skills.tar.gz", Type: "archive", Version: hex.EncodeToString(sum[:])[:12]}
+	if changed, err := CheckArchiveUpdate(meta); err != nil || changed {
+		t.Errorf("same checksum: changed = %v, err = %v", changed, err)
+	}
+}
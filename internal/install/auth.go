@@ -6,63 +6,15 @@ import (
 	"os"
 	"strconv"
 	"strings"
-)
-
-// Platform represents a git hosting platform.
-type Platform int
 
-const (
-	PlatformUnknown   Platform = iota
-	PlatformGitHub             // github.com and GitHub Enterprise
-	PlatformGitLab             // gitlab.com and self-hosted GitLab
-	PlatformBitbucket          // bitbucket.org
+	"skillshare/internal/credential"
+	"skillshare/internal/network"
+	"skillshare/internal/utils"
 )
 
-// extractHost returns the hostname from a clone URL.
-// Supports HTTPS (https://host/...) and SSH (git@host:...) formats.
-func extractHost(cloneURL string) string {
-	s := strings.TrimSpace(cloneURL)
-	if s == "" {
-		return ""
-	}
-
-	// SSH: git@host:owner/repo.git
-	if strings.Contains(s, "@") && strings.Contains(s, ":") && !strings.Contains(s, "://") {
-		at := strings.Index(s, "@")
-		colon := strings.Index(s[at:], ":")
-		if colon > 0 {
-			return s[at+1 : at+colon]
-		}
-	}
-
-	// HTTPS or file://
-	u, err := url.Parse(s)
-	if err != nil || u.Host == "" {
-		return ""
-	}
-	return u.Hostname()
-}
-
-// detectPlatform identifies the git hosting platform from a clone URL.
-func detectPlatform(cloneURL string) Platform {
-	host := strings.ToLower(extractHost(cloneURL))
-	if host == "" {
-		return PlatformUnknown
-	}
-	if strings.Contains(host, "github") {
-		return PlatformGitHub
-	}
-	if strings.Contains(host, "gitlab") {
-		return PlatformGitLab
-	}
-	if strings.Contains(host, "bitbucket") {
-		return PlatformBitbucket
-	}
-	return PlatformUnknown
-}
-
-// resolveToken looks up a token from environment variables based on the
-// detected platform. Platform-specific vars take priority over the generic
+// resolveToken looks up a token for the URL's host: first an auth entry in
+// config.yaml, then environment variables based on the detected platform.
+// Platform-specific vars take priority over the generic
 // SKILLSHARE_GIT_TOKEN. Returns empty strings if no token is available or
 // the URL is not HTTPS.
 func resolveToken(cloneURL string) (token, username string) {
@@ -70,17 +22,27 @@ func resolveToken(cloneURL string) (token, username string) {
 		return "", ""
 	}
 
-	platform := detectPlatform(cloneURL)
+	platform := credential.DetectPlatform(cloneURL)
+	if c, _ := credential.LookupURL(cloneURL); c != nil {
+		if c.Username != "" {
+			return c.Token, c.Username
+		}
+		if u := urlUsername(cloneURL); u != "" {
+			return c.Token, u
+		}
+		return c.Token, defaultUsername(platform)
+	}
+
 	switch platform {
-	case PlatformGitHub:
+	case utils.PlatformGitHub:
 		if t := os.Getenv("GITHUB_TOKEN"); t != "" {
 			return t, "x-access-token"
 		}
-	case PlatformGitLab:
+	case utils.PlatformGitLab:
 		if t := os.Getenv("GITLAB_TOKEN"); t != "" {
 			return t, "oauth2"
 		}
-	case PlatformBitbucket:
+	case utils.PlatformBitbucket:
 		if t := os.Getenv("BITBUCKET_TOKEN"); t != "" {
 			if u := os.Getenv("BITBUCKET_USERNAME"); u != "" {
 				return t, u
@@ -95,50 +57,79 @@ func resolveToken(cloneURL string) (token, username string) {
 		if u := urlUsername(cloneURL); u != "" {
 			return t, u
 		}
-		switch platform {
-		case PlatformGitLab:
-			return t, "oauth2"
-		case PlatformBitbucket:
-			return t, "x-token-auth"
-		default:
-			return t, "x-access-token"
-		}
+		return t, defaultUsername(platform)
 	}
 	return "", ""
 }
 
-// authEnv returns environment variables that inject token authentication
-// via GIT_CONFIG_COUNT/KEY/VALUE (Git 2.31+). This avoids the git -c
-// key=value format which breaks when tokens contain '=' characters.
-// If GIT_CONFIG_COUNT is already set in the environment, the new entry
-// is appended at the next available index to avoid overriding existing
-// git config entries (e.g. from CI pipelines).
-// Returns nil for SSH/file URLs or when no token is available.
-func authEnv(cloneURL string) []string {
-	token, username := resolveToken(cloneURL)
-	if token == "" {
-		return nil
+// defaultUsername is the username paired with a bare token on platform.
+func defaultUsername(platform utils.Platform) string {
+	switch platform {
+	case utils.PlatformGitLab:
+		return "oauth2"
+	case utils.PlatformBitbucket:
+		return "x-token-auth"
+	default:
+		return "x-access-token"
 	}
+}
 
-	host := extractHost(cloneURL)
-	if host == "" {
+// gitEnv returns environment variables that configure a git command for
+// cloneURL via GIT_CONFIG_COUNT/KEY/VALUE (Git 2.31+): the proxy and CA
+// bundle from the network config, a mirror rewrite, and token
+// authentication. This avoids the git -c key=value format which breaks
+// when tokens contain '=' characters.
+// If GIT_CONFIG_COUNT is already set in the environment, the new entries
+// are appended at the next available index to avoid overriding existing
+// git config entries (e.g. from CI pipelines).
+// A rewritten URL is authenticated for the mirror host, in the same
+// insteadOf rule, since git applies only one rewrite per URL.
+// Returns nil when there is nothing to configure.
+func gitEnv(cloneURL string) []string {
+	pairs := network.GitConfig()
+
+	rule, rewritten := network.Match(cloneURL)
+	fetchURL := network.RewriteURL(cloneURL)
+	token, username := resolveToken(fetchURL)
+	host := utils.URLHost(fetchURL)
+	switch {
+	case rewritten && token != "" && host != "":
+		pairs = append(pairs, [2]string{"url." + withCredentials(rule.To, username, token) + ".insteadOf", rule.From})
+	case rewritten:
+		pairs = append(pairs, [2]string{"url." + rule.To + ".insteadOf", rule.From})
+	case token != "" && host != "":
+		authed := fmt.Sprintf("https://%s:%s@%s/", username, token, host)
+		pairs = append(pairs, [2]string{"url." + authed + ".insteadOf", originalPrefix(cloneURL, host)})
+	}
+	if len(pairs) == 0 {
 		return nil
 	}
 
 	base := existingConfigCount()
-	authed := fmt.Sprintf("https://%s:%s@%s/", username, token, host)
-	original := originalPrefix(cloneURL, host)
-	return []string{
-		fmt.Sprintf("GIT_CONFIG_COUNT=%d", base+1),
-		fmt.Sprintf("GIT_CONFIG_KEY_%d=url.%s.insteadOf", base, authed),
-		fmt.Sprintf("GIT_CONFIG_VALUE_%d=%s", base, original),
+	env := []string{fmt.Sprintf("GIT_CONFIG_COUNT=%d", base+len(pairs))}
+	for i, p := range pairs {
+		env = append(env,
+			fmt.Sprintf("GIT_CONFIG_KEY_%d=%s", base+i, p[0]),
+			fmt.Sprintf("GIT_CONFIG_VALUE_%d=%s", base+i, p[1]))
+	}
+	return env
+}
+
+// withCredentials adds username:token to an https URL prefix.
+func withCredentials(prefix, username, token string) string {
+	u, err := url.Parse(prefix)
+	if err != nil || u.Scheme != "https" {
+		return prefix
 	}
+	u.User = url.UserPassword(username, token)
+	return u.String()
 }
 
-// AuthEnvForURL returns git auth injection env for HTTPS clone/pull URLs.
-// Returns nil when no token is available or URL is not HTTPS.
+// AuthEnvForURL returns the git env for clone/pull/ls-remote of cloneURL:
+// token auth for HTTPS URLs, plus mirror rewrites, proxy and CA settings.
+// Returns nil when none applies.
 func AuthEnvForURL(cloneURL string) []string {
-	return authEnv(cloneURL)
+	return gitEnv(cloneURL)
 }
 
 // existingConfigCount returns the current GIT_CONFIG_COUNT from the
@@ -179,7 +170,7 @@ func sanitizeTokens(text string) string {
 			text = strings.ReplaceAll(text, t, "[REDACTED]")
 		}
 	}
-	return text
+	return credential.Redact(text)
 }
 
 // urlUsername extracts the username from an HTTPS URL, if present.
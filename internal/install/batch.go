@@ -0,0 +1,203 @@
+package install
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// stagingPrefix names the hidden staging directory of a batch inside the
+// source directory. Being hidden, it is skipped by discovery and
+// reconciliation while a batch is in flight.
+const stagingPrefix = ".skillshare-staging-"
+
+// Batch installs several skills from one discovery as a unit. Stage copies
+// and audits each skill into a staging directory under the source, so
+// nothing in the source changes until Commit moves every staged skill into
+// place. A failed Commit, or a Rollback, restores the source to how it was.
+//
+// Usage mirrors a database transaction:
+//
+//	batch, err := NewBatch(sourceDir)
+//	defer batch.Rollback()
+//	batch.Stage(...) // for each skill
+//	batch.Commit(ctx)
+type Batch struct {
+	sourceDir  string
+	stagingDir string
+	staged     []stagedSkill
+	committed  []committedSkill
+	created    []string // Directories Commit created above a destination
+}
+
+type stagedSkill struct {
+	rel     string // Destination relative to the source directory
+	path    string // Staged copy
+	replace bool   // --force: an existing skill at the destination is replaced
+}
+
+type committedSkill struct {
+	dest   string
+	backup string // Replaced skill, moved aside; empty when dest was new
+}
+
+// NewBatch creates the staging directory for a batch under sourceDir.
+func NewBatch(sourceDir string) (*Batch, error) {
+	if err := os.MkdirAll(sourceDir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create source directory: %w", err)
+	}
+	dir, err := os.MkdirTemp(sourceDir, stagingPrefix+"*")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create staging directory: %w", err)
+	}
+	return &Batch{sourceDir: sourceDir, stagingDir: dir}, nil
+}
+
+// Stage copies skill into the staging directory and audits it. destPath is
+// where Commit will put it and must lie inside the source directory. An
+// existing skill there is an error unless opts.Force is set, and is only
+// replaced at Commit. The returned result reports destPath as SkillPath.
+func (b *Batch) Stage(discovery *DiscoveryResult, skill SkillInfo, destPath string, opts InstallOptions) (*InstallResult, error) {
+	rel, err := filepath.Rel(b.sourceDir, destPath)
+	if err != nil || rel == "." || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return nil, fmt.Errorf("destination %s is outside %s", destPath, b.sourceDir)
+	}
+	if _, err := os.Stat(destPath); err == nil && !opts.Force {
+		fullSource, _ := discoveredSource(discovery, skill)
+		return nil, fmt.Errorf("already exists. To overwrite:\n       skillshare install %s --force", fullSource)
+	}
+
+	stagePath := filepath.Join(b.stagingDir, "new", rel)
+	opts.DryRun = false
+	result, err := InstallFromDiscovery(discovery, skill, stagePath, opts)
+	if err != nil {
+		os.RemoveAll(stagePath)
+		return nil, err
+	}
+	result.SkillPath = destPath
+	b.staged = append(b.staged, stagedSkill{rel: rel, path: stagePath, replace: opts.Force})
+	return result, nil
+}
+
+// Staged returns the number of skills staged so far.
+func (b *Batch) Staged() int {
+	return len(b.staged)
+}
+
+// Commit moves every staged skill into place, replacing existing skills
+// staged with --force. If a move fails or ctx is cancelled (Ctrl-C), the
+// moves already made are undone and the error is returned. After a
+// successful Commit, Rollback only removes the staging directory.
+func (b *Batch) Commit(ctx context.Context) error {
+	for _, s := range b.staged {
+		if err := ctx.Err(); err != nil {
+			return b.abort(fmt.Errorf("install interrupted: %w", err))
+		}
+		if err := b.commitOne(s); err != nil {
+			return b.abort(fmt.Errorf("failed to install %s: %w", filepath.ToSlash(s.rel), err))
+		}
+	}
+	b.committed, b.created = nil, nil
+	return os.RemoveAll(b.stagingDir)
+}
+
+func (b *Batch) commitOne(s stagedSkill) error {
+	dest := filepath.Join(b.sourceDir, s.rel)
+	c := committedSkill{dest: dest}
+	if _, err := os.Lstat(dest); err == nil {
+		if !s.replace {
+			return fmt.Errorf("%s already exists", dest)
+		}
+		c.backup = filepath.Join(b.stagingDir, "old", s.rel)
+		if err := os.MkdirAll(filepath.Dir(c.backup), 0755); err != nil {
+			return err
+		}
+		if err := os.Rename(dest, c.backup); err != nil {
+			return fmt.Errorf("failed to move existing skill aside: %w", err)
+		}
+	} else if err := b.mkdirParents(filepath.Dir(dest)); err != nil {
+		return err
+	}
+
+	if err := os.Rename(s.path, dest); err != nil {
+		if c.backup != "" {
+			os.Rename(c.backup, dest) //nolint:errcheck
+		}
+		return err
+	}
+	b.committed = append(b.committed, c)
+	return nil
+}
+
+// mkdirParents creates dir and remembers the topmost directory it had to
+// create, so Rollback can remove it again.
+func (b *Batch) mkdirParents(dir string) error {
+	top := ""
+	for d := dir; d != b.sourceDir && strings.HasPrefix(d, b.sourceDir); d = filepath.Dir(d) {
+		if _, err := os.Stat(d); err == nil {
+			break
+		}
+		top = d
+	}
+	if top == "" {
+		return nil
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	b.created = append(b.created, top)
+	return nil
+}
+
+func (b *Batch) abort(err error) error {
+	if rbErr := b.Rollback(); rbErr != nil {
+		return fmt.Errorf("%w (rollback incomplete: %v)", err, rbErr)
+	}
+	return err
+}
+
+// Rollback undoes the moves of an unfinished Commit, newest first, and
+// removes the staging directory. It is safe to defer right after NewBatch.
+func (b *Batch) Rollback() error {
+	var errs []error
+	for i := len(b.committed) - 1; i >= 0; i-- {
+		c := b.committed[i]
+		if err := os.RemoveAll(c.dest); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if c.backup != "" {
+			if err := os.Rename(c.backup, c.dest); err != nil {
+				errs = append(errs, fmt.Errorf("failed to restore %s: %w", c.dest, err))
+			}
+		}
+	}
+	for i := len(b.created) - 1; i >= 0; i-- {
+		removeEmptyDirs(b.created[i])
+	}
+	b.committed, b.created = nil, nil
+
+	if len(errs) > 0 {
+		// Keep the staging directory: it may hold replaced skills.
+		return errors.Join(errs...)
+	}
+	return os.RemoveAll(b.stagingDir)
+}
+
+// removeEmptyDirs removes dir and its subdirectories, deepest first, as
+// long as they are empty. Anything written there since is left alone.
+func removeEmptyDirs(dir string) {
+	var dirs []string
+	filepath.WalkDir(dir, func(path string, d os.DirEntry, err error) error { //nolint:errcheck
+		if err == nil && d.IsDir() {
+			dirs = append(dirs, path)
+		}
+		return nil
+	})
+	for i := len(dirs) - 1; i >= 0; i-- {
+		os.Remove(dirs[i]) //nolint:errcheck
+	}
+}
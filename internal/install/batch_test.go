@@ -0,0 +1,163 @@
+package install
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// newTestDiscovery lays out a fake cloned repo with one skill per name.
+func newTestDiscovery(t *testing.T, names ...string) *DiscoveryResult {
+	t.Helper()
+	repo := t.TempDir()
+	for _, name := range names {
+		dir := filepath.Join(repo, "repo", "skills", name)
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			t.Fatal(err)
+		}
+		content := "---\nname: " + name + "\n---\n# " + name + "\n"
+		if err := os.WriteFile(filepath.Join(dir, "SKILL.md"), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return &DiscoveryResult{
+		RepoPath: repo,
+		Source:   &Source{Type: SourceTypeGitHub, Raw: "github.com/org/repo", CloneURL: "https://github.com/org/repo.git"},
+		Commit:   "abc1234",
+	}
+}
+
+func stageAll(t *testing.T, b *Batch, d *DiscoveryResult, source string, opts InstallOptions, names ...string) {
+	t.Helper()
+	for _, name := range names {
+		skill := SkillInfo{Name: name, Path: "skills/" + name}
+		if _, err := b.Stage(d, skill, filepath.Join(source, name), opts); err != nil {
+			t.Fatalf("Stage(%s): %v", name, err)
+		}
+	}
+}
+
+func assertNoStaging(t *testing.T, source string) {
+	t.Helper()
+	entries, _ := os.ReadDir(source)
+	for _, e := range entries {
+		if strings.HasPrefix(e.Name(), stagingPrefix) {
+			t.Errorf("staging directory %s left behind", e.Name())
+		}
+	}
+}
+
+func TestBatch_StageThenCommit(t *testing.T) {
+	source := t.TempDir()
+	d := newTestDiscovery(t, "alpha", "beta")
+
+	b, err := NewBatch(source)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer b.Rollback() //nolint:errcheck
+	stageAll(t, b, d, source, InstallOptions{SkipAudit: true}, "alpha", "beta")
+
+	if _, err := os.Stat(filepath.Join(source, "alpha")); !os.IsNotExist(err) {
+		t.Fatal("Stage must not touch the destination")
+	}
+	if err := b.Commit(context.Background()); err != nil {
+		t.Fatalf("Commit: %v", err)
+	}
+	for _, name := range []string{"alpha", "beta"} {
+		if _, err := os.Stat(filepath.Join(source, name, "SKILL.md")); err != nil {
+			t.Errorf("%s not installed: %v", name, err)
+		}
+		if meta, err := ReadMeta(filepath.Join(source, name)); err != nil || meta == nil || meta.Version != "abc1234" {
+			t.Errorf("%s meta = %+v, %v", name, meta, err)
+		}
+	}
+	if err := b.Rollback(); err != nil {
+		t.Fatalf("Rollback after Commit: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(source, "alpha")); err != nil {
+		t.Error("Rollback after Commit must keep installed skills")
+	}
+	assertNoStaging(t, source)
+}
+
+func TestBatch_CancelledCommitRollsBack(t *testing.T) {
+	source := t.TempDir()
+	old := filepath.Join(source, "alpha")
+	if err := os.MkdirAll(old, 0755); err != nil {
+		t.Fatal(err)
+	}
+	os.WriteFile(filepath.Join(old, "SKILL.md"), []byte("old"), 0644) //nolint:errcheck
+	d := newTestDiscovery(t, "alpha", "beta")
+
+	b, err := NewBatch(source)
+	if err != nil {
+		t.Fatal(err)
+	}
+	stageAll(t, b, d, source, InstallOptions{SkipAudit: true, Force: true}, "alpha", "beta")
+
+	// Commit the first skill, then fail on the second.
+	ctx, cancel := context.WithCancel(context.Background())
+	if err := b.commitOne(b.staged[0]); err != nil {
+		t.Fatal(err)
+	}
+	b.staged = b.staged[1:]
+	cancel()
+	if err := b.Commit(ctx); err == nil || !strings.Contains(err.Error(), "interrupted") {
+		t.Fatalf("Commit error = %v, want interrupted", err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(old, "SKILL.md"))
+	if err != nil || string(data) != "old" {
+		t.Errorf("replaced skill not restored: %q, %v", data, err)
+	}
+	if _, err := os.Stat(filepath.Join(source, "beta")); !os.IsNotExist(err) {
+		t.Error("beta should not be installed")
+	}
+	assertNoStaging(t, source)
+}
+
+func TestBatch_StageRefusesExisting(t *testing.T) {
+	source := t.TempDir()
+	os.MkdirAll(filepath.Join(source, "alpha"), 0755) //nolint:errcheck
+	d := newTestDiscovery(t, "alpha")
+
+	b, err := NewBatch(source)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer b.Rollback() //nolint:errcheck
+	_, err = b.Stage(d, SkillInfo{Name: "alpha", Path: "skills/alpha"}, filepath.Join(source, "alpha"), InstallOptions{SkipAudit: true})
+	if err == nil || !strings.Contains(err.Error(), "already exists") {
+		t.Fatalf("Stage error = %v, want already exists", err)
+	}
+	if _, err := b.Stage(d, SkillInfo{Name: "alpha", Path: "skills/alpha"}, filepath.Join(t.TempDir(), "alpha"), InstallOptions{SkipAudit: true}); err == nil {
+		t.Fatal("Stage outside the source directory should fail")
+	}
+}
+
+func TestBatch_RollbackRemovesCreatedDirs(t *testing.T) {
+	source := t.TempDir()
+	d := newTestDiscovery(t, "alpha")
+
+	b, err := NewBatch(source)
+	if err != nil {
+		t.Fatal(err)
+	}
+	skill := SkillInfo{Name: "alpha", Path: "skills/alpha"}
+	if _, err := b.Stage(d, skill, filepath.Join(source, "frontend", "react", "alpha"), InstallOptions{SkipAudit: true}); err != nil {
+		t.Fatal(err)
+	}
+	if err := b.commitOne(b.staged[0]); err != nil {
+		t.Fatal(err)
+	}
+	if err := b.Rollback(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(filepath.Join(source, "frontend")); !os.IsNotExist(err) {
+		t.Error("--into directory created by the batch should be removed")
+	}
+	assertNoStaging(t, source)
+}
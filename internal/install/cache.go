@@ -0,0 +1,294 @@
+package install
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"skillshare/internal/filelock"
+	"skillshare/internal/parallel"
+)
+
+// CacheDir is the directory of the shared clone cache. Installs and updates
+// that read files out of a repository fetch into a bare repository here and
+// copy files out of it locally, so several skills from one repository cost a
+// single fetch. When empty (the default, and in unit tests) every install
+// clones straight from the remote. The CLI sets it to <cache dir>/repos,
+// shared by global and project mode.
+var CacheDir string
+
+// CacheMaxBytes is the size the cache is pruned back to after a fetch grows
+// it past that size. Zero disables pruning during installs; `cache prune`
+// still applies the configured limit.
+var CacheMaxBytes int64
+
+// cacheLockTimeout bounds the wait for another process using a cache entry;
+// past it the install clones directly instead.
+const cacheLockTimeout = 2 * time.Minute
+
+const (
+	// cacheRef holds the fetched remote HEAD in each cached repository.
+	cacheRef = "refs/heads/skillshare"
+	// cacheInfoFile records the URL and usage times of a cached repository.
+	cacheInfoFile = "skillshare-cache.json"
+)
+
+// CacheEntry describes one cached repository.
+type CacheEntry struct {
+	Key       string    `json:"key"`
+	URL       string    `json:"url"`
+	Path      string    `json:"path"`
+	Size      int64     `json:"size"`
+	FetchedAt time.Time `json:"fetched_at"`
+	UsedAt    time.Time `json:"used_at"`
+}
+
+var (
+	cacheLocks   sync.Map // key → *sync.Mutex
+	cacheFetched sync.Map // keys fetched by this process
+)
+
+// lockCacheEntry takes the file lock of the cache entry at dir, excluding
+// other processes (global and project mode take different operation locks)
+// while it is created, fetched or removed. Close the file to release it.
+func lockCacheEntry(dir string) (*os.File, error) {
+	if err := os.MkdirAll(filepath.Dir(dir), 0755); err != nil {
+		return nil, err
+	}
+	return filelock.Lock(dir+".lock", cacheLockTimeout)
+}
+
+// cacheKey names the cache entry for a clone URL. Spellings of the same
+// repository (trailing .git, host case) share an entry.
+func cacheKey(url string) string {
+	sum := sha256.Sum256([]byte(parallel.RemoteKey(url)))
+	return hex.EncodeToString(sum[:])[:16]
+}
+
+// errCacheUnavailable marks failures to set up the cache itself, as opposed
+// to failures to reach the remote; callers fall back to a direct clone.
+var errCacheUnavailable = errors.New("clone cache unavailable")
+
+// fetchToCache brings the cached copy of url up to date and returns the
+// path of its bare repository and its size before the fetch. A repository
+// is fetched at most once per process, so a batch of skills from one
+// monorepo fetches it once.
+//
+// Cached repositories are partial clones (--filter=blob:none): the fetch
+// brings commits and trees only, and file contents are fetched on demand
+// when a skill is read out of the cache. Servers without filter support
+// get a plain shallow fetch.
+func fetchToCache(url string) (dir string, before int64, warning string, err error) {
+	key := cacheKey(url)
+	dir = filepath.Join(CacheDir, key+".git")
+
+	mu, _ := cacheLocks.LoadOrStore(key, &sync.Mutex{})
+	mu.(*sync.Mutex).Lock()
+	defer mu.(*sync.Mutex).Unlock()
+
+	lock, err := lockCacheEntry(dir)
+	if err != nil {
+		return "", 0, "", fmt.Errorf("%w: %v", errCacheUnavailable, err)
+	}
+	defer lock.Close()
+
+	entry := readCacheEntry(dir)
+	entry.Key, entry.URL = key, url
+	now := time.Now()
+
+	if _, done := cacheFetched.Load(key); done && hasCacheRef(dir) {
+		entry.UsedAt = now
+		writeCacheEntry(dir, entry)
+		return dir, dirSize(dir), "", nil
+	}
+
+	if !hasCacheRef(dir) {
+		os.RemoveAll(dir)
+		if err := os.MkdirAll(CacheDir, 0755); err != nil {
+			return "", 0, "", fmt.Errorf("%w: %v", errCacheUnavailable, err)
+		}
+		for _, args := range [][]string{
+			{"init", "--bare", "--quiet", dir},
+			{"--git-dir", dir, "remote", "add", "origin", url},
+			{"--git-dir", dir, "symbolic-ref", "HEAD", cacheRef},
+		} {
+			if err := runGitCommand(args, ""); err != nil {
+				os.RemoveAll(dir)
+				return "", 0, "", fmt.Errorf("%w: %v", errCacheUnavailable, err)
+			}
+		}
+	} else {
+		runGitCommand([]string{"--git-dir", dir, "remote", "set-url", "origin", url}, "") //nolint:errcheck
+	}
+	before = dirSize(dir)
+
+	fetch := []string{"--git-dir", dir, "fetch", "--quiet", "--depth", "1", "--force"}
+	refspec := []string{"origin", "+HEAD:" + cacheRef}
+	setPromisor(dir, true)
+	fetchErr := runGitCommandEnv(append(append(fetch, "--filter=blob:none"), refspec...), "", gitEnv(url))
+	if fetchErr != nil {
+		// Old git or a server without filter support: fetch everything.
+		setPromisor(dir, false)
+		fetchErr = runGitCommandEnv(append(fetch, refspec...), "", gitEnv(url))
+	}
+	if fetchErr != nil {
+		if !hasCacheRef(dir) {
+			os.RemoveAll(dir)
+			return "", 0, "", fetchErr
+		}
+		entry.UsedAt = now
+		writeCacheEntry(dir, entry)
+		return dir, before, fmt.Sprintf("could not reach %s, using cached copy from %s",
+			url, entry.FetchedAt.Local().Format("2006-01-02 15:04")), nil
+	}
+
+	cacheFetched.Store(key, struct{}{})
+	entry.FetchedAt, entry.UsedAt = now, now
+	writeCacheEntry(dir, entry)
+	if CacheMaxBytes > 0 {
+		pruneCache(CacheMaxBytes, false, dir) //nolint:errcheck
+	}
+	return dir, before, "", nil
+}
+
+// setPromisor marks origin as a partial-clone remote, so git fetches
+// missing blobs from it on demand, or clears the mark.
+func setPromisor(dir string, on bool) {
+	if on {
+		runGitCommand([]string{"--git-dir", dir, "config", "remote.origin.promisor", "true"}, "")                //nolint:errcheck
+		runGitCommand([]string{"--git-dir", dir, "config", "remote.origin.partialclonefilter", "blob:none"}, "") //nolint:errcheck
+		return
+	}
+	runGitCommand([]string{"--git-dir", dir, "config", "--unset", "remote.origin.promisor"}, "")           //nolint:errcheck
+	runGitCommand([]string{"--git-dir", dir, "config", "--unset", "remote.origin.partialclonefilter"}, "") //nolint:errcheck
+}
+
+func hasCacheRef(dir string) bool {
+	return runGitCommand([]string{"--git-dir", dir, "rev-parse", "--verify", "--quiet", cacheRef}, "") == nil
+}
+
+func readCacheEntry(dir string) CacheEntry {
+	var entry CacheEntry
+	if data, err := os.ReadFile(filepath.Join(dir, cacheInfoFile)); err == nil {
+		json.Unmarshal(data, &entry) //nolint:errcheck
+	}
+	entry.Path = dir
+	return entry
+}
+
+func writeCacheEntry(dir string, entry CacheEntry) {
+	data, err := json.MarshalIndent(entry, "", "  ")
+	if err != nil {
+		return
+	}
+	os.WriteFile(filepath.Join(dir, cacheInfoFile), data, 0644) //nolint:errcheck
+}
+
+// ListCache returns the cached repositories, most recently used first.
+func ListCache() ([]CacheEntry, error) {
+	if CacheDir == "" {
+		return nil, nil
+	}
+	dirEntries, err := os.ReadDir(CacheDir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var entries []CacheEntry
+	for _, d := range dirEntries {
+		if !d.IsDir() || !strings.HasSuffix(d.Name(), ".git") {
+			continue
+		}
+		dir := filepath.Join(CacheDir, d.Name())
+		entry := readCacheEntry(dir)
+		entry.Key = strings.TrimSuffix(d.Name(), ".git")
+		entry.Size = dirSize(dir)
+		entries = append(entries, entry)
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].UsedAt.After(entries[j].UsedAt)
+	})
+	return entries, nil
+}
+
+// PruneCache removes least recently used repositories until the cache is at
+// most maxBytes. maxBytes <= 0 removes every entry. With dryRun nothing is
+// deleted. Returns the entries removed (or that would be removed).
+func PruneCache(maxBytes int64, dryRun bool) ([]CacheEntry, error) {
+	return pruneCache(maxBytes, dryRun, "")
+}
+
+// pruneCache is PruneCache keeping the entry at keep. Entries another
+// process or install is using are skipped.
+func pruneCache(maxBytes int64, dryRun bool, keep string) ([]CacheEntry, error) {
+	entries, err := ListCache()
+	if err != nil {
+		return nil, err
+	}
+
+	var total int64
+	for _, e := range entries {
+		total += e.Size
+	}
+
+	var removed []CacheEntry
+	// entries are newest first; evict from the end.
+	for i := len(entries) - 1; i >= 0 && (maxBytes <= 0 || total > maxBytes); i-- {
+		e := entries[i]
+		if e.Path == keep {
+			continue
+		}
+		if !dryRun {
+			busy, err := removeCacheEntry(e.Path)
+			if err != nil {
+				return removed, fmt.Errorf("failed to remove %s: %w", e.Path, err)
+			}
+			if busy {
+				continue
+			}
+		}
+		total -= e.Size
+		removed = append(removed, e)
+	}
+	return removed, nil
+}
+
+// removeCacheEntry deletes the cache entry at dir unless another process
+// or install holds its lock, reporting busy then.
+func removeCacheEntry(dir string) (busy bool, err error) {
+	f, err := os.OpenFile(dir+".lock", os.O_CREATE|os.O_RDWR, 0644)
+	if err != nil {
+		return false, err
+	}
+	defer f.Close()
+	ok, err := filelock.TryLock(f)
+	if err != nil {
+		return false, err
+	}
+	if !ok {
+		return true, nil
+	}
+	return false, os.RemoveAll(dir)
+}
+
+func dirSize(dir string) int64 {
+	var size int64
+	filepath.Walk(dir, func(_ string, info os.FileInfo, err error) error { //nolint:errcheck
+		if err == nil && !info.IsDir() {
+			size += info.Size()
+		}
+		return nil
+	})
+	return size
+}
@@ -0,0 +1,107 @@
+package install
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCacheKey_SameRepository(t *testing.T) {
+	if cacheKey("https://GitHub.com/org/mono.git") != cacheKey("https://github.com/org/mono") {
+		t.Error("spellings of one repository should share a cache key")
+	}
+	if cacheKey("https://github.com/org/a") == cacheKey("https://github.com/org/b") {
+		t.Error("different repositories should not share a cache key")
+	}
+}
+
+func TestPruneCache_EvictsLeastRecentlyUsed(t *testing.T) {
+	old := CacheDir
+	CacheDir = t.TempDir()
+	defer func() { CacheDir = old }()
+
+	now := time.Now()
+	for i, name := range []string{"newest", "middle", "oldest"} {
+		dir := filepath.Join(CacheDir, name+".git")
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, "pack"), []byte(strings.Repeat("x", 1000)), 0644); err != nil {
+			t.Fatal(err)
+		}
+		writeCacheEntry(dir, CacheEntry{URL: "https://example.com/" + name, UsedAt: now.Add(-time.Duration(i) * time.Hour)})
+	}
+
+	entries, err := ListCache()
+	if err != nil || len(entries) != 3 || entries[0].Key != "newest" {
+		t.Fatalf("ListCache() = %+v, %v", entries, err)
+	}
+
+	// Each entry is a little over 1000 bytes; a 2500 byte limit keeps two.
+	removed, err := PruneCache(2500, true)
+	if err != nil || len(removed) != 1 || removed[0].Key != "oldest" {
+		t.Fatalf("dry-run PruneCache = %+v, %v", removed, err)
+	}
+	if _, err := os.Stat(filepath.Join(CacheDir, "oldest.git")); err != nil {
+		t.Error("dry run removed an entry")
+	}
+
+	removed, err = PruneCache(0, false)
+	if err != nil || len(removed) != 3 {
+		t.Fatalf("PruneCache(0) = %+v, %v", removed, err)
+	}
+	if entries, _ := ListCache(); len(entries) != 0 {
+		t.Errorf("cache not empty after prune: %+v", entries)
+	}
+}
+
+func TestPruneCache_SkipsEntryInUse(t *testing.T) {
+	old := CacheDir
+	CacheDir = t.TempDir()
+	defer func() { CacheDir = old }()
+
+	for _, name := range []string{"busy", "idle"} {
+		dir := filepath.Join(CacheDir, name+".git")
+		os.MkdirAll(dir, 0755)
+		writeCacheEntry(dir, CacheEntry{URL: "https://example.com/" + name})
+	}
+	lock, err := lockCacheEntry(filepath.Join(CacheDir, "busy.git"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer lock.Close()
+
+	removed, err := PruneCache(0, false)
+	if err != nil || len(removed) != 1 || removed[0].Key != "idle" {
+		t.Fatalf("PruneCache(0) = %+v, %v; want only idle removed", removed, err)
+	}
+	if _, err := os.Stat(filepath.Join(CacheDir, "busy.git")); err != nil {
+		t.Error("an entry in use by another install was removed")
+	}
+}
+
+func TestFetchToCache_PrunesOverLimit(t *testing.T) {
+	if !isGitInstalled() {
+		t.Skip("git not installed")
+	}
+	oldDir, oldMax := CacheDir, CacheMaxBytes
+	CacheDir, CacheMaxBytes = t.TempDir(), 1
+	defer func() { CacheDir, CacheMaxBytes = oldDir, oldMax }()
+
+	stale := filepath.Join(CacheDir, "stale.git")
+	os.MkdirAll(stale, 0755)
+	writeCacheEntry(stale, CacheEntry{URL: "https://example.com/stale", UsedAt: time.Now().Add(-time.Hour)})
+
+	dir, _, _, err := fetchToCache(newMonorepo(t))
+	if err != nil {
+		t.Fatalf("fetchToCache: %v", err)
+	}
+	if _, err := os.Stat(stale); !os.IsNotExist(err) {
+		t.Error("stale entry should be pruned once the cache is over its limit")
+	}
+	if !hasCacheRef(dir) {
+		t.Error("the entry just fetched must be kept")
+	}
+}
@@ -0,0 +1,237 @@
+package install
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// fetchResult describes the files fetched from a remote repository.
+type fetchResult struct {
+	Subdir     string // subdir actually fetched (may differ from the one asked for)
+	Commit     string // short hash of the fetched HEAD
+	Downloaded int64  // bytes added on disk by this fetch; 0 when served from cache
+	Warning    string // e.g. remote unreachable, cached copy used
+}
+
+// fetchRepo reads the HEAD of a remote repository into destPath, laid out
+// as the repository root. With a subdir only that directory is fetched:
+// the clone skips file contents (--filter=blob:none) and checks out just the
+// subdir, so installing one skill from a large monorepo downloads little
+// more than the skill. A subdir that does not exist is resolved by skill
+// name (see resolveSubdirInTree).
+//
+// With CacheDir set the fetch goes through the shared clone cache;
+// otherwise it clones straight into destPath.
+func fetchRepo(url, subdir, destPath string) (*fetchResult, error) {
+	if CacheDir != "" {
+		res, err := fetchViaCache(url, subdir, destPath)
+		if !errors.Is(err, errCacheUnavailable) {
+			return res, err
+		}
+	}
+	return fetchDirect(url, subdir, destPath)
+}
+
+// fetchDirect does a narrow clone into destPath. Old git and servers
+// without filter support fall back to a plain shallow clone.
+func fetchDirect(url, subdir, destPath string) (*fetchResult, error) {
+	narrow := subdir != ""
+	if narrow {
+		args := []string{"clone", "--quiet", "--depth", "1", "--filter=blob:none", "--sparse", url, destPath}
+		if err := runGitCommandEnv(args, "", gitEnv(url)); err != nil {
+			if !isUnsupportedOption(err) {
+				return nil, err
+			}
+			os.RemoveAll(destPath)
+			narrow = false
+		}
+	}
+	if !narrow {
+		if err := cloneRepo(url, destPath, true); err != nil {
+			return nil, err
+		}
+	}
+
+	res := &fetchResult{}
+	if subdir != "" {
+		paths, err := listTree(destPath, "HEAD")
+		if err != nil {
+			return nil, err
+		}
+		resolved, err := resolveSubdirInTree(paths, subdir, readTreeSkillIgnore(destPath, "HEAD"))
+		if err != nil {
+			return nil, err
+		}
+		res.Subdir = resolved
+		if narrow {
+			if err := runGitCommandEnv([]string{"sparse-checkout", "set", resolved}, destPath, gitEnv(url)); err != nil {
+				return nil, fmt.Errorf("failed to check out %s: %w", resolved, err)
+			}
+		}
+	}
+	if hash, err := getGitCommit(destPath); err == nil {
+		res.Commit = hash
+	}
+	res.Downloaded = dirSize(filepath.Join(destPath, ".git"))
+	return res, nil
+}
+
+// fetchViaCache fetches into the clone cache and extracts the files with
+// git archive; blobs outside the subdir are never downloaded.
+func fetchViaCache(url, subdir, destPath string) (*fetchResult, error) {
+	dir, before, warning, err := fetchToCache(url)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &fetchResult{Warning: warning}
+	if subdir != "" {
+		paths, err := listTree(dir, cacheRef)
+		if err != nil {
+			return nil, err
+		}
+		resolved, err := resolveSubdirInTree(paths, subdir, readTreeSkillIgnore(dir, cacheRef))
+		if err != nil {
+			return nil, err
+		}
+		res.Subdir = resolved
+	}
+
+	if err := os.MkdirAll(destPath, 0755); err != nil {
+		return nil, err
+	}
+	if err := extractArchive(dir, url, res.Subdir, destPath); err != nil {
+		return nil, fmt.Errorf("failed to read %s from cache: %w", url, err)
+	}
+	if hash, err := gitOutput(dir, "rev-parse", "--short", cacheRef); err == nil {
+		res.Commit = hash
+	}
+	if after := dirSize(dir); after > before {
+		res.Downloaded = after - before
+	}
+	return res, nil
+}
+
+// extractArchive writes the cached tree (or one subdir of it) to destPath.
+// Blobs missing from the partial cache are fetched from origin in one batch.
+func extractArchive(gitDir, url, subdir, destPath string) error {
+	args := []string{"--git-dir", gitDir, "archive", "--format=tar", cacheRef}
+	if subdir != "" {
+		args = append(args, "--", subdir)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), gitCommandTimeout)
+	defer cancel()
+	cmd := gitCommand(ctx, args...)
+	cmd.Env = append(cmd.Env, gitEnv(url)...)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return wrapGitError(stderr.String(), err, usedTokenAuth(gitEnv(url)))
+	}
+	return extractTar(&stdout, destPath)
+}
+
+// listTree returns every file path in the tree of ref.
+func listTree(repoPath, ref string) ([]string, error) {
+	out, err := gitOutput(repoPath, "ls-tree", "-r", "--name-only", "--full-tree", ref)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list repository files: %w", err)
+	}
+	if out == "" {
+		return nil, nil
+	}
+	return strings.Split(out, "\n"), nil
+}
+
+// readTreeSkillIgnore reads .skillignore patterns from the root of ref.
+func readTreeSkillIgnore(repoPath, ref string) []string {
+	out, err := gitOutput(repoPath, "show", ref+":.skillignore")
+	if err != nil {
+		return nil
+	}
+	return parseSkillIgnore(out)
+}
+
+// subdirError reports a subdirectory that does not resolve in the fetched
+// tree, as opposed to a failure to fetch it.
+type subdirError struct{ msg string }
+
+func (e *subdirError) Error() string { return e.msg }
+
+func subdirErrorf(format string, args ...any) error {
+	return &subdirError{msg: fmt.Sprintf(format, args...)}
+}
+
+// resolveSubdirInTree resolves a subdirectory path against the file paths of
+// a repository tree, before anything is checked out. It first checks for an
+// exact match. If not found, it looks for a skill (a directory holding
+// SKILL.md) whose name matches the base of subdir.
+// Returns the resolved subdir path (may differ from input) or an error.
+func resolveSubdirInTree(paths []string, subdir string, ignore []string) (string, error) {
+	subdir = strings.Trim(filepath.ToSlash(subdir), "/")
+
+	// 1. Exact match
+	for _, p := range paths {
+		if p == subdir {
+			return "", subdirErrorf("'%s' is not a directory", subdir)
+		}
+		if strings.HasPrefix(p, subdir+"/") {
+			return subdir, nil
+		}
+	}
+
+	// 2. Fuzzy match — directories holding a SKILL.md whose basename matches
+	baseName := path.Base(subdir)
+	var candidates []string
+	for _, p := range paths {
+		if path.Base(p) != "SKILL.md" {
+			continue
+		}
+		dir := path.Dir(p)
+		if dir == "." || path.Base(dir) != baseName || matchSkillIgnore(dir, ignore) {
+			continue
+		}
+		candidates = append(candidates, dir)
+	}
+
+	switch len(candidates) {
+	case 0:
+		return "", subdirErrorf("subdirectory '%s' does not exist in repository", subdir)
+	case 1:
+		return candidates[0], nil
+	default:
+		return "", subdirErrorf("subdirectory '%s' is ambiguous — multiple matches found:\n  %s",
+			subdir, strings.Join(candidates, "\n  "))
+	}
+}
+
+// gitOutput runs a local git command in repoPath (a work tree or a bare
+// repository) and returns its trimmed stdout.
+func gitOutput(repoPath string, args ...string) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), gitCommandTimeout)
+	defer cancel()
+	cmd := gitCommand(ctx, append([]string{"-C", repoPath}, args...)...)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return "", wrapGitError(stderr.String(), err, false)
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+// isUnsupportedOption reports whether git rejected a command-line option,
+// as versions before partial clone and sparse-checkout do.
+func isUnsupportedOption(err error) bool {
+	msg := err.Error()
+	return strings.Contains(msg, "unknown option") ||
+		strings.Contains(msg, "unrecognized argument") ||
+		strings.Contains(msg, "usage: git clone")
+}
@@ -0,0 +1,117 @@
+package install
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// newMonorepo creates a repository with two skills and a large unrelated
+// file, allowing partial clones over file://.
+func newMonorepo(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	files := map[string]string{
+		"skills/vue/SKILL.md":   "---\nname: vue\n---\n# Vue",
+		"skills/vue/run.sh":     "#!/bin/sh\necho vue\n",
+		"skills/react/SKILL.md": "---\nname: react\n---\n# React",
+		"assets/big.bin":        strings.Repeat("0123456789abcdef", 64*1024),
+	}
+	for name, content := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		mode := os.FileMode(0644)
+		if strings.HasSuffix(name, ".sh") {
+			mode = 0755
+		}
+		if err := os.WriteFile(path, []byte(content), mode); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, args := range [][]string{
+		{"init", "--quiet"},
+		{"config", "uploadpack.allowFilter", "true"},
+		{"add", "."},
+		{"-c", "user.name=test", "-c", "user.email=test@example.com", "commit", "--quiet", "-m", "init"},
+	} {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+	}
+	return "file://" + dir
+}
+
+func TestFetchRepo_SubdirOnly(t *testing.T) {
+	if !isGitInstalled() {
+		t.Skip("git not installed")
+	}
+	url := newMonorepo(t)
+
+	for _, tc := range []struct {
+		name  string
+		cache bool
+	}{
+		{"direct", false},
+		{"cached", true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			old := CacheDir
+			CacheDir = ""
+			if tc.cache {
+				CacheDir = t.TempDir()
+			}
+			defer func() { CacheDir = old }()
+
+			dest := filepath.Join(t.TempDir(), "repo")
+			res, err := fetchRepo(url, "vue", dest)
+			if err != nil {
+				t.Fatalf("fetchRepo: %v", err)
+			}
+			if res.Subdir != "skills/vue" {
+				t.Errorf("Subdir = %q, want skills/vue", res.Subdir)
+			}
+			if res.Commit == "" {
+				t.Error("Commit not set")
+			}
+			if res.Downloaded <= 0 || res.Downloaded > 512*1024 {
+				t.Errorf("Downloaded = %d, want a small positive size", res.Downloaded)
+			}
+
+			if _, err := os.Stat(filepath.Join(dest, "skills", "vue", "SKILL.md")); err != nil {
+				t.Errorf("skill not fetched: %v", err)
+			}
+			info, err := os.Stat(filepath.Join(dest, "skills", "vue", "run.sh"))
+			if err != nil || info.Mode()&0100 == 0 {
+				t.Errorf("run.sh missing or not executable: %v", err)
+			}
+			for _, other := range []string{"skills/react/SKILL.md", "assets/big.bin"} {
+				if _, err := os.Stat(filepath.Join(dest, filepath.FromSlash(other))); err == nil {
+					t.Errorf("%s fetched, want only the subdir", other)
+				}
+			}
+		})
+	}
+}
+
+func TestFetchRepo_UnknownSubdir(t *testing.T) {
+	if !isGitInstalled() {
+		t.Skip("git not installed")
+	}
+	old := CacheDir
+	CacheDir = t.TempDir()
+	defer func() { CacheDir = old }()
+
+	_, err := fetchRepo(newMonorepo(t), "missing", filepath.Join(t.TempDir(), "repo"))
+	if err == nil || !strings.Contains(err.Error(), "does not exist") {
+		t.Fatalf("err = %v, want does not exist", err)
+	}
+	if fetchErr := subdirFetchError(err); fetchErr != err {
+		t.Errorf("resolution error was wrapped: %v", fetchErr)
+	}
+}
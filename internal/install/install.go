@@ -3,6 +3,7 @@ package install
 import (
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"os"
@@ -12,6 +13,7 @@ import (
 	"time"
 
 	"skillshare/internal/audit"
+	"skillshare/internal/credential"
 	"skillshare/internal/utils"
 )
 
@@ -30,6 +32,9 @@ type InstallOptions struct {
 	SkipAudit        bool     // Skip security audit entirely
 	AuditThreshold   string   // Block threshold: CRITICAL/HIGH/MEDIUM/LOW/INFO
 	AuditProjectRoot string   // Project root for project-mode audit rule resolution
+	AllowPartial     bool     // Batch installs keep the skills that succeeded when others fail
+	Branch           string   // Tracked repos: branch to clone and follow instead of the default
+	Ref              string   // Tracked repos: tag or commit to pin the clone at
 }
 
 // ShouldInstallAll returns true if all discovered skills should be installed without prompting.
@@ -45,6 +50,7 @@ type InstallResult struct {
 	Source         string
 	Action         string // "cloned", "copied", "updated", "skipped"
 	Warnings       []string
+	Downloaded     int64 // Bytes downloaded by a git clone; 0 when served from cache
 	AuditThreshold string
 	AuditRiskScore int
 	AuditRiskLabel string
@@ -60,9 +66,13 @@ type SkillInfo struct {
 
 // DiscoveryResult contains discovered skills from a repository
 type DiscoveryResult struct {
-	RepoPath string      // Temp directory where repo was cloned
-	Skills   []SkillInfo // Discovered skills
-	Source   *Source     // Original source
+	RepoPath   string      // Temp directory where repo was cloned
+	Skills     []SkillInfo // Discovered skills
+	Source     *Source     // Original source
+	Warnings   []string    // e.g. remote unreachable, cached copy used
+	Commit     string      // Short hash of the fetched commit, or archive version
+	ETag       string      // HTTP ETag of an archive source
+	Downloaded int64       // Bytes downloaded by the clone
 }
 
 // Install executes the installation from source to destination
@@ -101,6 +111,10 @@ func Install(source *Source, destPath string, opts InstallOptions) (*InstallResu
 		return installFromLocal(source, destPath, result, opts)
 	case SourceTypeGitHub, SourceTypeGitHTTPS, SourceTypeGitSSH:
 		return installFromGit(source, destPath, result, opts)
+	case SourceTypeArchive:
+		return installFromArchive(source, destPath, result, opts)
+	case SourceTypeOCI:
+		return installFromOCI(source, destPath, result, opts)
 	default:
 		return nil, fmt.Errorf("unsupported source type: %s", source.Type)
 	}
@@ -200,7 +214,8 @@ func DiscoverFromGit(source *Source) (*DiscoveryResult, error) {
 	}
 
 	repoPath := filepath.Join(tempDir, "repo")
-	if err := cloneRepo(source.CloneURL, repoPath, true); err != nil {
+	fetched, err := fetchRepo(source.CloneURL, "", repoPath)
+	if err != nil {
 		os.RemoveAll(tempDir)
 		return nil, fmt.Errorf("failed to clone repository: %w", err)
 	}
@@ -216,62 +231,34 @@ func DiscoverFromGit(source *Source) (*DiscoveryResult, error) {
 		}
 	}
 
-	return &DiscoveryResult{
-		RepoPath: tempDir,
-		Skills:   skills,
-		Source:   source,
-	}, nil
-}
-
-// resolveSubdir resolves a subdirectory path within a cloned repo.
-// It first checks for an exact match. If not found, it scans the repo for
-// SKILL.md files and looks for a skill whose name matches filepath.Base(subdir).
-// Returns the resolved subdir path (may differ from input) or an error.
-func resolveSubdir(repoPath, subdir string) (string, error) {
-	// 1. Exact match — fast path
-	exact := filepath.Join(repoPath, subdir)
-	info, err := os.Stat(exact)
-	if err == nil {
-		if !info.IsDir() {
-			return "", fmt.Errorf("'%s' is not a directory", subdir)
-		}
-		return subdir, nil
-	}
-	if !os.IsNotExist(err) {
-		return "", fmt.Errorf("cannot access subdirectory: %w", err)
-	}
-
-	// 2. Fuzzy match — scan for SKILL.md files whose directory basename matches
-	baseName := filepath.Base(subdir)
-	skills := discoverSkills(repoPath, false) // exclude root
-	var candidates []string
-	for _, sk := range skills {
-		if sk.Name == baseName {
-			candidates = append(candidates, sk.Path)
-		}
+	result := &DiscoveryResult{
+		RepoPath:   tempDir,
+		Skills:     skills,
+		Source:     source,
+		Commit:     fetched.Commit,
+		Downloaded: fetched.Downloaded,
 	}
-
-	switch len(candidates) {
-	case 0:
-		return "", fmt.Errorf("subdirectory '%s' does not exist in repository", subdir)
-	case 1:
-		return candidates[0], nil
-	default:
-		return "", fmt.Errorf("subdirectory '%s' is ambiguous — multiple matches found:\n  %s",
-			subdir, strings.Join(candidates, "\n  "))
+	if fetched.Warning != "" {
+		result.Warnings = append(result.Warnings, fetched.Warning)
 	}
+	return result, nil
 }
 
 // readSkillIgnore reads a .skillignore file from the given directory.
-// Returns a list of patterns (exact names or trailing-wildcard like "prefix-*").
-// Lines starting with # and empty lines are skipped.
 func readSkillIgnore(dir string) []string {
 	data, err := os.ReadFile(filepath.Join(dir, ".skillignore"))
 	if err != nil {
 		return nil
 	}
+	return parseSkillIgnore(string(data))
+}
+
+// parseSkillIgnore returns the patterns of .skillignore content (exact
+// names or trailing-wildcard like "prefix-*"). Lines starting with # and
+// empty lines are skipped.
+func parseSkillIgnore(data string) []string {
 	var patterns []string
-	for _, line := range strings.Split(string(data), "\n") {
+	for _, line := range strings.Split(data, "\n") {
 		line = strings.TrimSpace(line)
 		if line == "" || strings.HasPrefix(line, "#") {
 			continue
@@ -371,18 +358,14 @@ func DiscoverFromGitSubdir(source *Source) (*DiscoveryResult, error) {
 		return nil, fmt.Errorf("failed to create temp directory: %w", err)
 	}
 
+	// Fetch only the subdirectory (resolved by exact match or fuzzy by skill name)
 	repoPath := filepath.Join(tempDir, "repo")
-	if err := cloneRepo(source.CloneURL, repoPath, true); err != nil {
-		os.RemoveAll(tempDir)
-		return nil, fmt.Errorf("failed to clone repository: %w", err)
-	}
-
-	// Resolve subdirectory (exact match or fuzzy by skill name)
-	resolved, err := resolveSubdir(repoPath, source.Subdir)
+	fetched, err := fetchRepo(source.CloneURL, source.Subdir, repoPath)
 	if err != nil {
 		os.RemoveAll(tempDir)
-		return nil, err
+		return nil, subdirFetchError(err)
 	}
+	resolved := fetched.Subdir
 	if resolved != source.Subdir {
 		source.Subdir = resolved
 		source.Name = filepath.Base(resolved)
@@ -392,11 +375,27 @@ func DiscoverFromGitSubdir(source *Source) (*DiscoveryResult, error) {
 	// Discover skills within the subdirectory (include root)
 	skills := discoverSkills(subdirPath, true)
 
-	return &DiscoveryResult{
-		RepoPath: tempDir,
-		Skills:   skills,
-		Source:   source,
-	}, nil
+	result := &DiscoveryResult{
+		RepoPath:   tempDir,
+		Skills:     skills,
+		Source:     source,
+		Commit:     fetched.Commit,
+		Downloaded: fetched.Downloaded,
+	}
+	if fetched.Warning != "" {
+		result.Warnings = append(result.Warnings, fetched.Warning)
+	}
+	return result, nil
+}
+
+// subdirFetchError keeps subdirectory resolution errors as they are and
+// wraps everything else as a clone failure.
+func subdirFetchError(err error) error {
+	var resolveErr *subdirError
+	if errors.As(err, &resolveErr) {
+		return err
+	}
+	return fmt.Errorf("failed to clone repository: %w", err)
 }
 
 // CleanupDiscovery removes the temporary directory from discovery
@@ -408,25 +407,7 @@ func CleanupDiscovery(result *DiscoveryResult) {
 
 // InstallFromDiscovery installs a skill from a discovered repository
 func InstallFromDiscovery(discovery *DiscoveryResult, skill SkillInfo, destPath string, opts InstallOptions) (*InstallResult, error) {
-	// Build full source path
-	// For subdir discovery, skill.Path is relative to the subdir
-	// For whole-repo discovery, skill.Path is relative to repo root
-	var fullSource string
-	var fullSubdir string
-
-	if skill.Path == "." {
-		// Root skill of a subdir discovery
-		fullSource = discovery.Source.Raw
-		fullSubdir = discovery.Source.Subdir
-	} else if discovery.Source.HasSubdir() {
-		// Nested skill within subdir discovery
-		fullSource = discovery.Source.Raw + "/" + skill.Path
-		fullSubdir = discovery.Source.Subdir + "/" + skill.Path
-	} else {
-		// Whole-repo discovery
-		fullSource = discovery.Source.Raw + "/" + skill.Path
-		fullSubdir = skill.Path
-	}
+	fullSource, fullSubdir := discoveredSource(discovery, skill)
 
 	result := &InstallResult{
 		SkillName: skill.Name,
@@ -477,16 +458,21 @@ func InstallFromDiscovery(discovery *DiscoveryResult, skill SkillInfo, destPath
 
 	// Write metadata
 	source := &Source{
-		Type:     discovery.Source.Type,
-		Raw:      fullSource,
-		CloneURL: discovery.Source.CloneURL,
-		Subdir:   fullSubdir,
-		Name:     skill.Name,
+		Type:       discovery.Source.Type,
+		Raw:        fullSource,
+		CloneURL:   discovery.Source.CloneURL,
+		Subdir:     fullSubdir,
+		Name:       skill.Name,
+		ArchiveURL: discovery.Source.ArchiveURL,
+		SHA256:     discovery.Source.SHA256,
 	}
 	meta := NewMetaFromSource(source)
-	if hash, err := getGitCommit(filepath.Join(discovery.RepoPath, "repo")); err == nil {
+	if discovery.Commit != "" {
+		meta.Version = discovery.Commit
+	} else if hash, err := getGitCommit(filepath.Join(discovery.RepoPath, "repo")); err == nil {
 		meta.Version = hash
 	}
+	meta.ETag = discovery.ETag
 	if err := WriteMeta(destPath, meta); err != nil {
 		result.Warnings = append(result.Warnings, fmt.Sprintf("failed to write metadata: %v", err))
 	}
@@ -495,6 +481,30 @@ func InstallFromDiscovery(discovery *DiscoveryResult, skill SkillInfo, destPath
 	return result, nil
 }
 
+// discoveredSource returns the install source and repo subdirectory of a
+// discovered skill.
+// For subdir discovery, skill.Path is relative to the subdir.
+// For whole-repo discovery, skill.Path is relative to repo root.
+func discoveredSource(discovery *DiscoveryResult, skill SkillInfo) (fullSource, fullSubdir string) {
+	if skill.Path == "." {
+		// Root skill of a subdir discovery
+		fullSource = discovery.Source.Raw
+		fullSubdir = discovery.Source.Subdir
+	} else if discovery.Source.HasSubdir() {
+		// Nested skill within subdir discovery
+		fullSource = discovery.Source.Raw + "/" + skill.Path
+		fullSubdir = discovery.Source.Subdir + "/" + skill.Path
+	} else {
+		// Whole-repo discovery
+		fullSource = discovery.Source.Raw + "/" + skill.Path
+		fullSubdir = skill.Path
+	}
+	if discovery.Source.IsArchive() {
+		fullSource = discovery.Source.WithSubdir(fullSubdir)
+	}
+	return fullSource, fullSubdir
+}
+
 func installFromGitSubdir(source *Source, destPath string, result *InstallResult, opts InstallOptions) (*InstallResult, error) {
 	if opts.DryRun {
 		result.Action = "would clone and extract"
@@ -508,16 +518,17 @@ func installFromGitSubdir(source *Source, destPath string, result *InstallResult
 	}
 	defer os.RemoveAll(tempDir)
 
+	// Fetch only the subdirectory (resolved by exact match or fuzzy by skill name)
 	tempRepoPath := filepath.Join(tempDir, "repo")
-	if err := cloneRepo(source.CloneURL, tempRepoPath, true); err != nil {
-		return nil, fmt.Errorf("failed to clone repository: %w", err)
-	}
-
-	// Resolve subdirectory (exact match or fuzzy by skill name)
-	resolved, err := resolveSubdir(tempRepoPath, source.Subdir)
+	fetched, err := fetchRepo(source.CloneURL, source.Subdir, tempRepoPath)
 	if err != nil {
-		return nil, err
+		return nil, subdirFetchError(err)
+	}
+	if fetched.Warning != "" {
+		result.Warnings = append(result.Warnings, fetched.Warning)
 	}
+	result.Downloaded = fetched.Downloaded
+	resolved := fetched.Subdir
 	if resolved != source.Subdir {
 		source.Subdir = resolved
 		source.Name = filepath.Base(resolved)
@@ -537,10 +548,7 @@ func installFromGitSubdir(source *Source, destPath string, result *InstallResult
 
 	// Write metadata
 	meta := NewMetaFromSource(source)
-	// Try to get the commit hash from temp repo
-	if hash, err := getGitCommit(tempRepoPath); err == nil {
-		meta.Version = hash
-	}
+	meta.Version = fetched.Commit
 	if err := WriteMeta(destPath, meta); err != nil {
 		result.Warnings = append(result.Warnings, fmt.Sprintf("failed to write metadata: %v", err))
 	}
@@ -762,7 +770,8 @@ func runGitCommandEnv(args []string, dir string, extraEnv []string) error {
 
 func usedTokenAuth(extraEnv []string) bool {
 	for _, env := range extraEnv {
-		if strings.HasPrefix(env, "GIT_CONFIG_KEY_") && strings.Contains(env, ".insteadOf") {
+		// Only credential rules carry userinfo; mirror rewrites do not.
+		if strings.HasPrefix(env, "GIT_CONFIG_KEY_") && strings.Contains(env, ".insteadOf") && strings.Contains(env, "@") {
 			return true
 		}
 	}
@@ -778,10 +787,15 @@ func wrapGitError(stderr string, err error, tokenAuthAttempted bool) error {
 		if tokenAuthAttempted {
 			return fmt.Errorf("authentication failed — token rejected, check permissions and expiry\n       %s", s)
 		}
+		hint := ""
+		for _, credErr := range credential.Errors() {
+			hint += "\n       " + sanitizeTokens(credErr.Error())
+		}
 		return fmt.Errorf("authentication required — options:\n"+
 			"       1. SSH URL: git@<host>:<owner>/<repo>.git\n"+
 			"       2. Token env var: GITHUB_TOKEN, GITLAB_TOKEN, BITBUCKET_TOKEN, or SKILLSHARE_GIT_TOKEN\n"+
-			"       3. Git credential helper: gh auth login\n       %s", s)
+			"       3. Git credential helper: gh auth login\n"+
+			"       4. Per-host auth entry in config.yaml (auth:)%s\n       %s", hint, s)
 	}
 	if s != "" {
 		return fmt.Errorf("%s", s)
@@ -798,7 +812,7 @@ func cloneRepo(url, destPath string, shallow bool) error {
 		args = append(args, "--depth", "1")
 	}
 	args = append(args, url, destPath)
-	return runGitCommandEnv(args, "", authEnv(url))
+	return runGitCommandEnv(args, "", gitEnv(url))
 }
 
 // gitPull performs a git pull (quiet mode).
@@ -806,7 +820,7 @@ func cloneRepo(url, destPath string, shallow bool) error {
 // authentication via GIT_CONFIG env vars (same mechanism as cloneRepo).
 func gitPull(repoPath string) error {
 	remoteURL := getRemoteURL(repoPath)
-	return runGitCommandEnv([]string{"pull", "--quiet"}, repoPath, authEnv(remoteURL))
+	return runGitCommandEnv([]string{"pull", "--quiet"}, repoPath, gitEnv(remoteURL))
 }
 
 // getRemoteURL returns the fetch URL for the "origin" remote, or "".
@@ -884,17 +898,15 @@ type TrackedRepoResult struct {
 	SkillCount int      // Number of skills discovered
 	Skills     []string // Names of discovered skills
 	Action     string   // "cloned", "updated", "skipped"
+	Branch     string   // Branch the repo follows, if not the default
+	Ref        string   // Tag or commit the repo is pinned at
 	Warnings   []string
 }
 
-// InstallTrackedRepo clones a git repository as a tracked repo.
-// The repo is cloned to @<repo-name>/ and preserves .git for updates.
-func InstallTrackedRepo(source *Source, sourceDir string, opts InstallOptions) (*TrackedRepoResult, error) {
-	if !source.IsGit() {
-		return nil, fmt.Errorf("--track requires a git repository source")
-	}
-
-	// Determine repo name: opts.Name > TrackName (owner-repo) > source.Name
+// TrackedRepoDest returns where InstallTrackedRepo places the repo:
+// <sourceDir>[/<into>]/_<name>, where name is opts.Name, the owner-repo
+// track name, or the source name, in that order.
+func TrackedRepoDest(source *Source, sourceDir string, opts InstallOptions) string {
 	repoName := opts.Name
 	if repoName == "" {
 		repoName = source.TrackName()
@@ -908,14 +920,29 @@ func InstallTrackedRepo(source *Source, sourceDir string, opts InstallOptions) (
 	if !strings.HasPrefix(repoName, "_") {
 		trackedName = "_" + repoName
 	}
-	destBase := sourceDir
 	if opts.Into != "" {
-		destBase = filepath.Join(sourceDir, opts.Into)
-		if err := os.MkdirAll(destBase, 0755); err != nil {
+		return filepath.Join(sourceDir, opts.Into, trackedName)
+	}
+	return filepath.Join(sourceDir, trackedName)
+}
+
+// InstallTrackedRepo clones a git repository as a tracked repo.
+// The repo is cloned to @<repo-name>/ and preserves .git for updates.
+func InstallTrackedRepo(source *Source, sourceDir string, opts InstallOptions) (*TrackedRepoResult, error) {
+	if !source.IsGit() {
+		return nil, fmt.Errorf("--track requires a git repository source")
+	}
+	if opts.Branch != "" && opts.Ref != "" {
+		return nil, fmt.Errorf("--branch and --ref cannot be used together")
+	}
+
+	destPath := TrackedRepoDest(source, sourceDir, opts)
+	trackedName := filepath.Base(destPath)
+	if opts.Into != "" {
+		if err := os.MkdirAll(filepath.Dir(destPath), 0755); err != nil {
 			return nil, fmt.Errorf("failed to create --into directory: %w", err)
 		}
 	}
-	destPath := filepath.Join(destBase, trackedName)
 
 	result := &TrackedRepoResult{
 		RepoName: trackedName,
@@ -944,9 +971,14 @@ func InstallTrackedRepo(source *Source, sourceDir string, opts InstallOptions) (
 	}
 
 	// Clone the repository (full clone, not shallow, to support updates)
-	if err := cloneRepoFull(source.CloneURL, destPath); err != nil {
+	if err := cloneRepoFull(source.CloneURL, destPath, opts.Branch); err != nil {
 		return nil, fmt.Errorf("failed to clone repository: %w", err)
 	}
+	if err := checkoutNewTrackedRepo(destPath, opts); err != nil {
+		os.RemoveAll(destPath) //nolint:errcheck
+		return nil, err
+	}
+	result.Branch, result.Ref = TrackedRepoRefs(destPath)
 
 	// Discover skills in the cloned repo (exclude root for tracked repos)
 	skills := discoverSkills(destPath, false)
@@ -978,6 +1010,14 @@ func updateTrackedRepo(repoPath string, result *TrackedRepoResult, opts InstallO
 		return nil, fmt.Errorf("'%s' is not a git repository", repoPath)
 	}
 
+	result.Branch, result.Ref = TrackedRepoRefs(repoPath)
+	if result.Ref != "" {
+		// Pinned repos only move with 'skillshare update --to/--latest'
+		result.Action = "skipped"
+		result.Warnings = append(result.Warnings, fmt.Sprintf("pinned at %s, not updated", result.Ref))
+		return result, nil
+	}
+
 	if opts.DryRun {
 		result.Action = "would update (git pull)"
 		return result, nil
@@ -998,9 +1038,32 @@ func updateTrackedRepo(repoPath string, result *TrackedRepoResult, opts InstallO
 	return result, nil
 }
 
-// cloneRepoFull performs a full git clone (quiet mode for cleaner output)
-func cloneRepoFull(url, destPath string) error {
-	return runGitCommandEnv([]string{"clone", "--quiet", url, destPath}, "", authEnv(url))
+// cloneRepoFull performs a full git clone (quiet mode for cleaner output),
+// of branch if given.
+func cloneRepoFull(url, destPath, branch string) error {
+	args := []string{"clone", "--quiet"}
+	if branch != "" {
+		args = append(args, "--branch", branch)
+	}
+	return runGitCommandEnv(append(args, url, destPath), "", gitEnv(url))
+}
+
+// checkoutNewTrackedRepo records the branch of a fresh clone, or moves it
+// to the ref it is pinned at.
+func checkoutNewTrackedRepo(repoPath string, opts InstallOptions) error {
+	switch {
+	case opts.Branch != "":
+		// clone --branch also accepts tags, which would leave the repo
+		// detached without being pinned.
+		if _, err := gitOutput(repoPath, "rev-parse", "--verify", "--quiet", "refs/remotes/origin/"+opts.Branch); err != nil {
+			return fmt.Errorf("'%s' is not a branch of the repository; use --ref to pin a tag or commit", opts.Branch)
+		}
+		return setTrackedRepoRefs(repoPath, opts.Branch, "")
+	case opts.Ref != "":
+		_, err := CheckoutTrackedRepo(repoPath, opts.Ref)
+		return err
+	}
+	return nil
 }
 
 // GetUpdatableSkills returns skill names that have metadata with a remote source.
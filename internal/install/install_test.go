@@ -149,14 +149,11 @@ func TestDiscoverSkills_FindsHiddenDirs(t *testing.T) {
 	}
 }
 
-func TestResolveSubdir(t *testing.T) {
+func TestResolveSubdirInTree(t *testing.T) {
 	t.Run("exact match", func(t *testing.T) {
-		repoPath := t.TempDir()
-		// Create exact subdir with SKILL.md
-		os.MkdirAll(filepath.Join(repoPath, "vue"), 0755)
-		os.WriteFile(filepath.Join(repoPath, "vue", "SKILL.md"), []byte("# Vue"), 0644)
+		paths := []string{"README.md", "vue/SKILL.md"}
 
-		resolved, err := resolveSubdir(repoPath, "vue")
+		resolved, err := resolveSubdirInTree(paths, "vue", nil)
 		if err != nil {
 			t.Fatalf("unexpected error: %v", err)
 		}
@@ -166,12 +163,22 @@ func TestResolveSubdir(t *testing.T) {
 	})
 
 	t.Run("fuzzy match via nested skill", func(t *testing.T) {
-		repoPath := t.TempDir()
 		// Skill lives under skills/ prefix, not at root
-		os.MkdirAll(filepath.Join(repoPath, "skills", "vue"), 0755)
-		os.WriteFile(filepath.Join(repoPath, "skills", "vue", "SKILL.md"), []byte("# Vue"), 0644)
+		paths := []string{"skills/vue/SKILL.md", "skills/vue/ref.md"}
 
-		resolved, err := resolveSubdir(repoPath, "vue")
+		resolved, err := resolveSubdirInTree(paths, "vue", nil)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if resolved != "skills/vue" {
+			t.Errorf("resolved = %q, want %q", resolved, "skills/vue")
+		}
+	})
+
+	t.Run("fuzzy match honors skillignore", func(t *testing.T) {
+		paths := []string{"skills/vue/SKILL.md", "legacy/vue/SKILL.md"}
+
+		resolved, err := resolveSubdirInTree(paths, "vue", []string{"legacy"})
 		if err != nil {
 			t.Fatalf("unexpected error: %v", err)
 		}
@@ -181,9 +188,8 @@ func TestResolveSubdir(t *testing.T) {
 	})
 
 	t.Run("no match", func(t *testing.T) {
-		repoPath := t.TempDir()
-		// Empty repo — no skills at all
-		_, err := resolveSubdir(repoPath, "nonexistent")
+		// Root skill only: root is never a fuzzy candidate
+		_, err := resolveSubdirInTree([]string{"SKILL.md"}, "nonexistent", nil)
 		if err == nil {
 			t.Fatal("expected error, got nil")
 		}
@@ -193,14 +199,10 @@ func TestResolveSubdir(t *testing.T) {
 	})
 
 	t.Run("ambiguous match", func(t *testing.T) {
-		repoPath := t.TempDir()
 		// Two different paths with same skill name
-		os.MkdirAll(filepath.Join(repoPath, "frontend", "pdf"), 0755)
-		os.WriteFile(filepath.Join(repoPath, "frontend", "pdf", "SKILL.md"), []byte("# PDF FE"), 0644)
-		os.MkdirAll(filepath.Join(repoPath, "backend", "pdf"), 0755)
-		os.WriteFile(filepath.Join(repoPath, "backend", "pdf", "SKILL.md"), []byte("# PDF BE"), 0644)
+		paths := []string{"frontend/pdf/SKILL.md", "backend/pdf/SKILL.md"}
 
-		_, err := resolveSubdir(repoPath, "pdf")
+		_, err := resolveSubdirInTree(paths, "pdf", nil)
 		if err == nil {
 			t.Fatal("expected error, got nil")
 		}
@@ -216,11 +218,7 @@ func TestResolveSubdir(t *testing.T) {
 	})
 
 	t.Run("not a directory", func(t *testing.T) {
-		repoPath := t.TempDir()
-		// Create a file (not dir) at the subdir path
-		os.WriteFile(filepath.Join(repoPath, "vue"), []byte("not a dir"), 0644)
-
-		_, err := resolveSubdir(repoPath, "vue")
+		_, err := resolveSubdirInTree([]string{"vue"}, "vue", nil)
 		if err == nil {
 			t.Fatal("expected error, got nil")
 		}
@@ -230,6 +228,14 @@ func TestResolveSubdir(t *testing.T) {
 	})
 }
 
+func TestParseSkillIgnore(t *testing.T) {
+	got := parseSkillIgnore("# comment\r\nalpha\r\n\n  beta-*  \ngroup/gamma\n")
+	want := []string{"alpha", "beta-*", "group/gamma"}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("parseSkillIgnore() = %q, want %q", got, want)
+	}
+}
+
 func TestWrapGitError(t *testing.T) {
 	tests := []struct {
 		name       string
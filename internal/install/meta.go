@@ -19,6 +19,8 @@ type SkillMeta struct {
 	RepoURL     string    `json:"repo_url,omitempty"` // Git repo URL (for git sources)
 	Subdir      string    `json:"subdir,omitempty"`   // Subdirectory path (for monorepo)
 	Version     string    `json:"version,omitempty"`  // Git commit hash or version
+	ETag        string    `json:"etag,omitempty"`     // HTTP ETag of an archive source
+	Digest      string    `json:"digest,omitempty"`   // Manifest digest of an OCI source
 }
 
 // WriteMeta saves metadata to the skill directory
@@ -64,6 +66,32 @@ func HasMeta(skillPath string) bool {
 	return err == nil
 }
 
+// IsArchive reports whether the skill was installed from an archive source.
+func (m *SkillMeta) IsArchive() bool {
+	return strings.HasPrefix(m.Type, SourceTypeArchive.String())
+}
+
+// IsOCI reports whether the skill was installed from an OCI registry.
+func (m *SkillMeta) IsOCI() bool {
+	return m.Type == SourceTypeOCI.String()
+}
+
+// RemoteURL returns what the skill is checked against for updates: the git
+// repo URL, the archive URL or the oci:// reference. Empty for local skills.
+func (m *SkillMeta) RemoteURL() string {
+	switch {
+	case m.RepoURL != "":
+		return m.RepoURL
+	case m.IsOCI():
+		return m.Source
+	case m.IsArchive():
+		if source, err := ParseSource(m.Source); err == nil {
+			return source.ArchiveURL
+		}
+	}
+	return ""
+}
+
 // NewMetaFromSource creates a SkillMeta from a Source
 func NewMetaFromSource(source *Source) *SkillMeta {
 	meta := &SkillMeta{
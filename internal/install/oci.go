@@ -0,0 +1,100 @@
+package install
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"skillshare/internal/oci"
+)
+
+func installFromOCI(source *Source, destPath string, result *InstallResult, opts InstallOptions) (*InstallResult, error) {
+	if opts.DryRun {
+		result.Action = "would pull"
+		return result, nil
+	}
+
+	artifact, err := oci.NewClient().Pull(source.OCIRef)
+	if err != nil {
+		return nil, fmt.Errorf("failed to pull %s: %w", source.OCIRef, err)
+	}
+	result.Downloaded = int64(len(artifact.Layer))
+
+	tempDir, err := os.MkdirTemp("", "skillshare-install-*")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create temp directory: %w", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	root := filepath.Join(tempDir, "skill")
+	gz, err := gzip.NewReader(bytes.NewReader(artifact.Layer))
+	if err != nil {
+		return nil, fmt.Errorf("failed to extract %s: %w", source.OCIRef, err)
+	}
+	defer gz.Close()
+	if err := extractTar(gz, root); err != nil {
+		return nil, fmt.Errorf("failed to extract %s: %w", source.OCIRef, err)
+	}
+
+	if err := copyDir(root, destPath); err != nil {
+		return nil, fmt.Errorf("failed to copy skill: %w", err)
+	}
+
+	// Security audit
+	if err := auditInstalledSkill(destPath, result, opts); err != nil {
+		return nil, err
+	}
+
+	// Write metadata
+	meta := NewMetaFromSource(source)
+	meta.Version = artifact.Annotations[oci.AnnotationVersion]
+	if meta.Version == "" {
+		meta.Version = source.OCIRef.Tag
+	}
+	meta.Digest = artifact.Digest
+	if err := WriteMeta(destPath, meta); err != nil {
+		result.Warnings = append(result.Warnings, fmt.Sprintf("failed to write metadata: %v", err))
+	}
+
+	// Check for SKILL.md
+	checkSkillFile(destPath, result)
+
+	result.Action = "pulled"
+	return result, nil
+}
+
+// OCIUpdate is the outcome of checking an OCI skill for updates.
+type OCIUpdate struct {
+	Changed   bool     // The installed tag now points at a different digest
+	NewerTags []string // Version tags above the installed one, highest first
+}
+
+// CheckOCIUpdate asks the registry whether the tag a skill was installed
+// from has moved, and which newer version tags exist. Skills pinned by
+// digest only report newer tags.
+func CheckOCIUpdate(meta *SkillMeta) (*OCIUpdate, error) {
+	ref, err := oci.ParseReference(meta.Source)
+	if err != nil {
+		return nil, err
+	}
+	client := oci.NewClient()
+	update := &OCIUpdate{}
+
+	if ref.Digest == "" {
+		digest, err := client.Resolve(ref)
+		if err != nil {
+			return nil, err
+		}
+		update.Changed = digest != meta.Digest
+	}
+	if ref.Tag != "" {
+		tags, err := client.Tags(ref)
+		if err != nil {
+			return nil, err
+		}
+		update.NewerTags = oci.NewerTags(ref.Tag, tags)
+	}
+	return update, nil
+}
@@ -0,0 +1,46 @@
+package install
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"skillshare/internal/oci"
+	"skillshare/internal/testutil"
+)
+
+func TestInstallFromOCI_RejectsSymlinkChain(t *testing.T) {
+	t.Setenv("DOCKER_CONFIG", t.TempDir())
+	reg := testutil.NewRegistry(t)
+
+	var layer bytes.Buffer
+	gz := gzip.NewWriter(&layer)
+	tw := tar.NewWriter(gz)
+	tw.WriteHeader(&tar.Header{Name: "d", Linkname: ".", Typeflag: tar.TypeSymlink})
+	tw.WriteHeader(&tar.Header{Name: "d/e", Linkname: "..", Typeflag: tar.TypeSymlink})
+	tw.WriteHeader(&tar.Header{Name: "e/pwned.txt", Mode: 0644, Size: 1, Typeflag: tar.TypeReg})
+	tw.Write([]byte("x"))
+	tw.Close()
+	gz.Close()
+
+	source, err := ParseSource("oci://" + reg.Host() + "/team/evil:1.0.0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := oci.NewClient().Push(source.OCIRef, layer.Bytes(), nil); err != nil {
+		t.Fatalf("Push: %v", err)
+	}
+
+	dest := filepath.Join(t.TempDir(), "evil")
+	_, err = installFromOCI(source, dest, &InstallResult{}, InstallOptions{SkipAudit: true})
+	if err == nil || !strings.Contains(err.Error(), "through symlink") {
+		t.Fatalf("err = %v, want symlink escape rejected", err)
+	}
+	if _, err := os.Stat(dest); err == nil {
+		t.Error("skill installed from a layer with a symlink escape")
+	}
+}
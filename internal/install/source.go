@@ -6,6 +6,9 @@ import (
 	"path/filepath"
 	"regexp"
 	"strings"
+
+	"skillshare/internal/network"
+	"skillshare/internal/oci"
 )
 
 // SourceType represents the type of installation source
@@ -17,6 +20,8 @@ const (
 	SourceTypeGitHub
 	SourceTypeGitHTTPS
 	SourceTypeGitSSH
+	SourceTypeArchive
+	SourceTypeOCI
 )
 
 func (t SourceType) String() string {
@@ -29,6 +34,10 @@ func (t SourceType) String() string {
 		return "git-https"
 	case SourceTypeGitSSH:
 		return "git-ssh"
+	case SourceTypeArchive:
+		return "archive"
+	case SourceTypeOCI:
+		return "oci"
 	default:
 		return "unknown"
 	}
@@ -42,6 +51,11 @@ type Source struct {
 	Subdir   string // Subdirectory path for monorepo
 	Path     string // Local path (empty for git)
 	Name     string // Derived skill name
+
+	ArchiveURL string // Archive download URL (archive sources only)
+	SHA256     string // Pinned archive checksum from #sha256= (optional)
+
+	OCIRef oci.Reference // Registry reference (oci sources only)
 }
 
 // GitHub URL pattern: github.com/owner/repo[/path/to/subdir]
@@ -53,6 +67,9 @@ var gitSSHPattern = regexp.MustCompile(`^git@([^:]+):([^/]+)/(.+?)(?:\.git)?(?:/
 // Git HTTPS pattern: https://host/owner/repo[.git]
 var gitHTTPSPattern = regexp.MustCompile(`^https?://([^/]+)/([^/]+)/([^/]+?)(?:\.git)?(?:/(.+))?$`)
 
+// Archive pattern: (https|http|file)://…/name.(tar.gz|tgz|tar|zip)[//subdir][#sha256=<hex>]
+var archivePattern = regexp.MustCompile(`^((?:https?|file)://[^#]*?\.(?:tar\.gz|tgz|tar|zip))(?://([^#]*))?(?:#sha256=([0-9a-fA-F]{64}))?$`)
+
 // File URL pattern: file:///path/to/repo
 var fileURLPattern = regexp.MustCompile(`^file://(.+)$`)
 
@@ -63,11 +80,35 @@ func ParseSource(input string) (*Source, error) {
 		return nil, fmt.Errorf("source cannot be empty")
 	}
 
+	// A mirror URL is recorded as the URL it mirrors; fetches are
+	// rewritten back to the mirror
+	input = network.Canonical(input)
+
 	// Expand GitHub shorthand: owner/repo -> github.com/owner/repo
 	input = expandGitHubShorthand(input)
 
 	source := &Source{Raw: input}
 
+	// Check for OCI registry reference
+	if strings.HasPrefix(input, oci.Scheme) {
+		ref, err := oci.ParseReference(input)
+		if err != nil {
+			return nil, err
+		}
+		source.Type = SourceTypeOCI
+		source.OCIRef = ref
+		source.Name = ref.Name()
+		return source, nil
+	}
+
+	// Check for archive URL before git URLs: release assets live on git hosts too
+	if matches := archivePattern.FindStringSubmatch(input); matches != nil {
+		return parseArchive(matches, source)
+	}
+	if strings.Contains(input, "#sha256=") {
+		return nil, fmt.Errorf("#sha256= is only supported for .tar.gz, .tgz, .tar and .zip archive URLs")
+	}
+
 	// Check for file:// URL (for testing with local git repos)
 	if matches := fileURLPattern.FindStringSubmatch(input); matches != nil {
 		return parseFileURL(matches, source)
@@ -114,6 +155,7 @@ func expandGitHubShorthand(input string) string {
 		strings.HasPrefix(input, "https://") ||
 		strings.HasPrefix(input, "git@") ||
 		strings.HasPrefix(input, "file://") ||
+		strings.HasPrefix(input, oci.Scheme) ||
 		isLocalPath(input) {
 		return input
 	}
@@ -245,6 +287,53 @@ func parseFileURL(matches []string, source *Source) (*Source, error) {
 	return source, nil
 }
 
+func parseArchive(matches []string, source *Source) (*Source, error) {
+	// matches: [full, url, subdir, sha256]
+	source.Type = SourceTypeArchive
+	source.ArchiveURL = matches[1]
+	source.SHA256 = strings.ToLower(matches[3])
+
+	subdir := strings.Trim(matches[2], "/")
+	if subdir == "." {
+		subdir = ""
+	}
+	if subdir != "" {
+		source.Subdir = subdir
+		source.Name = filepath.Base(subdir)
+	} else {
+		source.Name = archiveBaseName(source.ArchiveURL)
+	}
+	return source, nil
+}
+
+// archiveBaseName returns the file name of an archive URL without its
+// extension: https://host/dl/pdf-skill.tar.gz → "pdf-skill".
+func archiveBaseName(url string) string {
+	name := url[strings.LastIndex(url, "/")+1:]
+	for _, ext := range archiveExtensions {
+		if strings.HasSuffix(name, ext) {
+			return strings.TrimSuffix(name, ext)
+		}
+	}
+	return name
+}
+
+// archiveExtensions lists the supported archive formats, longest first.
+var archiveExtensions = []string{".tar.gz", ".tgz", ".tar", ".zip"}
+
+// WithSubdir returns the source string for a subdirectory of an archive
+// source, keeping the checksum pin: <url>//<subdir>#sha256=<hex>.
+func (s *Source) WithSubdir(subdir string) string {
+	raw := s.ArchiveURL
+	if subdir != "" {
+		raw += "//" + subdir
+	}
+	if s.SHA256 != "" {
+		raw += "#sha256=" + s.SHA256
+	}
+	return raw
+}
+
 func parseGitHTTPS(matches []string, source *Source) (*Source, error) {
 	// matches: [full, host, owner, repo, subdir]
 	host := matches[1]
@@ -328,6 +417,16 @@ func (s *Source) IsGit() bool {
 		s.Type == SourceTypeGitSSH
 }
 
+// IsArchive returns true if this source is a downloadable archive
+func (s *Source) IsArchive() bool {
+	return s.Type == SourceTypeArchive
+}
+
+// IsOCI returns true if this source is an OCI registry artifact
+func (s *Source) IsOCI() bool {
+	return s.Type == SourceTypeOCI
+}
+
 // TrackName returns a unique name for --track mode in "owner-repo" format.
 // For GitHub: https://github.com/openai/skills.git → "openai-skills"
 // For SSH:    git@github.com:openai/skills.git    → "openai-skills"
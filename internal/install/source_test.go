@@ -1,7 +1,10 @@
 package install
 
 import (
+	"strings"
 	"testing"
+
+	"skillshare/internal/network"
 )
 
 func TestParseSource_LocalPath(t *testing.T) {
@@ -370,6 +373,114 @@ func TestParseSource_FileURL(t *testing.T) {
 	}
 }
 
+func TestParseSource_MirrorURLIsCanonical(t *testing.T) {
+	if err := network.Configure(network.Config{Rewrites: []network.Rewrite{
+		{From: "https://github.com/", To: "https://git.corp/github/"},
+	}}); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { network.Configure(network.Config{}) })
+
+	source, err := ParseSource("https://git.corp/github/org/skills/pdf")
+	if err != nil {
+		t.Fatalf("ParseSource() error = %v", err)
+	}
+	if source.CloneURL != "https://github.com/org/skills.git" || source.Subdir != "pdf" {
+		t.Errorf("CloneURL = %s, Subdir = %s; want the github.com repository", source.CloneURL, source.Subdir)
+	}
+}
+
+func TestParseSource_OCI(t *testing.T) {
+	source, err := ParseSource("oci://harbor.example.com/team/skills/pdf:1.2.0")
+	if err != nil {
+		t.Fatalf("ParseSource() error = %v", err)
+	}
+	if !source.IsOCI() || source.IsGit() || source.IsArchive() {
+		t.Errorf("Type = %v, want oci", source.Type)
+	}
+	if source.OCIRef.Registry != "harbor.example.com" || source.OCIRef.Repository != "team/skills/pdf" || source.OCIRef.Tag != "1.2.0" {
+		t.Errorf("OCIRef = %+v", source.OCIRef)
+	}
+	if source.Name != "pdf" {
+		t.Errorf("Name = %v, want pdf", source.Name)
+	}
+
+	if _, err := ParseSource("oci://harbor.example.com"); err == nil {
+		t.Error("expected error for OCI reference without repository")
+	}
+}
+
+func TestParseSource_Archive(t *testing.T) {
+	sum := "9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08"
+	tests := []struct {
+		name       string
+		input      string
+		wantURL    string
+		wantSubdir string
+		wantSHA    string
+		wantName   string
+	}{
+		{
+			name:     "tarball",
+			input:    "https://example.com/dl/pdf-skill.tar.gz",
+			wantURL:  "https://example.com/dl/pdf-skill.tar.gz",
+			wantName: "pdf-skill",
+		},
+		{
+			name:       "release asset with subdir and checksum",
+			input:      "https://github.com/org/skills/releases/download/v1.2/skills.zip//skills/pdf#sha256=" + strings.ToUpper(sum),
+			wantURL:    "https://github.com/org/skills/releases/download/v1.2/skills.zip",
+			wantSubdir: "skills/pdf",
+			wantSHA:    sum,
+			wantName:   "pdf",
+		},
+		{
+			name:     "file archive",
+			input:    "file:///tmp/bundle.tgz",
+			wantURL:  "file:///tmp/bundle.tgz",
+			wantName: "bundle",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			source, err := ParseSource(tt.input)
+			if err != nil {
+				t.Fatalf("ParseSource() error = %v", err)
+			}
+			if !source.IsArchive() || source.IsGit() {
+				t.Errorf("Type = %v, want archive", source.Type)
+			}
+			if source.ArchiveURL != tt.wantURL {
+				t.Errorf("ArchiveURL = %v, want %v", source.ArchiveURL, tt.wantURL)
+			}
+			if source.Subdir != tt.wantSubdir {
+				t.Errorf("Subdir = %v, want %v", source.Subdir, tt.wantSubdir)
+			}
+			if source.SHA256 != tt.wantSHA {
+				t.Errorf("SHA256 = %v, want %v", source.SHA256, tt.wantSHA)
+			}
+			if source.Name != tt.wantName {
+				t.Errorf("Name = %v, want %v", source.Name, tt.wantName)
+			}
+		})
+	}
+
+	t.Run("WithSubdir keeps checksum", func(t *testing.T) {
+		source, _ := ParseSource("https://example.com/skills.zip#sha256=" + sum)
+		want := "https://example.com/skills.zip//skills/pdf#sha256=" + sum
+		if got := source.WithSubdir("skills/pdf"); got != want {
+			t.Errorf("WithSubdir() = %v, want %v", got, want)
+		}
+	})
+
+	t.Run("checksum on git source", func(t *testing.T) {
+		if _, err := ParseSource("https://github.com/org/repo#sha256=" + sum); err == nil {
+			t.Error("expected error for #sha256= on a git source")
+		}
+	})
+}
+
 func TestParseSource_Errors(t *testing.T) {
 	tests := []struct {
 		name  string
@@ -0,0 +1,134 @@
+package install
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+
+	"skillshare/internal/oci"
+)
+
+// Git config keys in a tracked repo recording the branch it follows or the
+// ref it is pinned at. They travel with the clone, so reconcile can carry
+// them into the skills list of the config.
+const (
+	trackedBranchKey = "skillshare.branch"
+	trackedRefKey    = "skillshare.ref"
+)
+
+// TrackedRepoRefs returns the branch a tracked repo was switched to and the
+// tag or commit it is pinned at. Both are empty for a repo following its
+// default branch; ref is set for pinned repos, which update leaves alone.
+func TrackedRepoRefs(repoPath string) (branch, ref string) {
+	branch, _ = gitOutput(repoPath, "config", "--local", "--get", trackedBranchKey)
+	ref, _ = gitOutput(repoPath, "config", "--local", "--get", trackedRefKey)
+	return branch, ref
+}
+
+// CheckoutTrackedRepo fetches origin and switches a tracked repo to ref. A
+// branch of origin is checked out and followed by later updates; a tag or
+// commit detaches the repo and pins it there. Reports whether the repo is
+// now pinned.
+func CheckoutTrackedRepo(repoPath, ref string) (pinned bool, err error) {
+	if strings.HasPrefix(ref, "-") {
+		return false, fmt.Errorf("invalid ref: %s", ref)
+	}
+	env := gitEnv(getRemoteURL(repoPath))
+	if err := runGitCommandEnv([]string{"fetch", "--quiet", "--tags", "--force", "origin"}, repoPath, env); err != nil {
+		return false, fmt.Errorf("failed to fetch: %w", err)
+	}
+
+	if _, err := gitOutput(repoPath, "rev-parse", "--verify", "--quiet", "refs/remotes/origin/"+ref); err == nil {
+		if err := runGitCommand([]string{"checkout", "--quiet", "-B", ref, "--track", "origin/" + ref}, repoPath); err != nil {
+			return false, fmt.Errorf("failed to check out branch %s: %w", ref, err)
+		}
+		branch := ref
+		if head, err := gitOutput(repoPath, "symbolic-ref", "--quiet", "--short", "refs/remotes/origin/HEAD"); err == nil && head == "origin/"+ref {
+			branch = "" // back on the default branch
+		}
+		return false, setTrackedRepoRefs(repoPath, branch, "")
+	}
+
+	if _, err := gitOutput(repoPath, "rev-parse", "--verify", "--quiet", ref+"^{commit}"); err != nil {
+		return false, fmt.Errorf("'%s' is not a branch, tag or commit of origin", ref)
+	}
+	if err := runGitCommand([]string{"checkout", "--quiet", "--detach", ref}, repoPath); err != nil {
+		return false, fmt.Errorf("failed to check out %s: %w", ref, err)
+	}
+	return true, setTrackedRepoRefs(repoPath, "", ref)
+}
+
+// setTrackedRepoRefs records the branch and pin of a tracked repo; an
+// empty value removes the key.
+func setTrackedRepoRefs(repoPath, branch, ref string) error {
+	for key, value := range map[string]string{trackedBranchKey: branch, trackedRefKey: ref} {
+		if value == "" {
+			// Exits 5 when the key is not set; nothing to remove then.
+			gitOutput(repoPath, "config", "--local", "--unset", key) //nolint:errcheck
+			continue
+		}
+		if _, err := gitOutput(repoPath, "config", "--local", key, value); err != nil {
+			return fmt.Errorf("failed to record %s: %w", key, err)
+		}
+	}
+	return nil
+}
+
+// RemoteVersionTags lists the tags of a tracked repo's origin that look
+// like versions (1.2.0, v2); other tags are dropped.
+func RemoteVersionTags(repoPath string) ([]string, error) {
+	remoteURL := getRemoteURL(repoPath)
+	if remoteURL == "" {
+		return nil, fmt.Errorf("no origin remote")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), gitCommandTimeout)
+	defer cancel()
+	env := gitEnv(remoteURL)
+	cmd := gitCommand(ctx, "ls-remote", "--tags", "--refs", remoteURL)
+	cmd.Env = append(cmd.Env, env...)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, wrapGitError(stderr.String(), err, usedTokenAuth(env))
+	}
+
+	var tags []string
+	for _, line := range strings.Split(string(out), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) != 2 {
+			continue
+		}
+		tag := strings.TrimPrefix(fields[1], "refs/tags/")
+		if oci.LatestTag([]string{tag}) != "" {
+			tags = append(tags, tag)
+		}
+	}
+	return tags, nil
+}
+
+// PinUpdate is the outcome of checking a pinned tracked repo.
+type PinUpdate struct {
+	Ref    string // The ref the repo is pinned at
+	Latest string // Highest version tag above Ref; empty if none, or Ref is not a version
+}
+
+// CheckPinnedRepo reports the newest version tag above the pin of a tracked
+// repo. Returns nil for repos that are not pinned.
+func CheckPinnedRepo(repoPath string) (*PinUpdate, error) {
+	_, ref := TrackedRepoRefs(repoPath)
+	if ref == "" {
+		return nil, nil
+	}
+	update := &PinUpdate{Ref: ref}
+	tags, err := RemoteVersionTags(repoPath)
+	if err != nil {
+		return update, err
+	}
+	if newer := oci.NewerTags(ref, tags); len(newer) > 0 {
+		update.Latest = newer[0]
+	}
+	return update, nil
+}
@@ -0,0 +1,529 @@
+// Package lint checks skills for frontmatter and structure problems.
+package lint
+
+import (
+	"fmt"
+	"io/fs"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"skillshare/internal/config"
+	"skillshare/internal/utils"
+)
+
+const (
+	defaultMaxBodyLines         = 500
+	defaultDescriptionMinLength = 30
+	defaultDescriptionMaxLength = 1024
+)
+
+// Finding is a single lint problem. File is relative to the skill
+// directory; Line is 1-based, or 0 when the problem has no line.
+type Finding struct {
+	Rule     string `json:"rule"`
+	Severity string `json:"severity"`
+	Message  string `json:"message"`
+	File     string `json:"file"`
+	Line     int    `json:"line,omitempty"`
+	Fixable  bool   `json:"fixable,omitempty"`
+}
+
+// Result holds the findings for one skill.
+type Result struct {
+	Skill    string    `json:"skill"`
+	Path     string    `json:"path"`
+	Findings []Finding `json:"findings"`
+	Fixed    []string  `json:"fixed,omitempty"`
+}
+
+// Count returns the number of findings at each severity.
+func (r *Result) Count() (errors, warnings, infos int) {
+	for _, f := range r.Findings {
+		switch f.Severity {
+		case SeverityError:
+			errors++
+		case SeverityWarning:
+			warnings++
+		default:
+			infos++
+		}
+	}
+	return
+}
+
+// Options configures Check.
+type Options struct {
+	Config config.LintConfig
+	// Targets are extra target names accepted in frontmatter targets,
+	// typically the targets configured by the user.
+	Targets []string
+}
+
+// Check lints the skill at dir. name is the display name used in results.
+func Check(dir, name string, opts Options) *Result {
+	res := &Result{Skill: name, Path: dir, Findings: []Finding{}}
+	c := &checker{dir: dir, opts: opts, res: res}
+	c.run()
+	sort.SliceStable(res.Findings, func(i, j int) bool {
+		a, b := res.Findings[i], res.Findings[j]
+		if a.File != b.File {
+			return a.File < b.File
+		}
+		return a.Line < b.Line
+	})
+	return res
+}
+
+// Fix applies the safe fixes for r's fixable findings and lints the skill
+// again. The names of applied fixes are recorded in the new result's Fixed.
+func Fix(r *Result, opts Options) (*Result, error) {
+	var fixed []string
+	for _, f := range r.Findings {
+		if !f.Fixable {
+			continue
+		}
+		switch f.Rule {
+		case RuleNameRequired, RuleNameMatchesDir:
+			skillFile := filepath.Join(r.Path, "SKILL.md")
+			info, err := os.Stat(skillFile)
+			if err != nil {
+				return r, err
+			}
+			data, err := os.ReadFile(skillFile)
+			if err != nil {
+				return r, err
+			}
+			updated := utils.SetFrontmatterName(string(data), filepath.Base(r.Path))
+			// Keep the file's permissions; the skill may be group-writable
+			// or private.
+			if err := config.WriteFileAtomic(skillFile, []byte(updated), info.Mode().Perm()); err != nil {
+				return r, err
+			}
+			fixed = append(fixed, fmt.Sprintf("SKILL.md: set name to %s", filepath.Base(r.Path)))
+		case RuleScriptExecutable:
+			p := filepath.Join(r.Path, filepath.FromSlash(f.File))
+			info, err := os.Stat(p)
+			if err != nil {
+				return r, err
+			}
+			if err := os.Chmod(p, info.Mode().Perm()|0111); err != nil {
+				return r, err
+			}
+			fixed = append(fixed, fmt.Sprintf("%s: made executable", f.File))
+		}
+	}
+	out := Check(r.Path, r.Skill, opts)
+	out.Fixed = fixed
+	return out, nil
+}
+
+type checker struct {
+	dir  string
+	opts Options
+	res  *Result
+
+	fields    map[string]*yaml.Node
+	keyLines  map[string]int
+	body      []string
+	bodyStart int // file line of the first body line
+	content   string
+}
+
+func (c *checker) report(rule, file string, line int, format string, args ...any) {
+	def, _ := findRule(rule)
+	severity := def.Severity
+	if override, ok := c.opts.Config.Rules[rule]; ok {
+		severity = strings.ToLower(override)
+	}
+	if severity == SeverityOff {
+		return
+	}
+	c.res.Findings = append(c.res.Findings, Finding{
+		Rule:     rule,
+		Severity: severity,
+		Message:  fmt.Sprintf(format, args...),
+		File:     file,
+		Line:     line,
+		Fixable:  def.Fixable,
+	})
+}
+
+func (c *checker) run() {
+	data, err := os.ReadFile(filepath.Join(c.dir, "SKILL.md"))
+	if err != nil {
+		c.report(RuleSkillFile, "SKILL.md", 0, "SKILL.md not found")
+		return
+	}
+	c.content = string(data)
+	if !c.parse() {
+		return
+	}
+	c.checkName()
+	c.checkDescription()
+	c.checkKeys()
+	c.checkTargets()
+	c.checkAllowedTools()
+	c.checkBody()
+	c.checkLinks()
+	c.checkReferences()
+	c.checkScripts()
+}
+
+// parse splits SKILL.md into frontmatter fields and body. It returns false
+// when the frontmatter is too broken for field checks.
+func (c *checker) parse() bool {
+	lines := strings.Split(strings.ReplaceAll(c.content, "\r\n", "\n"), "\n")
+	c.fields = map[string]*yaml.Node{}
+	c.keyLines = map[string]int{}
+
+	if strings.TrimSpace(lines[0]) != "---" {
+		c.report(RuleFrontmatter, "SKILL.md", 1, "missing frontmatter block (--- name/description ---)")
+		c.report(RuleNameRequired, "SKILL.md", 1, "name is required")
+		c.body, c.bodyStart = lines, 1
+		return false
+	}
+	end := -1
+	for i := 1; i < len(lines); i++ {
+		if strings.TrimSpace(lines[i]) == "---" {
+			end = i
+			break
+		}
+	}
+	if end < 0 {
+		c.report(RuleFrontmatter, "SKILL.md", 1, "frontmatter is not closed with ---")
+		return false
+	}
+	c.body, c.bodyStart = lines[end+1:], end+2
+
+	var doc yaml.Node
+	if err := yaml.Unmarshal([]byte(strings.Join(lines[1:end], "\n")), &doc); err != nil {
+		c.report(RuleFrontmatter, "SKILL.md", 1, "invalid YAML: %v", err)
+		return false
+	}
+	if len(doc.Content) == 0 {
+		return true
+	}
+	root := doc.Content[0]
+	if root.Kind != yaml.MappingNode {
+		c.report(RuleFrontmatter, "SKILL.md", 2, "frontmatter must be a mapping of key: value")
+		return false
+	}
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		key := root.Content[i]
+		c.fields[key.Value] = root.Content[i+1]
+		c.keyLines[key.Value] = key.Line + 1 // +1 for the opening ---
+	}
+	return true
+}
+
+func (c *checker) scalar(key string) string {
+	n, ok := c.fields[key]
+	if !ok || n.Kind != yaml.ScalarNode {
+		return ""
+	}
+	return strings.TrimSpace(n.Value)
+}
+
+func (c *checker) checkName() {
+	name := c.scalar("name")
+	dirName := filepath.Base(c.dir)
+	switch {
+	case name == "":
+		c.report(RuleNameRequired, "SKILL.md", c.keyLines["name"], "name is required")
+	case name != dirName:
+		c.report(RuleNameMatchesDir, "SKILL.md", c.keyLines["name"], "name %q does not match directory %q", name, dirName)
+	}
+}
+
+var triggerPattern = regexp.MustCompile(`(?i)\b(use (this skill |it )?(when|for|if|to)|when (the )?user|trigger|invoke when)\b|"[^"]{3,}"`)
+
+func (c *checker) checkDescription() {
+	desc := c.scalar("description")
+	line := c.keyLines["description"]
+	if desc == "" {
+		c.report(RuleDescription, "SKILL.md", line, "description is required")
+		return
+	}
+
+	minLen := c.opts.Config.DescriptionMinLength
+	if minLen == 0 {
+		minLen = defaultDescriptionMinLength
+	}
+	maxLen := c.opts.Config.DescriptionMaxLength
+	if maxLen == 0 {
+		maxLen = defaultDescriptionMaxLength
+	}
+	n := len([]rune(desc))
+	if n < minLen {
+		c.report(RuleDescriptionLength, "SKILL.md", line, "description is %d characters, at least %d recommended", n, minLen)
+	} else if n > maxLen {
+		c.report(RuleDescriptionLength, "SKILL.md", line, "description is %d characters, at most %d allowed", n, maxLen)
+	}
+	if !triggerPattern.MatchString(desc) {
+		c.report(RuleDescriptionTrigger, "SKILL.md", line, "description should say when to use the skill (e.g. \"Use when the user asks to ...\")")
+	}
+}
+
+func (c *checker) checkKeys() {
+	known := map[string]bool{}
+	for _, k := range KnownFrontmatterKeys {
+		known[k] = true
+	}
+	for key := range c.fields {
+		if !known[key] {
+			c.report(RuleUnknownKey, "SKILL.md", c.keyLines[key], "unknown frontmatter key %q", key)
+		}
+	}
+}
+
+func (c *checker) checkTargets() {
+	n, ok := c.fields["targets"]
+	if !ok {
+		return
+	}
+	line := c.keyLines["targets"]
+	var values []string
+	switch n.Kind {
+	case yaml.SequenceNode:
+		for _, item := range n.Content {
+			values = append(values, item.Value)
+		}
+	case yaml.ScalarNode:
+		if n.Tag == "!!null" {
+			return
+		}
+		values = []string{n.Value}
+	default:
+		c.report(RuleUnknownTarget, "SKILL.md", line, "targets must be a list of target names")
+		return
+	}
+
+	known := map[string]bool{}
+	for _, t := range config.KnownTargetNames() {
+		known[t] = true
+	}
+	for _, t := range c.opts.Targets {
+		known[t] = true
+	}
+	for _, v := range values {
+		if !known[v] {
+			c.report(RuleUnknownTarget, "SKILL.md", line, "unknown target %q", v)
+		}
+	}
+}
+
+var toolPattern = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_.-]*(\([^()]*\))?$`)
+
+func (c *checker) checkAllowedTools() {
+	n, ok := c.fields["allowed-tools"]
+	if !ok {
+		return
+	}
+	line := c.keyLines["allowed-tools"]
+	var tools []string
+	switch n.Kind {
+	case yaml.ScalarNode:
+		var err error
+		if tools, err = splitTools(n.Value); err != nil {
+			c.report(RuleAllowedTools, "SKILL.md", line, "allowed-tools: %v", err)
+			return
+		}
+	case yaml.SequenceNode:
+		for _, item := range n.Content {
+			tools = append(tools, strings.TrimSpace(item.Value))
+		}
+	default:
+		c.report(RuleAllowedTools, "SKILL.md", line, "allowed-tools must be a string or a list")
+		return
+	}
+	for _, tool := range tools {
+		if !toolPattern.MatchString(tool) {
+			c.report(RuleAllowedTools, "SKILL.md", line, "malformed tool %q (expected Name or Name(pattern))", tool)
+		}
+	}
+}
+
+// splitTools splits an allowed-tools string on spaces and commas outside
+// parentheses: "Bash(git add:*) Read, Grep" -> [Bash(git add:*) Read Grep].
+func splitTools(s string) ([]string, error) {
+	var tools []string
+	var cur strings.Builder
+	depth := 0
+	flush := func() {
+		if cur.Len() > 0 {
+			tools = append(tools, cur.String())
+			cur.Reset()
+		}
+	}
+	for _, r := range s {
+		switch {
+		case r == '(':
+			depth++
+		case r == ')':
+			depth--
+			if depth < 0 {
+				return nil, fmt.Errorf("unbalanced parentheses")
+			}
+		case (r == ' ' || r == ',' || r == '\t' || r == '\n') && depth == 0:
+			flush()
+			continue
+		}
+		cur.WriteRune(r)
+	}
+	if depth != 0 {
+		return nil, fmt.Errorf("unbalanced parentheses")
+	}
+	flush()
+	return tools, nil
+}
+
+func (c *checker) checkBody() {
+	maxLines := c.opts.Config.MaxBodyLines
+	if maxLines == 0 {
+		maxLines = defaultMaxBodyLines
+	}
+	n := len(c.body)
+	for n > 0 && strings.TrimSpace(c.body[n-1]) == "" {
+		n--
+	}
+	if n > maxLines {
+		c.report(RuleBodySize, "SKILL.md", c.bodyStart, "body is %d lines, keep it under %d and move detail to references/", n, maxLines)
+	}
+}
+
+var linkPattern = regexp.MustCompile(`!?\[[^\]]*\]\(([^)\s]+)(?:\s+"[^"]*")?\)`)
+
+// links returns the relative link targets in the SKILL.md body with their
+// file lines, skipping fenced code blocks.
+func (c *checker) links() map[string]int {
+	out := map[string]int{}
+	inFence := false
+	for i, line := range c.body {
+		if strings.HasPrefix(strings.TrimSpace(line), "```") {
+			inFence = !inFence
+			continue
+		}
+		if inFence {
+			continue
+		}
+		for _, m := range linkPattern.FindAllStringSubmatch(line, -1) {
+			target := m[1]
+			if strings.Contains(target, "://") || strings.HasPrefix(target, "#") ||
+				strings.HasPrefix(target, "mailto:") || strings.HasPrefix(target, "/") {
+				continue
+			}
+			if j := strings.IndexAny(target, "#?"); j >= 0 {
+				target = target[:j]
+			}
+			if decoded, err := url.PathUnescape(target); err == nil {
+				target = decoded
+			}
+			target = path.Clean(strings.TrimPrefix(target, "./"))
+			if _, seen := out[target]; !seen {
+				out[target] = c.bodyStart + i
+			}
+		}
+	}
+	return out
+}
+
+func (c *checker) checkLinks() {
+	links := c.links()
+	targets := make([]string, 0, len(links))
+	for t := range links {
+		targets = append(targets, t)
+	}
+	sort.Strings(targets)
+	for _, t := range targets {
+		if strings.HasPrefix(t, "../") {
+			continue
+		}
+		if _, err := os.Stat(filepath.Join(c.dir, filepath.FromSlash(t))); err != nil {
+			c.report(RuleMissingFile, "SKILL.md", links[t], "linked file %s does not exist", t)
+		}
+	}
+}
+
+// checkReferences reports files under references/ that neither SKILL.md
+// nor another reachable reference mentions.
+func (c *checker) checkReferences() {
+	refDir := filepath.Join(c.dir, "references")
+	var files []string
+	filepath.WalkDir(refDir, func(p string, d fs.DirEntry, err error) error { //nolint:errcheck
+		if err != nil || d.IsDir() || utils.IsHidden(d.Name()) {
+			return nil
+		}
+		rel, _ := filepath.Rel(c.dir, p)
+		files = append(files, filepath.ToSlash(rel))
+		return nil
+	})
+	if len(files) == 0 {
+		return
+	}
+
+	reachable := map[string]bool{}
+	texts := []string{c.content}
+	for changed := true; changed; {
+		changed = false
+		for _, f := range files {
+			if reachable[f] {
+				continue
+			}
+			inRefs := strings.TrimPrefix(f, "references/")
+			for _, text := range texts {
+				if strings.Contains(text, f) || strings.Contains(text, inRefs) {
+					reachable[f] = true
+					changed = true
+					if data, err := os.ReadFile(filepath.Join(c.dir, filepath.FromSlash(f))); err == nil {
+						texts = append(texts, string(data))
+					}
+					break
+				}
+			}
+		}
+	}
+
+	for _, f := range files {
+		if !reachable[f] {
+			c.report(RuleUnreachableRef, f, 0, "%s is not linked from SKILL.md", f)
+		}
+	}
+}
+
+func (c *checker) checkScripts() {
+	if runtime.GOOS == "windows" {
+		return
+	}
+	scriptsDir := filepath.Join(c.dir, "scripts")
+	filepath.WalkDir(scriptsDir, func(p string, d fs.DirEntry, err error) error { //nolint:errcheck
+		if err != nil || d.IsDir() || !d.Type().IsRegular() {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil || info.Mode().Perm()&0111 != 0 || !hasShebang(p) {
+			return nil
+		}
+		rel, _ := filepath.Rel(c.dir, p)
+		c.report(RuleScriptExecutable, filepath.ToSlash(rel), 1, "script has a shebang but is not executable")
+		return nil
+	})
+}
+
+func hasShebang(p string) bool {
+	f, err := os.Open(p)
+	if err != nil {
+		return false
+	}
+	defer f.Close()
+	buf := make([]byte, 2)
+	n, _ := f.Read(buf)
+	return n == 2 && string(buf) == "#!"
+}
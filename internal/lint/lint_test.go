@@ -0,0 +1,237 @@
+package lint
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"skillshare/internal/config"
+)
+
+const goodSkill = `---
+name: pdf
+description: Extract text and tables from PDF files. Use when the user asks to read or convert a PDF.
+allowed-tools: Bash(python:*) Read, Grep
+targets: [claude]
+---
+
+# PDF
+
+See [usage](references/usage.md).
+`
+
+func writeSkill(t *testing.T, name string, files map[string]string) string {
+	t.Helper()
+	dir := filepath.Join(t.TempDir(), name)
+	for rel, content := range files {
+		p := filepath.Join(dir, filepath.FromSlash(rel))
+		if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(p, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func rulesOf(r *Result) []string {
+	var ids []string
+	for _, f := range r.Findings {
+		ids = append(ids, f.Rule)
+	}
+	return ids
+}
+
+func hasRule(r *Result, id string) bool {
+	for _, f := range r.Findings {
+		if f.Rule == id {
+			return true
+		}
+	}
+	return false
+}
+
+func TestCheck_CleanSkill(t *testing.T) {
+	dir := writeSkill(t, "pdf", map[string]string{
+		"SKILL.md":            goodSkill,
+		"references/usage.md": "usage",
+	})
+	if r := Check(dir, "pdf", Options{}); len(r.Findings) != 0 {
+		t.Errorf("Findings = %+v, want none", r.Findings)
+	}
+}
+
+func TestCheck_HooksKeyIsKnown(t *testing.T) {
+	dir := writeSkill(t, "pdf", map[string]string{
+		"SKILL.md": strings.Replace(goodSkill, "targets: [claude]\n",
+			"targets: [claude]\nhooks:\n  post-install: pip install -r scripts/requirements.txt\n", 1),
+		"references/usage.md": "usage",
+	})
+	if r := Check(dir, "pdf", Options{}); hasRule(r, RuleUnknownKey) {
+		t.Errorf("hooks reported as an unknown key: %+v", r.Findings)
+	}
+}
+
+func TestCheck_ReportsProblems(t *testing.T) {
+	dir := writeSkill(t, "pdf", map[string]string{
+		"SKILL.md": `---
+name: pdf-tools
+description: Helps.
+color: blue
+targets: [claude, notepad]
+allowed-tools: Bash(git:* Read
+---
+
+# PDF
+
+See [missing](references/nope.md) and [web](https://example.com).
+
+` + "```\n[in code](references/ignored.md)\n```\n",
+		"references/orphan.md": "orphan",
+		"scripts/run.sh":       "#!/bin/sh\necho hi\n",
+	})
+
+	r := Check(dir, "pdf", Options{})
+	want := []string{
+		RuleNameMatchesDir, RuleDescriptionLength, RuleDescriptionTrigger, RuleUnknownKey,
+		RuleUnknownTarget, RuleAllowedTools, RuleMissingFile, RuleUnreachableRef,
+	}
+	if runtime.GOOS != "windows" {
+		want = append(want, RuleScriptExecutable)
+	}
+	for _, id := range want {
+		if !hasRule(r, id) {
+			t.Errorf("missing rule %s in %v", id, rulesOf(r))
+		}
+	}
+	for _, f := range r.Findings {
+		if f.Rule == RuleUnknownKey && f.Line != 4 {
+			t.Errorf("unknown-key line = %d, want 4", f.Line)
+		}
+		if f.Rule == RuleMissingFile && !strings.Contains(f.Message, "references/nope.md") {
+			t.Errorf("missing-file message = %q", f.Message)
+		}
+	}
+	if errors, _, _ := r.Count(); errors != 4 {
+		t.Errorf("errors = %d, want 4 (%v)", errors, rulesOf(r))
+	}
+}
+
+func TestCheck_ConfigOverrides(t *testing.T) {
+	body := strings.Repeat("line\n", 20)
+	dir := writeSkill(t, "pdf", map[string]string{
+		"SKILL.md": "---\nname: pdf\ndescription: Short one.\n---\n" + body,
+	})
+	opts := Options{Config: config.LintConfig{
+		Rules:        map[string]string{RuleDescriptionTrigger: "off", RuleBodySize: "error"},
+		MaxBodyLines: 10,
+	}}
+	r := Check(dir, "pdf", opts)
+	if hasRule(r, RuleDescriptionTrigger) {
+		t.Error("description-trigger should be disabled")
+	}
+	for _, f := range r.Findings {
+		if f.Rule == RuleBodySize && f.Severity != SeverityError {
+			t.Errorf("body-size severity = %s, want error", f.Severity)
+		}
+	}
+	if !hasRule(r, RuleBodySize) {
+		t.Error("body-size should be reported")
+	}
+}
+
+func TestFix_NameAndScripts(t *testing.T) {
+	dir := writeSkill(t, "pdf", map[string]string{
+		"SKILL.md":       "---\nname: old\ndescription: Extract PDF text. Use when the user asks about PDFs.\n---\n",
+		"scripts/run.sh": "#!/bin/sh\n",
+	})
+	r := Check(dir, "pdf", Options{})
+	fixed, err := Fix(r, Options{})
+	if err != nil {
+		t.Fatalf("Fix() error: %v", err)
+	}
+	if len(fixed.Findings) != 0 {
+		t.Errorf("Findings after fix = %+v", fixed.Findings)
+	}
+	data, _ := os.ReadFile(filepath.Join(dir, "SKILL.md"))
+	if !strings.Contains(string(data), "name: pdf\n") {
+		t.Errorf("SKILL.md = %q", data)
+	}
+	if runtime.GOOS != "windows" {
+		info, _ := os.Stat(filepath.Join(dir, "scripts", "run.sh"))
+		if info.Mode().Perm()&0111 == 0 {
+			t.Error("script should be executable after fix")
+		}
+	}
+}
+
+func TestFix_KeepsSkillFileMode(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("file modes")
+	}
+	dir := writeSkill(t, "pdf", map[string]string{
+		"SKILL.md": "---\nname: old\ndescription: Extract PDF text. Use when the user asks about PDFs.\n---\n",
+	})
+	skillFile := filepath.Join(dir, "SKILL.md")
+	if err := os.Chmod(skillFile, 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Fix(Check(dir, "pdf", Options{}), Options{}); err != nil {
+		t.Fatalf("Fix() error: %v", err)
+	}
+	info, _ := os.Stat(skillFile)
+	if info.Mode().Perm() != 0600 {
+		t.Errorf("SKILL.md mode = %v, want 0600", info.Mode().Perm())
+	}
+}
+
+func TestCheck_MissingSkillFileAndFrontmatter(t *testing.T) {
+	dir := writeSkill(t, "x", map[string]string{"README.md": "hi"})
+	if r := Check(dir, "x", Options{}); !hasRule(r, RuleSkillFile) {
+		t.Errorf("want skill-file, got %v", rulesOf(r))
+	}
+	dir = writeSkill(t, "y", map[string]string{"SKILL.md": "# No frontmatter\n"})
+	if r := Check(dir, "y", Options{}); !hasRule(r, RuleFrontmatter) || !hasRule(r, RuleNameRequired) {
+		t.Errorf("want frontmatter + name-required, got %v", rulesOf(r))
+	}
+}
+
+func TestSARIF(t *testing.T) {
+	base := t.TempDir()
+	res := &Result{Skill: "pdf", Path: filepath.Join(base, "pdf"), Findings: []Finding{
+		{Rule: RuleUnknownKey, Severity: SeverityWarning, Message: "unknown", File: "SKILL.md", Line: 3},
+		{Rule: RuleUnreachableRef, Severity: SeverityInfo, Message: "orphan", File: "references/a.md"},
+	}}
+	data, err := SARIF([]*Result{res}, base, "1.0.0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var log sarifLog
+	if err := json.Unmarshal(data, &log); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	results := log.Runs[0].Results
+	if len(results) != 2 || results[0].Locations[0].PhysicalLocation.ArtifactLocation.URI != "pdf/SKILL.md" {
+		t.Fatalf("results = %+v", results)
+	}
+	if results[0].Locations[0].PhysicalLocation.Region.StartLine != 3 || results[1].Level != "note" {
+		t.Errorf("unexpected region/level: %+v", results)
+	}
+	if len(log.Runs[0].Tool.Driver.Rules) != len(Rules()) {
+		t.Errorf("driver rules = %d, want %d", len(log.Runs[0].Tool.Driver.Rules), len(Rules()))
+	}
+}
+
+func TestSplitTools(t *testing.T) {
+	got, err := splitTools("Bash(git add:*) Read, Grep")
+	if err != nil || strings.Join(got, "|") != "Bash(git add:*)|Read|Grep" {
+		t.Errorf("splitTools() = %v, %v", got, err)
+	}
+	if _, err := splitTools("Bash(git"); err == nil {
+		t.Error("unbalanced parentheses should fail")
+	}
+}
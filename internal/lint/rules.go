@@ -0,0 +1,91 @@
+package lint
+
+// Severities, in decreasing order of importance. SeverityOff disables a
+// rule through LintConfig.Rules.
+const (
+	SeverityError   = "error"
+	SeverityWarning = "warning"
+	SeverityInfo    = "info"
+	SeverityOff     = "off"
+)
+
+// Rule describes one lint check.
+type Rule struct {
+	ID          string `json:"id"`
+	Severity    string `json:"severity"` // default severity
+	Description string `json:"description"`
+	Fixable     bool   `json:"fixable,omitempty"`
+}
+
+// Rule IDs.
+const (
+	RuleSkillFile          = "skill-file"
+	RuleFrontmatter        = "frontmatter"
+	RuleNameRequired       = "name-required"
+	RuleNameMatchesDir     = "name-matches-dir"
+	RuleDescription        = "description-required"
+	RuleDescriptionLength  = "description-length"
+	RuleDescriptionTrigger = "description-trigger"
+	RuleUnknownKey         = "unknown-key"
+	RuleUnknownTarget      = "unknown-target"
+	RuleBodySize           = "body-size"
+	RuleMissingFile        = "missing-file"
+	RuleUnreachableRef     = "unreachable-reference"
+	RuleScriptExecutable   = "script-executable"
+	RuleAllowedTools       = "allowed-tools"
+)
+
+var rules = []Rule{
+	{RuleSkillFile, SeverityError, "Skill directory has a SKILL.md", false},
+	{RuleFrontmatter, SeverityError, "SKILL.md starts with valid YAML frontmatter", false},
+	{RuleNameRequired, SeverityError, "Frontmatter sets name", true},
+	{RuleNameMatchesDir, SeverityError, "Frontmatter name matches the skill directory", true},
+	{RuleDescription, SeverityError, "Frontmatter sets description", false},
+	{RuleDescriptionLength, SeverityWarning, "Description length is within limits", false},
+	{RuleDescriptionTrigger, SeverityWarning, "Description says when to use the skill", false},
+	{RuleUnknownKey, SeverityWarning, "Frontmatter only uses known keys", false},
+	{RuleUnknownTarget, SeverityError, "targets lists known target names", false},
+	{RuleBodySize, SeverityWarning, "SKILL.md body stays small; move detail to references/", false},
+	{RuleMissingFile, SeverityError, "Relative links in SKILL.md point to existing files", false},
+	{RuleUnreachableRef, SeverityWarning, "Files in references/ are linked from SKILL.md", false},
+	{RuleScriptExecutable, SeverityWarning, "Files in scripts/ with a shebang are executable", true},
+	{RuleAllowedTools, SeverityError, "allowed-tools is a list of tool names like Bash(git:*)", false},
+}
+
+// Rules returns every lint rule with its default severity.
+func Rules() []Rule {
+	out := make([]Rule, len(rules))
+	copy(out, rules)
+	return out
+}
+
+func findRule(id string) (Rule, bool) {
+	for _, r := range rules {
+		if r.ID == id {
+			return r, true
+		}
+	}
+	return Rule{}, false
+}
+
+// KnownFrontmatterKeys lists the SKILL.md frontmatter keys that
+// RuleUnknownKey accepts: the Agent Skills fields, common client
+// extensions, and skillshare's own fields.
+var KnownFrontmatterKeys = []string{
+	"name",
+	"description",
+	"license",
+	"allowed-tools",
+	"metadata",
+	"compatibility",
+	"version",
+	"model",
+	"argument-hint",
+	"disable-model-invocation",
+	"user-invocable",
+	"targets",
+	"template",
+	"review-by",
+	"owner",
+	"hooks",
+}
@@ -0,0 +1,207 @@
+// Package network holds the settings for reaching remote hosts: URL
+// rewrite rules for mirrors, an HTTP(S) proxy and an extra CA bundle. They
+// come from the `network:` section of config.yaml and apply to every HTTP
+// client built here and, through git config, to every git fetch.
+package network
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"skillshare/internal/credential"
+	"skillshare/internal/utils"
+)
+
+// Rewrite replaces the URL prefix From with To, like git's insteadOf.
+type Rewrite struct {
+	From string `yaml:"from"`
+	To   string `yaml:"to"`
+}
+
+// Config is the network section of config.yaml.
+type Config struct {
+	Rewrites []Rewrite `yaml:"rewrites,omitempty"`
+	Proxy    string    `yaml:"proxy,omitempty"`
+	CAFile   string    `yaml:"ca_file,omitempty"`
+}
+
+// Validate checks rewrite rules and the proxy URL.
+func (c Config) Validate() error {
+	for _, r := range c.Rewrites {
+		if r.From == "" || r.To == "" {
+			return fmt.Errorf("rewrite needs both from and to (from %q, to %q)", r.From, r.To)
+		}
+	}
+	if c.Proxy != "" {
+		u, err := url.Parse(c.Proxy)
+		if err != nil || u.Host == "" {
+			return fmt.Errorf("invalid proxy %q: want http://host:port", c.Proxy)
+		}
+	}
+	return nil
+}
+
+var (
+	mu       sync.RWMutex
+	current  Config
+	caPath   string         // Expanded CAFile
+	rootCAs  *x509.CertPool // System roots plus CAFile; nil without CAFile
+	proxyURL *url.URL
+)
+
+// Configure applies cfg to clients created afterwards. The CLI calls it
+// with config.yaml's network section at startup. An unreadable CA bundle
+// is an error, and the previous settings stay in place.
+func Configure(cfg Config) error {
+	if err := cfg.Validate(); err != nil {
+		return err
+	}
+	var pool *x509.CertPool
+	path := ""
+	if cfg.CAFile != "" {
+		path = expandHome(cfg.CAFile)
+		pem, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("ca_file: %w", err)
+		}
+		pool, err = x509.SystemCertPool()
+		if err != nil || pool == nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(pem) {
+			return fmt.Errorf("ca_file %s: no PEM certificates found", path)
+		}
+	}
+	var proxy *url.URL
+	if cfg.Proxy != "" {
+		proxy, _ = url.Parse(cfg.Proxy)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	current, caPath, rootCAs, proxyURL = cfg, path, pool, proxy
+	return nil
+}
+
+// Match returns the rewrite rule with the longest From that prefixes
+// rawURL.
+func Match(rawURL string) (Rewrite, bool) {
+	mu.RLock()
+	defer mu.RUnlock()
+	var best Rewrite
+	found := false
+	for _, r := range current.Rewrites {
+		if strings.HasPrefix(rawURL, r.From) && len(r.From) > len(best.From) {
+			best, found = r, true
+		}
+	}
+	return best, found
+}
+
+// RewriteURL applies the matching rewrite rule to rawURL, or returns it
+// unchanged.
+func RewriteURL(rawURL string) string {
+	if r, ok := Match(rawURL); ok {
+		return r.To + strings.TrimPrefix(rawURL, r.From)
+	}
+	return rawURL
+}
+
+// Canonical maps a mirror URL back to the URL it stands for, by the rule
+// with the longest matching To. Sources are stored in canonical form so
+// configs stay portable between networks.
+func Canonical(rawURL string) string {
+	mu.RLock()
+	defer mu.RUnlock()
+	var best Rewrite
+	for _, r := range current.Rewrites {
+		if strings.HasPrefix(rawURL, r.To) && len(r.To) > len(best.To) {
+			best = r
+		}
+	}
+	if best.To == "" {
+		return rawURL
+	}
+	return best.From + strings.TrimPrefix(rawURL, best.To)
+}
+
+// GitConfig returns the proxy and CA settings as git config key/value
+// pairs (http.proxy, http.sslCAInfo). Rewrites are left to the caller,
+// which combines them with credentials.
+func GitConfig() [][2]string {
+	mu.RLock()
+	defer mu.RUnlock()
+	var pairs [][2]string
+	if current.Proxy != "" {
+		pairs = append(pairs, [2]string{"http.proxy", current.Proxy})
+	}
+	if caPath != "" {
+		pairs = append(pairs, [2]string{"http.sslCAInfo", caPath})
+	}
+	return pairs
+}
+
+// Transport returns an HTTP transport using the configured proxy (or the
+// HTTP_PROXY/HTTPS_PROXY environment) and CA bundle. It does not rewrite
+// URLs.
+func Transport() http.RoundTripper {
+	mu.RLock()
+	defer mu.RUnlock()
+	t := http.DefaultTransport.(*http.Transport).Clone()
+	if proxyURL != nil {
+		t.Proxy = http.ProxyURL(proxyURL)
+	}
+	if rootCAs != nil {
+		t.TLSClientConfig = &tls.Config{RootCAs: rootCAs, MinVersion: tls.VersionTLS12}
+	}
+	return t
+}
+
+// NewClient returns an HTTP client that applies the rewrite rules on top
+// of Transport.
+func NewClient(timeout time.Duration) *http.Client {
+	return &http.Client{Timeout: timeout, Transport: &rewriter{next: Transport()}}
+}
+
+// rewriter sends each request (including redirects) to its rewritten URL.
+type rewriter struct {
+	next http.RoundTripper
+}
+
+func (rt *rewriter) RoundTrip(req *http.Request) (*http.Response, error) {
+	target := RewriteURL(req.URL.String())
+	if target == req.URL.String() {
+		return rt.next.RoundTrip(req)
+	}
+	u, err := url.Parse(target)
+	if err != nil {
+		return nil, fmt.Errorf("rewrite %s: %w", req.URL, err)
+	}
+	out := req.Clone(req.Context())
+	out.URL, out.Host = u, ""
+	if u.Host != req.URL.Host {
+		// Credentials belong to the original host; use the mirror's own.
+		out.Header.Del("Authorization")
+		if err := credential.Authorize(out); err != nil {
+			return nil, err
+		}
+	}
+	return rt.next.RoundTrip(out)
+}
+
+func expandHome(path string) string {
+	if utils.HasTildePrefix(path) {
+		if home, err := os.UserHomeDir(); err == nil {
+			return filepath.Join(home, path[1:])
+		}
+	}
+	return path
+}
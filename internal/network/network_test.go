@@ -0,0 +1,149 @@
+package network
+
+import (
+	"encoding/pem"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func configure(t *testing.T, cfg Config) {
+	t.Helper()
+	if err := Configure(cfg); err != nil {
+		t.Fatalf("Configure: %v", err)
+	}
+	t.Cleanup(func() { Configure(Config{}) })
+}
+
+func TestValidate(t *testing.T) {
+	if err := (Config{Rewrites: []Rewrite{{From: "https://github.com/"}}}).Validate(); err == nil {
+		t.Error("rewrite without to should fail")
+	}
+	if err := (Config{Proxy: "proxy.corp"}).Validate(); err == nil {
+		t.Error("proxy without scheme should fail")
+	}
+	if err := (Config{Proxy: "http://proxy.corp:3128"}).Validate(); err != nil {
+		t.Errorf("valid proxy: %v", err)
+	}
+}
+
+func TestRewriteURL(t *testing.T) {
+	configure(t, Config{Rewrites: []Rewrite{
+		{From: "https://github.com/", To: "https://mirror.corp/github/"},
+		{From: "https://github.com/acme/", To: "https://git.corp/acme/"},
+	}})
+
+	tests := map[string]string{
+		"https://github.com/org/repo.git":  "https://mirror.corp/github/org/repo.git",
+		"https://github.com/acme/repo.git": "https://git.corp/acme/repo.git",
+		"https://gitlab.com/org/repo.git":  "https://gitlab.com/org/repo.git",
+	}
+	for in, want := range tests {
+		if got := RewriteURL(in); got != want {
+			t.Errorf("RewriteURL(%s) = %s, want %s", in, got, want)
+		}
+	}
+
+	if got := Canonical("https://mirror.corp/github/org/repo"); got != "https://github.com/org/repo" {
+		t.Errorf("Canonical() = %s", got)
+	}
+	if got := Canonical("https://other.corp/x"); got != "https://other.corp/x" {
+		t.Errorf("Canonical() changed an unmatched URL: %s", got)
+	}
+}
+
+func TestGitConfig(t *testing.T) {
+	if got := GitConfig(); got != nil {
+		t.Errorf("GitConfig() without settings = %v", got)
+	}
+	caFile := filepath.Join(t.TempDir(), "ca.pem")
+	srv := httptest.NewTLSServer(http.NotFoundHandler())
+	defer srv.Close()
+	writeCA(t, srv, caFile)
+
+	configure(t, Config{Proxy: "http://proxy.corp:3128", CAFile: caFile})
+	want := [][2]string{{"http.proxy", "http://proxy.corp:3128"}, {"http.sslCAInfo", caFile}}
+	if got := GitConfig(); !reflect.DeepEqual(got, want) {
+		t.Errorf("GitConfig() = %v, want %v", got, want)
+	}
+}
+
+func TestNewClient_Rewrites(t *testing.T) {
+	var gotAuth string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotAuth = r.Header.Get("Authorization")
+		io.WriteString(w, r.URL.Path)
+	}))
+	defer srv.Close()
+	configure(t, Config{Rewrites: []Rewrite{{From: "https://github.com/", To: srv.URL + "/mirror/"}}})
+
+	req, _ := http.NewRequest("GET", "https://github.com/org/repo/index.json", nil)
+	req.Header.Set("Authorization", "token github-secret")
+	resp, err := NewClient(5 * time.Second).Do(req)
+	if err != nil {
+		t.Fatalf("GET: %v", err)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if string(body) != "/mirror/org/repo/index.json" {
+		t.Errorf("mirror served %q", body)
+	}
+	if gotAuth != "" {
+		t.Errorf("original host credentials sent to mirror: %q", gotAuth)
+	}
+}
+
+func TestNewClient_Proxy(t *testing.T) {
+	var proxied string
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		proxied = r.URL.String()
+	}))
+	defer proxy.Close()
+	configure(t, Config{Proxy: proxy.URL})
+
+	resp, err := NewClient(5 * time.Second).Get("http://skills.example.com/hub.json")
+	if err != nil {
+		t.Fatalf("GET: %v", err)
+	}
+	resp.Body.Close()
+	if proxied != "http://skills.example.com/hub.json" {
+		t.Errorf("proxy saw %q", proxied)
+	}
+}
+
+func TestNewClient_CAFile(t *testing.T) {
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer srv.Close()
+
+	if _, err := NewClient(5 * time.Second).Get(srv.URL); err == nil {
+		t.Fatal("self-signed server should fail without ca_file")
+	}
+
+	caFile := filepath.Join(t.TempDir(), "ca.pem")
+	writeCA(t, srv, caFile)
+	configure(t, Config{CAFile: caFile})
+	resp, err := NewClient(5 * time.Second).Get(srv.URL)
+	if err != nil {
+		t.Fatalf("GET with ca_file: %v", err)
+	}
+	resp.Body.Close()
+
+	os.WriteFile(caFile, []byte("not a cert"), 0644)
+	if err := Configure(Config{CAFile: caFile}); err == nil || !strings.Contains(err.Error(), "no PEM") {
+		t.Errorf("Configure() with a bad bundle = %v", err)
+	}
+}
+
+func writeCA(t *testing.T, srv *httptest.Server, path string) {
+	t.Helper()
+	data := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: srv.Certificate().Raw})
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,109 @@
+package oci
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// credentials are a username and password (or identity token) for a
+// registry.
+type credentials struct {
+	Username string
+	Secret   string
+}
+
+// dockerConfig is the part of ~/.docker/config.json used for registry auth.
+type dockerConfig struct {
+	Auths map[string]struct {
+		Auth          string `json:"auth"`
+		IdentityToken string `json:"identitytoken"`
+	} `json:"auths"`
+	CredsStore  string            `json:"credsStore"`
+	CredHelpers map[string]string `json:"credHelpers"`
+}
+
+// dockerConfigPath returns $DOCKER_CONFIG/config.json or
+// ~/.docker/config.json.
+func dockerConfigPath() string {
+	if dir := os.Getenv("DOCKER_CONFIG"); dir != "" {
+		return filepath.Join(dir, "config.json")
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".docker", "config.json")
+}
+
+// lookupCredentials finds credentials for registry the way docker does:
+// a per-registry credential helper, then an inline auth entry, then the
+// default credential store. Returns nil when there are none, so requests
+// go out anonymously.
+func lookupCredentials(registry string) (*credentials, error) {
+	data, err := os.ReadFile(dockerConfigPath())
+	if err != nil {
+		return nil, nil
+	}
+	var cfg dockerConfig
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("invalid docker config %s: %w", dockerConfigPath(), err)
+	}
+
+	if helper := cfg.CredHelpers[registry]; helper != "" {
+		return credentialHelper(helper, registry)
+	}
+	for _, key := range []string{registry, "https://" + registry, "http://" + registry} {
+		entry, ok := cfg.Auths[key]
+		if !ok {
+			continue
+		}
+		if entry.Auth != "" {
+			decoded, err := base64.StdEncoding.DecodeString(entry.Auth)
+			if err != nil {
+				return nil, fmt.Errorf("invalid auth for %s in docker config: %w", registry, err)
+			}
+			user, pass, _ := strings.Cut(string(decoded), ":")
+			if entry.IdentityToken != "" {
+				pass = entry.IdentityToken
+			}
+			return &credentials{Username: user, Secret: pass}, nil
+		}
+		if entry.IdentityToken != "" {
+			return &credentials{Username: "<token>", Secret: entry.IdentityToken}, nil
+		}
+	}
+	if cfg.CredsStore != "" {
+		return credentialHelper(cfg.CredsStore, registry)
+	}
+	return nil, nil
+}
+
+// credentialHelper runs docker-credential-<helper> get for registry.
+func credentialHelper(helper, registry string) (*credentials, error) {
+	cmd := exec.Command("docker-credential-"+helper, "get")
+	cmd.Stdin = strings.NewReader(registry)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		msg := strings.TrimSpace(stdout.String() + stderr.String())
+		if strings.Contains(msg, "credentials not found") {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("docker-credential-%s: %v %s", helper, err, msg)
+	}
+	var out struct {
+		Username string `json:"Username"`
+		Secret   string `json:"Secret"`
+	}
+	if err := json.Unmarshal(stdout.Bytes(), &out); err != nil {
+		return nil, fmt.Errorf("docker-credential-%s: invalid output: %w", helper, err)
+	}
+	return &credentials{Username: out.Username, Secret: out.Secret}, nil
+}
@@ -0,0 +1,490 @@
+package oci
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"skillshare/internal/network"
+)
+
+const (
+	// ArtifactType identifies skill artifacts in a registry.
+	ArtifactType = "application/vnd.skillshare.skill.v1"
+	// LayerMediaType is the media type of the skill's files: a gzipped tar
+	// of the skill directory.
+	LayerMediaType = "application/vnd.skillshare.skill.layer.v1.tar+gzip"
+
+	manifestMediaType = "application/vnd.oci.image.manifest.v1+json"
+	emptyMediaType    = "application/vnd.oci.empty.v1+json"
+
+	// requestTimeout bounds a single registry request.
+	requestTimeout = 5 * time.Minute
+	// maxManifestSize caps manifest downloads; real ones are a few KB.
+	maxManifestSize = 4 << 20
+	// maxLayerSize caps the skill layer a manifest may declare. The layer
+	// is held in memory, and its size comes from the registry.
+	maxLayerSize = 256 << 20
+)
+
+// emptyConfig is the OCI empty descriptor payload used as artifact config.
+var emptyConfig = []byte("{}")
+
+// Descriptor points at a blob in a registry.
+type Descriptor struct {
+	MediaType   string            `json:"mediaType"`
+	Digest      string            `json:"digest"`
+	Size        int64             `json:"size"`
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+// Manifest is an OCI image manifest carrying a skill artifact.
+type Manifest struct {
+	SchemaVersion int               `json:"schemaVersion"`
+	MediaType     string            `json:"mediaType"`
+	ArtifactType  string            `json:"artifactType,omitempty"`
+	Config        Descriptor        `json:"config"`
+	Layers        []Descriptor      `json:"layers"`
+	Annotations   map[string]string `json:"annotations,omitempty"`
+}
+
+// Artifact is a pulled skill.
+type Artifact struct {
+	Digest      string            // Manifest digest
+	Annotations map[string]string // Manifest annotations
+	Layer       []byte            // Gzipped tar of the skill directory
+}
+
+// Client talks to OCI registries. Credentials come from the docker config
+// (see lookupCredentials); bearer tokens are cached per repository.
+type Client struct {
+	http *http.Client
+
+	mu   sync.Mutex
+	auth map[string]string // registry/repository:scope → Authorization header
+}
+
+// NewClient returns a registry client.
+func NewClient() *Client {
+	return &Client{
+		http: &http.Client{Timeout: requestTimeout, Transport: network.Transport()},
+		auth: map[string]string{},
+	}
+}
+
+// Digest returns the sha256 digest of data in OCI form.
+func Digest(data []byte) string {
+	sum := sha256.Sum256(data)
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
+
+// Push uploads a skill layer and tags a manifest for it at ref.Tag.
+// Returns the manifest digest.
+func (c *Client) Push(ref Reference, layer []byte, annotations map[string]string) (string, error) {
+	if ref.Tag == "" || ref.Digest != "" {
+		return "", fmt.Errorf("publish needs a tag, not a digest: %s", ref)
+	}
+	if err := c.uploadBlob(ref, emptyConfig); err != nil {
+		return "", err
+	}
+	if err := c.uploadBlob(ref, layer); err != nil {
+		return "", err
+	}
+
+	manifest := Manifest{
+		SchemaVersion: 2,
+		MediaType:     manifestMediaType,
+		ArtifactType:  ArtifactType,
+		Config:        Descriptor{MediaType: emptyMediaType, Digest: Digest(emptyConfig), Size: int64(len(emptyConfig))},
+		Layers: []Descriptor{{
+			MediaType: LayerMediaType,
+			Digest:    Digest(layer),
+			Size:      int64(len(layer)),
+		}},
+		Annotations: annotations,
+	}
+	body, err := json.Marshal(manifest)
+	if err != nil {
+		return "", err
+	}
+
+	resp, err := c.do(ref, true, func() (*http.Request, error) {
+		req, err := http.NewRequest(http.MethodPut, c.url(ref, "manifests", ref.Tag), bytes.NewReader(body))
+		if err == nil {
+			req.Header.Set("Content-Type", manifestMediaType)
+		}
+		return req, err
+	})
+	if err != nil {
+		return "", err
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusOK {
+		return "", registryError(resp, "push manifest")
+	}
+	return Digest(body), nil
+}
+
+// uploadBlob uploads data unless the registry already has it.
+func (c *Client) uploadBlob(ref Reference, data []byte) error {
+	digest := Digest(data)
+	resp, err := c.do(ref, true, func() (*http.Request, error) {
+		return http.NewRequest(http.MethodHead, c.url(ref, "blobs", digest), nil)
+	})
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	if resp.StatusCode == http.StatusOK {
+		return nil
+	}
+
+	resp, err = c.do(ref, true, func() (*http.Request, error) {
+		return http.NewRequest(http.MethodPost, c.url(ref, "blobs", "uploads")+"/", nil)
+	})
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusAccepted {
+		return registryError(resp, "start upload")
+	}
+	location, err := resp.Request.URL.Parse(resp.Header.Get("Location"))
+	if err != nil || resp.Header.Get("Location") == "" {
+		return fmt.Errorf("start upload: registry returned no upload location")
+	}
+	q := location.Query()
+	q.Set("digest", digest)
+	location.RawQuery = q.Encode()
+
+	resp, err = c.do(ref, true, func() (*http.Request, error) {
+		req, err := http.NewRequest(http.MethodPut, location.String(), bytes.NewReader(data))
+		if err == nil {
+			req.Header.Set("Content-Type", "application/octet-stream")
+		}
+		return req, err
+	})
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusCreated {
+		return registryError(resp, "upload blob")
+	}
+	return nil
+}
+
+// Pull fetches the skill artifact at ref. A ref with a digest is verified
+// against it.
+func (c *Client) Pull(ref Reference) (*Artifact, error) {
+	manifest, digest, err := c.manifest(ref)
+	if err != nil {
+		return nil, err
+	}
+
+	var layer *Descriptor
+	for i := range manifest.Layers {
+		if manifest.Layers[i].MediaType == LayerMediaType {
+			layer = &manifest.Layers[i]
+			break
+		}
+	}
+	if layer == nil {
+		return nil, fmt.Errorf("%s is not a skill artifact (artifact type %q)", ref, manifest.ArtifactType)
+	}
+	if layer.Size < 0 || layer.Size > maxLayerSize {
+		return nil, fmt.Errorf("%s: skill layer of %d bytes exceeds the %d MB limit", ref, layer.Size, maxLayerSize>>20)
+	}
+
+	resp, err := c.do(ref, false, func() (*http.Request, error) {
+		return http.NewRequest(http.MethodGet, c.url(ref, "blobs", layer.Digest), nil)
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, registryError(resp, "download skill")
+	}
+	data, err := io.ReadAll(io.LimitReader(resp.Body, layer.Size+1))
+	if err != nil {
+		return nil, fmt.Errorf("download skill: %w", err)
+	}
+	if int64(len(data)) != layer.Size || Digest(data) != layer.Digest {
+		return nil, fmt.Errorf("download skill: layer does not match digest %s", layer.Digest)
+	}
+
+	return &Artifact{Digest: digest, Annotations: manifest.Annotations, Layer: data}, nil
+}
+
+// manifest fetches and decodes the manifest of ref and returns its digest.
+func (c *Client) manifest(ref Reference) (*Manifest, string, error) {
+	resp, err := c.do(ref, false, func() (*http.Request, error) {
+		req, err := http.NewRequest(http.MethodGet, c.url(ref, "manifests", ref.manifestRef()), nil)
+		if err == nil {
+			req.Header.Set("Accept", manifestMediaType)
+		}
+		return req, err
+	})
+	if err != nil {
+		return nil, "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, "", registryError(resp, "fetch "+ref.String())
+	}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxManifestSize))
+	if err != nil {
+		return nil, "", fmt.Errorf("fetch %s: %w", ref, err)
+	}
+	digest := Digest(body)
+	if ref.Digest != "" && digest != ref.Digest {
+		return nil, "", fmt.Errorf("digest mismatch for %s: registry returned %s", ref, digest)
+	}
+
+	var manifest Manifest
+	if err := json.Unmarshal(body, &manifest); err != nil {
+		return nil, "", fmt.Errorf("invalid manifest for %s: %w", ref, err)
+	}
+	return &manifest, digest, nil
+}
+
+// Resolve returns the manifest digest ref currently points at.
+func (c *Client) Resolve(ref Reference) (string, error) {
+	resp, err := c.do(ref, false, func() (*http.Request, error) {
+		req, err := http.NewRequest(http.MethodHead, c.url(ref, "manifests", ref.manifestRef()), nil)
+		if err == nil {
+			req.Header.Set("Accept", manifestMediaType)
+		}
+		return req, err
+	})
+	if err != nil {
+		return "", err
+	}
+	resp.Body.Close()
+	if resp.StatusCode == http.StatusOK {
+		if digest := resp.Header.Get("Docker-Content-Digest"); digest != "" {
+			return digest, nil
+		}
+	}
+	// Registries that omit the digest header (or HEAD) get a full GET.
+	_, digest, err := c.manifest(ref)
+	return digest, err
+}
+
+// Tags lists the tags of ref's repository.
+func (c *Client) Tags(ref Reference) ([]string, error) {
+	var tags []string
+	next := c.url(ref, "tags", "list")
+	for next != "" {
+		pageURL := next
+		resp, err := c.do(ref, false, func() (*http.Request, error) {
+			return http.NewRequest(http.MethodGet, pageURL, nil)
+		})
+		if err != nil {
+			return nil, err
+		}
+		if resp.StatusCode != http.StatusOK {
+			err := registryError(resp, "list tags")
+			resp.Body.Close()
+			return nil, err
+		}
+		var page struct {
+			Tags []string `json:"tags"`
+		}
+		err = json.NewDecoder(io.LimitReader(resp.Body, maxManifestSize)).Decode(&page)
+		resp.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("list tags: %w", err)
+		}
+		tags = append(tags, page.Tags...)
+
+		next = ""
+		if link := nextLink(resp.Header.Get("Link")); link != "" {
+			if u, err := resp.Request.URL.Parse(link); err == nil {
+				next = u.String()
+			}
+		}
+	}
+	return tags, nil
+}
+
+// nextLink extracts the target of a rel="next" Link header.
+func nextLink(header string) string {
+	for _, part := range strings.Split(header, ",") {
+		target, params, ok := strings.Cut(strings.TrimSpace(part), ";")
+		if ok && strings.Contains(params, `rel="next"`) {
+			return strings.Trim(strings.TrimSpace(target), "<>")
+		}
+	}
+	return ""
+}
+
+func (c *Client) url(ref Reference, kind, name string) string {
+	return fmt.Sprintf("%s/v2/%s/%s/%s", ref.baseURL(), ref.Repository, kind, name)
+}
+
+// do sends a request built by build, answering one auth challenge.
+// build is called again for the retry, so request bodies can be re-read.
+func (c *Client) do(ref Reference, push bool, build func() (*http.Request, error)) (*http.Response, error) {
+	scope := "repository:" + ref.Repository + ":pull"
+	if push {
+		scope += ",push"
+	}
+	key := ref.Registry + "/" + scope
+
+	send := func() (*http.Response, error) {
+		req, err := build()
+		if err != nil {
+			return nil, err
+		}
+		c.mu.Lock()
+		if h := c.auth[key]; h != "" {
+			req.Header.Set("Authorization", h)
+		}
+		c.mu.Unlock()
+		resp, err := c.http.Do(req)
+		if err != nil {
+			return nil, fmt.Errorf("registry %s: %w", ref.Registry, err)
+		}
+		return resp, nil
+	}
+
+	resp, err := send()
+	if err != nil || resp.StatusCode != http.StatusUnauthorized {
+		return resp, err
+	}
+	challenge := resp.Header.Get("WWW-Authenticate")
+	resp.Body.Close()
+
+	header, err := c.authorize(ref, challenge, scope)
+	if err != nil {
+		return nil, err
+	}
+	c.mu.Lock()
+	c.auth[key] = header
+	c.mu.Unlock()
+	return send()
+}
+
+// authorize answers a WWW-Authenticate challenge with docker config
+// credentials, fetching a bearer token when the registry asks for one.
+func (c *Client) authorize(ref Reference, challenge, scope string) (string, error) {
+	creds, err := lookupCredentials(ref.Registry)
+	if err != nil {
+		return "", err
+	}
+	scheme, params := parseChallenge(challenge)
+
+	switch strings.ToLower(scheme) {
+	case "basic":
+		if creds == nil {
+			return "", fmt.Errorf("authentication required for %s — run: docker login %s", ref.Registry, ref.Registry)
+		}
+		return "Basic " + basicAuth(creds), nil
+	case "bearer":
+		realm, err := url.Parse(params["realm"])
+		if err != nil || params["realm"] == "" {
+			return "", fmt.Errorf("registry %s sent an invalid auth challenge", ref.Registry)
+		}
+		q := realm.Query()
+		if params["service"] != "" {
+			q.Set("service", params["service"])
+		}
+		q.Set("scope", scope)
+		realm.RawQuery = q.Encode()
+
+		req, err := http.NewRequest(http.MethodGet, realm.String(), nil)
+		if err != nil {
+			return "", err
+		}
+		if creds != nil {
+			req.Header.Set("Authorization", "Basic "+basicAuth(creds))
+		}
+		resp, err := c.http.Do(req)
+		if err != nil {
+			return "", fmt.Errorf("registry %s: token request: %w", ref.Registry, err)
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			if creds == nil {
+				return "", fmt.Errorf("authentication required for %s — run: docker login %s", ref.Registry, ref.Registry)
+			}
+			return "", registryError(resp, "authenticate to "+ref.Registry)
+		}
+		var token struct {
+			Token       string `json:"token"`
+			AccessToken string `json:"access_token"`
+		}
+		if err := json.NewDecoder(resp.Body).Decode(&token); err != nil {
+			return "", fmt.Errorf("registry %s: invalid token response: %w", ref.Registry, err)
+		}
+		if token.Token == "" {
+			token.Token = token.AccessToken
+		}
+		return "Bearer " + token.Token, nil
+	default:
+		return "", fmt.Errorf("registry %s: unsupported auth scheme %q", ref.Registry, scheme)
+	}
+}
+
+func basicAuth(creds *credentials) string {
+	return base64.StdEncoding.EncodeToString([]byte(creds.Username + ":" + creds.Secret))
+}
+
+// parseChallenge splits `Bearer realm="…",service="…"` into the scheme
+// and its parameters.
+func parseChallenge(header string) (string, map[string]string) {
+	scheme, rest, _ := strings.Cut(strings.TrimSpace(header), " ")
+	params := map[string]string{}
+	for rest != "" {
+		var key, val string
+		key, rest, _ = strings.Cut(rest, "=")
+		key = strings.ToLower(strings.TrimSpace(key))
+		rest = strings.TrimSpace(rest)
+		if strings.HasPrefix(rest, `"`) {
+			end := strings.Index(rest[1:], `"`)
+			if end < 0 {
+				val, rest = rest[1:], ""
+			} else {
+				val, rest = rest[1:end+1], rest[end+2:]
+			}
+		} else {
+			val, rest, _ = strings.Cut(rest, ",")
+		}
+		rest = strings.TrimLeft(strings.TrimSpace(rest), ",")
+		if key != "" {
+			params[key] = val
+		}
+	}
+	return scheme, params
+}
+
+// registryError turns a failed response into an error, including the
+// registry's error message when it sent one.
+func registryError(resp *http.Response, action string) error {
+	var body struct {
+		Errors []struct {
+			Code    string `json:"code"`
+			Message string `json:"message"`
+		} `json:"errors"`
+	}
+	data, _ := io.ReadAll(io.LimitReader(resp.Body, 64<<10))
+	if json.Unmarshal(data, &body) == nil && len(body.Errors) > 0 {
+		e := body.Errors[0]
+		return fmt.Errorf("%s: %s (%s %s)", action, e.Message, resp.Status, e.Code)
+	}
+	if resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden {
+		return fmt.Errorf("%s: access denied (%s) — check docker login for %s", action, resp.Status, resp.Request.URL.Host)
+	}
+	return fmt.Errorf("%s: %s", action, resp.Status)
+}
@@ -0,0 +1,163 @@
+package oci
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"skillshare/internal/testutil"
+)
+
+func TestClient_PushPullRoundtrip(t *testing.T) {
+	t.Setenv("DOCKER_CONFIG", t.TempDir())
+	reg := testutil.NewRegistry(t)
+	client := NewClient()
+
+	ref, _ := ParseReference("oci://" + reg.Host() + "/team/pdf:1.0.0")
+	layer := []byte("layer-bytes")
+	digest, err := client.Push(ref, layer, map[string]string{AnnotationVersion: "1.0.0"})
+	if err != nil {
+		t.Fatalf("Push: %v", err)
+	}
+
+	artifact, err := client.Pull(ref)
+	if err != nil {
+		t.Fatalf("Pull: %v", err)
+	}
+	if artifact.Digest != digest {
+		t.Errorf("pulled digest = %s, want %s", artifact.Digest, digest)
+	}
+	if !bytes.Equal(artifact.Layer, layer) {
+		t.Errorf("pulled layer = %q", artifact.Layer)
+	}
+	if artifact.Annotations[AnnotationVersion] != "1.0.0" {
+		t.Errorf("annotations = %v", artifact.Annotations)
+	}
+
+	resolved, err := client.Resolve(ref)
+	if err != nil || resolved != digest {
+		t.Errorf("Resolve = %s, %v; want %s", resolved, err, digest)
+	}
+
+	pinned := ref
+	pinned.Tag, pinned.Digest = "", digest
+	if _, err := client.Pull(pinned); err != nil {
+		t.Errorf("Pull by digest: %v", err)
+	}
+
+	ref.Tag = "1.1.0"
+	if _, err := client.Push(ref, []byte("newer"), nil); err != nil {
+		t.Fatalf("Push 1.1.0: %v", err)
+	}
+	tags, err := client.Tags(ref)
+	if err != nil {
+		t.Fatalf("Tags: %v", err)
+	}
+	sort.Strings(tags)
+	if !reflect.DeepEqual(tags, []string{"1.0.0", "1.1.0"}) {
+		t.Errorf("Tags = %v", tags)
+	}
+}
+
+func TestClient_PullDigestMismatch(t *testing.T) {
+	t.Setenv("DOCKER_CONFIG", t.TempDir())
+	reg := testutil.NewRegistry(t)
+	client := NewClient()
+
+	ref, _ := ParseReference("oci://" + reg.Host() + "/pdf:1.0.0")
+	if _, err := client.Push(ref, []byte("layer"), nil); err != nil {
+		t.Fatalf("Push: %v", err)
+	}
+	ref.Digest = "sha256:" + sixtyFour
+	if _, err := client.Pull(ref); err == nil {
+		t.Error("Pull with an unknown digest should fail")
+	}
+}
+
+func TestClient_PullRejectsOversizedLayer(t *testing.T) {
+	t.Setenv("DOCKER_CONFIG", t.TempDir())
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.Contains(r.URL.Path, "/blobs/") {
+			t.Error("layer downloaded despite its declared size")
+		}
+		json.NewEncoder(w).Encode(Manifest{
+			SchemaVersion: 2,
+			Layers:        []Descriptor{{MediaType: LayerMediaType, Digest: "sha256:" + sixtyFour, Size: 1 << 40}},
+		})
+	}))
+	defer srv.Close()
+
+	ref, _ := ParseReference("oci://" + strings.TrimPrefix(srv.URL, "http://") + "/pdf:1.0.0")
+	if _, err := NewClient().Pull(ref); err == nil || !strings.Contains(err.Error(), "exceeds") {
+		t.Errorf("Pull error = %v, want the layer size rejected", err)
+	}
+}
+
+func TestClient_BearerToken(t *testing.T) {
+	t.Setenv("DOCKER_CONFIG", t.TempDir())
+	reg := testutil.NewRegistry(t)
+	reg.Token = "secret-token"
+	client := NewClient()
+
+	ref, _ := ParseReference("oci://" + reg.Host() + "/pdf:1.0.0")
+	if _, err := client.Push(ref, []byte("layer"), nil); err != nil {
+		t.Fatalf("Push with token auth: %v", err)
+	}
+	if _, err := client.Pull(ref); err != nil {
+		t.Fatalf("Pull with token auth: %v", err)
+	}
+}
+
+func TestParseChallenge(t *testing.T) {
+	scheme, params := parseChallenge(`Bearer realm="https://auth.example.com/token",service="registry.example.com",scope="repository:a/b:pull"`)
+	if scheme != "Bearer" {
+		t.Errorf("scheme = %q", scheme)
+	}
+	want := map[string]string{
+		"realm":   "https://auth.example.com/token",
+		"service": "registry.example.com",
+		"scope":   "repository:a/b:pull",
+	}
+	if !reflect.DeepEqual(params, want) {
+		t.Errorf("params = %v, want %v", params, want)
+	}
+}
+
+func TestLookupCredentials_DockerConfig(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("DOCKER_CONFIG", dir)
+	auth := base64.StdEncoding.EncodeToString([]byte("alice:s3cret"))
+	config := `{"auths":{"https://harbor.example.com":{"auth":"` + auth + `"}}}`
+	if err := os.WriteFile(filepath.Join(dir, "config.json"), []byte(config), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	creds, err := lookupCredentials("harbor.example.com")
+	if err != nil {
+		t.Fatalf("lookupCredentials: %v", err)
+	}
+	if creds == nil || creds.Username != "alice" || creds.Secret != "s3cret" {
+		t.Errorf("creds = %+v", creds)
+	}
+	if creds, _ := lookupCredentials("ghcr.io"); creds != nil {
+		t.Errorf("unknown registry should have no credentials, got %+v", creds)
+	}
+}
+
+func TestRegistryError_IncludesMessage(t *testing.T) {
+	t.Setenv("DOCKER_CONFIG", t.TempDir())
+	reg := testutil.NewRegistry(t)
+	ref, _ := ParseReference("oci://" + reg.Host() + "/missing:1.0.0")
+	_, err := NewClient().Pull(ref)
+	if err == nil || !strings.Contains(err.Error(), "manifest unknown") {
+		t.Errorf("error = %v, want registry message", err)
+	}
+}
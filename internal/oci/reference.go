@@ -0,0 +1,109 @@
+// Package oci publishes skills to, and installs them from, OCI registries
+// (Harbor, GHCR, registry:2, …) as artifacts following the OCI distribution
+// spec. A skill is one gzipped tar layer; its SKILL.md frontmatter is
+// recorded as manifest annotations.
+package oci
+
+import (
+	"fmt"
+	"net"
+	"regexp"
+	"strings"
+)
+
+// Scheme prefixes OCI skill sources: oci://registry/repo[:tag][@digest].
+const Scheme = "oci://"
+
+// Reference names an artifact in a registry.
+type Reference struct {
+	Registry   string // host[:port]
+	Repository string // e.g. team/skills/pdf
+	Tag        string // e.g. 1.2.0; "latest" when neither tag nor digest is given
+	Digest     string // e.g. sha256:…; pins the artifact when set
+}
+
+var (
+	repositoryPattern = regexp.MustCompile(`^[a-z0-9]+(?:(?:[._]|__|-+)[a-z0-9]+)*(?:/[a-z0-9]+(?:(?:[._]|__|-+)[a-z0-9]+)*)*$`)
+	tagPattern        = regexp.MustCompile(`^[A-Za-z0-9_][A-Za-z0-9._-]{0,127}$`)
+	digestPattern     = regexp.MustCompile(`^sha256:[a-f0-9]{64}$`)
+)
+
+// ParseReference parses registry/repo[:tag][@digest], with or without the
+// oci:// prefix.
+func ParseReference(s string) (Reference, error) {
+	raw := strings.TrimPrefix(s, Scheme)
+	var ref Reference
+
+	if i := strings.Index(raw, "@"); i >= 0 {
+		ref.Digest = raw[i+1:]
+		raw = raw[:i]
+		if !digestPattern.MatchString(ref.Digest) {
+			return Reference{}, fmt.Errorf("invalid digest in %s: want sha256:<64 hex>", s)
+		}
+	}
+
+	slash := strings.Index(raw, "/")
+	if slash <= 0 {
+		return Reference{}, fmt.Errorf("invalid OCI reference %s: want oci://registry/repository[:tag]", s)
+	}
+	ref.Registry = raw[:slash]
+	path := raw[slash+1:]
+
+	// A colon after the last slash separates the tag (a colon in the
+	// registry part is a port).
+	if i := strings.LastIndex(path, ":"); i > strings.LastIndex(path, "/") {
+		ref.Tag = path[i+1:]
+		path = path[:i]
+		if !tagPattern.MatchString(ref.Tag) {
+			return Reference{}, fmt.Errorf("invalid tag %q in %s", ref.Tag, s)
+		}
+	}
+	ref.Repository = path
+	if !repositoryPattern.MatchString(ref.Repository) {
+		return Reference{}, fmt.Errorf("invalid repository %q in %s: use lowercase letters, digits and separators", ref.Repository, s)
+	}
+	if ref.Tag == "" && ref.Digest == "" {
+		ref.Tag = "latest"
+	}
+	return ref, nil
+}
+
+// String formats the reference without the oci:// prefix.
+func (r Reference) String() string {
+	s := r.Registry + "/" + r.Repository
+	if r.Tag != "" {
+		s += ":" + r.Tag
+	}
+	if r.Digest != "" {
+		s += "@" + r.Digest
+	}
+	return s
+}
+
+// Name returns the last path segment of the repository, the default
+// skill name.
+func (r Reference) Name() string {
+	return r.Repository[strings.LastIndex(r.Repository, "/")+1:]
+}
+
+// manifestRef returns what to fetch the manifest by: the digest when
+// pinned, else the tag.
+func (r Reference) manifestRef() string {
+	if r.Digest != "" {
+		return r.Digest
+	}
+	return r.Tag
+}
+
+// baseURL returns the registry API root. Loopback registries (a local
+// registry:2) are spoken to over plain HTTP, like docker does.
+func (r Reference) baseURL() string {
+	host := r.Registry
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+	if host == "localhost" || net.ParseIP(host).IsLoopback() {
+		return "http://" + r.Registry
+	}
+	return "https://" + r.Registry
+}
@@ -0,0 +1,61 @@
+package oci
+
+import "testing"
+
+func TestParseReference(t *testing.T) {
+	tests := []struct {
+		in   string
+		want Reference
+	}{
+		{"oci://ghcr.io/org/skills/pdf:1.2.0", Reference{Registry: "ghcr.io", Repository: "org/skills/pdf", Tag: "1.2.0"}},
+		{"ghcr.io/org/pdf", Reference{Registry: "ghcr.io", Repository: "org/pdf", Tag: "latest"}},
+		{"oci://localhost:5000/pdf:v2", Reference{Registry: "localhost:5000", Repository: "pdf", Tag: "v2"}},
+		{
+			"oci://harbor.example.com/team/pdf@sha256:" + sixtyFour,
+			Reference{Registry: "harbor.example.com", Repository: "team/pdf", Digest: "sha256:" + sixtyFour},
+		},
+		{
+			"oci://harbor.example.com/team/pdf:1.0@sha256:" + sixtyFour,
+			Reference{Registry: "harbor.example.com", Repository: "team/pdf", Tag: "1.0", Digest: "sha256:" + sixtyFour},
+		},
+	}
+	for _, tt := range tests {
+		got, err := ParseReference(tt.in)
+		if err != nil {
+			t.Errorf("ParseReference(%q) error: %v", tt.in, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseReference(%q) = %+v, want %+v", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestParseReference_Invalid(t *testing.T) {
+	for _, in := range []string{
+		"oci://ghcr.io",
+		"oci:///pdf",
+		"oci://ghcr.io/Org/PDF:1.0",
+		"oci://ghcr.io/org/pdf:bad tag",
+		"oci://ghcr.io/org/pdf@sha256:abc",
+	} {
+		if _, err := ParseReference(in); err == nil {
+			t.Errorf("ParseReference(%q) should fail", in)
+		}
+	}
+}
+
+func TestReference_BaseURL(t *testing.T) {
+	tests := map[string]string{
+		"localhost:5000": "http://localhost:5000",
+		"127.0.0.1:5000": "http://127.0.0.1:5000",
+		"ghcr.io":        "https://ghcr.io",
+	}
+	for registry, want := range tests {
+		if got := (Reference{Registry: registry}).baseURL(); got != want {
+			t.Errorf("baseURL(%s) = %s, want %s", registry, got, want)
+		}
+	}
+}
+
+const sixtyFour = "0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"
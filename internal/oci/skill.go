@@ -0,0 +1,188 @@
+package oci
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// annotationPrefix namespaces SKILL.md frontmatter fields in manifest
+	// annotations: dev.skillshare.skill.<field>.
+	annotationPrefix = "dev.skillshare.skill."
+
+	AnnotationTitle       = "org.opencontainers.image.title"
+	AnnotationDescription = "org.opencontainers.image.description"
+	AnnotationVersion     = "org.opencontainers.image.version"
+	AnnotationCreated     = "org.opencontainers.image.created"
+)
+
+// skipOnPack lists files that belong to an installation, not the skill.
+var skipOnPack = map[string]bool{
+	".git":                  true,
+	".skillshare-meta.json": true,
+}
+
+// PackDir returns a gzipped tar of dir. Entries are sorted and carry no
+// timestamps or owners, so packing the same files twice gives the same
+// digest.
+func PackDir(dir string) ([]byte, error) {
+	var paths []string
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if path == dir {
+			return nil
+		}
+		if skipOnPack[info.Name()] {
+			if info.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if info.Mode().IsRegular() || info.IsDir() {
+			paths = append(paths, path)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(paths)
+
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			return nil, err
+		}
+		rel, _ := filepath.Rel(dir, path)
+		hdr := &tar.Header{Name: filepath.ToSlash(rel), ModTime: time.Unix(0, 0), Format: tar.FormatPAX}
+		if info.IsDir() {
+			hdr.Typeflag, hdr.Name, hdr.Mode = tar.TypeDir, hdr.Name+"/", 0755
+			if err := tw.WriteHeader(hdr); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		hdr.Typeflag, hdr.Size, hdr.Mode = tar.TypeReg, info.Size(), 0644
+		if info.Mode()&0111 != 0 {
+			hdr.Mode = 0755
+		}
+		if err := tw.WriteHeader(hdr); err != nil {
+			return nil, err
+		}
+		f, err := os.Open(path)
+		if err != nil {
+			return nil, err
+		}
+		_, err = io.Copy(tw, f)
+		f.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return nil, err
+	}
+	if err := gz.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// Annotations builds manifest annotations for a skill from its SKILL.md
+// frontmatter fields: the standard OCI title, description and version, and
+// every field under dev.skillshare.skill.<field>.
+func Annotations(name, version string, frontmatter map[string]string) map[string]string {
+	a := map[string]string{
+		AnnotationTitle:   name,
+		AnnotationCreated: time.Now().UTC().Format(time.RFC3339),
+	}
+	if version != "" {
+		a[AnnotationVersion] = version
+	}
+	if desc := frontmatter["description"]; desc != "" {
+		a[AnnotationDescription] = desc
+	}
+	for key, val := range frontmatter {
+		a[annotationPrefix+key] = val
+	}
+	return a
+}
+
+// NewerTags returns the version-like tags (1.2.0, v2) greater than
+// current, highest first. Other tags (latest, main) are ignored; a current
+// tag that is not a version has no newer tags.
+func NewerTags(current string, tags []string) []string {
+	cur, ok := parseVersionTag(current)
+	if !ok {
+		return nil
+	}
+	var newer []string
+	for _, tag := range tags {
+		if v, ok := parseVersionTag(tag); ok && compareVersionParts(v, cur) > 0 {
+			newer = append(newer, tag)
+		}
+	}
+	sort.Slice(newer, func(i, j int) bool {
+		a, _ := parseVersionTag(newer[i])
+		b, _ := parseVersionTag(newer[j])
+		return compareVersionParts(a, b) > 0
+	})
+	return newer
+}
+
+// LatestTag returns the highest version-like tag, or "" if there is none.
+func LatestTag(tags []string) string {
+	var latest string
+	var best []int
+	for _, tag := range tags {
+		if v, ok := parseVersionTag(tag); ok && (best == nil || compareVersionParts(v, best) > 0) {
+			latest, best = tag, v
+		}
+	}
+	return latest
+}
+
+func parseVersionTag(tag string) ([]int, bool) {
+	parts := strings.Split(strings.TrimPrefix(tag, "v"), ".")
+	nums := make([]int, len(parts))
+	for i, p := range parts {
+		n, err := strconv.Atoi(p)
+		if err != nil || n < 0 {
+			return nil, false
+		}
+		nums[i] = n
+	}
+	return nums, true
+}
+
+func compareVersionParts(a, b []int) int {
+	for i := 0; i < len(a) || i < len(b); i++ {
+		var x, y int
+		if i < len(a) {
+			x = a[i]
+		}
+		if i < len(b) {
+			y = b[i]
+		}
+		if x != y {
+			if x < y {
+				return -1
+			}
+			return 1
+		}
+	}
+	return 0
+}
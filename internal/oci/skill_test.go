@@ -0,0 +1,94 @@
+package oci
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestPackDir_Deterministic(t *testing.T) {
+	dir := t.TempDir()
+	os.WriteFile(filepath.Join(dir, "SKILL.md"), []byte("---\nname: pdf\n---\n"), 0644)
+	os.MkdirAll(filepath.Join(dir, "scripts"), 0755)
+	os.WriteFile(filepath.Join(dir, "scripts", "run.sh"), []byte("echo hi\n"), 0755)
+	os.WriteFile(filepath.Join(dir, ".skillshare-meta.json"), []byte("{}"), 0644)
+	os.MkdirAll(filepath.Join(dir, ".git"), 0755)
+	os.WriteFile(filepath.Join(dir, ".git", "HEAD"), []byte("ref"), 0644)
+
+	first, err := PackDir(dir)
+	if err != nil {
+		t.Fatalf("PackDir: %v", err)
+	}
+	later := time.Now().Add(time.Hour)
+	os.Chtimes(filepath.Join(dir, "SKILL.md"), later, later)
+	second, err := PackDir(dir)
+	if err != nil {
+		t.Fatalf("PackDir: %v", err)
+	}
+	if Digest(first) != Digest(second) {
+		t.Error("packing unchanged files should give the same digest")
+	}
+
+	files := tarEntries(t, first)
+	want := []string{"SKILL.md", "scripts/", "scripts/run.sh"}
+	if !reflect.DeepEqual(files, want) {
+		t.Errorf("packed entries = %v, want %v", files, want)
+	}
+}
+
+func TestAnnotations(t *testing.T) {
+	a := Annotations("pdf", "1.2.0", map[string]string{"description": "PDF tools", "owner": "docs"})
+	if a[AnnotationTitle] != "pdf" || a[AnnotationVersion] != "1.2.0" || a[AnnotationDescription] != "PDF tools" {
+		t.Errorf("standard annotations wrong: %v", a)
+	}
+	if a["dev.skillshare.skill.owner"] != "docs" {
+		t.Errorf("frontmatter annotation missing: %v", a)
+	}
+	if _, err := time.Parse(time.RFC3339, a[AnnotationCreated]); err != nil {
+		t.Errorf("created annotation %q: %v", a[AnnotationCreated], err)
+	}
+}
+
+func TestNewerTags(t *testing.T) {
+	tags := []string{"1.0.0", "1.2.0", "latest", "1.10.0", "v2", "0.9", "main"}
+	got := NewerTags("1.0.0", tags)
+	want := []string{"v2", "1.10.0", "1.2.0"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("NewerTags = %v, want %v", got, want)
+	}
+	if got := NewerTags("latest", tags); got != nil {
+		t.Errorf("non-version tag should have no newer tags, got %v", got)
+	}
+	if got := LatestTag(tags); got != "v2" {
+		t.Errorf("LatestTag = %q, want v2", got)
+	}
+	if got := LatestTag([]string{"main", "latest"}); got != "" {
+		t.Errorf("LatestTag without version tags = %q, want empty", got)
+	}
+}
+
+func tarEntries(t *testing.T, layer []byte) []string {
+	t.Helper()
+	gz, err := gzip.NewReader(bytes.NewReader(layer))
+	if err != nil {
+		t.Fatalf("gzip: %v", err)
+	}
+	tr := tar.NewReader(gz)
+	var names []string
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return names
+		}
+		if err != nil {
+			t.Fatalf("tar: %v", err)
+		}
+		names = append(names, hdr.Name)
+	}
+}
@@ -0,0 +1,261 @@
+// Package oplock provides a cross-process advisory lock per skillshare
+// scope (the global source, or one project root). Every mutating command
+// and web UI request holds it, so a CLI sync, the UI server and the daemon
+// never write the same files at the same time.
+package oplock
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"skillshare/internal/config"
+	"skillshare/internal/filelock"
+)
+
+// FileName is the lock file name inside the scope's state directory.
+const FileName = "skillshare.lock"
+
+// DefaultTimeout is how long Acquire waits for another process by default.
+// SKILLSHARE_LOCK_TIMEOUT (a Go duration such as "2m") overrides it.
+const DefaultTimeout = 30 * time.Second
+
+// pollInterval is how often a waiting Acquire retries.
+const pollInterval = 100 * time.Millisecond
+
+// Holder describes the process that holds a lock.
+type Holder struct {
+	PID     int       `json:"pid"`
+	Command string    `json:"command"`
+	Since   time.Time `json:"since"`
+}
+
+func (h Holder) String() string {
+	if h.PID == 0 {
+		return "another skillshare process"
+	}
+	if h.Command == "" {
+		return fmt.Sprintf("PID %d", h.PID)
+	}
+	return fmt.Sprintf("PID %d running `%s`", h.PID, h.Command)
+}
+
+// LockedError is returned when the lock is still held after the timeout.
+type LockedError struct {
+	Path   string
+	Holder Holder
+}
+
+func (e *LockedError) Error() string {
+	msg := "skillshare is busy: lock held by " + e.Holder.String()
+	if !e.Holder.Since.IsZero() {
+		msg += fmt.Sprintf(" since %s", e.Holder.Since.Format("15:04:05"))
+	}
+	return msg + " (retry when it finishes)"
+}
+
+// IsLocked reports whether err is a LockedError.
+func IsLocked(err error) bool {
+	var le *LockedError
+	return errors.As(err, &le)
+}
+
+// Lock is a held operation lock. Release it when the operation ends.
+type Lock struct {
+	path string
+	held *heldLock
+}
+
+// slots serializes callers within this process, one slot per lock path,
+// before they take the file lock: the flock alone does not exclude a
+// second goroutine of the same process (e.g. the web UI and the in-process
+// daemon of `ui --daemon`). heldMu guards the map only, never a wait.
+var (
+	heldMu sync.Mutex
+	slots  = map[string]chan struct{}{}
+)
+
+// heldLock is the file lock shared by a Lock and its Nested locks.
+type heldLock struct {
+	f     *os.File
+	slot  chan struct{}
+	count int // guarded by heldMu
+}
+
+func slotFor(path string) chan struct{} {
+	heldMu.Lock()
+	defer heldMu.Unlock()
+	slot := slots[path]
+	if slot == nil {
+		slot = make(chan struct{}, 1)
+		slots[path] = slot
+	}
+	return slot
+}
+
+// Path returns the lock file for the scope of configPath: next to the
+// project config (.skillshare/skillshare.lock) in project mode, or in
+// the state directory in global mode.
+func Path(configPath string) string {
+	configDir := filepath.Dir(configPath)
+	if filepath.Base(configDir) == ".skillshare" {
+		return filepath.Join(configDir, FileName)
+	}
+	return filepath.Join(config.StateDir(), FileName)
+}
+
+// Timeout returns the configured wait timeout.
+func Timeout() time.Duration {
+	if v := os.Getenv("SKILLSHARE_LOCK_TIMEOUT"); v != "" {
+		if d, err := time.ParseDuration(v); err == nil && d >= 0 {
+			return d
+		}
+	}
+	return DefaultTimeout
+}
+
+// Acquire takes the lock at path for command, waiting up to timeout for
+// another process, or another goroutine of this one, to release it. onWait,
+// if set, is called once with the current holder when Acquire has to wait.
+// Acquire is not re-entrant: an operation run inside one that holds the
+// lock gets it from Nested instead.
+func Acquire(path, command string, timeout time.Duration, onWait func(Holder)) (*Lock, error) {
+	deadline := time.Now().Add(timeout)
+	waited := false
+	wait := func() {
+		if !waited && onWait != nil {
+			onWait(readHolder(path))
+		}
+		waited = true
+	}
+
+	slot := slotFor(path)
+	select {
+	case slot <- struct{}{}:
+	default:
+		wait()
+		timer := time.NewTimer(time.Until(deadline))
+		defer timer.Stop()
+		select {
+		case slot <- struct{}{}:
+		case <-timer.C:
+			return nil, &LockedError{Path: path, Holder: readHolder(path)}
+		}
+	}
+
+	f, err := lockFile(path, deadline, wait)
+	if err != nil {
+		<-slot
+		return nil, err
+	}
+	writeHolder(f, Holder{PID: os.Getpid(), Command: command, Since: time.Now()})
+	return &Lock{path: path, held: &heldLock{f: f, slot: slot, count: 1}}, nil
+}
+
+// lockFile opens path and polls for its file lock until deadline.
+func lockFile(path string, deadline time.Time, wait func()) (*os.File, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, fmt.Errorf("failed to create lock directory: %w", err)
+	}
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_RDWR, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open lock file: %w", err)
+	}
+	for {
+		ok, err := filelock.TryLock(f)
+		if err != nil {
+			f.Close()
+			return nil, fmt.Errorf("failed to lock %s: %w", path, err)
+		}
+		if ok {
+			return f, nil
+		}
+		if !time.Now().Before(deadline) {
+			holder := readHolder(path)
+			f.Close()
+			return nil, &LockedError{Path: path, Holder: holder}
+		}
+		wait()
+		time.Sleep(pollInterval)
+	}
+}
+
+// Nested returns a lock for an operation run inside the one holding l,
+// such as the sync at the end of a pull, when l covers path. Releasing it
+// leaves l held. Returns nil if l is nil, released, or for another path.
+func (l *Lock) Nested(path string) *Lock {
+	if l == nil {
+		return nil
+	}
+	heldMu.Lock()
+	defer heldMu.Unlock()
+	if l.path != path || l.held == nil || l.held.count == 0 {
+		return nil
+	}
+	l.held.count++
+	return &Lock{path: path, held: l.held}
+}
+
+// Release drops the lock. It is safe to call on a nil Lock and more than
+// once.
+func (l *Lock) Release() {
+	if l == nil || l.held == nil {
+		return
+	}
+	heldMu.Lock()
+	h := l.held
+	l.held = nil
+	h.count--
+	last := h.count == 0
+	heldMu.Unlock()
+	if !last {
+		return
+	}
+	h.f.Truncate(0)      //nolint:errcheck
+	filelock.Unlock(h.f) //nolint:errcheck
+	h.f.Close()
+	<-h.slot
+}
+
+// ReadHolder returns the holder recorded in the lock file at path and
+// whether the lock is currently held by another process.
+func ReadHolder(path string) (Holder, bool) {
+	f, err := os.OpenFile(path, os.O_RDWR, 0644)
+	if err != nil {
+		return Holder{}, false
+	}
+	defer f.Close()
+
+	ok, err := filelock.TryLock(f)
+	if err != nil {
+		return Holder{}, false
+	}
+	if ok {
+		filelock.Unlock(f) //nolint:errcheck
+		return Holder{}, false
+	}
+	return readHolder(path), true
+}
+
+func readHolder(path string) Holder {
+	var h Holder
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return h
+	}
+	json.Unmarshal(data, &h) //nolint:errcheck
+	return h
+}
+
+func writeHolder(f *os.File, h Holder) {
+	data, err := json.Marshal(h)
+	if err != nil {
+		return
+	}
+	f.Truncate(0)                    //nolint:errcheck
+	f.WriteAt(append(data, '\n'), 0) //nolint:errcheck
+}
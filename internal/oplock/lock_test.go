@@ -0,0 +1,152 @@
+package oplock
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"skillshare/internal/filelock"
+)
+
+func TestAcquire_WritesHolderAndReleases(t *testing.T) {
+	path := filepath.Join(t.TempDir(), FileName)
+
+	l, err := Acquire(path, "sync", time.Second, nil)
+	if err != nil {
+		t.Fatalf("Acquire() error: %v", err)
+	}
+	h := readHolder(path)
+	if h.PID != os.Getpid() || h.Command != "sync" {
+		t.Errorf("holder = %+v, want pid %d running sync", h, os.Getpid())
+	}
+
+	l.Release()
+	l.Release() // idempotent
+	if _, isHeld := ReadHolder(path); isHeld {
+		t.Error("lock should be free after Release()")
+	}
+}
+
+func TestNested_SharesLock(t *testing.T) {
+	path := filepath.Join(t.TempDir(), FileName)
+
+	outer, err := Acquire(path, "pull", time.Second, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	inner := outer.Nested(path)
+	if inner == nil {
+		t.Fatal("Nested() = nil for the held path")
+	}
+	if other := outer.Nested(path + ".other"); other != nil {
+		t.Error("Nested() should not cover another path")
+	}
+	inner.Release()
+	if !isHeldByOther(t, path) {
+		t.Error("lock should stay held until the outer Release()")
+	}
+	outer.Release()
+	if isHeldByOther(t, path) {
+		t.Error("lock should be free after the outer Release()")
+	}
+	if outer.Nested(path) != nil {
+		t.Error("Nested() of a released lock should be nil")
+	}
+}
+
+func TestAcquire_ExcludesSameProcess(t *testing.T) {
+	path := filepath.Join(t.TempDir(), FileName)
+
+	first, err := Acquire(path, "ui POST /api/sync", time.Second, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Acquire(path, "daemon cleanup", 100*time.Millisecond, nil); !IsLocked(err) {
+		t.Fatalf("second Acquire() in the same process error = %v, want LockedError", err)
+	}
+
+	acquired := make(chan *Lock)
+	go func() {
+		l, err := Acquire(path, "daemon cleanup", 5*time.Second, nil)
+		if err != nil {
+			t.Error(err)
+		}
+		acquired <- l
+	}()
+	time.Sleep(50 * time.Millisecond)
+	first.Release()
+	select {
+	case l := <-acquired:
+		l.Release()
+	case <-time.After(5 * time.Second):
+		t.Fatal("waiting Acquire() did not get the lock after Release()")
+	}
+}
+
+func TestAcquire_TimesOutWithHolder(t *testing.T) {
+	path := filepath.Join(t.TempDir(), FileName)
+
+	// Simulate another process: a separate open file holding the lock
+	other, err := os.OpenFile(path, os.O_CREATE|os.O_RDWR, 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer other.Close()
+	if ok, err := filelock.TryLock(other); !ok || err != nil {
+		t.Fatalf("TryLock() = (%v, %v)", ok, err)
+	}
+	writeHolder(other, Holder{PID: 4242, Command: "install", Since: time.Now()})
+
+	var waitedOn Holder
+	_, err = Acquire(path, "sync", 200*time.Millisecond, func(h Holder) { waitedOn = h })
+	if !IsLocked(err) {
+		t.Fatalf("Acquire() error = %v, want LockedError", err)
+	}
+	if !strings.Contains(err.Error(), "PID 4242 running `install`") {
+		t.Errorf("error = %q, want holder details", err)
+	}
+	if waitedOn.PID != 4242 {
+		t.Errorf("onWait holder = %+v, want pid 4242", waitedOn)
+	}
+
+	// Released by the other process: Acquire succeeds
+	filelock.Unlock(other) //nolint:errcheck
+	l, err := Acquire(path, "sync", time.Second, nil)
+	if err != nil {
+		t.Fatalf("Acquire() after release error: %v", err)
+	}
+	l.Release()
+}
+
+func TestPath(t *testing.T) {
+	project := filepath.Join("repo", ".skillshare", "config.yaml")
+	if got, want := Path(project), filepath.Join("repo", ".skillshare", FileName); got != want {
+		t.Errorf("Path(project) = %q, want %q", got, want)
+	}
+
+	t.Setenv("XDG_STATE_HOME", filepath.Join("state"))
+	if got, want := Path(filepath.Join("cfg", "config.yaml")), filepath.Join("state", "skillshare", FileName); got != want {
+		t.Errorf("Path(global) = %q, want %q", got, want)
+	}
+}
+
+func TestTimeout_EnvOverride(t *testing.T) {
+	t.Setenv("SKILLSHARE_LOCK_TIMEOUT", "2s")
+	if got := Timeout(); got != 2*time.Second {
+		t.Errorf("Timeout() = %v, want 2s", got)
+	}
+	t.Setenv("SKILLSHARE_LOCK_TIMEOUT", "bogus")
+	if got := Timeout(); got != DefaultTimeout {
+		t.Errorf("Timeout() = %v, want default", got)
+	}
+}
+
+// isHeldByOther checks the lock from a separate open file, as another
+// process would.
+func isHeldByOther(t *testing.T, path string) bool {
+	t.Helper()
+	_, isHeld := ReadHolder(path)
+	return isHeld
+}
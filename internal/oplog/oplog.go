@@ -26,6 +26,7 @@ type Entry struct {
 	Status    string         `json:"status"`
 	Message   string         `json:"msg,omitempty"`
 	Duration  int64          `json:"ms,omitempty"`
+	Changeset string         `json:"changeset,omitempty"` // ID of the reversible changeset, see undo
 }
 
 // LogDir returns the logs directory derived from a config file path.
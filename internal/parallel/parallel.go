@@ -0,0 +1,173 @@
+// Package parallel runs network-bound work (git fetches, ls-remote) on a
+// bounded pool of workers, with a per-host limit so one git server is not
+// hit by every worker at once.
+package parallel
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"sync"
+)
+
+const (
+	// DefaultJobs is the number of workers used when --jobs is not given.
+	DefaultJobs = 8
+	// DefaultPerHost caps concurrent requests to a single host.
+	DefaultPerHost = 4
+)
+
+// Pool runs work items with at most jobs running at once, and at most
+// perHost running against the same host.
+type Pool struct {
+	jobs    int
+	perHost int
+
+	mu    sync.Mutex
+	hosts map[string]chan struct{}
+}
+
+// NewPool returns a pool. Values below 1 mean 1; perHost is capped at jobs.
+func NewPool(jobs, perHost int) *Pool {
+	if jobs < 1 {
+		jobs = 1
+	}
+	if perHost < 1 {
+		perHost = 1
+	}
+	if perHost > jobs {
+		perHost = jobs
+	}
+	return &Pool{jobs: jobs, perHost: perHost, hosts: make(map[string]chan struct{})}
+}
+
+// Run calls fn(i) for each i in [0, n) and waits for all calls to return.
+// host(i) names the host item i talks to; "" means no per-host limit
+// (local paths). host may be nil.
+//
+// Results should be written by index so callers can print them in input
+// order regardless of completion order.
+func (p *Pool) Run(n int, host func(int) string, fn func(int)) {
+	if n == 0 {
+		return
+	}
+	sem := make(chan struct{}, p.jobs)
+	var wg sync.WaitGroup
+
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			// Wait for the host before taking a global slot, so workers
+			// queued on a busy host do not starve items for other hosts.
+			if host != nil {
+				if hs := p.hostSem(host(i)); hs != nil {
+					hs <- struct{}{}
+					defer func() { <-hs }()
+				}
+			}
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			fn(i)
+		}(i)
+	}
+	wg.Wait()
+}
+
+func (p *Pool) hostSem(host string) chan struct{} {
+	if host == "" {
+		return nil
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	hs, ok := p.hosts[host]
+	if !ok {
+		hs = make(chan struct{}, p.perHost)
+		p.hosts[host] = hs
+	}
+	return hs
+}
+
+// Memo runs a function once per key and shares the result with every
+// caller, including callers that arrive while the first call is running.
+// It is used to query each remote URL once when many skills come from the
+// same repository.
+type Memo[V any] struct {
+	mu    sync.Mutex
+	calls map[string]*memoCall[V]
+}
+
+type memoCall[V any] struct {
+	done chan struct{}
+	val  V
+	err  error
+}
+
+// Do returns the result of fn for key, calling fn only for the first caller.
+// A panic in fn is returned as an error to that caller and every waiter.
+func (m *Memo[V]) Do(key string, fn func() (V, error)) (V, error) {
+	m.mu.Lock()
+	if m.calls == nil {
+		m.calls = make(map[string]*memoCall[V])
+	}
+	if c, ok := m.calls[key]; ok {
+		m.mu.Unlock()
+		<-c.done
+		return c.val, c.err
+	}
+	c := &memoCall[V]{done: make(chan struct{})}
+	m.calls[key] = c
+	m.mu.Unlock()
+
+	func() {
+		defer close(c.done)
+		defer func() {
+			if r := recover(); r != nil {
+				c.err = fmt.Errorf("%s: panic: %v", key, r)
+			}
+		}()
+		c.val, c.err = fn()
+	}()
+	return c.val, c.err
+}
+
+// HostOf returns the host of a git remote URL, or "" for local paths.
+// It accepts https://, ssh://, git:// and scp-style (git@host:owner/repo) URLs.
+func HostOf(remote string) string {
+	remote = strings.TrimSpace(remote)
+	if remote == "" {
+		return ""
+	}
+	if strings.Contains(remote, "://") {
+		u, err := url.Parse(remote)
+		if err != nil || u.Scheme == "file" {
+			return ""
+		}
+		return strings.ToLower(u.Hostname())
+	}
+	// scp-style: [user@]host:path — but not a Windows drive (C:\...) or a
+	// path that merely contains a colon after a slash.
+	colon := strings.Index(remote, ":")
+	if colon <= 1 || strings.ContainsAny(remote[:colon], "/\\") {
+		return ""
+	}
+	host := remote[:colon]
+	if at := strings.LastIndex(host, "@"); at >= 0 {
+		host = host[at+1:]
+	}
+	return strings.ToLower(host)
+}
+
+// RemoteKey normalizes a remote URL so that spellings of the same
+// repository (trailing .git or slash, letter case of the host) share a key.
+func RemoteKey(remote string) string {
+	key := strings.TrimSpace(remote)
+	key = strings.TrimSuffix(key, "/")
+	key = strings.TrimSuffix(key, ".git")
+	if host := HostOf(key); host != "" {
+		if i := strings.Index(strings.ToLower(key), host); i >= 0 {
+			key = key[:i] + host + key[i+len(host):]
+		}
+	}
+	return key
+}
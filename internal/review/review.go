@@ -0,0 +1,228 @@
+// Package review finds skills that are due for review: past the review-by
+// date in their frontmatter, or unchanged for longer than the configured
+// maximum age.
+package review
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"skillshare/internal/config"
+	"skillshare/internal/install"
+	"skillshare/internal/sync"
+	"skillshare/internal/utils"
+)
+
+// ReviewsFile is the name of the file recording when skills were reviewed.
+const ReviewsFile = "reviews.json"
+
+// DateFormat is the layout of review-by dates.
+const DateFormat = "2006-01-02"
+
+// Where LastChanged came from.
+const (
+	ChangedGit       = "git"       // last commit touching the skill
+	ChangedInstalled = "installed" // SkillMeta.InstalledAt
+	ChangedModified  = "modified"  // SKILL.md modification time
+	ChangedReviewed  = "reviewed"  // skillshare review
+)
+
+// Status is the review state of one skill.
+type Status struct {
+	Name        string    `json:"name"` // flat name
+	RelPath     string    `json:"relPath"`
+	Owner       string    `json:"owner,omitempty"`
+	ReviewBy    string    `json:"reviewBy,omitempty"` // as written in frontmatter
+	ReviewedAt  time.Time `json:"reviewedAt,omitzero"`
+	LastChanged time.Time `json:"lastChanged,omitzero"` // only looked up with a maximum age
+	ChangedFrom string    `json:"changedFrom,omitempty"`
+	Stale       bool      `json:"stale"`
+	Reason      string    `json:"reason,omitempty"`
+}
+
+// Policy configures Check.
+type Policy struct {
+	MaxAgeMonths int       // skills unchanged for longer are stale; 0 disables
+	Now          time.Time // zero uses time.Now
+}
+
+// Reviews is the persisted record of when skills were last reviewed.
+type Reviews struct {
+	path  string
+	Items map[string]time.Time `json:"reviews"` // flat name → time
+}
+
+// ReviewsPath returns the reviews file for the scope of configPath: next to
+// the project config (.skillshare/reviews.json) in project mode, or in the
+// state directory in global mode.
+func ReviewsPath(configPath string) string {
+	configDir := filepath.Dir(configPath)
+	if filepath.Base(configDir) == ".skillshare" {
+		return filepath.Join(configDir, ReviewsFile)
+	}
+	return filepath.Join(config.StateDir(), ReviewsFile)
+}
+
+// LoadReviews reads the reviews file. A missing file yields an empty record.
+func LoadReviews(path string) (*Reviews, error) {
+	r := &Reviews{path: path, Items: map[string]time.Time{}}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return r, nil
+		}
+		return nil, err
+	}
+	if err := json.Unmarshal(data, r); err != nil {
+		return nil, err
+	}
+	if r.Items == nil {
+		r.Items = map[string]time.Time{}
+	}
+	return r, nil
+}
+
+// Mark records that the skill was reviewed at t.
+func (r *Reviews) Mark(name string, t time.Time) {
+	r.Items[name] = t.UTC()
+}
+
+// Save writes the reviews file atomically.
+func (r *Reviews) Save() error {
+	if err := os.MkdirAll(filepath.Dir(r.path), 0755); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return err
+	}
+	return config.WriteFileAtomic(r.path, data, 0644)
+}
+
+// Check returns the review state of every skill, stale ones first, then by
+// name. A skill is stale when its review-by date has passed and it has not
+// been reviewed since, or when nothing (commit, install or review) touched
+// it within the policy's maximum age.
+func Check(skills []sync.DiscoveredSkill, reviews *Reviews, p Policy) []Status {
+	now := p.Now
+	if now.IsZero() {
+		now = time.Now()
+	}
+	out := make([]Status, 0, len(skills))
+	for _, skill := range skills {
+		out = append(out, check(skill, reviews, p.MaxAgeMonths, now))
+	}
+	sort.SliceStable(out, func(i, j int) bool {
+		if out[i].Stale != out[j].Stale {
+			return out[i].Stale
+		}
+		return out[i].Name < out[j].Name
+	})
+	return out
+}
+
+// Stale filters statuses down to the stale ones.
+func Stale(statuses []Status) []Status {
+	var out []Status
+	for _, s := range statuses {
+		if s.Stale {
+			out = append(out, s)
+		}
+	}
+	return out
+}
+
+func check(skill sync.DiscoveredSkill, reviews *Reviews, maxAgeMonths int, now time.Time) Status {
+	skillFile := filepath.Join(skill.SourcePath, "SKILL.md")
+	st := Status{
+		Name:     skill.FlatName,
+		RelPath:  skill.RelPath,
+		Owner:    unquote(utils.ParseFrontmatterField(skillFile, "owner")),
+		ReviewBy: unquote(utils.ParseFrontmatterField(skillFile, "review-by")),
+	}
+	if reviews != nil {
+		st.ReviewedAt = reviews.Items[skill.FlatName]
+	}
+	reviewed := st.ReviewedAt
+	// Looking up history costs a git call per skill; only do it when a
+	// maximum age needs it.
+	if maxAgeMonths > 0 {
+		st.LastChanged, st.ChangedFrom = lastChanged(skill.SourcePath, skillFile)
+	}
+	if reviewed.After(st.LastChanged) {
+		st.LastChanged, st.ChangedFrom = reviewed, ChangedReviewed
+	}
+
+	if st.ReviewBy != "" {
+		due, err := time.ParseInLocation(DateFormat, st.ReviewBy, now.Location())
+		switch {
+		case err != nil:
+			st.Stale = true
+			st.Reason = fmt.Sprintf("review-by %q is not a date (use YYYY-MM-DD)", st.ReviewBy)
+			return st
+		case !now.Before(due) && reviewed.Before(due):
+			st.Stale = true
+			st.Reason = "review was due " + st.ReviewBy
+			return st
+		}
+	}
+
+	if maxAgeMonths > 0 && !st.LastChanged.IsZero() && st.LastChanged.AddDate(0, maxAgeMonths, 0).Before(now) {
+		st.Stale = true
+		st.Reason = fmt.Sprintf("unchanged for %d months (%s %s)", monthsBetween(st.LastChanged, now), st.ChangedFrom, st.LastChanged.Local().Format(DateFormat))
+	}
+	return st
+}
+
+// lastChanged returns the newest of the last commit touching the skill and
+// its install time, falling back to the SKILL.md modification time when
+// neither is known.
+func lastChanged(skillPath, skillFile string) (time.Time, string) {
+	var when time.Time
+	from := ""
+	if t, ok := lastCommit(skillPath); ok {
+		when, from = t, ChangedGit
+	}
+	if meta, err := install.ReadMeta(skillPath); err == nil && meta != nil && meta.InstalledAt.After(when) {
+		when, from = meta.InstalledAt, ChangedInstalled
+	}
+	if when.IsZero() {
+		if info, err := os.Stat(skillFile); err == nil {
+			when, from = info.ModTime(), ChangedModified
+		}
+	}
+	return when, from
+}
+
+// lastCommit returns the time of the last commit touching dir, in whichever
+// repository contains it (the source repo or a tracked repo).
+func lastCommit(dir string) (time.Time, bool) {
+	out, err := exec.Command("git", "-C", dir, "log", "-1", "--format=%ct", "--", ".").Output()
+	if err != nil {
+		return time.Time{}, false
+	}
+	sec, err := strconv.ParseInt(strings.TrimSpace(string(out)), 10, 64)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return time.Unix(sec, 0), true
+}
+
+func monthsBetween(from, to time.Time) int {
+	months := (to.Year()-from.Year())*12 + int(to.Month()-from.Month())
+	if to.Day() < from.Day() {
+		months--
+	}
+	return months
+}
+
+func unquote(s string) string {
+	return strings.Trim(s, `"'`)
+}
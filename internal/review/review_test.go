@@ -0,0 +1,93 @@
+package review
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"skillshare/internal/install"
+	"skillshare/internal/sync"
+)
+
+func writeSkill(t *testing.T, root, name, frontmatter string) sync.DiscoveredSkill {
+	t.Helper()
+	dir := filepath.Join(root, name)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	content := "---\nname: " + name + "\n" + frontmatter + "---\nBody.\n"
+	if err := os.WriteFile(filepath.Join(dir, "SKILL.md"), []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return sync.DiscoveredSkill{SourcePath: dir, RelPath: name, FlatName: name}
+}
+
+func TestCheck(t *testing.T) {
+	root := t.TempDir()
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.Local)
+
+	overdue := writeSkill(t, root, "overdue", "review-by: 2026-09-01\nowner: alice\n")
+	future := writeSkill(t, root, "future", "review-by: \"2027-01-01\"\n")
+	reviewed := writeSkill(t, root, "reviewed", "review-by: 2026-09-01\n")
+	bad := writeSkill(t, root, "bad", "review-by: next spring\n")
+	old := writeSkill(t, root, "old", "")
+	if err := install.WriteMeta(old.SourcePath, &install.SkillMeta{Source: "x", InstalledAt: now.AddDate(0, -8, 0)}); err != nil {
+		t.Fatal(err)
+	}
+	fresh := writeSkill(t, root, "fresh", "")
+	if err := install.WriteMeta(fresh.SourcePath, &install.SkillMeta{Source: "x", InstalledAt: now.AddDate(0, -1, 0)}); err != nil {
+		t.Fatal(err)
+	}
+
+	reviews, err := LoadReviews(filepath.Join(root, ReviewsFile))
+	if err != nil {
+		t.Fatal(err)
+	}
+	reviews.Mark("reviewed", time.Date(2026, 9, 5, 0, 0, 0, 0, time.UTC))
+
+	skills := []sync.DiscoveredSkill{overdue, future, reviewed, bad, old, fresh}
+	got := map[string]Status{}
+	for _, st := range Check(skills, reviews, Policy{MaxAgeMonths: 6, Now: now}) {
+		got[st.Name] = st
+	}
+
+	for name, wantStale := range map[string]bool{
+		"overdue": true, "future": false, "reviewed": false, "bad": true, "old": true, "fresh": false,
+	} {
+		if got[name].Stale != wantStale {
+			t.Errorf("%s: stale = %v (%s), want %v", name, got[name].Stale, got[name].Reason, wantStale)
+		}
+	}
+	if got["overdue"].Owner != "alice" || got["overdue"].Reason != "review was due 2026-09-01" {
+		t.Errorf("overdue = %+v", got["overdue"])
+	}
+	if got["old"].ChangedFrom != ChangedInstalled {
+		t.Errorf("old changed from %q, want %q", got["old"].ChangedFrom, ChangedInstalled)
+	}
+
+	// Without a maximum age only review-by dates count.
+	if stale := Stale(Check(skills, reviews, Policy{Now: now})); len(stale) != 2 {
+		t.Errorf("Stale() without max age = %+v, want overdue and bad", stale)
+	}
+}
+
+func TestReviews_SaveLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state", ReviewsFile)
+	r, err := LoadReviews(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	at := time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)
+	r.Mark("pdf", at)
+	if err := r.Save(); err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := LoadReviews(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !loaded.Items["pdf"].Equal(at) {
+		t.Errorf("loaded = %v, want %v", loaded.Items, at)
+	}
+}
@@ -0,0 +1,259 @@
+// Package scaffold creates new skills from template directories and from
+// existing skills.
+package scaffold
+
+import (
+	"bytes"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"skillshare/internal/utils"
+)
+
+// TemplatesDirName is the directory next to the skills source that holds
+// one sub-directory per template.
+const TemplatesDirName = "templates"
+
+// templateField marks an installed skill as a template in its frontmatter
+// ("template: true"). It is dropped from skills created from the template.
+const templateField = "template"
+
+// Template origins reported in Template.Origin.
+const (
+	OriginDir   = "templates"
+	OriginSkill = "skill"
+)
+
+// Template is a directory that new skills can be scaffolded from.
+type Template struct {
+	Name   string
+	Path   string
+	Origin string
+}
+
+// Vars are the values substituted for {{name}}, {{title}}, {{author}},
+// {{targets}} and {{date}} in template file contents and paths.
+type Vars struct {
+	Name    string
+	Title   string
+	Author  string
+	Targets []string
+}
+
+// TemplatesDir returns the templates directory for a skills source:
+// ~/.config/skillshare/templates globally, .skillshare/templates in a project.
+func TemplatesDir(sourceDir string) string {
+	return filepath.Join(filepath.Dir(sourceDir), TemplatesDirName)
+}
+
+// Discover lists the templates available for sourceDir: every directory in
+// templatesDir, plus installed skills whose frontmatter sets template: true.
+// On a name clash the templates directory wins.
+func Discover(templatesDir, sourceDir string) []Template {
+	var out []Template
+	seen := map[string]bool{}
+
+	if entries, err := os.ReadDir(templatesDir); err == nil {
+		for _, e := range entries {
+			if !e.IsDir() || utils.IsHidden(e.Name()) {
+				continue
+			}
+			out = append(out, Template{Name: e.Name(), Path: filepath.Join(templatesDir, e.Name()), Origin: OriginDir})
+			seen[e.Name()] = true
+		}
+	}
+
+	filepath.WalkDir(sourceDir, func(path string, d fs.DirEntry, err error) error { //nolint:errcheck
+		if err != nil {
+			return nil
+		}
+		if d.IsDir() {
+			if path != sourceDir && (d.Name() == ".git" || utils.IsHidden(d.Name())) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if d.Name() != "SKILL.md" || !isTrue(utils.ParseFrontmatterField(path, templateField)) {
+			return nil
+		}
+		dir := filepath.Dir(path)
+		name := filepath.Base(dir)
+		if fm, _ := utils.ParseSkillName(dir); fm != "" {
+			name = fm
+		}
+		if !seen[name] {
+			out = append(out, Template{Name: name, Path: dir, Origin: OriginSkill})
+			seen[name] = true
+		}
+		return filepath.SkipDir
+	})
+
+	sort.Slice(out, func(i, j int) bool { return out[i].Name < out[j].Name })
+	return out
+}
+
+// Find returns the discovered template called name.
+func Find(templates []Template, name string) (Template, bool) {
+	for _, t := range templates {
+		if t.Name == name {
+			return t, true
+		}
+	}
+	return Template{}, false
+}
+
+// Render copies the template at srcDir into destDir, substituting vars in
+// file contents and path names. SKILL.md gets its frontmatter name set to
+// vars.Name and loses the template marker. It returns the relative paths
+// written; destDir must not exist yet.
+func Render(srcDir, destDir string, vars Vars) ([]string, error) {
+	replacer := vars.replacer()
+	return copyTree(srcDir, destDir, vars.Name, func(data []byte) []byte {
+		if isBinary(data) {
+			return data
+		}
+		return []byte(replacer.Replace(string(data)))
+	}, replacer.Replace)
+}
+
+// Clone copies an existing skill into destDir under a new name, rewriting
+// the frontmatter name. File contents are otherwise left untouched.
+func Clone(srcDir, destDir, name string) ([]string, error) {
+	return copyTree(srcDir, destDir, name, nil, nil)
+}
+
+// removeFrontmatterField drops top-level "field:" lines from the frontmatter.
+func removeFrontmatterField(content, field string) string {
+	lines := strings.Split(content, "\n")
+	if len(lines) == 0 || strings.TrimSpace(lines[0]) != "---" {
+		return content
+	}
+	out := lines[:1:1]
+	i := 1
+	for ; i < len(lines); i++ {
+		if strings.TrimSpace(lines[i]) == "---" {
+			break
+		}
+		if strings.HasPrefix(lines[i], field+":") {
+			continue
+		}
+		out = append(out, lines[i])
+	}
+	out = append(out, lines[i:]...)
+	return strings.Join(out, "\n")
+}
+
+func (v Vars) replacer() *strings.Replacer {
+	targets := "[]"
+	if len(v.Targets) > 0 {
+		targets = "[" + strings.Join(v.Targets, ", ") + "]"
+	}
+	values := map[string]string{
+		"name":    v.Name,
+		"title":   v.Title,
+		"author":  v.Author,
+		"targets": targets,
+		"date":    time.Now().Format("2006-01-02"),
+	}
+	var pairs []string
+	for key, val := range values {
+		pairs = append(pairs, "{{"+key+"}}", val, "{{ "+key+" }}", val)
+	}
+	return strings.NewReplacer(pairs...)
+}
+
+// copyTree copies srcDir to destDir, skipping git and install metadata.
+// transform rewrites file contents and renamePath rewrites relative paths;
+// either may be nil.
+func copyTree(srcDir, destDir, name string, transform func([]byte) []byte, renamePath func(string) string) ([]string, error) {
+	info, err := os.Stat(srcDir)
+	if err != nil {
+		return nil, err
+	}
+	if !info.IsDir() {
+		return nil, fmt.Errorf("%s is not a directory", srcDir)
+	}
+	if _, err := os.Stat(destDir); err == nil {
+		return nil, fmt.Errorf("%s already exists", destDir)
+	}
+
+	var written []string
+	err = filepath.WalkDir(srcDir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, _ := filepath.Rel(srcDir, path)
+		if rel == "." {
+			return os.MkdirAll(destDir, 0755)
+		}
+		if d.Name() == ".git" || d.Name() == ".skillshare-meta.json" {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if d.Type()&fs.ModeSymlink != 0 {
+			return nil
+		}
+
+		destRel := rel
+		if renamePath != nil {
+			destRel = renamePath(rel)
+		}
+		dest := filepath.Join(destDir, destRel)
+		if d.IsDir() {
+			return os.MkdirAll(dest, 0755)
+		}
+
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		if transform != nil {
+			data = transform(data)
+		}
+		if destRel == "SKILL.md" {
+			content := removeFrontmatterField(string(data), templateField)
+			data = []byte(utils.SetFrontmatterName(content, name))
+		}
+		fi, err := d.Info()
+		if err != nil {
+			return err
+		}
+		if err := os.MkdirAll(filepath.Dir(dest), 0755); err != nil {
+			return err
+		}
+		if err := os.WriteFile(dest, data, fi.Mode().Perm()); err != nil {
+			return err
+		}
+		written = append(written, filepath.ToSlash(destRel))
+		return nil
+	})
+	if err != nil {
+		os.RemoveAll(destDir)
+		return nil, err
+	}
+	if _, err := os.Stat(filepath.Join(destDir, "SKILL.md")); err != nil {
+		os.RemoveAll(destDir)
+		return nil, fmt.Errorf("%s has no SKILL.md", srcDir)
+	}
+	return written, nil
+}
+
+func isBinary(data []byte) bool {
+	n := len(data)
+	if n > 8000 {
+		n = 8000
+	}
+	return bytes.IndexByte(data[:n], 0) >= 0
+}
+
+func isTrue(v string) bool {
+	v = strings.ToLower(strings.Trim(v, `"'`))
+	return v == "true" || v == "yes"
+}
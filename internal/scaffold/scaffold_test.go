@@ -0,0 +1,108 @@
+package scaffold
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func readFile(t *testing.T, path string) string {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
+
+func TestDiscover_TemplatesDirAndMarkedSkills(t *testing.T) {
+	root := t.TempDir()
+	source := filepath.Join(root, "skills")
+	templates := TemplatesDir(source)
+
+	writeFile(t, filepath.Join(templates, "python-tool", "SKILL.md"), "---\nname: {{name}}\n---\n")
+	writeFile(t, filepath.Join(templates, "shared", "SKILL.md"), "---\nname: {{name}}\n---\n")
+	writeFile(t, filepath.Join(source, "team-base", "SKILL.md"), "---\nname: team-base\ntemplate: true\n---\n")
+	writeFile(t, filepath.Join(source, "_repo", "shared", "SKILL.md"), "---\nname: shared\ntemplate: true\n---\n")
+	writeFile(t, filepath.Join(source, "plain", "SKILL.md"), "---\nname: plain\n---\n")
+
+	got := Discover(templates, source)
+	var names []string
+	for _, tpl := range got {
+		names = append(names, tpl.Name+":"+tpl.Origin)
+	}
+	want := []string{"python-tool:templates", "shared:templates", "team-base:skill"}
+	if !reflect.DeepEqual(names, want) {
+		t.Errorf("Discover() = %v, want %v", names, want)
+	}
+}
+
+func TestRender_SubstitutesVarsAndPaths(t *testing.T) {
+	src := filepath.Join(t.TempDir(), "tpl")
+	writeFile(t, filepath.Join(src, "SKILL.md"), "---\nname: base\ntemplate: true\ntargets: {{targets}}\n---\n\n# {{ title }}\nBy {{author}}\n")
+	writeFile(t, filepath.Join(src, "scripts", "{{name}}.py"), "print('{{name}}')\n")
+	writeFile(t, filepath.Join(src, ".skillshare-meta.json"), "{}")
+
+	dest := filepath.Join(t.TempDir(), "pdf-tools")
+	written, err := Render(src, dest, Vars{Name: "pdf-tools", Title: "Pdf Tools", Author: "Ada", Targets: []string{"claude", "cursor"}})
+	if err != nil {
+		t.Fatalf("Render() error: %v", err)
+	}
+	if want := []string{"SKILL.md", "scripts/pdf-tools.py"}; !reflect.DeepEqual(written, want) {
+		t.Errorf("written = %v, want %v", written, want)
+	}
+
+	skill := readFile(t, filepath.Join(dest, "SKILL.md"))
+	for _, want := range []string{"name: pdf-tools\n", "targets: [claude, cursor]", "# Pdf Tools", "By Ada"} {
+		if !strings.Contains(skill, want) {
+			t.Errorf("SKILL.md missing %q:\n%s", want, skill)
+		}
+	}
+	if strings.Contains(skill, "template:") {
+		t.Errorf("SKILL.md should drop the template marker:\n%s", skill)
+	}
+	if got := readFile(t, filepath.Join(dest, "scripts", "pdf-tools.py")); got != "print('pdf-tools')\n" {
+		t.Errorf("script = %q", got)
+	}
+}
+
+func TestClone_RewritesNameOnly(t *testing.T) {
+	src := filepath.Join(t.TempDir(), "old")
+	writeFile(t, filepath.Join(src, "SKILL.md"), "---\nname: old\ndescription: Keeps {{name}} literal\n---\n")
+	writeFile(t, filepath.Join(src, ".git", "HEAD"), "ref")
+
+	dest := filepath.Join(t.TempDir(), "new")
+	if _, err := Clone(src, dest, "new"); err != nil {
+		t.Fatalf("Clone() error: %v", err)
+	}
+	if got := readFile(t, filepath.Join(dest, "SKILL.md")); got != "---\nname: new\ndescription: Keeps {{name}} literal\n---\n" {
+		t.Errorf("SKILL.md = %q", got)
+	}
+	if _, err := os.Stat(filepath.Join(dest, ".git")); !os.IsNotExist(err) {
+		t.Error(".git should not be copied")
+	}
+}
+
+func TestRender_RequiresSkillFile(t *testing.T) {
+	src := filepath.Join(t.TempDir(), "tpl")
+	writeFile(t, filepath.Join(src, "README.md"), "hi")
+	dest := filepath.Join(t.TempDir(), "x")
+	if _, err := Render(src, dest, Vars{Name: "x"}); err == nil {
+		t.Fatal("Render() should fail without SKILL.md")
+	}
+	if _, err := os.Stat(dest); !os.IsNotExist(err) {
+		t.Error("partial output should be removed")
+	}
+}
@@ -9,6 +9,9 @@ import (
 	"sort"
 	"strings"
 	"time"
+
+	"skillshare/internal/credential"
+	"skillshare/internal/network"
 )
 
 type indexDocument struct {
@@ -131,11 +134,14 @@ func loadIndex(indexURL string) (*indexDocument, error) {
 	}
 
 	if strings.HasPrefix(s, "http://") || strings.HasPrefix(s, "https://") {
-		client := &http.Client{Timeout: 15 * time.Second}
+		client := network.NewClient(15 * time.Second)
 		req, err := http.NewRequest("GET", s, nil)
 		if err != nil {
 			return nil, err
 		}
+		if err := credential.Authorize(req); err != nil {
+			return nil, fmt.Errorf("fetch hub: %w", err)
+		}
 		resp, err := client.Do(req)
 		if err != nil {
 			return nil, fmt.Errorf("fetch hub: %w", err)
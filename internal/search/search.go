@@ -13,6 +13,9 @@ import (
 	"strings"
 	"sync"
 	"time"
+
+	"skillshare/internal/credential"
+	"skillshare/internal/network"
 )
 
 // SearchResult represents a skill found via search
@@ -507,7 +510,7 @@ func lastPathSegment(path string) string {
 
 // newGitHubClient creates an HTTP client for GitHub API
 func newGitHubClient() *http.Client {
-	return &http.Client{Timeout: 15 * time.Second}
+	return network.NewClient(15 * time.Second)
 }
 
 // newGitHubRequest creates a request with auth header if token is available
@@ -534,6 +537,11 @@ var ghTokenChecked bool
 
 // getGitHubToken attempts to get a GitHub token from various sources
 func getGitHubToken() string {
+	// 0. A github.com auth entry in config.yaml
+	if c, _ := credential.Lookup("github.com"); c != nil {
+		return c.Token
+	}
+
 	// 1. Check GITHUB_TOKEN environment variable
 	if token := os.Getenv("GITHUB_TOKEN"); token != "" {
 		return token
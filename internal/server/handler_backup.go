@@ -46,6 +46,44 @@ func (s *Server) handleListBackups(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
+type backupSkillJSON struct {
+	Name   string  `json:"name"`
+	Files  int     `json:"files"`
+	SizeKB float64 `json:"sizeKB"`
+}
+
+// handleShowBackup returns the skills stored in one backup, per target
+func (s *Server) handleShowBackup(w http.ResponseWriter, r *http.Request) {
+	bk, err := backup.GetBackupByTimestamp(r.PathValue("timestamp"))
+	if err != nil {
+		writeError(w, http.StatusNotFound, err.Error())
+		return
+	}
+
+	targets := make(map[string][]backupSkillJSON, len(bk.Targets))
+	for _, t := range bk.Targets {
+		snap, err := backup.LoadSnapshot(bk.Path, t)
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		skills := make([]backupSkillJSON, 0)
+		for _, sk := range snap.Skills() {
+			skills = append(skills, backupSkillJSON{
+				Name:   sk.Name,
+				Files:  sk.Files,
+				SizeKB: float64(sk.Size) / 1024,
+			})
+		}
+		targets[t] = skills
+	}
+
+	writeJSON(w, map[string]any{
+		"backup":  toBackupJSON(*bk),
+		"targets": targets,
+	})
+}
+
 // handleCreateBackup creates a backup of target(s)
 func (s *Server) handleCreateBackup(w http.ResponseWriter, r *http.Request) {
 	start := time.Now()
@@ -128,9 +166,11 @@ func (s *Server) handleRestore(w http.ResponseWriter, r *http.Request) {
 	defer s.mu.Unlock()
 
 	var body struct {
-		Timestamp string `json:"timestamp"`
-		Target    string `json:"target"`
-		Force     bool   `json:"force"`
+		Timestamp string   `json:"timestamp"`
+		Target    string   `json:"target"`
+		Force     bool     `json:"force"`
+		Skills    []string `json:"skills"`   // restore only these skills
+		ToSource  bool     `json:"toSource"` // restore skills into the source dir
 	}
 	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
 		writeError(w, http.StatusBadRequest, "invalid JSON body")
@@ -158,6 +198,46 @@ func (s *Server) handleRestore(w http.ResponseWriter, r *http.Request) {
 
 	opts := backup.RestoreOptions{Force: body.Force}
 
+	if body.ToSource && len(body.Skills) == 0 {
+		writeError(w, http.StatusBadRequest, "toSource requires skills")
+		return
+	}
+
+	if len(body.Skills) > 0 {
+		destDir := t.Path
+		if body.ToSource {
+			destDir = s.cfg.Source
+		}
+		if err := backup.ValidateRestoreSkills(bk.Path, body.Target, destDir, body.Skills, opts); err != nil {
+			writeError(w, http.StatusConflict, err.Error())
+			return
+		}
+		if err := backup.RestoreSkills(bk.Path, body.Target, destDir, body.Skills, opts); err != nil {
+			writeError(w, http.StatusInternalServerError, "restore failed: "+err.Error())
+			return
+		}
+
+		args := map[string]any{
+			"target": body.Target,
+			"from":   body.Timestamp,
+			"skills": body.Skills,
+			"force":  body.Force,
+			"scope":  "ui",
+		}
+		if body.ToSource {
+			args["to"] = "source"
+		}
+		s.writeOpsLog("restore", "ok", start, args, "")
+
+		writeJSON(w, map[string]any{
+			"success":   true,
+			"target":    body.Target,
+			"timestamp": body.Timestamp,
+			"skills":    body.Skills,
+		})
+		return
+	}
+
 	// Validate first
 	if err := backup.ValidateRestore(bk.Path, body.Target, t.Path, opts); err != nil {
 		writeError(w, http.StatusConflict, err.Error())
@@ -6,12 +6,15 @@ import (
 
 	"skillshare/internal/git"
 	"skillshare/internal/install"
+	"skillshare/internal/parallel"
 )
 
 type repoCheckResult struct {
 	Name    string `json:"name"`
 	Status  string `json:"status"`
 	Behind  int    `json:"behind"`
+	Pinned  string `json:"pinned,omitempty"`
+	Latest  string `json:"latest,omitempty"`
 	Message string `json:"message,omitempty"`
 }
 
@@ -32,14 +35,27 @@ func (s *Server) handleCheck(w http.ResponseWriter, r *http.Request) {
 	repos, _ := install.GetTrackedRepos(sourceDir)
 	skills, _ := install.GetUpdatableSkills(sourceDir)
 
-	var repoResults []repoCheckResult
-	for _, repo := range repos {
-		repoPath := filepath.Join(sourceDir, repo)
-		result := repoCheckResult{Name: repo}
+	pool := parallel.NewPool(parallel.DefaultJobs, parallel.DefaultPerHost)
+
+	repoResults := make([]repoCheckResult, len(repos))
+	pool.Run(len(repos), func(i int) string {
+		return parallel.HostOf(git.GetRemoteURL(filepath.Join(sourceDir, repos[i])))
+	}, func(i int) {
+		repoPath := filepath.Join(sourceDir, repos[i])
+		result := repoCheckResult{Name: repos[i]}
 
 		if isDirty, _ := git.IsDirty(repoPath); isDirty {
 			result.Status = "dirty"
 			result.Message = "has uncommitted changes"
+		} else if pin, err := install.CheckPinnedRepo(repoPath); pin != nil {
+			if err != nil {
+				result.Status = "error"
+				result.Message = err.Error()
+			} else {
+				result.Status = "pinned"
+				result.Pinned = pin.Ref
+				result.Latest = pin.Latest
+			}
 		} else if behind, err := git.GetBehindCount(repoPath); err != nil {
 			result.Status = "error"
 			result.Message = err.Error()
@@ -50,19 +66,31 @@ func (s *Server) handleCheck(w http.ResponseWriter, r *http.Request) {
 			result.Behind = behind
 		}
 
-		repoResults = append(repoResults, result)
-	}
+		repoResults[i] = result
+	})
 
-	var skillResults []skillCheckResult
-	for _, skill := range skills {
-		skillPath := filepath.Join(sourceDir, skill)
-		result := skillCheckResult{Name: skill}
+	// Skills from the same repository share one ls-remote.
+	metas := make([]*install.SkillMeta, len(skills))
+	for i, skill := range skills {
+		if meta, err := install.ReadMeta(filepath.Join(sourceDir, skill)); err == nil {
+			metas[i] = meta
+		}
+	}
+	remotes := &parallel.Memo[string]{}
+	skillResults := make([]skillCheckResult, len(skills))
+	pool.Run(len(skills), func(i int) string {
+		if metas[i] == nil {
+			return ""
+		}
+		return parallel.HostOf(metas[i].RemoteURL())
+	}, func(i int) {
+		result := skillCheckResult{Name: skills[i]}
+		defer func() { skillResults[i] = result }()
 
-		meta, err := install.ReadMeta(skillPath)
-		if err != nil || meta == nil || meta.RepoURL == "" {
+		meta := metas[i]
+		if meta == nil || meta.RemoteURL() == "" {
 			result.Status = "local"
-			skillResults = append(skillResults, result)
-			continue
+			return
 		}
 
 		result.Source = meta.Source
@@ -71,7 +99,33 @@ func (s *Server) handleCheck(w http.ResponseWriter, r *http.Request) {
 			result.InstalledAt = meta.InstalledAt.Format("2006-01-02")
 		}
 
-		remoteHash, err := git.GetRemoteHeadHash(meta.RepoURL)
+		if meta.IsOCI() {
+			update, err := install.CheckOCIUpdate(meta)
+			if err != nil {
+				result.Status = "error"
+			} else if update.Changed || len(update.NewerTags) > 0 {
+				result.Status = "update_available"
+			} else {
+				result.Status = "up_to_date"
+			}
+			return
+		}
+
+		if meta.IsArchive() {
+			changed, err := install.CheckArchiveUpdate(meta)
+			if err != nil {
+				result.Status = "error"
+			} else if changed {
+				result.Status = "update_available"
+			} else {
+				result.Status = "up_to_date"
+			}
+			return
+		}
+
+		remoteHash, err := remotes.Do(parallel.RemoteKey(meta.RepoURL), func() (string, error) {
+			return git.GetRemoteHeadHash(meta.RepoURL)
+		})
 		if err != nil {
 			result.Status = "error"
 		} else if meta.Version == remoteHash {
@@ -79,16 +133,7 @@ func (s *Server) handleCheck(w http.ResponseWriter, r *http.Request) {
 		} else {
 			result.Status = "update_available"
 		}
-
-		skillResults = append(skillResults, result)
-	}
-
-	if repoResults == nil {
-		repoResults = []repoCheckResult{}
-	}
-	if skillResults == nil {
-		skillResults = []skillCheckResult{}
-	}
+	})
 
 	writeJSON(w, map[string]any{
 		"tracked_repos": repoResults,
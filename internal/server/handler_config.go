@@ -62,7 +62,7 @@ func (s *Server) handlePutConfig(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
-	if err := os.WriteFile(s.configPath(), []byte(body.Raw), 0644); err != nil {
+	if err := config.WriteFileAtomic(s.configPath(), []byte(body.Raw), 0644); err != nil {
 		writeError(w, http.StatusInternalServerError, "failed to write config: "+err.Error())
 		return
 	}
@@ -148,18 +148,38 @@ type pullResponse struct {
 	SyncResults []syncTargetResult `json:"syncResults"`
 	DryRun      bool               `json:"dryRun"`
 	Message     string             `json:"message,omitempty"`
+	Stashed     bool               `json:"stashed,omitempty"`
+	Stage       string             `json:"stage,omitempty"`
+	Conflicts   []git.Conflict     `json:"conflicts,omitempty"`
 }
 
-// handlePull pulls changes and syncs to targets
+type pullRequest struct {
+	DryRun   bool   `json:"dryRun"`
+	Strategy string `json:"strategy"` // rebase (default) or merge
+	// Resolutions maps skill → "ours" | "theirs"; ResolveAll applies to
+	// every conflicted skill not listed there.
+	Resolutions map[string]string `json:"resolutions"`
+	ResolveAll  string            `json:"resolveAll"`
+	Abort       bool              `json:"abort"`
+}
+
+// handlePull pulls changes and syncs to targets. Local changes are stashed
+// and restored. On conflicts the response lists them (success=false) and
+// nothing is synced; post again with resolutions to continue, or with
+// abort to roll back.
 func (s *Server) handlePull(w http.ResponseWriter, r *http.Request) {
 	start := time.Now()
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	var body struct {
-		DryRun bool `json:"dryRun"`
-	}
+	var body pullRequest
 	json.NewDecoder(r.Body).Decode(&body)
+	for _, side := range append(mapValues(body.Resolutions), body.ResolveAll) {
+		if side != "" && side != git.SideOurs && side != git.SideTheirs {
+			writeError(w, http.StatusBadRequest, "resolution must be \"ours\" or \"theirs\"")
+			return
+		}
+	}
 
 	src := s.cfg.Source
 
@@ -172,39 +192,85 @@ func (s *Server) handlePull(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Check dirty
-	dirty, err := git.IsDirty(src)
-	if err != nil {
-		writeError(w, http.StatusInternalServerError, "failed to check git status: "+err.Error())
-		return
-	}
-	if dirty {
-		writeError(w, http.StatusBadRequest, "working tree has uncommitted changes — commit or stash before pulling")
+	if body.Abort {
+		if err := git.AbortPull(src); err != nil {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		s.writeOpsLog("pull", "ok", start, map[string]any{"abort": true, "scope": "ui"}, "")
+		writeJSON(w, pullResponse{Success: true, Message: "pull aborted", Commits: []git.CommitInfo{}, SyncResults: []syncTargetResult{}})
 		return
 	}
 
+	inProgress := git.InPullProgress(src)
+
 	if body.DryRun {
+		msg := "dry run: would pull and sync"
+		if inProgress {
+			msg = "dry run: a pull is waiting for conflict resolution"
+		} else if dirty, err := git.IsDirty(src); err == nil && dirty {
+			msg = "dry run: would stash local changes, pull, restore them and sync"
+		}
 		s.writeOpsLog("pull", "ok", start, map[string]any{
 			"summary": "dry run",
 			"dry_run": true,
 			"scope":   "ui",
 		}, "")
-		writeJSON(w, pullResponse{Success: true, DryRun: true, Message: "dry run: would pull and sync"})
+		writeJSON(w, pullResponse{Success: true, DryRun: true, Message: msg})
 		return
 	}
 
-	// Pull
-	info, err := git.Pull(src)
+	var res *git.PullResult
+	var err error
+	if inProgress {
+		res, err = git.ContinuePull(src)
+	} else {
+		res, err = git.SyncPull(src, git.PullOptions{Strategy: body.Strategy, Env: git.AuthEnvForRepo(src)})
+	}
+
+	// Apply requested resolutions until the pull is clean or a conflict
+	// has no resolution.
+	for err == nil && !res.Clean() && body.resolvesAll(res.Conflicts) {
+		for _, c := range res.Conflicts {
+			if err = git.ResolveSkill(src, c.Skill, body.resolutionFor(c.Skill)); err != nil {
+				break
+			}
+		}
+		if err == nil {
+			res, err = git.ContinuePull(src)
+		}
+	}
 	if err != nil {
+		s.writeOpsLog("pull", "error", start, map[string]any{"scope": "ui"}, err.Error())
 		writeError(w, http.StatusInternalServerError, "git pull failed: "+err.Error())
 		return
 	}
 
+	if !res.Clean() {
+		s.writeOpsLog("pull", "error", start, map[string]any{
+			"conflicts": len(res.Conflicts),
+			"stage":     res.Stage,
+			"scope":     "ui",
+		}, "conflicts need resolution")
+		writeJSON(w, pullResponse{
+			Success:     false,
+			Commits:     []git.CommitInfo{},
+			SyncResults: []syncTargetResult{},
+			Message:     "conflicts need resolution",
+			Stashed:     res.Stashed,
+			Stage:       res.Stage,
+			Conflicts:   res.Conflicts,
+		})
+		return
+	}
+	info := &res.UpdateInfo
+
 	resp := pullResponse{
 		Success:  true,
 		UpToDate: info.UpToDate,
 		Commits:  info.Commits,
 		Stats:    info.Stats,
+		Stashed:  res.Stashed,
 	}
 
 	if resp.Commits == nil {
@@ -266,3 +332,29 @@ func (s *Server) handlePull(w http.ResponseWriter, r *http.Request) {
 
 	writeJSON(w, resp)
 }
+
+// resolutionFor returns the requested side for skill, or "".
+func (b pullRequest) resolutionFor(skill string) string {
+	if side := b.Resolutions[skill]; side != "" {
+		return side
+	}
+	return b.ResolveAll
+}
+
+// resolvesAll reports whether every conflict has a requested resolution.
+func (b pullRequest) resolvesAll(conflicts []git.Conflict) bool {
+	for _, c := range conflicts {
+		if b.resolutionFor(c.Skill) == "" {
+			return false
+		}
+	}
+	return true
+}
+
+func mapValues(m map[string]string) []string {
+	out := make([]string, 0, len(m))
+	for _, v := range m {
+		out = append(out, v)
+	}
+	return out
+}
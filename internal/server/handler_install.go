@@ -61,7 +61,10 @@ func (s *Server) handleDiscover(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
-// handleInstallBatch re-clones a repo and installs each selected skill.
+// handleInstallBatch re-clones a repo and installs the selected skills as
+// one batch: all are staged and audited first, then moved in together. If
+// any fails, or the client goes away, nothing is installed unless the
+// request sets allowPartial.
 func (s *Server) handleInstallBatch(w http.ResponseWriter, r *http.Request) {
 	start := time.Now()
 	s.mu.Lock()
@@ -73,9 +76,10 @@ func (s *Server) handleInstallBatch(w http.ResponseWriter, r *http.Request) {
 			Name string `json:"name"`
 			Path string `json:"path"`
 		} `json:"skills"`
-		Force     bool   `json:"force"`
-		SkipAudit bool   `json:"skipAudit"`
-		Into      string `json:"into"`
+		Force        bool   `json:"force"`
+		SkipAudit    bool   `json:"skipAudit"`
+		Into         string `json:"into"`
+		AllowPartial bool   `json:"allowPartial"`
 	}
 	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
 		writeError(w, http.StatusBadRequest, "invalid JSON body")
@@ -106,13 +110,12 @@ func (s *Server) handleInstallBatch(w http.ResponseWriter, r *http.Request) {
 		Error    string   `json:"error,omitempty"`
 	}
 
-	// Ensure Into directory exists
-	if body.Into != "" {
-		if err := os.MkdirAll(filepath.Join(s.cfg.Source, body.Into), 0755); err != nil {
-			writeError(w, http.StatusInternalServerError, "failed to create into directory: "+err.Error())
-			return
-		}
+	batch, err := install.NewBatch(s.cfg.Source)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
 	}
+	defer batch.Rollback() //nolint:errcheck
 
 	results := make([]batchResultItem, 0, len(body.Skills))
 	installOpts := install.InstallOptions{
@@ -123,13 +126,15 @@ func (s *Server) handleInstallBatch(w http.ResponseWriter, r *http.Request) {
 	if s.IsProjectMode() {
 		installOpts.AuditProjectRoot = s.projectRoot
 	}
+	failed := 0
 	for _, sel := range body.Skills {
 		destPath := filepath.Join(s.cfg.Source, body.Into, sel.Name)
-		res, err := install.InstallFromDiscovery(discovery, install.SkillInfo{
+		res, err := batch.Stage(discovery, install.SkillInfo{
 			Name: sel.Name,
 			Path: sel.Path,
 		}, destPath, installOpts)
 		if err != nil {
+			failed++
 			results = append(results, batchResultItem{
 				Name:  sel.Name,
 				Error: err.Error(),
@@ -143,29 +148,55 @@ func (s *Server) handleInstallBatch(w http.ResponseWriter, r *http.Request) {
 		})
 	}
 
+	// Commit all staged skills, or none of them
+	rolledBack := false
+	var commitErr error
+	switch {
+	case failed > 0 && !body.AllowPartial:
+		rolledBack = true
+	case batch.Staged() > 0:
+		if commitErr = batch.Commit(r.Context()); commitErr != nil {
+			rolledBack = true
+		}
+	}
+	if rolledBack {
+		for i := range results {
+			if results[i].Error == "" {
+				results[i].Action = "rolled back"
+			}
+		}
+	}
+
 	// Summary for toast
 	installed := 0
 	installedSkills := make([]string, 0, len(results))
 	failedSkills := make([]string, 0, len(results))
 	var firstErr string
 	for _, r := range results {
-		if r.Error == "" {
+		if r.Error == "" && !rolledBack {
 			installed++
 			installedSkills = append(installedSkills, r.Name)
-		} else if firstErr == "" {
-			firstErr = r.Error
-			failedSkills = append(failedSkills, r.Name)
-		} else {
+		} else if r.Error != "" {
+			if firstErr == "" {
+				firstErr = r.Error
+			}
 			failedSkills = append(failedSkills, r.Name)
 		}
 	}
+	if commitErr != nil {
+		firstErr = commitErr.Error()
+	}
 	summary := fmt.Sprintf("Installed %d of %d skills", installed, len(body.Skills))
-	if firstErr != "" {
+	if rolledBack {
+		summary += " (rolled back; no skills were installed)"
+	} else if firstErr != "" {
 		summary += " (some errors)"
 	}
 
 	status := "ok"
-	if installed < len(body.Skills) {
+	if rolledBack {
+		status = "error"
+	} else if installed < len(body.Skills) {
 		status = "partial"
 	}
 	args := map[string]any{
@@ -182,6 +213,9 @@ func (s *Server) handleInstallBatch(w http.ResponseWriter, r *http.Request) {
 	if body.Into != "" {
 		args["into"] = body.Into
 	}
+	if body.AllowPartial {
+		args["allow_partial"] = true
+	}
 	if len(installedSkills) > 0 {
 		args["installed_skills"] = installedSkills
 	}
@@ -204,8 +238,9 @@ func (s *Server) handleInstallBatch(w http.ResponseWriter, r *http.Request) {
 	}
 
 	writeJSON(w, map[string]any{
-		"results": results,
-		"summary": summary,
+		"results":    results,
+		"summary":    summary,
+		"rolledBack": rolledBack,
 	})
 }
 
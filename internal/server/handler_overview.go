@@ -6,6 +6,7 @@ import (
 	"path/filepath"
 	"strings"
 
+	"skillshare/internal/daemon"
 	"skillshare/internal/git"
 	"skillshare/internal/install"
 	"skillshare/internal/sync"
@@ -56,11 +57,33 @@ func (s *Server) handleOverview(w http.ResponseWriter, r *http.Request) {
 	}
 	if s.IsProjectMode() {
 		resp["projectRoot"] = s.projectRoot
+	} else {
+		resp["daemon"] = buildDaemonStatus()
 	}
 
 	writeJSON(w, resp)
 }
 
+type daemonStatusItem struct {
+	Running bool                `json:"running"`
+	PID     int                 `json:"pid,omitempty"`
+	Tasks   []daemon.TaskStatus `json:"tasks"`
+}
+
+// buildDaemonStatus reports the scheduled maintenance state written by
+// `skillshare daemon` (or `ui --daemon`).
+func buildDaemonStatus() daemonStatusItem {
+	item := daemonStatusItem{Tasks: []daemon.TaskStatus{}}
+	if pid, running := daemon.RunningPID(); running {
+		item.Running = true
+		item.PID = pid
+	}
+	if st, err := daemon.ReadState(); err == nil && st != nil {
+		item.Tasks = st.Tasks
+	}
+	return item
+}
+
 func buildTrackedRepos(sourceDir string, skills []sync.DiscoveredSkill) []trackedRepoItem {
 	repoNames, err := install.GetTrackedRepos(sourceDir)
 	if err != nil || len(repoNames) == 0 {
@@ -0,0 +1,85 @@
+package server
+
+import (
+	"net/http"
+	"time"
+
+	"skillshare/internal/review"
+	"skillshare/internal/sync"
+)
+
+// handleReview returns the skills due for review. Query param all=true
+// returns every skill with its review state instead.
+func (s *Server) handleReview(w http.ResponseWriter, r *http.Request) {
+	discovered, err := sync.DiscoverSourceSkills(s.cfg.Source)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	reviews, err := review.LoadReviews(review.ReviewsPath(s.configPath()))
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	statuses := review.Check(discovered, reviews, review.Policy{MaxAgeMonths: s.reviewMaxAge()})
+	if r.URL.Query().Get("all") != "true" {
+		statuses = review.Stale(statuses)
+	}
+	if statuses == nil {
+		statuses = []review.Status{}
+	}
+	writeJSON(w, map[string]any{
+		"maxAgeMonths": s.reviewMaxAge(),
+		"skills":       statuses,
+	})
+}
+
+// handleMarkReviewed records a review of the skill named in the path.
+func (s *Server) handleMarkReviewed(w http.ResponseWriter, r *http.Request) {
+	start := time.Now()
+	name := r.PathValue("name")
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	discovered, err := sync.DiscoverSourceSkills(s.cfg.Source)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	found := false
+	for _, d := range discovered {
+		if d.FlatName == name {
+			found = true
+			break
+		}
+	}
+	if !found {
+		writeError(w, http.StatusNotFound, "skill not found: "+name)
+		return
+	}
+
+	reviews, err := review.LoadReviews(review.ReviewsPath(s.configPath()))
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	now := time.Now()
+	reviews.Mark(name, now)
+	if err := reviews.Save(); err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	s.writeOpsLog("review", "ok", start, map[string]any{"skills": []string{name}}, "")
+	writeJSON(w, map[string]any{"success": true, "reviewedAt": now.UTC()})
+}
+
+// reviewMaxAge returns the configured maximum skill age for the current mode.
+func (s *Server) reviewMaxAge() int {
+	if s.IsProjectMode() && s.projectCfg != nil {
+		return s.projectCfg.Review.MaxAgeMonths
+	}
+	return s.cfg.Review.MaxAgeMonths
+}
@@ -0,0 +1,35 @@
+package server
+
+import (
+	"net/http"
+	"strconv"
+
+	"skillshare/internal/stats"
+)
+
+// handleStats returns approximate token costs per skill and per target.
+// Query params: budget and top override the configured defaults.
+func (s *Server) handleStats(w http.ResponseWriter, r *http.Request) {
+	opts := stats.Options{Budget: s.statsBudget(), DefaultMode: s.cfg.Mode}
+	if n, err := strconv.Atoi(r.URL.Query().Get("budget")); err == nil && n > 0 {
+		opts.Budget = n
+	}
+	if n, err := strconv.Atoi(r.URL.Query().Get("top")); err == nil && n > 0 {
+		opts.Top = n
+	}
+
+	report, err := stats.Analyze(s.cfg.Source, s.cfg.Targets, opts)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	writeJSON(w, report)
+}
+
+// statsBudget returns the configured per-skill budget for the current mode.
+func (s *Server) statsBudget() int {
+	if s.IsProjectMode() && s.projectCfg != nil {
+		return s.projectCfg.Stats.Budget
+	}
+	return s.cfg.Stats.Budget
+}
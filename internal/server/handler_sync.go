@@ -8,6 +8,7 @@ import (
 	"time"
 
 	ssync "skillshare/internal/sync"
+	"skillshare/internal/syncbase"
 	"skillshare/internal/utils"
 )
 
@@ -108,6 +109,10 @@ func (s *Server) handleSync(w http.ResponseWriter, r *http.Request) {
 		results = append(results, res)
 	}
 
+	if !body.DryRun {
+		syncbase.RecordSource(syncbase.Dir(s.configPath()), s.cfg.Source) //nolint:errcheck
+	}
+
 	// Log the sync operation
 	s.writeOpsLog("sync", "ok", start, map[string]any{
 		"targets_total":  len(results),
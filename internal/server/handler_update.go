@@ -118,6 +118,16 @@ func (s *Server) updateSingle(name string, force bool) updateResultItem {
 }
 
 func (s *Server) updateTrackedRepo(name, repoPath string, force bool) updateResultItem {
+	// Pinned repos are detached at a tag or commit; there is nothing to pull
+	if _, ref := install.TrackedRepoRefs(repoPath); ref != "" {
+		return updateResultItem{
+			Name:    name,
+			Action:  "skipped",
+			Message: "pinned at " + ref,
+			IsRepo:  true,
+		}
+	}
+
 	// Check for uncommitted changes
 	if isDirty, _ := git.IsDirty(repoPath); isDirty {
 		if !force {
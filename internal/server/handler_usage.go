@@ -0,0 +1,98 @@
+package server
+
+import (
+	"net/http"
+	"strconv"
+
+	"skillshare/internal/sync"
+	"skillshare/internal/usage"
+)
+
+// defaultUsageDays is the window reported when the request sets none.
+const defaultUsageDays = 30
+
+type usageSourceJSON struct {
+	Target   string   `json:"target"`
+	Patterns []string `json:"patterns"`
+}
+
+// handleUsage returns recorded skill invocations per skill, target and day.
+// Query param days sets the window (0 for all time). Usage tracking is
+// global only and opt-in; when it is off, enabled is false and nothing is
+// read.
+func (s *Server) handleUsage(w http.ResponseWriter, r *http.Request) {
+	days := defaultUsageDays
+	if n, err := strconv.Atoi(r.URL.Query().Get("days")); err == nil && n >= 0 {
+		days = n
+	}
+
+	resp := map[string]any{
+		"enabled": s.usageEnabled(),
+		"days":    days,
+		"skills":  []usage.SkillUsage{},
+		"unused":  []string{},
+		"sources": s.usageSources(),
+	}
+	if !s.usageEnabled() {
+		writeJSON(w, resp)
+		return
+	}
+
+	events, err := usage.Load(usage.Dir())
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	discovered, err := sync.DiscoverSourceSkills(s.cfg.Source)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	summary := usage.Summarize(events, usage.Since(days))
+	if len(summary) > 0 {
+		resp["skills"] = summary
+	}
+	if unused := usage.Unused(discovered, summary); len(unused) > 0 {
+		resp["unused"] = unused
+	}
+	writeJSON(w, resp)
+}
+
+// handleUsageScan reads new transcript lines into the usage store.
+func (s *Server) handleUsageScan(w http.ResponseWriter, r *http.Request) {
+	if !s.usageEnabled() {
+		writeError(w, http.StatusBadRequest, "usage tracking is off; enable it with 'skillshare usage enable'")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	discovered, err := sync.DiscoverSourceSkills(s.cfg.Source)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	added, err := usage.Update(usage.Dir(), usage.Sources(s.cfg.Targets), usage.NamesFor(discovered))
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	writeJSON(w, map[string]any{"added": added})
+}
+
+// usageEnabled reports whether usage tracking is on. It is global only.
+func (s *Server) usageEnabled() bool {
+	return !s.IsProjectMode() && s.cfg.Usage.Enabled
+}
+
+func (s *Server) usageSources() []usageSourceJSON {
+	out := []usageSourceJSON{}
+	if s.IsProjectMode() {
+		return out
+	}
+	for _, src := range usage.Sources(s.cfg.Targets) {
+		out = append(out, usageSourceJSON{Target: src.Target, Patterns: src.Patterns})
+	}
+	return out
+}
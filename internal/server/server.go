@@ -7,15 +7,24 @@ import (
 	"net/http"
 	"os"
 	"os/signal"
+	"path/filepath"
 	"strings"
 	"sync"
 	"syscall"
 	"time"
 
 	"skillshare/internal/config"
+	"skillshare/internal/credential"
+	"skillshare/internal/install"
+	"skillshare/internal/network"
+	"skillshare/internal/oplock"
 	"skillshare/internal/version"
 )
 
+// opLockTimeout caps how long a request waits for another process's
+// operation lock, well below the server's write timeout.
+const opLockTimeout = 10 * time.Second
+
 // Server holds the HTTP server state
 type Server struct {
 	cfg     *config.Config
@@ -49,7 +58,7 @@ func New(cfg *config.Config, addr, uiDistDir string) *Server {
 		uiDistDir: uiDistDir,
 	}
 	s.registerRoutes()
-	s.handler = s.withConfigAutoReload(s.mux)
+	s.handler = s.withOpLock(s.withConfigAutoReload(s.mux))
 	return s
 }
 
@@ -64,8 +73,9 @@ func NewProject(cfg *config.Config, projectCfg *config.ProjectConfig, projectRoo
 		projectCfg:  projectCfg,
 		uiDistDir:   uiDistDir,
 	}
+	_ = install.UpdateGitIgnore(filepath.Join(projectRoot, ".skillshare"), oplock.FileName)
 	s.registerRoutes()
-	s.handler = s.withConfigAutoReload(s.mux)
+	s.handler = s.withOpLock(s.withConfigAutoReload(s.mux))
 	return s
 }
 
@@ -110,6 +120,8 @@ func (s *Server) reloadConfig() error {
 		return err
 	}
 	s.cfg = newCfg
+	credential.Configure(newCfg.Auth)
+	network.Configure(newCfg.Network) //nolint:errcheck // keeps the previous settings on error
 	return nil
 }
 
@@ -145,6 +157,45 @@ func (s *Server) withConfigAutoReload(next http.Handler) http.Handler {
 	})
 }
 
+// isMutatingRequest reports whether r may write skills, targets or config.
+func isMutatingRequest(r *http.Request) bool {
+	if !strings.HasPrefix(r.URL.Path, "/api/") {
+		return false
+	}
+	switch r.Method {
+	case http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// withOpLock holds the cross-process operation lock for mutating requests,
+// so the dashboard never writes alongside a CLI command or the daemon.
+// s.mu still serializes requests within this process.
+func (s *Server) withOpLock(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !isMutatingRequest(r) {
+			next.ServeHTTP(w, r)
+			return
+		}
+		timeout := oplock.Timeout()
+		if timeout > opLockTimeout {
+			timeout = opLockTimeout
+		}
+		l, err := oplock.Acquire(oplock.Path(s.configPath()), "ui "+r.Method+" "+r.URL.Path, timeout, nil)
+		if err != nil {
+			code := http.StatusInternalServerError
+			if oplock.IsLocked(err) {
+				code = http.StatusConflict
+			}
+			writeError(w, code, err.Error())
+			return
+		}
+		defer l.Release()
+		next.ServeHTTP(w, r)
+	})
+}
+
 // Start starts the HTTP server with graceful shutdown on SIGTERM/SIGINT.
 func (s *Server) Start() error {
 	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
@@ -258,6 +309,7 @@ func (s *Server) registerRoutes() {
 
 	// Backups
 	s.mux.HandleFunc("GET /api/backups", s.handleListBackups)
+	s.mux.HandleFunc("GET /api/backups/{timestamp}", s.handleShowBackup)
 	s.mux.HandleFunc("POST /api/backup", s.handleCreateBackup)
 	s.mux.HandleFunc("POST /api/backup/cleanup", s.handleCleanupBackups)
 	s.mux.HandleFunc("POST /api/restore", s.handleRestore)
@@ -280,6 +332,17 @@ func (s *Server) registerRoutes() {
 	s.mux.HandleFunc("GET /api/audit", s.handleAuditAll)
 	s.mux.HandleFunc("GET /api/audit/{name}", s.handleAuditSkill)
 
+	// Stats
+	s.mux.HandleFunc("GET /api/stats", s.handleStats)
+
+	// Review
+	s.mux.HandleFunc("GET /api/review", s.handleReview)
+	s.mux.HandleFunc("POST /api/review/{name}", s.handleMarkReviewed)
+
+	// Usage
+	s.mux.HandleFunc("GET /api/usage", s.handleUsage)
+	s.mux.HandleFunc("POST /api/usage/scan", s.handleUsageScan)
+
 	// Log
 	s.mux.HandleFunc("GET /api/log", s.handleListLog)
 	s.mux.HandleFunc("DELETE /api/log", s.handleClearLog)
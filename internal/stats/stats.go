@@ -0,0 +1,227 @@
+// Package stats estimates how much agent context skills cost, per skill
+// and per target.
+package stats
+
+import (
+	"bytes"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"skillshare/internal/config"
+	"skillshare/internal/sync"
+	"skillshare/internal/utils"
+)
+
+// DefaultBudget is the per-skill token budget (description + body) used
+// when none is configured.
+const DefaultBudget = 5000
+
+// DefaultTop is how many of the heaviest skills are listed per target.
+const DefaultTop = 5
+
+// maxFileSize skips large files (datasets, binaries) that agents would not
+// read into context wholesale.
+const maxFileSize = 1 << 20
+
+// SkillCost is the approximate token cost of one skill. Description is
+// loaded into every session; Body when the skill is used; References only
+// when the agent opens them.
+type SkillCost struct {
+	Name        string `json:"name"` // flat name as synced to targets
+	RelPath     string `json:"relPath"`
+	Description int    `json:"description"`
+	Body        int    `json:"body"`
+	References  int    `json:"references"`
+	Total       int    `json:"total"`
+	OverBudget  bool   `json:"overBudget,omitempty"`
+}
+
+// TargetStats aggregates the skills that sync to one target.
+type TargetStats struct {
+	Name        string      `json:"name"`
+	Mode        string      `json:"mode"`
+	Skills      int         `json:"skills"`
+	Description int         `json:"description"`
+	Body        int         `json:"body"`
+	References  int         `json:"references"`
+	Total       int         `json:"total"`
+	OverBudget  []string    `json:"overBudget"`
+	Top         []SkillCost `json:"top"`
+}
+
+// Report is the result of Analyze.
+type Report struct {
+	Budget  int           `json:"budget"`
+	Skills  []SkillCost   `json:"skills"`
+	Targets []TargetStats `json:"targets"`
+}
+
+// Options configures Analyze.
+type Options struct {
+	Budget      int    // per-skill budget for description + body; 0 = DefaultBudget
+	Top         int    // heaviest skills listed per target; 0 = DefaultTop
+	DefaultMode string // sync mode for targets without one
+}
+
+// EstimateTokens approximates the token count of text using the common
+// heuristic of one token per four characters.
+func EstimateTokens(text string) int {
+	n := len([]rune(strings.TrimSpace(text)))
+	return (n + 3) / 4
+}
+
+// Analyze measures every skill in sourcePath and aggregates the costs per
+// target after include/exclude and frontmatter targets filtering.
+func Analyze(sourcePath string, targets map[string]config.TargetConfig, opts Options) (*Report, error) {
+	if opts.Budget <= 0 {
+		opts.Budget = DefaultBudget
+	}
+	if opts.Top <= 0 {
+		opts.Top = DefaultTop
+	}
+
+	discovered, err := sync.DiscoverSourceSkills(sourcePath)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &Report{Budget: opts.Budget, Skills: make([]SkillCost, 0, len(discovered)), Targets: []TargetStats{}}
+	costs := make(map[string]SkillCost, len(discovered))
+	for _, skill := range discovered {
+		cost := Measure(skill)
+		cost.OverBudget = cost.Description+cost.Body > opts.Budget
+		costs[skill.FlatName] = cost
+		report.Skills = append(report.Skills, cost)
+	}
+	sortByTotal(report.Skills)
+
+	names := make([]string, 0, len(targets))
+	for name := range targets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		target := targets[name]
+		mode := target.Mode
+		if mode == "" {
+			mode = opts.DefaultMode
+		}
+		if mode == "" {
+			mode = "merge"
+		}
+
+		// Symlink mode links the whole source, so no filtering applies.
+		skills := discovered
+		if mode != "symlink" {
+			if skills, err = sync.FilterSkills(discovered, target.Include, target.Exclude); err != nil {
+				return nil, err
+			}
+			skills = sync.FilterSkillsByTarget(skills, name)
+		}
+
+		ts := TargetStats{Name: name, Mode: mode, Skills: len(skills), OverBudget: []string{}}
+		var members []SkillCost
+		for _, skill := range skills {
+			c := costs[skill.FlatName]
+			ts.Description += c.Description
+			ts.Body += c.Body
+			ts.References += c.References
+			ts.Total += c.Total
+			if c.OverBudget {
+				ts.OverBudget = append(ts.OverBudget, c.Name)
+			}
+			members = append(members, c)
+		}
+		sortByTotal(members)
+		if len(members) > opts.Top {
+			members = members[:opts.Top]
+		}
+		ts.Top = append([]SkillCost{}, members...)
+		sort.Strings(ts.OverBudget)
+		report.Targets = append(report.Targets, ts)
+	}
+
+	return report, nil
+}
+
+// Measure estimates the token cost of a single skill.
+func Measure(skill sync.DiscoveredSkill) SkillCost {
+	cost := SkillCost{Name: skill.FlatName, RelPath: skill.RelPath}
+
+	if data, err := os.ReadFile(filepath.Join(skill.SourcePath, "SKILL.md")); err == nil {
+		frontmatter, body := splitFrontmatter(string(data))
+		cost.Description = EstimateTokens(frontmatter)
+		cost.Body = EstimateTokens(body)
+	}
+
+	filepath.WalkDir(skill.SourcePath, func(path string, d fs.DirEntry, err error) error { //nolint:errcheck
+		if err != nil {
+			return nil
+		}
+		if d.IsDir() {
+			if path != skill.SourcePath && (utils.IsHidden(d.Name()) || d.Name() == "scripts") {
+				return filepath.SkipDir
+			}
+			// Nested skills are measured on their own.
+			if path != skill.SourcePath && fileExists(filepath.Join(path, "SKILL.md")) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if path == filepath.Join(skill.SourcePath, "SKILL.md") || utils.IsHidden(d.Name()) {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil || info.Size() > maxFileSize {
+			return nil
+		}
+		data, err := os.ReadFile(path)
+		if err != nil || bytes.IndexByte(data, 0) >= 0 {
+			return nil
+		}
+		cost.References += EstimateTokens(string(data))
+		return nil
+	})
+
+	cost.Total = cost.Description + cost.Body + cost.References
+	return cost
+}
+
+// splitFrontmatter returns the frontmatter (what agents preload: name,
+// description and other metadata) and the body of a SKILL.md.
+func splitFrontmatter(content string) (string, string) {
+	content = strings.ReplaceAll(content, "\r\n", "\n")
+	if !strings.HasPrefix(content, "---\n") {
+		return "", content
+	}
+	rest := content[4:]
+	end := strings.Index(rest, "\n---")
+	if end < 0 {
+		return "", content
+	}
+	body := rest[end+4:]
+	if i := strings.IndexByte(body, '\n'); i >= 0 {
+		body = body[i+1:]
+	} else {
+		body = ""
+	}
+	return rest[:end], body
+}
+
+func sortByTotal(costs []SkillCost) {
+	sort.SliceStable(costs, func(i, j int) bool {
+		if costs[i].Total != costs[j].Total {
+			return costs[i].Total > costs[j].Total
+		}
+		return costs[i].Name < costs[j].Name
+	})
+}
+
+func fileExists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
@@ -0,0 +1,98 @@
+package stats
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"skillshare/internal/config"
+)
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestEstimateTokens(t *testing.T) {
+	tests := []struct {
+		in   string
+		want int
+	}{
+		{"", 0},
+		{"abcd", 1},
+		{"abcde", 2},
+		{"  abcd\n", 1},
+		{"日本語の", 1},
+	}
+	for _, tt := range tests {
+		if got := EstimateTokens(tt.in); got != tt.want {
+			t.Errorf("EstimateTokens(%q) = %d, want %d", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestSplitFrontmatter(t *testing.T) {
+	fm, body := splitFrontmatter("---\nname: a\ndescription: b\n---\n# Body\n")
+	if fm != "name: a\ndescription: b" || body != "# Body\n" {
+		t.Errorf("splitFrontmatter() = %q, %q", fm, body)
+	}
+	fm, body = splitFrontmatter("# No frontmatter\n")
+	if fm != "" || body != "# No frontmatter\n" {
+		t.Errorf("splitFrontmatter() = %q, %q", fm, body)
+	}
+}
+
+func TestAnalyze(t *testing.T) {
+	src := t.TempDir()
+	writeFile(t, filepath.Join(src, "big", "SKILL.md"),
+		"---\nname: big\ndescription: Big skill.\n---\n"+strings.Repeat("x", 400))
+	writeFile(t, filepath.Join(src, "big", "references", "guide.md"), strings.Repeat("y", 800))
+	writeFile(t, filepath.Join(src, "big", "scripts", "run.sh"), strings.Repeat("z", 800))
+	writeFile(t, filepath.Join(src, "big", "image.png"), "\x89PNG\x00\x00")
+	writeFile(t, filepath.Join(src, "small", "SKILL.md"),
+		"---\nname: small\ndescription: Small skill.\ntargets: [claude]\n---\nshort\n")
+
+	targets := map[string]config.TargetConfig{
+		"claude": {Path: filepath.Join(src, "..", "claude")},
+		"cursor": {Path: filepath.Join(src, "..", "cursor"), Exclude: []string{"big"}},
+		"codex":  {Path: filepath.Join(src, "..", "codex"), Mode: "symlink"},
+	}
+	report, err := Analyze(src, targets, Options{Budget: 50, Top: 1})
+	if err != nil {
+		t.Fatalf("Analyze() error: %v", err)
+	}
+
+	if len(report.Skills) != 2 || report.Skills[0].Name != "big" {
+		t.Fatalf("Skills = %+v, want big first", report.Skills)
+	}
+	big := report.Skills[0]
+	if big.Body != 100 || big.References != 200 || !big.OverBudget {
+		t.Errorf("big = %+v, want body 100, references 200 (scripts and binaries skipped), over budget", big)
+	}
+	if report.Skills[1].OverBudget {
+		t.Errorf("small should be within budget: %+v", report.Skills[1])
+	}
+
+	byName := map[string]TargetStats{}
+	for _, ts := range report.Targets {
+		byName[ts.Name] = ts
+	}
+	if got := byName["claude"]; got.Skills != 2 || len(got.Top) != 1 || got.Top[0].Name != "big" ||
+		len(got.OverBudget) != 1 {
+		t.Errorf("claude = %+v", got)
+	}
+	// cursor excludes big and small only targets claude.
+	if got := byName["cursor"]; got.Skills != 0 || got.Total != 0 {
+		t.Errorf("cursor = %+v, want no skills", got)
+	}
+	// Symlink mode ignores filters.
+	if got := byName["codex"]; got.Mode != "symlink" || got.Skills != 2 || got.Total != big.Total+report.Skills[1].Total {
+		t.Errorf("codex = %+v", got)
+	}
+}
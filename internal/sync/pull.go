@@ -16,6 +16,7 @@ type LocalSkillInfo struct {
 	TargetName string
 	Size       int64
 	ModTime    time.Time
+	Modified   bool // copy-mode managed copy edited in the target since sync
 }
 
 // PullOptions holds options for pull operation
@@ -31,7 +32,8 @@ type PullResult struct {
 	Failed  map[string]error
 }
 
-// FindLocalSkills finds all local (non-symlinked) skills in a target directory
+// FindLocalSkills finds all local (non-symlinked) skills in a target directory,
+// including copy-mode copies that were edited since the last sync.
 func FindLocalSkills(targetPath, sourcePath string) ([]LocalSkillInfo, error) {
 	var skills []LocalSkillInfo
 
@@ -92,19 +94,24 @@ func FindLocalSkills(targetPath, sourcePath string) ([]LocalSkillInfo, error) {
 			continue
 		}
 
-		// Skip copy-mode managed skills
+		// Skip copy-mode managed skills unless they were edited in place
+		modified := false
 		if manifest != nil {
-			if _, isManaged := manifest.Managed[entry.Name()]; isManaged {
-				continue
+			if checksum, isManaged := manifest.Managed[entry.Name()]; isManaged {
+				if current, err := DirChecksum(skillPath); err != nil || current == checksum {
+					continue
+				}
+				modified = true
 			}
 		}
 
 		// This is a local skill
 		skills = append(skills, LocalSkillInfo{
-			Name:    entry.Name(),
-			Path:    skillPath,
-			ModTime: skillInfo.ModTime(),
-			Size:    calculateDirSize(skillPath),
+			Name:     entry.Name(),
+			Path:     skillPath,
+			ModTime:  skillInfo.ModTime(),
+			Size:     calculateDirSize(skillPath),
+			Modified: modified,
 		})
 	}
 
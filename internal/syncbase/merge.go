@@ -0,0 +1,380 @@
+package syncbase
+
+import (
+	"bytes"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// File outcomes of a directory merge.
+const (
+	FileUnchanged = "unchanged" // same on both sides
+	FileSource    = "source"    // only the source changed it
+	FileLocal     = "local"     // only the local copy changed it
+	FileMerged    = "merged"    // both changed it; merged cleanly
+	FileConflict  = "conflict"  // both changed it; conflict markers written
+	FileDeleted   = "deleted"   // removed by the side that changed it
+)
+
+// metaFile is install metadata owned by the source; never merged.
+const metaFile = ".skillshare-meta.json"
+
+// FileResult is the outcome for one file of a merge.
+type FileResult struct {
+	Path   string `json:"path"`
+	Status string `json:"status"`
+}
+
+// Result is the outcome of MergeDirs.
+type Result struct {
+	Files     []FileResult `json:"files"`
+	Conflicts int          `json:"conflicts"`
+}
+
+// MergeDirs three-way merges the source and local versions of a skill
+// against base and writes the result to out. With an empty base, files
+// only one side has are kept and every region the sides disagree on
+// conflicts. Conflicting text files get git-style markers labelled with
+// localLabel; conflicting binary files keep the source version.
+func MergeDirs(base, source, local, out, localLabel string) (*Result, error) {
+	files := map[string]bool{}
+	for _, dir := range []string{base, source, local} {
+		if dir == "" {
+			continue
+		}
+		list, err := listFiles(dir)
+		if err != nil {
+			return nil, err
+		}
+		for _, f := range list {
+			files[f] = true
+		}
+	}
+	paths := make([]string, 0, len(files))
+	for f := range files {
+		paths = append(paths, f)
+	}
+	sort.Strings(paths)
+
+	res := &Result{}
+	for _, rel := range paths {
+		b := readOptional(base, rel)
+		o := readOptional(source, rel)
+		t := readOptional(local, rel)
+
+		var merged []byte
+		status := FileUnchanged
+		switch {
+		case rel == metaFile || sameContent(o, t):
+			merged = o
+		case sameContent(b, o):
+			merged, status = t, FileLocal
+		case sameContent(b, t):
+			merged, status = o, FileSource
+		case o == nil || t == nil || isBinary(o) || isBinary(t):
+			// Deleted on one side and edited on the other, or binary.
+			merged, status = o, FileConflict
+			if merged == nil {
+				merged = t
+			}
+		default:
+			ancestor := string(b)
+			if base == "" {
+				// No recorded base: treat the lines both sides share as
+				// the ancestor so only differing regions conflict.
+				ancestor = commonLines(string(o), string(t))
+			}
+			text, conflicts := ThreeWay(ancestor, string(o), string(t), localLabel)
+			merged, status = []byte(text), FileMerged
+			if conflicts {
+				status = FileConflict
+			}
+		}
+		if merged == nil && status != FileUnchanged {
+			status = FileDeleted
+		}
+		if status == FileConflict {
+			res.Conflicts++
+		}
+		res.Files = append(res.Files, FileResult{Path: rel, Status: status})
+
+		if merged == nil {
+			continue
+		}
+		dst := filepath.Join(out, filepath.FromSlash(rel))
+		if err := os.MkdirAll(filepath.Dir(dst), 0755); err != nil {
+			return nil, err
+		}
+		if err := os.WriteFile(dst, merged, fileMode(rel, source, local)); err != nil {
+			return nil, err
+		}
+	}
+	return res, nil
+}
+
+// ThreeWay merges ours and theirs line by line against base (diff3).
+// Regions changed on only one side take that side; regions changed
+// identically on both take either; other regions become conflicts.
+func ThreeWay(base, ours, theirs, theirsLabel string) (string, bool) {
+	b, o, t := splitKeep(base), splitKeep(ours), splitKeep(theirs)
+	mo := matchLines(b, o)
+	mt := matchLines(b, t)
+
+	var out strings.Builder
+	conflicts := false
+	chunk := func(bc, oc, tc []string) {
+		switch {
+		case equalLines(oc, bc):
+			writeLines(&out, tc)
+		case equalLines(tc, bc), equalLines(oc, tc):
+			writeLines(&out, oc)
+		default:
+			conflicts = true
+			out.WriteString("<<<<<<< source\n")
+			writeLines(&out, terminated(oc))
+			out.WriteString("=======\n")
+			writeLines(&out, terminated(tc))
+			out.WriteString(">>>>>>> " + theirsLabel + "\n")
+		}
+	}
+
+	i, j, k := 0, 0, 0
+	for {
+		s := i
+		for s < len(b) && (mo[s] < 0 || mt[s] < 0) {
+			s++
+		}
+		if s == len(b) {
+			chunk(b[i:], o[j:], t[k:])
+			break
+		}
+		chunk(b[i:s], o[j:mo[s]], t[k:mt[s]])
+		out.WriteString(b[s])
+		i, j, k = s+1, mo[s]+1, mt[s]+1
+	}
+	return out.String(), conflicts
+}
+
+// commonLines returns the longest common subsequence of the lines of a
+// and b.
+func commonLines(a, b string) string {
+	al, bl := splitKeep(a), splitKeep(b)
+	var out strings.Builder
+	for i, j := range matchLines(al, bl) {
+		if j >= 0 {
+			out.WriteString(al[i])
+		}
+	}
+	return out.String()
+}
+
+// Compare lists the files that differ between the source and local
+// versions of a skill: "modified", "local only" or "source only".
+// Install metadata is ignored.
+func Compare(source, local string) ([]FileResult, error) {
+	srcFiles, err := listFiles(source)
+	if err != nil {
+		return nil, err
+	}
+	localFiles, err := listFiles(local)
+	if err != nil {
+		return nil, err
+	}
+	inLocal := make(map[string]bool, len(localFiles))
+	for _, f := range localFiles {
+		inLocal[f] = true
+	}
+
+	var out []FileResult
+	for _, f := range srcFiles {
+		if f == metaFile {
+			continue
+		}
+		if !inLocal[f] {
+			out = append(out, FileResult{Path: f, Status: "source only"})
+			continue
+		}
+		delete(inLocal, f)
+		if !sameContent(readOptional(source, f), readOptional(local, f)) {
+			out = append(out, FileResult{Path: f, Status: "modified"})
+		}
+	}
+	for _, f := range localFiles {
+		if inLocal[f] && f != metaFile {
+			out = append(out, FileResult{Path: f, Status: "local only"})
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Path < out[j].Path })
+	return out, nil
+}
+
+// matchLines returns, for every line of a, the index of the matching line
+// in b along a longest common subsequence, or -1.
+func matchLines(a, b []string) []int {
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else if lcs[i+1][j] >= lcs[i][j+1] {
+				lcs[i][j] = lcs[i+1][j]
+			} else {
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+
+	match := make([]int, len(a))
+	for i := range match {
+		match[i] = -1
+	}
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case a[i] == b[j]:
+			match[i] = j
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			i++
+		default:
+			j++
+		}
+	}
+	return match
+}
+
+// Diff returns a minimal line diff of a and b ("- " removed, "+ " added).
+func Diff(a, b string) []string {
+	al := strings.Split(strings.TrimRight(a, "\n"), "\n")
+	bl := strings.Split(strings.TrimRight(b, "\n"), "\n")
+	match := matchLines(al, bl)
+
+	var out []string
+	j := 0
+	for i, line := range al {
+		if match[i] < 0 {
+			out = append(out, "- "+line)
+			continue
+		}
+		for ; j < match[i]; j++ {
+			out = append(out, "+ "+bl[j])
+		}
+		j++
+	}
+	for ; j < len(bl); j++ {
+		out = append(out, "+ "+bl[j])
+	}
+	return out
+}
+
+// splitKeep splits text into lines that keep their trailing newline.
+func splitKeep(s string) []string {
+	if s == "" {
+		return nil
+	}
+	lines := strings.SplitAfter(s, "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
+
+// terminated makes sure the last line ends with a newline so conflict
+// markers start on their own line.
+func terminated(lines []string) []string {
+	if n := len(lines); n > 0 && !strings.HasSuffix(lines[n-1], "\n") {
+		out := append([]string{}, lines...)
+		out[n-1] += "\n"
+		return out
+	}
+	return lines
+}
+
+func writeLines(sb *strings.Builder, lines []string) {
+	for _, l := range lines {
+		sb.WriteString(l)
+	}
+}
+
+func equalLines(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func listFiles(dir string) ([]string, error) {
+	var files []string
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if d.Name() == ".git" {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		files = append(files, filepath.ToSlash(rel))
+		return nil
+	})
+	return files, err
+}
+
+// readOptional returns the file content, or nil when dir is "" or the
+// file does not exist.
+func readOptional(dir, rel string) []byte {
+	if dir == "" {
+		return nil
+	}
+	data, err := os.ReadFile(filepath.Join(dir, filepath.FromSlash(rel)))
+	if err != nil {
+		return nil
+	}
+	if data == nil {
+		data = []byte{}
+	}
+	return data
+}
+
+func sameContent(a, b []byte) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return bytes.Equal(a, b)
+}
+
+func isBinary(data []byte) bool {
+	return bytes.IndexByte(data, 0) >= 0
+}
+
+// fileMode keeps the permission bits of the local copy when it has the
+// file, so an added executable bit survives the merge.
+func fileMode(rel string, dirs ...string) os.FileMode {
+	mode := os.FileMode(0644)
+	for _, dir := range dirs {
+		if info, err := os.Stat(filepath.Join(dir, filepath.FromSlash(rel))); err == nil {
+			mode = info.Mode().Perm()
+		}
+	}
+	return mode
+}
@@ -0,0 +1,158 @@
+// Package syncbase keeps a snapshot of every skill as it was last synced
+// to targets, and merges diverged copies against that common base.
+package syncbase
+
+import (
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+
+	"skillshare/internal/config"
+	"skillshare/internal/sync"
+)
+
+// DirName is the directory holding base snapshots.
+const DirName = "bases"
+
+const indexFile = "index.json"
+
+// Dir returns the base store for the scope of configPath: next to the
+// project config (.skillshare/bases) in project mode, or in the data
+// directory in global mode.
+func Dir(configPath string) string {
+	configDir := filepath.Dir(configPath)
+	if filepath.Base(configDir) == ".skillshare" {
+		return filepath.Join(configDir, DirName)
+	}
+	return filepath.Join(config.DataDir(), DirName)
+}
+
+// Store holds one snapshot per flat skill name plus an index of their
+// checksums, so unchanged skills are not copied again on every sync.
+type Store struct {
+	dir   string
+	index map[string]string // flat name → DirChecksum of the snapshot
+}
+
+// Open loads the store at dir. A missing store is empty.
+func Open(dir string) *Store {
+	s := &Store{dir: dir, index: map[string]string{}}
+	if data, err := os.ReadFile(filepath.Join(dir, indexFile)); err == nil {
+		json.Unmarshal(data, &s.index) //nolint:errcheck
+	}
+	return s
+}
+
+// Path returns the snapshot of a skill, or "" when none was recorded.
+func (s *Store) Path(flatName string) string {
+	if _, ok := s.index[flatName]; !ok {
+		return ""
+	}
+	p := filepath.Join(s.dir, flatName)
+	if _, err := os.Stat(p); err != nil {
+		return ""
+	}
+	return p
+}
+
+// Record snapshots the given skills as the new base. Skills whose content
+// is unchanged since the last snapshot are left alone. When prune is true,
+// snapshots of skills not in the list are removed.
+func (s *Store) Record(skills []sync.DiscoveredSkill, prune bool) error {
+	if err := os.MkdirAll(s.dir, 0755); err != nil {
+		return err
+	}
+
+	seen := make(map[string]bool, len(skills))
+	for _, skill := range skills {
+		seen[skill.FlatName] = true
+		sum, err := sync.DirChecksum(skill.SourcePath)
+		if err != nil {
+			continue
+		}
+		if s.index[skill.FlatName] == sum && s.Path(skill.FlatName) != "" {
+			continue
+		}
+		dest := filepath.Join(s.dir, skill.FlatName)
+		if err := os.RemoveAll(dest); err != nil {
+			return err
+		}
+		if err := copySkill(skill.SourcePath, dest); err != nil {
+			os.RemoveAll(dest)
+			delete(s.index, skill.FlatName)
+			return err
+		}
+		s.index[skill.FlatName] = sum
+	}
+
+	if prune {
+		for name := range s.index {
+			if !seen[name] {
+				os.RemoveAll(filepath.Join(s.dir, name))
+				delete(s.index, name)
+			}
+		}
+	}
+	return s.save()
+}
+
+// RecordSource snapshots every skill in sourcePath and drops snapshots of
+// skills that no longer exist. Called after a successful sync.
+func RecordSource(storeDir, sourcePath string) error {
+	skills, err := sync.DiscoverSourceSkills(sourcePath)
+	if err != nil {
+		return err
+	}
+	return Open(storeDir).Record(skills, true)
+}
+
+func (s *Store) save() error {
+	data, err := json.MarshalIndent(s.index, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp := filepath.Join(s.dir, indexFile+".tmp")
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, filepath.Join(s.dir, indexFile))
+}
+
+// copySkill copies a skill directory without .git.
+func copySkill(src, dst string) error {
+	return filepath.Walk(src, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, _ := filepath.Rel(src, path)
+		target := filepath.Join(dst, rel)
+		if info.IsDir() {
+			if info.Name() == ".git" {
+				return filepath.SkipDir
+			}
+			return os.MkdirAll(target, 0755)
+		}
+		if !info.Mode().IsRegular() {
+			return nil
+		}
+		return copyFile(path, target, info.Mode().Perm())
+	})
+}
+
+func copyFile(src, dst string, perm os.FileMode) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+	out, err := os.OpenFile(dst, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, perm)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
@@ -0,0 +1,158 @@
+package syncbase
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"skillshare/internal/sync"
+)
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func readFile(t *testing.T, path string) string {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
+
+func TestThreeWay(t *testing.T) {
+	base := "a\nb\nc\nd\n"
+
+	got, conflict := ThreeWay(base, "A\nb\nc\nd\n", "a\nb\nc\nD\n", "claude")
+	if conflict || got != "A\nb\nc\nD\n" {
+		t.Errorf("clean merge = %q, %v", got, conflict)
+	}
+
+	got, conflict = ThreeWay(base, "a\nB\nc\nd\n", "a\nX\nc\nd\n", "claude")
+	want := "a\n<<<<<<< source\nB\n=======\nX\n>>>>>>> claude\nc\nd\n"
+	if !conflict || got != want {
+		t.Errorf("conflict merge = %q, want %q", got, want)
+	}
+
+	if got, conflict = ThreeWay(base, "a\nb\nc\nd\ne\n", "a\nb\nc\nd\ne\n", "claude"); conflict || got != "a\nb\nc\nd\ne\n" {
+		t.Errorf("identical change = %q, %v", got, conflict)
+	}
+}
+
+func TestMergeDirs(t *testing.T) {
+	root := t.TempDir()
+	base, source, local := filepath.Join(root, "base"), filepath.Join(root, "source"), filepath.Join(root, "local")
+	writeFile(t, filepath.Join(base, "SKILL.md"), "# Skill\n\nintro\n\nbody\n")
+	writeFile(t, filepath.Join(source, "SKILL.md"), "# Skill v2\n\nintro\n\nbody\n")
+	writeFile(t, filepath.Join(local, "SKILL.md"), "# Skill\n\nintro\n\nbody\nlocal tip\n")
+	writeFile(t, filepath.Join(local, "notes.md"), "notes")
+	writeFile(t, filepath.Join(source, metaFile), "{}")
+
+	out := filepath.Join(root, "out")
+	res, err := MergeDirs(base, source, local, out, "claude")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Conflicts != 0 {
+		t.Fatalf("Conflicts = %d, files %+v", res.Conflicts, res.Files)
+	}
+	if got := readFile(t, filepath.Join(out, "SKILL.md")); got != "# Skill v2\n\nintro\n\nbody\nlocal tip\n" {
+		t.Errorf("SKILL.md = %q", got)
+	}
+	if got := readFile(t, filepath.Join(out, "notes.md")); got != "notes" {
+		t.Errorf("notes.md = %q", got)
+	}
+	if got := readFile(t, filepath.Join(out, metaFile)); got != "{}" {
+		t.Errorf("metadata should come from source, got %q", got)
+	}
+}
+
+func TestMergeDirs_NoBase(t *testing.T) {
+	root := t.TempDir()
+	source, local := filepath.Join(root, "source"), filepath.Join(root, "local")
+	writeFile(t, filepath.Join(source, "SKILL.md"), "title\nsource line\nfooter\n")
+	writeFile(t, filepath.Join(local, "SKILL.md"), "title\nlocal line\nfooter\n")
+
+	out := filepath.Join(root, "out")
+	res, err := MergeDirs("", source, local, out, "claude")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Conflicts != 1 {
+		t.Fatalf("Conflicts = %d, want 1", res.Conflicts)
+	}
+	got := readFile(t, filepath.Join(out, "SKILL.md"))
+	if !strings.HasPrefix(got, "title\n<<<<<<< source\nsource line\n") || !strings.HasSuffix(got, ">>>>>>> claude\nfooter\n") {
+		t.Errorf("SKILL.md = %q", got)
+	}
+}
+
+func TestCompare(t *testing.T) {
+	root := t.TempDir()
+	source, local := filepath.Join(root, "source"), filepath.Join(root, "local")
+	writeFile(t, filepath.Join(source, "SKILL.md"), "a")
+	writeFile(t, filepath.Join(local, "SKILL.md"), "b")
+	writeFile(t, filepath.Join(source, "same.md"), "x")
+	writeFile(t, filepath.Join(local, "same.md"), "x")
+	writeFile(t, filepath.Join(source, "gone.md"), "x")
+	writeFile(t, filepath.Join(local, "new.md"), "x")
+	writeFile(t, filepath.Join(source, metaFile), "{}")
+
+	got, err := Compare(source, local)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var parts []string
+	for _, f := range got {
+		parts = append(parts, f.Path+"="+f.Status)
+	}
+	want := "SKILL.md=modified,gone.md=source only,new.md=local only"
+	if strings.Join(parts, ",") != want {
+		t.Errorf("Compare() = %s, want %s", strings.Join(parts, ","), want)
+	}
+}
+
+func TestStore_Record(t *testing.T) {
+	src := t.TempDir()
+	writeFile(t, filepath.Join(src, "pdf", "SKILL.md"), "---\nname: pdf\n---\nv1\n")
+	writeFile(t, filepath.Join(src, "docx", "SKILL.md"), "---\nname: docx\n---\n")
+
+	dir := filepath.Join(t.TempDir(), DirName)
+	if err := RecordSource(dir, src); err != nil {
+		t.Fatal(err)
+	}
+	store := Open(dir)
+	p := store.Path("pdf")
+	if p == "" || readFile(t, filepath.Join(p, "SKILL.md")) != "---\nname: pdf\n---\nv1\n" {
+		t.Fatalf("Path(pdf) = %q", p)
+	}
+	if store.Path("missing") != "" {
+		t.Error("Path() of an unrecorded skill should be empty")
+	}
+
+	// Editing the source and removing a skill updates and prunes the store.
+	writeFile(t, filepath.Join(src, "pdf", "SKILL.md"), "---\nname: pdf\n---\nv2\n")
+	os.RemoveAll(filepath.Join(src, "docx"))
+	skills, err := sync.DiscoverSourceSkills(src)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := Open(dir).Record(skills, true); err != nil {
+		t.Fatal(err)
+	}
+	store = Open(dir)
+	if got := readFile(t, filepath.Join(store.Path("pdf"), "SKILL.md")); !strings.HasSuffix(got, "v2\n") {
+		t.Errorf("pdf base not updated: %q", got)
+	}
+	if store.Path("docx") != "" {
+		t.Error("docx base should be pruned")
+	}
+}
@@ -0,0 +1,147 @@
+package testutil
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// Registry is an in-memory OCI distribution registry for tests. It covers
+// the parts of the API skillshare uses: blob upload and download,
+// manifests by tag or digest, and tag listing.
+type Registry struct {
+	*httptest.Server
+
+	// Token, when set, makes the registry demand a bearer token from its
+	// own /token endpoint, which hands it out to anyone.
+	Token string
+
+	mu        sync.Mutex
+	blobs     map[string][]byte
+	manifests map[string][]byte            // digest → manifest
+	tags      map[string]map[string]string // repository → tag → digest
+}
+
+var registryPath = regexp.MustCompile(`^/v2/(.+)/(blobs|manifests|tags)/(.+)$`)
+
+// NewRegistry starts a registry on 127.0.0.1 and stops it when t ends.
+func NewRegistry(t *testing.T) *Registry {
+	t.Helper()
+	r := &Registry{
+		blobs:     map[string][]byte{},
+		manifests: map[string][]byte{},
+		tags:      map[string]map[string]string{},
+	}
+	r.Server = httptest.NewServer(http.HandlerFunc(r.serve))
+	t.Cleanup(r.Close)
+	return r
+}
+
+// Host returns the registry's host:port, as used in oci:// references.
+func (r *Registry) Host() string {
+	return strings.TrimPrefix(r.URL, "http://")
+}
+
+func (r *Registry) serve(w http.ResponseWriter, req *http.Request) {
+	if req.URL.Path == "/token" {
+		json.NewEncoder(w).Encode(map[string]string{"token": r.Token}) //nolint:errcheck
+		return
+	}
+	if r.Token != "" && req.Header.Get("Authorization") != "Bearer "+r.Token {
+		w.Header().Set("WWW-Authenticate", fmt.Sprintf(`Bearer realm="%s/token",service="test"`, r.URL))
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	m := registryPath.FindStringSubmatch(req.URL.Path)
+	if m == nil {
+		http.NotFound(w, req)
+		return
+	}
+	repo, kind, name := m[1], m[2], m[3]
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	switch {
+	case kind == "blobs" && name == "uploads/" && req.Method == http.MethodPost:
+		w.Header().Set("Location", "/v2/"+repo+"/blobs/uploads/session")
+		w.WriteHeader(http.StatusAccepted)
+	case kind == "blobs" && strings.HasPrefix(name, "uploads/") && req.Method == http.MethodPut:
+		data, _ := io.ReadAll(req.Body)
+		digest := req.URL.Query().Get("digest")
+		if digest != registryDigest(data) {
+			registryErr(w, http.StatusBadRequest, "DIGEST_INVALID", "digest mismatch")
+			return
+		}
+		r.blobs[digest] = data
+		w.WriteHeader(http.StatusCreated)
+	case kind == "blobs":
+		data, ok := r.blobs[name]
+		if !ok {
+			registryErr(w, http.StatusNotFound, "BLOB_UNKNOWN", "blob unknown")
+			return
+		}
+		w.Header().Set("Content-Length", fmt.Sprint(len(data)))
+		if req.Method == http.MethodGet {
+			w.Write(data) //nolint:errcheck
+		}
+	case kind == "manifests" && req.Method == http.MethodPut:
+		data, _ := io.ReadAll(req.Body)
+		digest := registryDigest(data)
+		r.manifests[digest] = data
+		if !strings.HasPrefix(name, "sha256:") {
+			if r.tags[repo] == nil {
+				r.tags[repo] = map[string]string{}
+			}
+			r.tags[repo][name] = digest
+		}
+		w.Header().Set("Docker-Content-Digest", digest)
+		w.WriteHeader(http.StatusCreated)
+	case kind == "manifests":
+		digest := name
+		if !strings.HasPrefix(name, "sha256:") {
+			digest = r.tags[repo][name]
+		}
+		data, ok := r.manifests[digest]
+		if !ok {
+			registryErr(w, http.StatusNotFound, "MANIFEST_UNKNOWN", "manifest unknown")
+			return
+		}
+		w.Header().Set("Content-Type", "application/vnd.oci.image.manifest.v1+json")
+		w.Header().Set("Docker-Content-Digest", digest)
+		if req.Method == http.MethodGet {
+			w.Write(data) //nolint:errcheck
+		}
+	case kind == "tags" && name == "list":
+		tags := []string{}
+		for tag := range r.tags[repo] {
+			tags = append(tags, tag)
+		}
+		sort.Strings(tags)
+		json.NewEncoder(w).Encode(map[string]any{"name": repo, "tags": tags}) //nolint:errcheck
+	default:
+		http.NotFound(w, req)
+	}
+}
+
+func registryDigest(data []byte) string {
+	sum := sha256.Sum256(data)
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
+
+func registryErr(w http.ResponseWriter, status int, code, msg string) {
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(map[string]any{ //nolint:errcheck
+		"errors": []map[string]string{{"code": code, "message": msg}},
+	})
+}
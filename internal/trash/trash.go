@@ -35,13 +35,10 @@ type TrashEntry struct {
 // MoveToTrash moves a skill directory to the trash.
 // Uses os.Rename for atomic same-device moves, falls back to copy+delete.
 func MoveToTrash(srcPath, name, trashBase string) (string, error) {
-	timestamp := time.Now().Format("2006-01-02_15-04-05")
-	trashName := name + "_" + timestamp
-	trashPath := filepath.Join(trashBase, trashName)
-
 	if err := os.MkdirAll(trashBase, 0755); err != nil {
 		return "", fmt.Errorf("failed to create trash directory: %w", err)
 	}
+	trashPath := uniqueTrashPath(trashBase, name)
 
 	// Try atomic rename first (same device)
 	if err := os.Rename(srcPath, trashPath); err == nil {
@@ -60,6 +57,36 @@ func MoveToTrash(srcPath, name, trashBase string) (string, error) {
 	return trashPath, nil
 }
 
+// CopyToTrash copies a skill directory into the trash, leaving the original
+// in place. Used to keep a restorable snapshot before a skill is modified.
+func CopyToTrash(srcPath, name, trashBase string) (string, error) {
+	if err := os.MkdirAll(trashBase, 0755); err != nil {
+		return "", fmt.Errorf("failed to create trash directory: %w", err)
+	}
+	trashPath := uniqueTrashPath(trashBase, name)
+
+	if err := copyDir(srcPath, trashPath); err != nil {
+		os.RemoveAll(trashPath)
+		return "", fmt.Errorf("failed to copy to trash: %w", err)
+	}
+
+	return trashPath, nil
+}
+
+// uniqueTrashPath returns "<name>_<timestamp>" under trashBase, bumping the
+// timestamp forward while an entry with that name already exists so two
+// trash operations within the same second never merge into one directory.
+func uniqueTrashPath(trashBase, name string) string {
+	t := time.Now()
+	for {
+		trashPath := filepath.Join(trashBase, name+"_"+t.Format("2006-01-02_15-04-05"))
+		if _, err := os.Lstat(trashPath); os.IsNotExist(err) {
+			return trashPath
+		}
+		t = t.Add(time.Second)
+	}
+}
+
 // List returns all trashed items sorted by date (newest first).
 func List(trashBase string) []TrashEntry {
 	entries, err := os.ReadDir(trashBase)
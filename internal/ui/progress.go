@@ -0,0 +1,144 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mattn/go-runewidth"
+	"github.com/pterm/pterm"
+)
+
+// maxProgressLines caps the in-flight items shown below the counter line.
+const maxProgressLines = 8
+
+// MultiProgress shows a counter line plus one line per item in flight, for
+// work that runs several items at once. Items are listed in the order they
+// started. On a non-TTY it prints the title once and stays quiet.
+// A nil *MultiProgress does nothing, so callers need not check for quiet
+// (e.g. --json) runs.
+type MultiProgress struct {
+	title string
+	total int
+	start time.Time
+
+	mu     sync.Mutex
+	done   int
+	active []string
+	drawn  int // lines currently on screen
+	frame  int
+	stop   chan struct{}
+	wg     sync.WaitGroup
+}
+
+var progressFrames = []string{"⠋", "⠙", "⠹", "⠸", "⠼", "⠴", "⠦", "⠧", "⠇", "⠏"}
+
+// StartMultiProgress starts a multi-line progress display for total items.
+func StartMultiProgress(title string, total int) *MultiProgress {
+	p := &MultiProgress{title: title, total: total, start: time.Now()}
+	if !IsTTY() {
+		fmt.Printf("... %s\n", title)
+		return p
+	}
+	p.stop = make(chan struct{})
+	p.wg.Add(1)
+	go func() {
+		defer p.wg.Done()
+		ticker := time.NewTicker(100 * time.Millisecond)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-p.stop:
+				return
+			case <-ticker.C:
+				p.mu.Lock()
+				p.frame++
+				p.redraw()
+				p.mu.Unlock()
+			}
+		}
+	}()
+	return p
+}
+
+// Begin marks an item as in flight.
+func (p *MultiProgress) Begin(name string) {
+	if p == nil {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.active = append(p.active, name)
+}
+
+// End marks an in-flight item as finished.
+func (p *MultiProgress) End(name string) {
+	if p == nil {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for i, n := range p.active {
+		if n == name {
+			p.active = append(p.active[:i], p.active[i+1:]...)
+			break
+		}
+	}
+	p.done++
+}
+
+// Stop clears the display and prints a one-line summary.
+func (p *MultiProgress) Stop(message string) {
+	if p == nil {
+		return
+	}
+	elapsed := time.Since(p.start)
+	if p.stop != nil {
+		close(p.stop)
+		p.wg.Wait()
+		p.mu.Lock()
+		p.clear()
+		p.mu.Unlock()
+		pterm.Success.Println(fmt.Sprintf("%s (%.1fs)", message, elapsed.Seconds()))
+		return
+	}
+	fmt.Printf("✓ %s (%.1fs)\n", message, elapsed.Seconds())
+}
+
+// clear moves the cursor to the first drawn line and erases to the end.
+func (p *MultiProgress) clear() {
+	if p.drawn > 0 {
+		fmt.Printf("\x1b[%dF\x1b[J", p.drawn)
+		p.drawn = 0
+	}
+}
+
+func (p *MultiProgress) redraw() {
+	width := pterm.GetTerminalWidth()
+	if width <= 0 {
+		width = 80
+	}
+
+	lines := []string{fmt.Sprintf("%s%s%s %s [%d/%d]",
+		Cyan, progressFrames[p.frame%len(progressFrames)], Reset, p.title, p.done, p.total)}
+	for i, name := range p.active {
+		if i == maxProgressLines {
+			lines = append(lines, fmt.Sprintf("  %s… and %d more%s", Gray, len(p.active)-i, Reset))
+			break
+		}
+		lines = append(lines, "  "+Gray+"→ "+Reset+name)
+	}
+
+	p.clear()
+	var b strings.Builder
+	for _, line := range lines {
+		if displayWidth(line) > width-1 {
+			line = runewidth.Truncate(ansiRegex.ReplaceAllString(line, ""), width-1, "…")
+		}
+		b.WriteString(line)
+		b.WriteString("\n")
+	}
+	fmt.Print(b.String())
+	p.drawn = len(lines)
+}
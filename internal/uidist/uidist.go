@@ -15,6 +15,7 @@ import (
 	"time"
 
 	"skillshare/internal/config"
+	"skillshare/internal/network"
 	"skillshare/internal/version"
 )
 
@@ -117,7 +118,7 @@ func cleanOldVersions(currentVer string) error {
 // fetchChecksum downloads checksums.txt and parses the SHA256 for AssetName.
 func fetchChecksum(ver string) (string, error) {
 	url := version.BuildChecksumsURL(ver)
-	client := &http.Client{Timeout: 30 * time.Second}
+	client := network.NewClient(30 * time.Second)
 	resp, err := client.Get(url)
 	if err != nil {
 		return "", err
@@ -154,7 +155,7 @@ const maxDownloadSize = 100 * 1024 * 1024 // 100 MB
 
 // downloadToTemp downloads a URL to a temporary file and returns its path.
 func downloadToTemp(url string) (string, error) {
-	client := &http.Client{Timeout: 120 * time.Second}
+	client := network.NewClient(120 * time.Second)
 	resp, err := client.Get(url)
 	if err != nil {
 		return "", err
@@ -0,0 +1,390 @@
+// Package undo records what mutating commands change (skills, target
+// backups, config) as changesets next to the operation log, and reverts
+// them.
+package undo
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"skillshare/internal/install"
+	"skillshare/internal/oplog"
+	"skillshare/internal/sync"
+	"skillshare/internal/trash"
+	"skillshare/internal/utils"
+)
+
+// ChangesetDir is the subdirectory of the log directory holding changesets.
+const ChangesetDir = "changesets"
+
+// Changeset records everything needed to revert one mutating command.
+// Its ID is stored in the command's oplog Entry.
+type Changeset struct {
+	ID        string        `json:"id"`
+	Command   string        `json:"cmd"`
+	Timestamp string        `json:"ts"`
+	Source    string        `json:"source,omitempty"` // source dir the skill paths are relative to
+	Trash     string        `json:"trash,omitempty"`  // trash dir used for snapshots
+	Skills    []SkillChange `json:"skills,omitempty"`
+	Backups   []BackupRef   `json:"backups,omitempty"`
+	Config    *ConfigDiff   `json:"config,omitempty"`
+	UndoneAt  string        `json:"undone_at,omitempty"`
+}
+
+// SkillChange describes how one skill (or tracked repo) in the source changed.
+// Before and After are DirChecksum values; an empty value means the skill
+// did not exist at that point.
+type SkillChange struct {
+	Name   string `json:"name"`
+	Before string `json:"before,omitempty"`
+	After  string `json:"after,omitempty"`
+	Trash  string `json:"trash,omitempty"` // trash entry holding the pre-state
+}
+
+// Kind returns "added", "removed" or "modified".
+func (c SkillChange) Kind() string {
+	switch {
+	case c.Before == "":
+		return "added"
+	case c.After == "":
+		return "removed"
+	default:
+		return "modified"
+	}
+}
+
+// Reversible reports whether the pre-state of the skill can be recovered.
+func (c SkillChange) Reversible() bool {
+	return c.Before == "" || c.Trash != ""
+}
+
+// BackupRef points at a target backup taken by the command.
+type BackupRef struct {
+	Target string `json:"target"`
+	Path   string `json:"path"`   // target directory
+	Backup string `json:"backup"` // backup (timestamp) directory holding the snapshot
+}
+
+// ConfigDiff holds the config file content before and after the command.
+type ConfigDiff struct {
+	Path   string `json:"path"`
+	Before string `json:"before"`
+	After  string `json:"after"`
+}
+
+// Lines returns a minimal line diff ("- " removed, "+ " added).
+func (d *ConfigDiff) Lines() []string {
+	a := strings.Split(strings.TrimRight(d.Before, "\n"), "\n")
+	b := strings.Split(strings.TrimRight(d.After, "\n"), "\n")
+
+	// LCS table; config files are small enough for the quadratic version.
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else if lcs[i+1][j] >= lcs[i][j+1] {
+				lcs[i][j] = lcs[i+1][j]
+			} else {
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+
+	var out []string
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case a[i] == b[j]:
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			out = append(out, "- "+a[i])
+			i++
+		default:
+			out = append(out, "+ "+b[j])
+			j++
+		}
+	}
+	for ; i < len(a); i++ {
+		out = append(out, "- "+a[i])
+	}
+	for ; j < len(b); j++ {
+		out = append(out, "+ "+b[j])
+	}
+	return out
+}
+
+// IsEmpty returns true when the changeset recorded no changes.
+func (c *Changeset) IsEmpty() bool {
+	return len(c.Skills) == 0 && len(c.Backups) == 0 && c.Config == nil
+}
+
+// Recorder captures the pre-state of a command and turns it into a
+// Changeset once the command finishes. All methods are safe on a nil
+// Recorder so callers don't need to guard commands without undo support.
+type Recorder struct {
+	cs           Changeset
+	configPath   string
+	configBefore []byte
+	before       map[string]string // skill rel path -> checksum
+	snapshots    map[string]string // skill rel path -> trash path
+}
+
+// NewRecorder starts recording a command. configPath selects the scope
+// (global or project) and trashBase is where snapshots are stored.
+func NewRecorder(cmd, configPath, trashBase string) *Recorder {
+	r := &Recorder{
+		cs: Changeset{
+			Command: cmd,
+			Trash:   trashBase,
+		},
+		configPath: configPath,
+		snapshots:  map[string]string{},
+	}
+	r.configBefore, _ = os.ReadFile(configPath)
+	return r
+}
+
+// TrackSource records pre-state checksums of every skill in sourceDir.
+func (r *Recorder) TrackSource(sourceDir string) {
+	if r == nil {
+		return
+	}
+	r.cs.Source = sourceDir
+	r.before = scanSkillChecksums(sourceDir)
+}
+
+// Preserve snapshots an existing skill into the trash before the command
+// modifies it, so the modification can be reverted.
+func (r *Recorder) Preserve(path string) {
+	if r == nil || r.before == nil {
+		return
+	}
+	rel, ok := r.relPath(path)
+	if !ok || r.snapshots[rel] != "" {
+		return
+	}
+	if _, exists := r.before[rel]; !exists {
+		return
+	}
+	if trashPath, err := trash.CopyToTrash(path, utils.PathToFlatName(rel), r.cs.Trash); err == nil {
+		r.snapshots[rel] = trashPath
+	}
+}
+
+// Trashed records that the command itself moved a skill to the trash.
+func (r *Recorder) Trashed(path, trashPath string) {
+	if r == nil || r.before == nil {
+		return
+	}
+	if rel, ok := r.relPath(path); ok {
+		r.snapshots[rel] = trashPath
+	}
+}
+
+// AddBackup records a target backup taken by the command.
+func (r *Recorder) AddBackup(target, targetPath, backupPath string) {
+	if r == nil || backupPath == "" {
+		return
+	}
+	r.cs.Backups = append(r.cs.Backups, BackupRef{Target: target, Path: targetPath, Backup: backupPath})
+}
+
+// Commit compares the current state against the recorded pre-state and
+// saves the resulting changeset. Returns the changeset ID, or "" when the
+// command changed nothing.
+func (r *Recorder) Commit() (string, error) {
+	if r == nil {
+		return "", nil
+	}
+
+	if r.before != nil {
+		after := scanSkillChecksums(r.cs.Source)
+		names := map[string]bool{}
+		for name := range r.before {
+			names[name] = true
+		}
+		for name := range after {
+			names[name] = true
+		}
+		for name := range names {
+			b, a := r.before[name], after[name]
+			if b == a {
+				// Unchanged — drop any snapshot taken defensively
+				if snap := r.snapshots[name]; snap != "" && a != "" {
+					os.RemoveAll(snap)
+				}
+				continue
+			}
+			r.cs.Skills = append(r.cs.Skills, SkillChange{
+				Name:   name,
+				Before: b,
+				After:  a,
+				Trash:  r.snapshots[name],
+			})
+		}
+		sort.Slice(r.cs.Skills, func(i, j int) bool {
+			return r.cs.Skills[i].Name < r.cs.Skills[j].Name
+		})
+	}
+
+	// A config created by the command (first-run init) is not reverted
+	configAfter, _ := os.ReadFile(r.configPath)
+	if len(r.configBefore) > 0 && string(configAfter) != string(r.configBefore) {
+		r.cs.Config = &ConfigDiff{
+			Path:   r.configPath,
+			Before: string(r.configBefore),
+			After:  string(configAfter),
+		}
+	}
+
+	if r.cs.IsEmpty() {
+		return "", nil
+	}
+
+	now := time.Now()
+	r.cs.ID = newChangesetID(now)
+	r.cs.Timestamp = now.Format(time.RFC3339Nano)
+	if err := SaveChangeset(r.configPath, &r.cs); err != nil {
+		return "", err
+	}
+	return r.cs.ID, nil
+}
+
+func (r *Recorder) relPath(path string) (string, bool) {
+	rel, err := filepath.Rel(r.cs.Source, path)
+	if err != nil || rel == "." || strings.HasPrefix(rel, "..") {
+		return "", false
+	}
+	return filepath.ToSlash(rel), true
+}
+
+// SaveChangeset writes cs into the changeset directory of the scope.
+func SaveChangeset(configPath string, cs *Changeset) error {
+	dir := filepath.Join(oplog.LogDir(configPath), ChangesetDir)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(cs, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(dir, cs.ID+".json"), data, 0644)
+}
+
+// LoadChangeset reads a changeset by ID.
+func LoadChangeset(configPath, id string) (*Changeset, error) {
+	if id == "" || strings.ContainsAny(id, `/\`) {
+		return nil, fmt.Errorf("invalid operation id %q", id)
+	}
+	data, err := os.ReadFile(filepath.Join(oplog.LogDir(configPath), ChangesetDir, id+".json"))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("operation %s not found", id)
+		}
+		return nil, err
+	}
+	var cs Changeset
+	if err := json.Unmarshal(data, &cs); err != nil {
+		return nil, fmt.Errorf("corrupt changeset %s: %w", id, err)
+	}
+	return &cs, nil
+}
+
+// ListChangesets returns all changesets of a scope, newest first.
+func ListChangesets(configPath string) ([]*Changeset, error) {
+	dir := filepath.Join(oplog.LogDir(configPath), ChangesetDir)
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var out []*Changeset
+	for _, e := range entries {
+		if e.IsDir() || !strings.HasSuffix(e.Name(), ".json") {
+			continue
+		}
+		cs, err := LoadChangeset(configPath, strings.TrimSuffix(e.Name(), ".json"))
+		if err != nil {
+			continue // skip corrupt files
+		}
+		out = append(out, cs)
+	}
+
+	sort.Slice(out, func(i, j int) bool { return ranAfter(out[i], out[j]) })
+	return out, nil
+}
+
+// ranAfter reports whether a was recorded after b. IDs only resolve
+// milliseconds, so the nanosecond timestamps decide when they parse.
+func ranAfter(a, b *Changeset) bool {
+	ta, errA := time.Parse(time.RFC3339Nano, a.Timestamp)
+	tb, errB := time.Parse(time.RFC3339Nano, b.Timestamp)
+	if errA == nil && errB == nil && !ta.Equal(tb) {
+		return ta.After(tb)
+	}
+	return a.ID > b.ID
+}
+
+// LatestChangeset returns the most recent changeset that has not been undone.
+func LatestChangeset(configPath string) (*Changeset, error) {
+	all, err := ListChangesets(configPath)
+	if err != nil {
+		return nil, err
+	}
+	for _, cs := range all {
+		if cs.UndoneAt == "" {
+			return cs, nil
+		}
+	}
+	return nil, fmt.Errorf("no operation to undo")
+}
+
+// newChangesetID returns a sortable, unique ID like "20260118-153045.123-a1b2".
+func newChangesetID(t time.Time) string {
+	buf := make([]byte, 2)
+	rand.Read(buf) //nolint:errcheck
+	return t.Format("20060102-150405.000") + "-" + hex.EncodeToString(buf)
+}
+
+// scanSkillChecksums walks sourceDir and checksums every skill directory
+// (has SKILL.md) and tracked repo (has .git), keyed by relative path.
+// Group directories are descended into, skills are not.
+func scanSkillChecksums(sourceDir string) map[string]string {
+	sums := map[string]string{}
+	filepath.Walk(sourceDir, func(path string, info os.FileInfo, err error) error { //nolint:errcheck
+		if err != nil || !info.IsDir() || path == sourceDir {
+			return nil
+		}
+		if strings.HasPrefix(info.Name(), ".") {
+			return filepath.SkipDir
+		}
+		_, skillErr := os.Stat(filepath.Join(path, "SKILL.md"))
+		if skillErr != nil && !install.IsGitRepo(path) {
+			return nil
+		}
+		rel, relErr := filepath.Rel(sourceDir, path)
+		if relErr != nil {
+			return filepath.SkipDir
+		}
+		if sum, sumErr := sync.DirChecksum(path); sumErr == nil {
+			sums[filepath.ToSlash(rel)] = sum
+		}
+		return filepath.SkipDir
+	})
+	return sums
+}
@@ -0,0 +1,233 @@
+package undo
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeSkill(t *testing.T, dir, name, content string) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	if err := os.MkdirAll(path, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(path, "SKILL.md"), []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func tempConfigPath(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	// Isolate XDG_STATE_HOME so global-mode log dirs don't collide across tests
+	t.Setenv("XDG_STATE_HOME", filepath.Join(dir, "state"))
+	return filepath.Join(dir, "config.yaml")
+}
+
+func newTestRecorder(t *testing.T) (cfgPath, source, trashDir string) {
+	t.Helper()
+	cfgPath = tempConfigPath(t)
+	root := filepath.Dir(cfgPath)
+	source = filepath.Join(root, "skills")
+	trashDir = filepath.Join(root, "trash")
+	if err := os.MkdirAll(source, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(cfgPath, []byte("source: "+source+"\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return cfgPath, source, trashDir
+}
+
+func TestRecorder_NoChanges(t *testing.T) {
+	cfgPath, source, trashDir := newTestRecorder(t)
+	writeSkill(t, source, "keep", "# Keep")
+
+	r := NewRecorder("install", cfgPath, trashDir)
+	r.TrackSource(source)
+	r.Preserve(filepath.Join(source, "keep"))
+
+	id, err := r.Commit()
+	if err != nil {
+		t.Fatalf("Commit() error: %v", err)
+	}
+	if id != "" {
+		t.Errorf("Commit() id = %q, want empty for no-op", id)
+	}
+	// Defensive snapshot of an unchanged skill must be cleaned up
+	entries, _ := os.ReadDir(trashDir)
+	if len(entries) != 0 {
+		t.Errorf("trash has %d entries, want 0", len(entries))
+	}
+}
+
+func TestRecorder_NilSafe(t *testing.T) {
+	var r *Recorder
+	r.TrackSource("/nowhere")
+	r.Preserve("/nowhere/x")
+	r.Trashed("/nowhere/x", "/trash/x")
+	r.AddBackup("claude", "/t", "/b")
+	if id, err := r.Commit(); id != "" || err != nil {
+		t.Errorf("nil Commit() = (%q, %v), want (\"\", nil)", id, err)
+	}
+}
+
+func TestRevert_AddedSkill(t *testing.T) {
+	cfgPath, source, trashDir := newTestRecorder(t)
+
+	r := NewRecorder("install", cfgPath, trashDir)
+	r.TrackSource(source)
+	writeSkill(t, source, "new-skill", "# New")
+	id, err := r.Commit()
+	if err != nil || id == "" {
+		t.Fatalf("Commit() = (%q, %v)", id, err)
+	}
+
+	cs, err := LatestChangeset(cfgPath)
+	if err != nil {
+		t.Fatalf("LatestChangeset() error: %v", err)
+	}
+	if len(cs.Skills) != 1 || cs.Skills[0].Kind() != "added" {
+		t.Fatalf("Skills = %+v, want one added change", cs.Skills)
+	}
+
+	res, err := Revert(cfgPath, cs, RevertOptions{})
+	if err != nil {
+		t.Fatalf("Revert() error: %v", err)
+	}
+	if len(res.Removed) != 1 || res.Removed[0] != "new-skill" {
+		t.Errorf("Removed = %v, want [new-skill]", res.Removed)
+	}
+	if _, err := os.Stat(filepath.Join(source, "new-skill")); !os.IsNotExist(err) {
+		t.Error("new-skill should be gone from source")
+	}
+
+	// Already undone: nothing left to undo
+	if _, err := LatestChangeset(cfgPath); err == nil {
+		t.Error("LatestChangeset() should fail after undo")
+	}
+}
+
+func TestRevert_ModifiedSkill(t *testing.T) {
+	cfgPath, source, trashDir := newTestRecorder(t)
+	path := writeSkill(t, source, "group/mod", "# v1")
+
+	r := NewRecorder("update", cfgPath, trashDir)
+	r.TrackSource(source)
+	r.Preserve(path)
+	writeSkill(t, source, "group/mod", "# v2")
+	if _, err := r.Commit(); err != nil {
+		t.Fatal(err)
+	}
+
+	cs, _ := LatestChangeset(cfgPath)
+	if len(cs.Skills) != 1 || cs.Skills[0].Name != "group/mod" || cs.Skills[0].Kind() != "modified" {
+		t.Fatalf("Skills = %+v, want group/mod modified", cs.Skills)
+	}
+
+	if _, err := Revert(cfgPath, cs, RevertOptions{}); err != nil {
+		t.Fatalf("Revert() error: %v", err)
+	}
+	data, _ := os.ReadFile(filepath.Join(path, "SKILL.md"))
+	if string(data) != "# v1" {
+		t.Errorf("SKILL.md = %q, want %q", data, "# v1")
+	}
+
+	// Nested skills are trashed under flat names, not in subdirectories
+	entries, _ := os.ReadDir(trashDir)
+	for _, e := range entries {
+		if !strings.HasPrefix(e.Name(), "group__mod_") {
+			t.Errorf("trash entry %q, want group__mod_<timestamp>", e.Name())
+		}
+	}
+}
+
+func TestRevert_IrreversibleNeedsForce(t *testing.T) {
+	cfgPath, source, trashDir := newTestRecorder(t)
+	writeSkill(t, source, "mod", "# v1")
+
+	r := NewRecorder("update", cfgPath, trashDir)
+	r.TrackSource(source)
+	writeSkill(t, source, "mod", "# v2") // no Preserve
+	if _, err := r.Commit(); err != nil {
+		t.Fatal(err)
+	}
+
+	cs, _ := LatestChangeset(cfgPath)
+	if _, err := Revert(cfgPath, cs, RevertOptions{}); err == nil {
+		t.Fatal("Revert() should refuse without --force")
+	}
+	res, err := Revert(cfgPath, cs, RevertOptions{Force: true})
+	if err != nil {
+		t.Fatalf("Revert(force) error: %v", err)
+	}
+	if len(res.Skipped) != 1 {
+		t.Errorf("Skipped = %v, want [mod]", res.Skipped)
+	}
+}
+
+func TestConflicts_LaterOperation(t *testing.T) {
+	cfgPath, source, trashDir := newTestRecorder(t)
+
+	r1 := NewRecorder("install", cfgPath, trashDir)
+	r1.TrackSource(source)
+	path := writeSkill(t, source, "shared", "# v1")
+	if _, err := r1.Commit(); err != nil {
+		t.Fatal(err)
+	}
+	first, _ := LatestChangeset(cfgPath)
+
+	r2 := NewRecorder("update", cfgPath, trashDir)
+	r2.TrackSource(source)
+	r2.Preserve(path)
+	writeSkill(t, source, "shared", "# v2")
+	if _, err := r2.Commit(); err != nil {
+		t.Fatal(err)
+	}
+
+	conflicts := Conflicts(cfgPath, first)
+	if len(conflicts) == 0 {
+		t.Fatal("Conflicts() should report the later update")
+	}
+	if !strings.Contains(strings.Join(conflicts, "\n"), "later operation") {
+		t.Errorf("Conflicts() = %v, want a later-operation entry", conflicts)
+	}
+}
+
+func TestRevert_Config(t *testing.T) {
+	cfgPath, source, trashDir := newTestRecorder(t)
+	before, _ := os.ReadFile(cfgPath)
+
+	r := NewRecorder("target", cfgPath, trashDir)
+	r.TrackSource(source)
+	os.WriteFile(cfgPath, append(before, []byte("mode: copy\n")...), 0644)
+	if _, err := r.Commit(); err != nil {
+		t.Fatal(err)
+	}
+
+	cs, _ := LatestChangeset(cfgPath)
+	if cs.Config == nil {
+		t.Fatal("Config diff not recorded")
+	}
+	if lines := cs.Config.Lines(); len(lines) != 1 || lines[0] != "+ mode: copy" {
+		t.Errorf("Lines() = %v, want [+ mode: copy]", lines)
+	}
+
+	if _, err := Revert(cfgPath, cs, RevertOptions{}); err != nil {
+		t.Fatalf("Revert() error: %v", err)
+	}
+	got, _ := os.ReadFile(cfgPath)
+	if string(got) != string(before) {
+		t.Errorf("config = %q, want %q", got, before)
+	}
+}
+
+func TestLoadChangeset_RejectsPaths(t *testing.T) {
+	cfgPath := tempConfigPath(t)
+	if _, err := LoadChangeset(cfgPath, "../evil"); err == nil {
+		t.Error("LoadChangeset() should reject path separators")
+	}
+}
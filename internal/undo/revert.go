@@ -0,0 +1,244 @@
+package undo
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"skillshare/internal/backup"
+	"skillshare/internal/config"
+	"skillshare/internal/install"
+	"skillshare/internal/sync"
+	"skillshare/internal/trash"
+	"skillshare/internal/utils"
+)
+
+// RevertOptions holds options for reverting a changeset.
+type RevertOptions struct {
+	Force bool // Revert despite conflicts; irreversible changes are skipped
+}
+
+// RevertResult summarizes what a revert did.
+type RevertResult struct {
+	Removed  []string // skills added by the operation, now moved to trash
+	Restored []string // skills restored to their pre-operation state
+	Skipped  []string // changes that could not be reverted (Force only)
+	Targets  []string // targets restored from backup
+	Config   bool     // config file restored
+}
+
+// Conflicts returns human-readable reasons why cs cannot be reverted
+// cleanly: later operations that touched the same skills, targets or
+// config, and skills or config edited since the operation ran.
+func Conflicts(configPath string, cs *Changeset) []string {
+	var out []string
+
+	all, _ := ListChangesets(configPath)
+	for _, later := range all {
+		if !ranAfter(later, cs) || later.UndoneAt != "" {
+			continue
+		}
+		if overlap := changesetOverlap(cs, later); overlap != "" {
+			out = append(out, fmt.Sprintf("later operation %s (%s) also changed %s", later.ID, later.Command, overlap))
+		}
+	}
+
+	for _, c := range cs.Skills {
+		path := filepath.Join(cs.Source, filepath.FromSlash(c.Name))
+		current := currentChecksum(path)
+		if current != c.After {
+			out = append(out, fmt.Sprintf("%s was changed after the operation", c.Name))
+		}
+		if c.Before != "" && c.Trash != "" {
+			if _, err := os.Stat(c.Trash); err != nil {
+				out = append(out, fmt.Sprintf("%s: trashed copy no longer exists (%s)", c.Name, c.Trash))
+			}
+		}
+		if !c.Reversible() {
+			out = append(out, fmt.Sprintf("%s: previous version was not preserved", c.Name))
+		}
+	}
+
+	if cs.Config != nil {
+		current, _ := os.ReadFile(cs.Config.Path)
+		if string(current) != cs.Config.After {
+			out = append(out, "config was changed after the operation")
+		}
+	}
+
+	for _, b := range cs.Backups {
+		if _, err := os.Stat(b.Backup); err != nil {
+			out = append(out, fmt.Sprintf("backup of %s no longer exists (%s)", b.Target, b.Backup))
+		}
+	}
+
+	return out
+}
+
+// Revert restores the state recorded in cs and marks it as undone.
+// Unless opts.Force is set, it refuses when Conflicts reports anything.
+func Revert(configPath string, cs *Changeset, opts RevertOptions) (*RevertResult, error) {
+	if cs.UndoneAt != "" {
+		return nil, fmt.Errorf("operation %s was already undone at %s", cs.ID, cs.UndoneAt)
+	}
+	if !opts.Force {
+		if conflicts := Conflicts(configPath, cs); len(conflicts) > 0 {
+			return nil, fmt.Errorf("cannot undo %s:\n  - %s", cs.ID, strings.Join(conflicts, "\n  - "))
+		}
+	}
+
+	result := &RevertResult{}
+
+	for _, c := range cs.Skills {
+		if !c.Reversible() {
+			result.Skipped = append(result.Skipped, c.Name)
+			continue
+		}
+		path := filepath.Join(cs.Source, filepath.FromSlash(c.Name))
+
+		// Move the post-operation version out of the way first
+		if _, err := os.Lstat(path); err == nil {
+			ignored := isGitIgnoredSkill(cs.Source, path)
+			if _, err := trash.MoveToTrash(path, utils.PathToFlatName(c.Name), cs.Trash); err != nil {
+				return result, fmt.Errorf("failed to move %s to trash: %w", c.Name, err)
+			}
+			if ignored && c.Before == "" {
+				dir, entry := gitIgnoreEntry(cs.Source, c.Name)
+				install.RemoveFromGitIgnore(dir, entry) //nolint:errcheck
+			}
+		}
+
+		if c.Before == "" {
+			result.Removed = append(result.Removed, c.Name)
+			continue
+		}
+
+		if _, err := os.Stat(c.Trash); err != nil {
+			result.Skipped = append(result.Skipped, c.Name)
+			continue
+		}
+		entry := &trash.TrashEntry{Name: filepath.Base(path), Path: c.Trash}
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			return result, err
+		}
+		if err := trash.Restore(entry, filepath.Dir(path)); err != nil {
+			return result, fmt.Errorf("failed to restore %s: %w", c.Name, err)
+		}
+		if isGitIgnoredSkill(cs.Source, path) {
+			dir, entry := gitIgnoreEntry(cs.Source, c.Name)
+			install.UpdateGitIgnore(dir, entry) //nolint:errcheck
+		}
+		result.Restored = append(result.Restored, c.Name)
+	}
+
+	for _, b := range cs.Backups {
+		if _, err := os.Stat(b.Backup); err != nil {
+			result.Skipped = append(result.Skipped, "target "+b.Target)
+			continue
+		}
+		if err := restoreTarget(b); err != nil {
+			return result, fmt.Errorf("failed to restore target %s: %w", b.Target, err)
+		}
+		result.Targets = append(result.Targets, b.Target)
+	}
+
+	if cs.Config != nil {
+		if err := config.WriteFileAtomic(cs.Config.Path, []byte(cs.Config.Before), 0644); err != nil {
+			return result, fmt.Errorf("failed to restore config: %w", err)
+		}
+		result.Config = true
+	}
+
+	cs.UndoneAt = time.Now().Format(time.RFC3339)
+	if err := SaveChangeset(configPath, cs); err != nil {
+		return result, fmt.Errorf("reverted, but failed to mark operation as undone: %w", err)
+	}
+
+	return result, nil
+}
+
+// restoreTarget puts back the entries the backup of a target holds,
+// replacing whatever is at their paths now. Other entries are left alone:
+// the backup skips symlinks, so wiping the target would drop every link
+// sync made. A target that is now a symlink itself (symlink mode) is
+// turned back into a directory first.
+func restoreTarget(b BackupRef) error {
+	snap, err := backup.LoadSnapshot(b.Backup, b.Target)
+	if err != nil {
+		return err
+	}
+	if info, err := os.Lstat(b.Path); err == nil && info.Mode()&os.ModeSymlink != 0 {
+		if err := os.Remove(b.Path); err != nil {
+			return err
+		}
+	}
+	var names []string
+	for _, s := range snap.Skills() {
+		names = append(names, s.Name)
+	}
+	if len(names) == 0 {
+		return os.MkdirAll(b.Path, 0755)
+	}
+	return backup.RestoreSkills(b.Backup, b.Target, b.Path, names, backup.RestoreOptions{Force: true})
+}
+
+// changesetOverlap returns a description of what a and b both touched, or "".
+func changesetOverlap(a, b *Changeset) string {
+	if a.Config != nil && b.Config != nil && a.Config.Path == b.Config.Path {
+		return "config"
+	}
+	if a.Source == b.Source {
+		names := map[string]bool{}
+		for _, c := range a.Skills {
+			names[c.Name] = true
+		}
+		for _, c := range b.Skills {
+			if names[c.Name] {
+				return "skill " + c.Name
+			}
+		}
+	}
+	targets := map[string]bool{}
+	for _, r := range a.Backups {
+		targets[r.Path] = true
+	}
+	for _, r := range b.Backups {
+		if targets[r.Path] {
+			return "target " + r.Target
+		}
+	}
+	return ""
+}
+
+// isGitIgnoredSkill reports whether install keeps path out of git: tracked
+// repos in global mode, and every remotely installed skill in project mode.
+func isGitIgnoredSkill(sourceDir, path string) bool {
+	if install.IsGitRepo(path) {
+		return true
+	}
+	return isProjectSource(sourceDir) && install.HasMeta(path)
+}
+
+// gitIgnoreEntry returns the .gitignore directory and entry for a skill,
+// mirroring install: <source>/.gitignore in global mode and
+// .skillshare/.gitignore with a "skills/" prefix in project mode.
+func gitIgnoreEntry(sourceDir, name string) (string, string) {
+	if isProjectSource(sourceDir) {
+		return filepath.Dir(sourceDir), "skills/" + name
+	}
+	return sourceDir, name
+}
+
+func isProjectSource(sourceDir string) bool {
+	return filepath.Base(sourceDir) == "skills" && filepath.Base(filepath.Dir(sourceDir)) == ".skillshare"
+}
+
+func currentChecksum(path string) string {
+	if _, err := os.Stat(path); err != nil {
+		return ""
+	}
+	sum, _ := sync.DirChecksum(path)
+	return sum
+}
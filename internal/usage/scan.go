@@ -0,0 +1,218 @@
+package usage
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+// maxLineSize bounds a single transcript line; longer lines are skipped.
+const maxLineSize = 8 << 20
+
+// skillPathRe matches a SKILL.md path inside a skills directory, as seen in
+// Read tool calls or shell commands that load a skill.
+var skillPathRe = regexp.MustCompile(`skills[/\\]+([A-Za-z0-9_.:-]+)[/\\]+SKILL\.md`)
+
+// Source is one target's transcript patterns.
+type Source struct {
+	Target   string
+	Patterns []string
+}
+
+// Names resolves the names a transcript may use for a skill (directory
+// name or frontmatter name) to its flat source name.
+type Names map[string]string
+
+// ExpandPattern lists the files matching pattern. A "**" segment matches
+// any number of directories; everything else follows filepath.Match.
+func ExpandPattern(pattern string) []string {
+	root, rest, ok := strings.Cut(filepath.ToSlash(pattern), "/**/")
+	if !ok {
+		matches, _ := filepath.Glob(pattern)
+		return matches
+	}
+	var files []string
+	filepath.WalkDir(filepath.FromSlash(root), func(p string, d fs.DirEntry, err error) error { //nolint:errcheck
+		if err != nil || d.IsDir() {
+			return nil
+		}
+		if ok, _ := filepath.Match(rest, d.Name()); ok {
+			files = append(files, p)
+		}
+		return nil
+	})
+	sort.Strings(files)
+	return files
+}
+
+// Scan reads the part of every transcript added since the last scan and
+// returns the skill invocations found. Cursors are advanced in place.
+func Scan(sources []Source, names Names, cursors map[string]Cursor) []Event {
+	var events []Event
+	for _, src := range sources {
+		for _, pattern := range src.Patterns {
+			for _, path := range ExpandPattern(pattern) {
+				events = append(events, scanFile(path, src.Target, names, cursors)...)
+			}
+		}
+	}
+	return events
+}
+
+// scanFile reads complete lines from the file's cursor onward. A file that
+// shrank was rotated or rewritten and is read from the start.
+func scanFile(path, target string, names Names, cursors map[string]Cursor) []Event {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil
+	}
+	cur := cursors[path]
+	if info.Size() < cur.Offset {
+		cur.Offset = 0
+	}
+	if info.Size() == cur.Offset {
+		return nil
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return nil
+	}
+	defer f.Close()
+	if _, err := f.Seek(cur.Offset, io.SeekStart); err != nil {
+		return nil
+	}
+
+	session := strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	var events []Event
+	r := bufio.NewReaderSize(f, 64*1024)
+	offset := cur.Offset
+	for {
+		line, err := r.ReadBytes('\n')
+		if err != nil {
+			// Incomplete last line: the agent is still writing it.
+			break
+		}
+		offset += int64(len(line))
+		if len(line) > maxLineSize {
+			continue
+		}
+		events = append(events, detectLine(line, target, session, info.ModTime(), names)...)
+	}
+
+	cursors[path] = Cursor{Offset: offset}
+	return events
+}
+
+// detectLine returns one event per skill the transcript line invokes: Skill
+// tool calls and tool calls that read a SKILL.md. Lines that are not JSON
+// are searched for SKILL.md paths only.
+func detectLine(line []byte, target, session string, fallback time.Time, names Names) []Event {
+	var found []string
+	var meta struct {
+		Timestamp string `json:"timestamp"`
+		Session   string `json:"sessionId"`
+		UUID      string `json:"uuid"`
+	}
+
+	var doc any
+	if json.Unmarshal(line, &doc) == nil {
+		json.Unmarshal(line, &meta) //nolint:errcheck
+		walkJSON(doc, func(obj map[string]any) {
+			switch obj["type"] {
+			case "tool_use", "function_call":
+			default:
+				return
+			}
+			if input, ok := obj["input"].(map[string]any); ok && obj["name"] == "Skill" {
+				for _, key := range []string{"skill", "command"} {
+					if s, ok := input[key].(string); ok {
+						found = append(found, s)
+					}
+				}
+			}
+			// A tool call that reads SKILL.md loads the skill.
+			walkStrings(obj, func(s string) {
+				for _, m := range skillPathRe.FindAllStringSubmatch(s, -1) {
+					found = append(found, m[1])
+				}
+			})
+		})
+	} else if bytes.Contains(line, []byte("SKILL.md")) {
+		for _, m := range skillPathRe.FindAllSubmatch(line, -1) {
+			found = append(found, string(m[1]))
+		}
+	}
+	if len(found) == 0 {
+		return nil
+	}
+
+	when := fallback
+	if t, err := time.Parse(time.RFC3339Nano, meta.Timestamp); err == nil {
+		when = t
+	}
+	if meta.Session != "" {
+		session = meta.Session
+	}
+
+	var events []Event
+	seen := map[string]bool{}
+	for _, raw := range found {
+		// Plugin skills are namespaced ("plugin:skill").
+		if i := strings.LastIndex(raw, ":"); i >= 0 {
+			raw = raw[i+1:]
+		}
+		skill, ok := names[raw]
+		if !ok || seen[skill] {
+			continue
+		}
+		seen[skill] = true
+		e := Event{Time: when.UTC(), Skill: skill, Target: target, Session: session}
+		if meta.UUID != "" {
+			e.ID = meta.UUID + ":" + skill
+		}
+		events = append(events, e)
+	}
+	return events
+}
+
+// walkJSON calls fn for every object in v.
+func walkJSON(v any, fn func(map[string]any)) {
+	switch t := v.(type) {
+	case map[string]any:
+		fn(t)
+		for _, child := range t {
+			walkJSON(child, fn)
+		}
+	case []any:
+		for _, child := range t {
+			walkJSON(child, fn)
+		}
+	}
+}
+
+// walkStrings calls fn for every string in v that mentions SKILL.md.
+func walkStrings(v any, fn func(string)) {
+	switch t := v.(type) {
+	case map[string]any:
+		for _, child := range t {
+			walkStrings(child, fn)
+		}
+	case []any:
+		for _, child := range t {
+			walkStrings(child, fn)
+		}
+	case string:
+		if strings.Contains(t, "SKILL.md") {
+			fn(t)
+		}
+	}
+}
@@ -0,0 +1,250 @@
+// Package usage counts how often agents invoke each skill, from the session
+// transcripts they keep on disk. Everything stays local: events are stored
+// as JSONL in the state directory.
+package usage
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"skillshare/internal/config"
+	"skillshare/internal/sync"
+	"skillshare/internal/utils"
+)
+
+// Store file names inside Dir().
+const (
+	EventsFile  = "events.jsonl"
+	CursorsFile = "cursors.json"
+)
+
+// DayFormat is the layout of per-day keys.
+const DayFormat = "2006-01-02"
+
+// Event is one skill invocation found in a transcript.
+type Event struct {
+	ID      string    `json:"id,omitempty"` // transcript message ID, when available
+	Time    time.Time `json:"ts"`
+	Skill   string    `json:"skill"`
+	Target  string    `json:"target"`
+	Session string    `json:"session,omitempty"`
+}
+
+// Cursor records how far a transcript has been read.
+type Cursor struct {
+	Offset int64 `json:"offset"`
+}
+
+// SkillUsage is the aggregated usage of one skill.
+type SkillUsage struct {
+	Skill    string         `json:"skill"`
+	Total    int            `json:"total"`
+	Targets  map[string]int `json:"targets"`
+	Days     map[string]int `json:"days"`
+	LastUsed time.Time      `json:"lastUsed"`
+}
+
+// Dir returns the usage store directory (~/.local/state/skillshare/usage).
+func Dir() string {
+	return filepath.Join(config.StateDir(), "usage")
+}
+
+// Sources returns the transcript patterns of every configured target: the
+// target's own transcripts setting, else the built-in spec for its name.
+// Targets without any are skipped.
+func Sources(targets map[string]config.TargetConfig) []Source {
+	var out []Source
+	for name, t := range targets {
+		patterns := t.Transcripts
+		if len(patterns) == 0 {
+			patterns = config.TranscriptPatterns(name)
+		} else {
+			expanded := make([]string, 0, len(patterns))
+			for _, p := range patterns {
+				if utils.HasTildePrefix(p) {
+					if home, err := os.UserHomeDir(); err == nil {
+						p = filepath.Join(home, p[1:])
+					}
+				}
+				expanded = append(expanded, p)
+			}
+			patterns = expanded
+		}
+		if len(patterns) > 0 {
+			out = append(out, Source{Target: name, Patterns: patterns})
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Target < out[j].Target })
+	return out
+}
+
+// NamesFor maps the directory name, flat name and frontmatter name of each
+// source skill to its flat name.
+func NamesFor(skills []sync.DiscoveredSkill) Names {
+	names := Names{}
+	for _, s := range skills {
+		names[s.FlatName] = s.FlatName
+		if base := filepath.Base(s.SourcePath); names[base] == "" {
+			names[base] = s.FlatName
+		}
+		if n, err := utils.ParseSkillName(s.SourcePath); err == nil && n != "" && names[n] == "" {
+			names[n] = s.FlatName
+		}
+	}
+	return names
+}
+
+// Load reads every stored event.
+func Load(dir string) ([]Event, error) {
+	f, err := os.Open(filepath.Join(dir, EventsFile))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var events []Event
+	sc := bufio.NewScanner(f)
+	sc.Buffer(make([]byte, 64*1024), 1<<20)
+	for sc.Scan() {
+		var e Event
+		if json.Unmarshal(sc.Bytes(), &e) == nil && e.Skill != "" {
+			events = append(events, e)
+		}
+	}
+	return events, sc.Err()
+}
+
+// Update scans the transcripts of sources for new invocations, appends them
+// to the store and returns how many were added. Events already stored
+// (same transcript message) are not added twice, so resumed sessions that
+// repeat their history are counted once.
+func Update(dir string, sources []Source, names Names) (int, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return 0, err
+	}
+	cursors := map[string]Cursor{}
+	if data, err := os.ReadFile(filepath.Join(dir, CursorsFile)); err == nil {
+		json.Unmarshal(data, &cursors) //nolint:errcheck
+	}
+
+	existing, err := Load(dir)
+	if err != nil {
+		return 0, err
+	}
+	seen := make(map[string]bool, len(existing))
+	for _, e := range existing {
+		if e.ID != "" {
+			seen[e.ID] = true
+		}
+	}
+
+	var fresh []Event
+	for _, e := range Scan(sources, names, cursors) {
+		if e.ID != "" {
+			if seen[e.ID] {
+				continue
+			}
+			seen[e.ID] = true
+		}
+		fresh = append(fresh, e)
+	}
+
+	if len(fresh) > 0 {
+		f, err := os.OpenFile(filepath.Join(dir, EventsFile), os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+		if err != nil {
+			return 0, err
+		}
+		enc := json.NewEncoder(f)
+		for _, e := range fresh {
+			if err := enc.Encode(e); err != nil {
+				f.Close()
+				return 0, err
+			}
+		}
+		if err := f.Close(); err != nil {
+			return 0, err
+		}
+	}
+
+	data, err := json.MarshalIndent(cursors, "", "  ")
+	if err != nil {
+		return 0, err
+	}
+	if err := config.WriteFileAtomic(filepath.Join(dir, CursorsFile), data, 0644); err != nil {
+		return 0, err
+	}
+	return len(fresh), nil
+}
+
+// Reset deletes the store.
+func Reset(dir string) error {
+	return os.RemoveAll(dir)
+}
+
+// Summarize aggregates events at or after since (zero for all) per skill,
+// most used first.
+func Summarize(events []Event, since time.Time) []SkillUsage {
+	bySkill := map[string]*SkillUsage{}
+	for _, e := range events {
+		if e.Time.Before(since) {
+			continue
+		}
+		u := bySkill[e.Skill]
+		if u == nil {
+			u = &SkillUsage{Skill: e.Skill, Targets: map[string]int{}, Days: map[string]int{}}
+			bySkill[e.Skill] = u
+		}
+		u.Total++
+		u.Targets[e.Target]++
+		u.Days[e.Time.Local().Format(DayFormat)]++
+		if e.Time.After(u.LastUsed) {
+			u.LastUsed = e.Time
+		}
+	}
+
+	out := make([]SkillUsage, 0, len(bySkill))
+	for _, u := range bySkill {
+		out = append(out, *u)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Total != out[j].Total {
+			return out[i].Total > out[j].Total
+		}
+		return out[i].Skill < out[j].Skill
+	})
+	return out
+}
+
+// Unused returns the skills with no usage in the summary, sorted.
+func Unused(skills []sync.DiscoveredSkill, summary []SkillUsage) []string {
+	used := make(map[string]bool, len(summary))
+	for _, u := range summary {
+		used[u.Skill] = true
+	}
+	var out []string
+	for _, s := range skills {
+		if !used[s.FlatName] {
+			out = append(out, s.FlatName)
+		}
+	}
+	sort.Strings(out)
+	return out
+}
+
+// Since returns the start of the window covering the last days days, or
+// the zero time when days is 0.
+func Since(days int) time.Time {
+	if days <= 0 {
+		return time.Time{}
+	}
+	now := time.Now()
+	start := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	return start.AddDate(0, 0, -(days - 1))
+}
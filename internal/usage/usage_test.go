@@ -0,0 +1,107 @@
+package usage
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+const (
+	claudeSkillLine = `{"type":"assistant","uuid":"u1","sessionId":"s1","timestamp":"2026-03-01T10:00:00Z","message":{"content":[{"type":"tool_use","name":"Skill","input":{"skill":"pdf-tools"}}]}}`
+	claudeReadLine  = `{"type":"assistant","uuid":"u2","sessionId":"s1","timestamp":"2026-03-02T10:00:00Z","message":{"content":[{"type":"tool_use","name":"Read","input":{"file_path":"/home/me/.claude/skills/docx/SKILL.md"}}]}}`
+	toolResultLine  = `{"type":"user","uuid":"u3","timestamp":"2026-03-02T10:01:00Z","message":{"content":[{"type":"tool_result","content":"skills/docx/SKILL.md skills/pdf/SKILL.md"}]}}`
+	codexLine       = `{"timestamp":"2026-03-03T09:00:00Z","type":"response_item","payload":{"type":"function_call","name":"shell","arguments":"{\"command\":[\"bash\",\"-lc\",\"cat ~/.codex/skills/docx/SKILL.md\"]}"}}`
+)
+
+var testNames = Names{"pdf": "pdf", "pdf-tools": "pdf", "docx": "docx"}
+
+func writeLines(t *testing.T, path string, lines ...string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	for _, l := range lines {
+		f.WriteString(l + "\n")
+	}
+}
+
+func TestDetectLine(t *testing.T) {
+	tests := []struct {
+		name string
+		line string
+		want string
+	}{
+		{"skill tool by frontmatter name", claudeSkillLine, "pdf"},
+		{"read SKILL.md", claudeReadLine, "docx"},
+		{"tool result is not an invocation", toolResultLine, ""},
+		{"codex shell call", codexLine, "docx"},
+		{"plain text path", "loaded skills/pdf/SKILL.md", "pdf"},
+		{"unknown skill", `{"type":"tool_use","name":"Skill","input":{"skill":"other"}}`, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, e := range detectLine([]byte(tt.line), "claude", "file", time.Now(), testNames) {
+				got = append(got, e.Skill)
+			}
+			if strings.Join(got, ",") != tt.want {
+				t.Errorf("detectLine() = %v, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUpdate_IncrementalAndDeduped(t *testing.T) {
+	root := t.TempDir()
+	store := filepath.Join(root, "usage")
+	transcript := filepath.Join(root, "projects", "app", "s1.jsonl")
+	writeLines(t, transcript, claudeSkillLine, toolResultLine)
+	sources := []Source{{Target: "claude", Patterns: []string{filepath.Join(root, "projects", "**", "*.jsonl")}}}
+
+	if n, err := Update(store, sources, testNames); err != nil || n != 1 {
+		t.Fatalf("first Update() = %d, %v; want 1", n, err)
+	}
+	if n, _ := Update(store, sources, testNames); n != 0 {
+		t.Errorf("rescan added %d events, want 0", n)
+	}
+
+	// New lines are picked up; a resumed session repeating u1 is not.
+	writeLines(t, transcript, claudeReadLine)
+	writeLines(t, filepath.Join(root, "projects", "app", "s2.jsonl"), claudeSkillLine)
+	if n, _ := Update(store, sources, testNames); n != 1 {
+		t.Errorf("incremental Update() = %d, want 1", n)
+	}
+
+	events, err := Load(store)
+	if err != nil {
+		t.Fatal(err)
+	}
+	summary := Summarize(events, time.Time{})
+	if len(summary) != 2 || summary[0].Total != 1 || summary[0].Targets["claude"] != 1 {
+		t.Fatalf("Summarize() = %+v", summary)
+	}
+	if got := Summarize(events, time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)); len(got) != 1 || got[0].Skill != "docx" {
+		t.Errorf("Summarize(since) = %+v", got)
+	}
+}
+
+func TestExpandPattern(t *testing.T) {
+	root := t.TempDir()
+	writeLines(t, filepath.Join(root, "a", "b", "one.jsonl"), "x")
+	writeLines(t, filepath.Join(root, "two.jsonl"), "x")
+	writeLines(t, filepath.Join(root, "a", "notes.txt"), "x")
+
+	if got := ExpandPattern(filepath.Join(root, "**", "*.jsonl")); len(got) != 2 {
+		t.Errorf("recursive pattern matched %v", got)
+	}
+	if got := ExpandPattern(filepath.Join(root, "*.jsonl")); len(got) != 1 {
+		t.Errorf("flat pattern matched %v", got)
+	}
+}
@@ -2,6 +2,7 @@ package utils
 
 import (
 	"bufio"
+	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
@@ -130,6 +131,46 @@ func extractFrontmatterRaw(filePath string) string {
 	return strings.Join(lines, "\n")
 }
 
+// ParseFrontmatterFields returns the top-level frontmatter fields of a
+// SKILL.md file as strings. Lists are joined with commas; nested maps are
+// skipped. Returns nil when there is no frontmatter.
+func ParseFrontmatterFields(filePath string) map[string]string {
+	raw := extractFrontmatterRaw(filePath)
+	if raw == "" {
+		return nil
+	}
+	var parsed map[string]any
+	if err := yaml.Unmarshal([]byte(raw), &parsed); err != nil {
+		return nil
+	}
+
+	fields := make(map[string]string, len(parsed))
+	for key, val := range parsed {
+		switch v := val.(type) {
+		case nil, map[string]any:
+		case []any:
+			items := make([]string, 0, len(v))
+			for _, item := range v {
+				items = append(items, strings.TrimSpace(fmt.Sprint(item)))
+			}
+			fields[key] = strings.Join(items, ",")
+		default:
+			fields[key] = strings.TrimSpace(fmt.Sprint(v))
+		}
+	}
+	return fields
+}
+
+// UnmarshalFrontmatter decodes the frontmatter of a SKILL.md file into v.
+// A file without frontmatter leaves v unchanged.
+func UnmarshalFrontmatter(filePath string, v any) error {
+	raw := extractFrontmatterRaw(filePath)
+	if raw == "" {
+		return nil
+	}
+	return yaml.Unmarshal([]byte(raw), v)
+}
+
 // ParseFrontmatterField reads a SKILL.md file and extracts the value of a given frontmatter field.
 // It supports both inline values and YAML block scalars (>, >-, |, |-).
 func ParseFrontmatterField(filePath, field string) string {
@@ -184,3 +225,24 @@ func ParseFrontmatterField(filePath, field string) string {
 
 	return ""
 }
+
+// SetFrontmatterName returns content with its frontmatter name set to
+// name, adding the field (or a frontmatter block) when missing.
+func SetFrontmatterName(content, name string) string {
+	lines := strings.Split(content, "\n")
+	if len(lines) == 0 || strings.TrimSpace(lines[0]) != "---" {
+		return "---\nname: " + name + "\n---\n\n" + content
+	}
+	for i := 1; i < len(lines); i++ {
+		trimmed := strings.TrimSpace(lines[i])
+		if trimmed == "---" {
+			break
+		}
+		if strings.HasPrefix(lines[i], "name:") {
+			lines[i] = "name: " + name
+			return strings.Join(lines, "\n")
+		}
+	}
+	lines = append(lines[:1], append([]string{"name: " + name}, lines[1:]...)...)
+	return strings.Join(lines, "\n")
+}
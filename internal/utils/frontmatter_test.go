@@ -144,3 +144,43 @@ func TestParseFrontmatterField_FileNotExist(t *testing.T) {
 		t.Errorf("expected empty string for non-existent file, got %q", got)
 	}
 }
+
+func TestParseFrontmatterFields(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "SKILL.md")
+	content := "---\nname: pdf\ndescription: PDF tools\ntargets:\n  - claude\n  - cursor\nversion: 1.2\nmetadata:\n  a: b\n---\n# Body\n"
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	got := ParseFrontmatterFields(path)
+	want := map[string]string{
+		"name":        "pdf",
+		"description": "PDF tools",
+		"targets":     "claude,cursor",
+		"version":     "1.2",
+	}
+	if len(got) != len(want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+	for k, v := range want {
+		if got[k] != v {
+			t.Errorf("%s = %q, want %q", k, got[k], v)
+		}
+	}
+
+	if got := ParseFrontmatterFields("/nonexistent/path/SKILL.md"); got != nil {
+		t.Errorf("expected nil for non-existent file, got %v", got)
+	}
+}
+
+func TestSetFrontmatterName(t *testing.T) {
+	tests := []struct{ in, want string }{
+		{"---\nname: a\n---\nbody", "---\nname: b\n---\nbody"},
+		{"---\ndescription: d\n---\n", "---\nname: b\ndescription: d\n---\n"},
+		{"# No frontmatter\n", "---\nname: b\n---\n\n# No frontmatter\n"},
+	}
+	for _, tt := range tests {
+		if got := SetFrontmatterName(tt.in, "b"); got != tt.want {
+			t.Errorf("SetFrontmatterName(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
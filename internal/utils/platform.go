@@ -0,0 +1,65 @@
+package utils
+
+import (
+	"net/url"
+	"strings"
+)
+
+// Platform represents a git hosting platform.
+type Platform int
+
+const (
+	PlatformUnknown   Platform = iota
+	PlatformGitHub             // github.com and GitHub Enterprise
+	PlatformGitLab             // gitlab.com and self-hosted GitLab
+	PlatformBitbucket          // bitbucket.org
+	PlatformGitea              // codeberg.org and self-hosted Gitea/Forgejo
+	PlatformAzure              // dev.azure.com and Azure DevOps Server
+)
+
+// URLHost returns the hostname from a clone URL.
+// Supports HTTPS (https://host/...) and SSH (git@host:...) formats.
+func URLHost(cloneURL string) string {
+	s := strings.TrimSpace(cloneURL)
+	if s == "" {
+		return ""
+	}
+
+	// SSH: git@host:owner/repo.git
+	if strings.Contains(s, "@") && strings.Contains(s, ":") && !strings.Contains(s, "://") {
+		at := strings.Index(s, "@")
+		colon := strings.Index(s[at:], ":")
+		if colon > 0 {
+			return s[at+1 : at+colon]
+		}
+	}
+
+	// HTTPS or file://
+	u, err := url.Parse(s)
+	if err != nil || u.Host == "" {
+		return ""
+	}
+	return u.Hostname()
+}
+
+// DetectPlatform identifies the git hosting platform from the hostname of
+// a clone URL. Use credential.DetectPlatform to also honor a platform set
+// for the host in config.yaml.
+func DetectPlatform(cloneURL string) Platform {
+	host := strings.ToLower(URLHost(cloneURL))
+	switch {
+	case host == "":
+		return PlatformUnknown
+	case strings.Contains(host, "github"):
+		return PlatformGitHub
+	case strings.Contains(host, "gitlab"):
+		return PlatformGitLab
+	case strings.Contains(host, "bitbucket"):
+		return PlatformBitbucket
+	case strings.Contains(host, "gitea") || strings.Contains(host, "forgejo") || host == "codeberg.org":
+		return PlatformGitea
+	case host == "dev.azure.com" || strings.HasSuffix(host, ".visualstudio.com"):
+		return PlatformAzure
+	}
+	return PlatformUnknown
+}
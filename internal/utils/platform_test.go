@@ -0,0 +1,58 @@
+package utils
+
+import "testing"
+
+func TestURLHost(t *testing.T) {
+	tests := []struct {
+		name string
+		url  string
+		want string
+	}{
+		{"https github", "https://github.com/org/repo.git", "github.com"},
+		{"https gitlab", "https://gitlab.com/org/repo.git", "gitlab.com"},
+		{"https bitbucket", "https://bitbucket.org/team/repo.git", "bitbucket.org"},
+		{"https with port", "https://git.example.com:8443/org/repo.git", "git.example.com"},
+		{"ssh github", "git@github.com:org/repo.git", "github.com"},
+		{"ssh gitlab", "git@gitlab.com:org/repo.git", "gitlab.com"},
+		{"ssh bitbucket", "git@bitbucket.org:team/repo.git", "bitbucket.org"},
+		{"file url", "file:///path/to/repo", ""},
+		{"empty", "", ""},
+		{"bare path", "/some/local/repo", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := URLHost(tt.url)
+			if got != tt.want {
+				t.Errorf("URLHost(%q) = %q, want %q", tt.url, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDetectPlatform(t *testing.T) {
+	tests := []struct {
+		name string
+		url  string
+		want Platform
+	}{
+		{"github.com", "https://github.com/org/repo.git", PlatformGitHub},
+		{"github enterprise", "https://github.mycompany.com/org/repo.git", PlatformGitHub},
+		{"gitlab.com", "https://gitlab.com/org/repo.git", PlatformGitLab},
+		{"self-hosted gitlab", "https://gitlab.internal.co/org/repo.git", PlatformGitLab},
+		{"bitbucket.org", "https://bitbucket.org/team/repo.git", PlatformBitbucket},
+		{"ssh github", "git@github.com:org/repo.git", PlatformGitHub},
+		{"codeberg", "https://codeberg.org/org/repo.git", PlatformGitea},
+		{"self-hosted gitea", "git@gitea.internal.co:org/repo.git", PlatformGitea},
+		{"azure devops", "https://dev.azure.com/org/project/_git/skills", PlatformAzure},
+		{"unknown host", "https://git.example.com/repo.git", PlatformUnknown},
+		{"empty", "", PlatformUnknown},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := DetectPlatform(tt.url)
+			if got != tt.want {
+				t.Errorf("DetectPlatform(%q) = %v, want %v", tt.url, got, tt.want)
+			}
+		})
+	}
+}
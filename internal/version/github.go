@@ -8,6 +8,8 @@ import (
 	"runtime"
 	"strings"
 	"time"
+
+	"skillshare/internal/network"
 )
 
 // RateLimitError indicates GitHub API rate limit was exceeded
@@ -76,7 +78,7 @@ func BuildChecksumsURL(ver string) string {
 
 // newGitHubClient creates an HTTP client with optional auth
 func newGitHubClient() *http.Client {
-	return &http.Client{Timeout: 10 * time.Second}
+	return network.NewClient(10 * time.Second)
 }
 
 // newGitHubRequest creates a request with auth header if token is available
@@ -7,6 +7,7 @@ import (
 	"strings"
 	"time"
 
+	"skillshare/internal/network"
 	"skillshare/internal/utils"
 )
 
@@ -21,7 +22,7 @@ func ReadLocalSkillVersion(sourceDir string) string {
 
 // FetchRemoteSkillVersion fetches the latest skill version from GitHub (3s timeout).
 func FetchRemoteSkillVersion() string {
-	client := &http.Client{Timeout: 3 * time.Second}
+	client := network.NewClient(3 * time.Second)
 	resp, err := client.Get(SkillSourceURL)
 	if err != nil {
 		return ""
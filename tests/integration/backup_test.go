@@ -3,8 +3,10 @@
 package integration
 
 import (
+	"encoding/json"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
@@ -47,19 +49,69 @@ targets:
 	backupResult := sb.RunCLI("backup")
 	backupResult.AssertSuccess(t)
 
-	// Verify backup contains local skill but not the symlinked one
+	// Verify the snapshot lists the local skill but not the symlinked one
 	backupDir := filepath.Join(sb.Home, ".local", "share", "skillshare", "backups")
-	entries, err := os.ReadDir(backupDir)
-	if err != nil || len(entries) == 0 {
-		t.Fatal("backup directory should contain a timestamp directory")
+	manifests, _ := filepath.Glob(filepath.Join(backupDir, "*", "claude.json"))
+	if len(manifests) != 1 {
+		t.Fatalf("expected one claude snapshot manifest, got %d", len(manifests))
 	}
 
-	backupPath := filepath.Join(backupDir, entries[0].Name(), "claude")
-	if _, err := os.Stat(filepath.Join(backupPath, "my-local", "SKILL.md")); err != nil {
+	var snap struct {
+		Files []struct {
+			Path string `json:"path"`
+		} `json:"files"`
+	}
+	if err := json.Unmarshal([]byte(sb.ReadFile(manifests[0])), &snap); err != nil {
+		t.Fatalf("invalid manifest: %v", err)
+	}
+	paths := map[string]bool{}
+	for _, f := range snap.Files {
+		paths[f.Path] = true
+	}
+	if !paths["my-local/SKILL.md"] {
 		t.Error("local skill should be in backup")
 	}
-	if _, err := os.Lstat(filepath.Join(backupPath, "agent-browser")); !os.IsNotExist(err) {
-		t.Error("symlinked skill should NOT be in backup")
+	for p := range paths {
+		if strings.HasPrefix(p, "agent-browser/") {
+			t.Error("symlinked skill should NOT be in backup")
+		}
+	}
+}
+
+func TestBackup_DeduplicatesUnchangedFiles(t *testing.T) {
+	sb := testutil.NewSandbox(t)
+	defer sb.Cleanup()
+
+	targetPath := sb.CreateTarget("claude")
+	os.MkdirAll(filepath.Join(targetPath, "local-skill"), 0755)
+	os.WriteFile(filepath.Join(targetPath, "local-skill", "SKILL.md"), []byte("# Local"), 0644)
+
+	sb.WriteConfig(`source: ` + sb.SourcePath + `
+targets:
+  claude:
+    path: ` + targetPath + `
+`)
+
+	sb.RunCLI("backup").AssertSuccess(t)
+	time.Sleep(1100 * time.Millisecond) // next timestamp directory
+	sb.RunCLI("backup").AssertSuccess(t)
+
+	objects := 0
+	objectsDir := filepath.Join(sb.Home, ".local", "share", "skillshare", "backups", ".objects")
+	filepath.Walk(objectsDir, func(_ string, info os.FileInfo, err error) error {
+		if err == nil && !info.IsDir() {
+			objects++
+		}
+		return nil
+	})
+	if objects != 1 {
+		t.Errorf("identical file should be stored once, got %d objects", objects)
+	}
+
+	result := sb.RunCLI("backup", "--list")
+	result.AssertSuccess(t)
+	if strings.Count(result.Stdout, "claude") < 2 {
+		t.Errorf("expected two backups in list:\n%s", result.Stdout)
 	}
 }
 
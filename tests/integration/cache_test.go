@@ -0,0 +1,88 @@
+//go:build !online
+
+package integration
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"skillshare/internal/testutil"
+)
+
+// setupCacheRemote creates a bare repository holding skills a and b.
+func setupCacheRemote(t *testing.T, sb *testutil.Sandbox) string {
+	t.Helper()
+	work := filepath.Join(sb.Root, "mono-work")
+	gitInit(t, work, false)
+	for _, name := range []string{"a", "b"} {
+		dir := filepath.Join(work, "skills", name)
+		os.MkdirAll(dir, 0755)
+		os.WriteFile(filepath.Join(dir, "SKILL.md"), []byte("---\nname: "+name+"\ndescription: Skill "+name+"\n---\n"), 0644)
+	}
+	gitAddCommit(t, work, "init")
+	remote := filepath.Join(sb.Root, "mono.git")
+	run(t, "", "git", "clone", "--quiet", "--bare", work, remote)
+	return remote
+}
+
+func TestCache_InstallsShareCloneAndWorkOffline(t *testing.T) {
+	sb := testutil.NewSandbox(t)
+	defer sb.Cleanup()
+	sb.WriteConfig(`source: ` + sb.SourcePath + `
+targets: {}
+`)
+	remote := setupCacheRemote(t, sb)
+
+	sb.RunCLI("install", "file://"+remote, "--skill", "a").AssertSuccess(t)
+
+	list := sb.RunCLI("cache", "--json")
+	list.AssertSuccess(t)
+	var out struct {
+		Repos []struct {
+			URL string `json:"url"`
+		} `json:"repos"`
+	}
+	if err := json.Unmarshal([]byte(list.Stdout), &out); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, list.Stdout)
+	}
+	if len(out.Repos) != 1 || out.Repos[0].URL != "file://"+remote {
+		t.Fatalf("cache repos = %+v, want the one remote", out.Repos)
+	}
+
+	// With the remote gone, the next install comes from the cached copy.
+	if err := os.Rename(remote, remote+".away"); err != nil {
+		t.Fatal(err)
+	}
+	offline := sb.RunCLI("install", "file://"+remote, "--skill", "b")
+	offline.AssertSuccess(t)
+	offline.AssertAnyOutputContains(t, "using cached copy")
+	if !sb.FileExists(filepath.Join(sb.SourcePath, "b", "SKILL.md")) {
+		t.Error("skill b not installed from cache")
+	}
+}
+
+func TestCache_Prune(t *testing.T) {
+	sb := testutil.NewSandbox(t)
+	defer sb.Cleanup()
+	sb.WriteConfig(`source: ` + sb.SourcePath + `
+targets: {}
+`)
+	remote := setupCacheRemote(t, sb)
+	sb.RunCLI("install", "file://"+remote, "--skill", "a").AssertSuccess(t)
+
+	within := sb.RunCLI("cache", "prune")
+	within.AssertSuccess(t)
+	within.AssertOutputContains(t, "within its limit")
+
+	dry := sb.RunCLI("cache", "prune", "--all", "--dry-run")
+	dry.AssertSuccess(t)
+	dry.AssertOutputContains(t, "Would remove 1 repo(s)")
+
+	all := sb.RunCLI("cache", "prune", "--all")
+	all.AssertSuccess(t)
+	all.AssertOutputContains(t, "Removed 1 repo(s)")
+
+	sb.RunCLI("cache").AssertOutputContains(t, "none")
+}
@@ -373,6 +373,62 @@ func TestCheckProject_MultiNames(t *testing.T) {
 	result.AssertOutputNotContains(t, "beta")
 }
 
+func TestCheck_Jobs_SameResultsInOrder(t *testing.T) {
+	sb := testutil.NewSandbox(t)
+	defer sb.Cleanup()
+	setupGlobalConfig(sb)
+
+	// One remote shared by several skills, as with a monorepo install.
+	remote := filepath.Join(sb.Root, "mono.git")
+	gitInit(t, remote, true)
+	work := filepath.Join(sb.Root, "mono-work")
+	gitClone(t, remote, work)
+	os.WriteFile(filepath.Join(work, "README.md"), []byte("mono"), 0644)
+	gitAddCommit(t, work, "init")
+	gitPush(t, work)
+
+	for _, name := range []string{"delta", "alpha", "charlie", "bravo", "echo"} {
+		dir := sb.CreateSkill(name, map[string]string{"SKILL.md": "# " + name})
+		meta := `{"source":"` + remote + `","type":"git","repo_url":"` + remote + `","version":"0000000"}`
+		os.WriteFile(filepath.Join(dir, ".skillshare-meta.json"), []byte(meta), 0644)
+	}
+
+	serial := sb.RunCLI("check", "--json", "--jobs", "1")
+	serial.AssertSuccess(t)
+	concurrent := sb.RunCLI("check", "--json", "-j", "4")
+	concurrent.AssertSuccess(t)
+
+	if serial.Stdout != concurrent.Stdout {
+		t.Errorf("--jobs 1 and --jobs 4 differ:\n%s\n---\n%s", serial.Stdout, concurrent.Stdout)
+	}
+	var output checkOutput
+	if err := json.Unmarshal([]byte(concurrent.Stdout), &output); err != nil {
+		t.Fatalf("failed to parse JSON: %v\noutput: %s", err, concurrent.Stdout)
+	}
+	if len(output.Skills) != 5 {
+		t.Fatalf("expected 5 skills, got %d", len(output.Skills))
+	}
+	for i, want := range []string{"alpha", "bravo", "charlie", "delta", "echo"} {
+		if output.Skills[i].Name != want || output.Skills[i].Status != "update_available" {
+			t.Errorf("skills[%d] = %+v, want %s update_available", i, output.Skills[i], want)
+		}
+	}
+}
+
+func TestCheck_Jobs_Invalid(t *testing.T) {
+	sb := testutil.NewSandbox(t)
+	defer sb.Cleanup()
+	setupGlobalConfig(sb)
+
+	result := sb.RunCLI("check", "--jobs=0")
+	result.AssertFailure(t)
+	result.AssertAnyOutputContains(t, "--jobs must be a positive number")
+
+	result = sb.RunCLI("update", "--all", "--jobs")
+	result.AssertFailure(t)
+	result.AssertAnyOutputContains(t, "--jobs requires a value")
+}
+
 // checkOutput mirrors the JSON structure for test parsing
 type checkOutput struct {
 	TrackedRepos []struct {
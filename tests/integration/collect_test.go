@@ -5,6 +5,7 @@ package integration
 import (
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"skillshare/internal/testutil"
@@ -210,3 +211,92 @@ targets:
 	result.AssertSuccess(t)
 	result.AssertOutputContains(t, "Specify a target")
 }
+
+// setupDivergedSkill syncs a skill, then replaces the target link with an
+// edited local copy and edits the source differently.
+func setupDivergedSkill(t *testing.T, sb *testutil.Sandbox, sourceEdit, localEdit string) string {
+	t.Helper()
+	base := "# Title\n\nintro\n\nbody\n"
+	sb.CreateSkill("shared", map[string]string{"SKILL.md": base})
+	targetPath := sb.CreateTarget("claude")
+	sb.WriteConfig(`source: ` + sb.SourcePath + `
+targets:
+  claude:
+    path: ` + targetPath + `
+`)
+	sb.RunCLI("sync").AssertSuccess(t)
+
+	local := filepath.Join(targetPath, "shared")
+	os.Remove(local)
+	sb.WriteFile(filepath.Join(local, "SKILL.md"), localEdit)
+	sb.WriteFile(filepath.Join(sb.SourcePath, "shared", "SKILL.md"), sourceEdit)
+	return targetPath
+}
+
+func TestCollect_Merge_CleanMergeRelinks(t *testing.T) {
+	sb := testutil.NewSandbox(t)
+	defer sb.Cleanup()
+
+	targetPath := setupDivergedSkill(t, sb,
+		"# Title v2\n\nintro\n\nbody\n",
+		"# Title\n\nintro\n\nbody\nlocal tip\n")
+
+	result := sb.RunCLIWithInput("y\n", "collect", "--merge")
+	result.AssertSuccess(t)
+	result.AssertOutputContains(t, "merged into source")
+
+	got := sb.ReadFile(filepath.Join(sb.SourcePath, "shared", "SKILL.md"))
+	if got != "# Title v2\n\nintro\n\nbody\nlocal tip\n" {
+		t.Errorf("merged SKILL.md = %q", got)
+	}
+	if !sb.IsSymlink(filepath.Join(targetPath, "shared")) {
+		t.Error("local copy should be replaced by a managed symlink")
+	}
+}
+
+func TestCollect_Merge_ConflictSkips(t *testing.T) {
+	sb := testutil.NewSandbox(t)
+	defer sb.Cleanup()
+
+	targetPath := setupDivergedSkill(t, sb,
+		"# Source title\n\nintro\n\nbody\n",
+		"# Local title\n\nintro\n\nbody\n")
+
+	result := sb.RunCLIWithInput("y\n", "collect", "--merge")
+	result.AssertSuccess(t)
+	result.AssertOutputContains(t, "conflict(s) in the merge, skipped")
+
+	if got := sb.ReadFile(filepath.Join(sb.SourcePath, "shared", "SKILL.md")); got != "# Source title\n\nintro\n\nbody\n" {
+		t.Errorf("source should be unchanged, got %q", got)
+	}
+	if sb.IsSymlink(filepath.Join(targetPath, "shared")) {
+		t.Error("skipped local copy should be left in place")
+	}
+
+	result = sb.RunCLI("collect", "--merge", "--force")
+	result.AssertSuccess(t)
+	result.AssertOutputContains(t, "merged with 1 conflict(s)")
+	got := sb.ReadFile(filepath.Join(sb.SourcePath, "shared", "SKILL.md"))
+	if !strings.Contains(got, "<<<<<<< source\n# Source title\n=======\n# Local title\n>>>>>>> claude\n") {
+		t.Errorf("expected conflict markers, got %q", got)
+	}
+}
+
+func TestCollect_Force_TakesLocal(t *testing.T) {
+	sb := testutil.NewSandbox(t)
+	defer sb.Cleanup()
+
+	targetPath := setupDivergedSkill(t, sb,
+		"# Source title\n\nintro\n\nbody\n",
+		"# Local title\n\nintro\n\nbody\n")
+
+	result := sb.RunCLI("collect", "--force")
+	result.AssertSuccess(t)
+
+	if got := sb.ReadFile(filepath.Join(sb.SourcePath, "shared", "SKILL.md")); got != "# Local title\n\nintro\n\nbody\n" {
+		t.Errorf("source should take the local version, got %q", got)
+	}
+	if !sb.IsSymlink(filepath.Join(targetPath, "shared")) {
+		t.Error("local copy should be replaced by a managed symlink")
+	}
+}
@@ -0,0 +1,75 @@
+//go:build !online
+
+package integration
+
+import (
+	"testing"
+
+	"skillshare/internal/testutil"
+)
+
+func TestDaemon_NoTasksConfigured(t *testing.T) {
+	sb := testutil.NewSandbox(t)
+	defer sb.Cleanup()
+
+	sb.WriteConfig(`source: ` + sb.SourcePath + `
+targets: {}
+`)
+
+	result := sb.RunCLI("daemon", "once")
+	result.AssertFailure(t)
+	result.AssertAnyOutputContains(t, "no daemon tasks configured")
+}
+
+func TestDaemon_OnceRunsTasksAndRecordsStatus(t *testing.T) {
+	sb := testutil.NewSandbox(t)
+	defer sb.Cleanup()
+
+	sb.WriteConfig(`source: ` + sb.SourcePath + `
+targets: {}
+daemon:
+  cleanup: 1d
+`)
+
+	result := sb.RunCLI("daemon", "once")
+	result.AssertSuccess(t)
+	result.AssertOutputContains(t, "cleanup")
+
+	status := sb.RunCLI("daemon", "status")
+	status.AssertSuccess(t)
+	status.AssertOutputContains(t, "Not running")
+	status.AssertOutputContains(t, "cleanup")
+	status.AssertOutputContains(t, "every 1d")
+
+	log := sb.RunCLI("log", "--cmd", "daemon")
+	log.AssertSuccess(t)
+	log.AssertOutputContains(t, "daemon")
+}
+
+func TestDaemon_InvalidInterval(t *testing.T) {
+	sb := testutil.NewSandbox(t)
+	defer sb.Cleanup()
+
+	sb.WriteConfig(`source: ` + sb.SourcePath + `
+targets: {}
+daemon:
+  sync: 5s
+`)
+
+	result := sb.RunCLI("daemon", "once")
+	result.AssertFailure(t)
+	result.AssertAnyOutputContains(t, "daemon.sync")
+}
+
+func TestDaemon_ProjectModeRejected(t *testing.T) {
+	sb := testutil.NewSandbox(t)
+	defer sb.Cleanup()
+
+	sb.WriteConfig(`source: ` + sb.SourcePath + `
+targets: {}
+`)
+
+	result := sb.RunCLI("daemon", "status", "-p")
+	result.AssertFailure(t)
+	result.AssertAnyOutputContains(t, "global mode only")
+}
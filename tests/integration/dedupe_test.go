@@ -0,0 +1,119 @@
+//go:build !online
+
+package integration
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"testing"
+
+	"skillshare/internal/testutil"
+)
+
+const dedupeBody = `
+# PDF
+
+Extract text and tables from PDF files using pdfplumber. Prefer pypdf for
+simple page text. For scanned documents run OCR first, then post-process
+the output to fix hyphenation and broken lines.
+`
+
+func setupDedupeSandbox(t *testing.T) *testutil.Sandbox {
+	t.Helper()
+	sb := testutil.NewSandbox(t)
+	sb.WriteConfig(`source: ` + sb.SourcePath + `
+targets: {}
+`)
+	sb.CreateSkill("pdf", map[string]string{
+		"SKILL.md": "---\nname: pdf\ndescription: Work with PDF files.\n---\n" + dedupeBody,
+	})
+	sb.CreateSkill("pdf-copy", map[string]string{
+		"SKILL.md": "---\nname: pdf\ndescription: Work with PDF files.\n---\n" + dedupeBody,
+	})
+	sb.CreateSkill("claude-pdf", map[string]string{
+		"SKILL.md": "---\nname: claude-pdf\ndescription: Work with PDF files.\n---\n" + dedupeBody + "\nAlways cite page numbers.\n",
+	})
+	sb.WriteFile(filepath.Join(sb.SourcePath, "claude-pdf", "references", "ocr.md"), "OCR notes")
+	return sb
+}
+
+func TestDedupe_ListsExactAndSimilar(t *testing.T) {
+	sb := setupDedupeSandbox(t)
+	defer sb.Cleanup()
+
+	result := sb.RunCLI("dedupe")
+	result.AssertSuccess(t)
+	result.AssertOutputContains(t, "exact")
+	result.AssertOutputContains(t, "similar")
+	result.AssertOutputContains(t, "3 duplicate pair(s) found")
+}
+
+func TestDedupe_MergeThenIgnore(t *testing.T) {
+	sb := setupDedupeSandbox(t)
+	defer sb.Cleanup()
+
+	result := sb.RunCLI("dedupe", "merge", "pdf", "claude-pdf")
+	result.AssertSuccess(t)
+	result.AssertOutputContains(t, "Merged references/ocr.md into pdf")
+	result.AssertOutputContains(t, "Kept pdf, moved claude-pdf to trash")
+	if sb.FileExists(filepath.Join(sb.SourcePath, "claude-pdf")) {
+		t.Error("claude-pdf should be trashed")
+	}
+	if !sb.FileExists(filepath.Join(sb.SourcePath, "pdf", "references", "ocr.md")) {
+		t.Error("ocr.md should be merged into pdf")
+	}
+
+	result = sb.RunCLI("dedupe", "ignore", "pdf-copy", "pdf")
+	result.AssertSuccess(t)
+
+	result = sb.RunCLI("dedupe", "--json")
+	result.AssertSuccess(t)
+	var out struct {
+		Pairs []struct{ A, B string } `json:"pairs"`
+	}
+	if err := json.Unmarshal([]byte(result.Stdout), &out); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, result.Stdout)
+	}
+	if len(out.Pairs) != 0 {
+		t.Errorf("pairs = %+v, want none after merge and ignore", out.Pairs)
+	}
+
+	result = sb.RunCLI("dedupe", "--all", "--json")
+	result.AssertSuccess(t)
+	if err := json.Unmarshal([]byte(result.Stdout), &out); err != nil {
+		t.Fatal(err)
+	}
+	if len(out.Pairs) != 1 {
+		t.Errorf("--all pairs = %+v, want the ignored pair", out.Pairs)
+	}
+}
+
+func TestDedupe_KeepAndUndo(t *testing.T) {
+	sb := setupDedupeSandbox(t)
+	defer sb.Cleanup()
+
+	result := sb.RunCLI("dedupe", "keep", "pdf", "pdf-copy")
+	result.AssertSuccess(t)
+	if sb.FileExists(filepath.Join(sb.SourcePath, "pdf-copy")) {
+		t.Fatal("pdf-copy should be trashed")
+	}
+
+	result = sb.RunCLI("undo", "--yes")
+	result.AssertSuccess(t)
+	if !sb.FileExists(filepath.Join(sb.SourcePath, "pdf-copy", "SKILL.md")) {
+		t.Error("undo should restore pdf-copy")
+	}
+}
+
+func TestDedupe_InvalidUsage(t *testing.T) {
+	sb := setupDedupeSandbox(t)
+	defer sb.Cleanup()
+
+	result := sb.RunCLI("dedupe", "keep", "pdf")
+	result.AssertFailure(t)
+	result.AssertAnyOutputContains(t, "requires two skill names")
+
+	result = sb.RunCLI("dedupe", "keep", "pdf", "nope")
+	result.AssertFailure(t)
+	result.AssertAnyOutputContains(t, "skill 'nope' not found")
+}
@@ -0,0 +1,109 @@
+//go:build !online
+
+package integration
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"skillshare/internal/oplog"
+	"skillshare/internal/testutil"
+)
+
+func skipHooksOnWindows(t *testing.T) {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("hook commands use sh")
+	}
+}
+
+// writeLocalSkill creates a skill outside the source directory to install
+// from.
+func writeLocalSkill(t *testing.T, sb *testutil.Sandbox, name, frontmatter string) string {
+	t.Helper()
+	dir := filepath.Join(sb.Root, "incoming", name)
+	os.MkdirAll(dir, 0755)
+	os.WriteFile(filepath.Join(dir, "SKILL.md"),
+		[]byte("---\nname: "+name+"\n"+frontmatter+"---\n# "+name+"\n"), 0644)
+	return dir
+}
+
+func TestHooks_PostInstallUserHook(t *testing.T) {
+	skipHooksOnWindows(t)
+	sb := testutil.NewSandbox(t)
+	defer sb.Cleanup()
+
+	record := filepath.Join(sb.Root, "installed.txt")
+	sb.WriteConfig(`source: ` + sb.SourcePath + `
+targets: {}
+hooks:
+  post-install:
+    - echo "$SKILLSHARE_SKILL_NAME $SKILLSHARE_MODE" >> ` + record + `
+`)
+	sb.CreateSkill("existing", map[string]string{"SKILL.md": "---\nname: existing\n---\n# existing"})
+	src := writeLocalSkill(t, sb, "pdf", "")
+
+	result := sb.RunCLI("install", src)
+	result.AssertSuccess(t)
+
+	got := strings.TrimSpace(sb.ReadFile(record))
+	if got != "pdf global" {
+		t.Errorf("post-install hook ran for %q, want only the new skill", got)
+	}
+
+	ops, _ := os.ReadFile(filepath.Join(oplog.LogDir(sb.ConfigPath), oplog.OpsFile))
+	if !strings.Contains(string(ops), `"cmd":"hook"`) || !strings.Contains(string(ops), "post-install") {
+		t.Errorf("hook run should be in the oplog:\n%s", ops)
+	}
+}
+
+func TestHooks_FailingPreInstallBlocksInstall(t *testing.T) {
+	skipHooksOnWindows(t)
+	sb := testutil.NewSandbox(t)
+	defer sb.Cleanup()
+
+	sb.WriteConfig(`source: ` + sb.SourcePath + `
+targets: {}
+hooks:
+  pre-install: test "$SKILLSHARE_INSTALL_SOURCE" != "` + filepath.Join(sb.Root, "incoming", "blocked") + `"
+`)
+	src := writeLocalSkill(t, sb, "blocked", "")
+
+	result := sb.RunCLI("install", src)
+	result.AssertFailure(t)
+	result.AssertAnyOutputContains(t, "pre-install hook")
+
+	if sb.FileExists(filepath.Join(sb.SourcePath, "blocked")) {
+		t.Error("install should not run after a failing pre-install hook")
+	}
+}
+
+func TestHooks_SkillHookNeedsTrust(t *testing.T) {
+	skipHooksOnWindows(t)
+	sb := testutil.NewSandbox(t)
+	defer sb.Cleanup()
+
+	sb.WriteConfig(`source: ` + sb.SourcePath + "\ntargets: {}\n")
+	src := writeLocalSkill(t, sb, "setup", "hooks:\n  post-install: touch .ready\n")
+	marker := filepath.Join(sb.SourcePath, "setup", ".ready")
+
+	result := sb.RunCLI("install", src)
+	result.AssertSuccess(t)
+	result.AssertAnyOutputContains(t, "not run: untrusted")
+	if sb.FileExists(marker) {
+		t.Fatal("an untrusted skill hook must not run")
+	}
+
+	result = sb.RunCLI("hooks")
+	result.AssertSuccess(t)
+	result.AssertAnyOutputContains(t, "setup (untrusted)")
+
+	sb.RunCLI("hooks", "trust", "setup").AssertSuccess(t)
+	sb.RunCLI("hooks", "run", "setup").AssertSuccess(t)
+	if !sb.FileExists(marker) {
+		t.Fatal("a trusted skill hook should run")
+	}
+}
@@ -0,0 +1,126 @@
+//go:build !online
+
+package integration
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"skillshare/internal/testutil"
+)
+
+// writeSkillsTarball writes a release-style tarball (one wrapper directory)
+// holding the given files, and returns its sha256.
+func writeSkillsTarball(t *testing.T, path string, files map[string]string) string {
+	t.Helper()
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	for name, body := range files {
+		tw.WriteHeader(&tar.Header{Name: "skills-1.0/" + name, Mode: 0644, Size: int64(len(body)), Typeflag: tar.TypeReg})
+		tw.Write([]byte(body))
+	}
+	tw.Close()
+	gz.Close()
+	if err := os.WriteFile(path, buf.Bytes(), 0644); err != nil {
+		t.Fatal(err)
+	}
+	sum := sha256.Sum256(buf.Bytes())
+	return hex.EncodeToString(sum[:])
+}
+
+func TestInstallArchive_CheckAndUpdate(t *testing.T) {
+	sb := testutil.NewSandbox(t)
+	defer sb.Cleanup()
+	sb.WriteConfig(`source: ` + sb.SourcePath + `
+targets: {}
+`)
+	archive := filepath.Join(sb.Root, "skills.tar.gz")
+	writeSkillsTarball(t, archive, map[string]string{
+		"pdf/SKILL.md":  "---\nname: pdf\ndescription: PDF v1\n---\n",
+		"docx/SKILL.md": "---\nname: docx\ndescription: DOCX\n---\n",
+	})
+
+	result := sb.RunCLI("install", "file://"+archive, "--skill", "pdf")
+	result.AssertSuccess(t)
+	result.AssertAnyOutputContains(t, "Downloaded")
+
+	pdf := filepath.Join(sb.SourcePath, "pdf")
+	if !sb.FileExists(filepath.Join(pdf, "SKILL.md")) {
+		t.Fatal("pdf skill not installed")
+	}
+	if sb.FileExists(filepath.Join(sb.SourcePath, "docx")) {
+		t.Error("docx installed, want only --skill pdf")
+	}
+	meta, _ := os.ReadFile(filepath.Join(pdf, ".skillshare-meta.json"))
+	if !strings.Contains(string(meta), `"archive-subdir"`) || !strings.Contains(string(meta), "skills.tar.gz//pdf") {
+		t.Errorf("unexpected metadata:\n%s", meta)
+	}
+
+	checkStatus := func() string {
+		t.Helper()
+		out := sb.RunCLI("check", "--json")
+		out.AssertSuccess(t)
+		var parsed struct {
+			Skills []struct {
+				Name, Status string
+			} `json:"skills"`
+		}
+		if err := json.Unmarshal([]byte(out.Stdout), &parsed); err != nil {
+			t.Fatalf("invalid JSON: %v\n%s", err, out.Stdout)
+		}
+		if len(parsed.Skills) != 1 {
+			t.Fatalf("skills = %+v, want pdf", parsed.Skills)
+		}
+		return parsed.Skills[0].Status
+	}
+	if got := checkStatus(); got != "up_to_date" {
+		t.Errorf("status = %q, want up_to_date", got)
+	}
+
+	// A new release replaces the archive in place.
+	writeSkillsTarball(t, archive, map[string]string{
+		"pdf/SKILL.md": "---\nname: pdf\ndescription: PDF v2\n---\n",
+	})
+	if got := checkStatus(); got != "update_available" {
+		t.Errorf("status = %q, want update_available", got)
+	}
+
+	sb.RunCLI("update", "pdf").AssertSuccess(t)
+	content, _ := os.ReadFile(filepath.Join(pdf, "SKILL.md"))
+	if !strings.Contains(string(content), "PDF v2") {
+		t.Errorf("skill not updated:\n%s", content)
+	}
+	if got := checkStatus(); got != "up_to_date" {
+		t.Errorf("status after update = %q, want up_to_date", got)
+	}
+}
+
+func TestInstallArchive_ChecksumMismatch(t *testing.T) {
+	sb := testutil.NewSandbox(t)
+	defer sb.Cleanup()
+	sb.WriteConfig(`source: ` + sb.SourcePath + `
+targets: {}
+`)
+	archive := filepath.Join(sb.Root, "pdf.tar.gz")
+	sum := writeSkillsTarball(t, archive, map[string]string{
+		"SKILL.md": "---\nname: pdf\ndescription: PDF\n---\n",
+	})
+
+	result := sb.RunCLI("install", "file://"+archive+"#sha256="+strings.Repeat("0", 64))
+	result.AssertFailure(t)
+	result.AssertAnyOutputContains(t, "checksum mismatch")
+
+	sb.RunCLI("install", "file://"+archive+"#sha256="+sum).AssertSuccess(t)
+	if !sb.FileExists(filepath.Join(sb.SourcePath, "pdf", "SKILL.md")) {
+		t.Error("pinned archive not installed")
+	}
+}
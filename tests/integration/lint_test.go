@@ -0,0 +1,98 @@
+//go:build !online
+
+package integration
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"skillshare/internal/testutil"
+)
+
+func TestLint_CleanSkills_Pass(t *testing.T) {
+	sb := testutil.NewSandbox(t)
+	defer sb.Cleanup()
+
+	sb.WriteConfig(`source: ` + sb.SourcePath + `
+targets: {}
+`)
+	sb.CreateSkill("pdf", map[string]string{
+		"SKILL.md": "---\nname: pdf\ndescription: Extract text from PDF files. Use when the user asks to read a PDF.\n---\n\n# PDF\n",
+	})
+
+	result := sb.RunCLI("lint")
+	result.AssertSuccess(t)
+	result.AssertOutputContains(t, "1 skills, 1 clean")
+}
+
+func TestLint_Errors_ExitNonZeroAndFix(t *testing.T) {
+	sb := testutil.NewSandbox(t)
+	defer sb.Cleanup()
+
+	sb.WriteConfig(`source: ` + sb.SourcePath + `
+targets: {}
+`)
+	sb.CreateSkill("pdf", map[string]string{
+		"SKILL.md": "---\nname: wrong\ndescription: Extract text from PDF files. Use when the user asks to read a PDF.\n---\n\n# PDF\n",
+	})
+	if err := os.MkdirAll(filepath.Join(sb.SourcePath, "empty-dir"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	result := sb.RunCLI("lint")
+	result.AssertFailure(t)
+	result.AssertOutputContains(t, "name-matches-dir")
+	result.AssertOutputContains(t, "skill-file")
+	result.AssertOutputContains(t, "skillshare lint --fix")
+
+	result = sb.RunCLI("lint", "pdf", "--fix")
+	result.AssertSuccess(t)
+	result.AssertOutputContains(t, "fixed SKILL.md: set name to pdf")
+	if content := sb.ReadFile(filepath.Join(sb.SourcePath, "pdf", "SKILL.md")); !strings.Contains(content, "name: pdf\n") {
+		t.Errorf("SKILL.md not fixed: %q", content)
+	}
+}
+
+func TestLint_JSONAndSARIF(t *testing.T) {
+	sb := testutil.NewSandbox(t)
+	defer sb.Cleanup()
+
+	sb.WriteConfig(`source: ` + sb.SourcePath + `
+targets: {}
+lint:
+  rules:
+    description-trigger: off
+`)
+	sb.CreateSkill("pdf", map[string]string{
+		"SKILL.md": "---\nname: pdf\ndescription: Extract text from PDF files quickly.\ncolour: red\n---\n\n# PDF\n",
+	})
+
+	result := sb.RunCLI("lint", "--json")
+	result.AssertSuccess(t)
+	var out struct {
+		Results []struct {
+			Skill    string `json:"skill"`
+			Findings []struct {
+				Rule string `json:"rule"`
+				Line int    `json:"line"`
+			} `json:"findings"`
+		} `json:"results"`
+		Summary struct {
+			Warnings int `json:"warnings"`
+		} `json:"summary"`
+	}
+	if err := json.Unmarshal([]byte(result.Stdout), &out); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, result.Stdout)
+	}
+	if out.Summary.Warnings != 1 || out.Results[0].Findings[0].Rule != "unknown-key" || out.Results[0].Findings[0].Line != 4 {
+		t.Errorf("unexpected JSON output: %+v", out)
+	}
+
+	result = sb.RunCLI("lint", "--format", "sarif")
+	result.AssertSuccess(t)
+	result.AssertOutputContains(t, `"version": "2.1.0"`)
+	result.AssertOutputContains(t, `"uri": "pdf/SKILL.md"`)
+}
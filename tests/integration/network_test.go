@@ -0,0 +1,75 @@
+//go:build !online
+
+package integration
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"skillshare/internal/testutil"
+)
+
+// TestNetwork_MirrorRewrite installs a GitHub source through a local mirror
+// and checks that the canonical URL is what gets recorded.
+func TestNetwork_MirrorRewrite(t *testing.T) {
+	sb := testutil.NewSandbox(t)
+	defer sb.Cleanup()
+
+	work := filepath.Join(sb.Root, "work")
+	gitInit(t, work, false)
+	os.MkdirAll(filepath.Join(work, "pdf"), 0755)
+	os.WriteFile(filepath.Join(work, "pdf", "SKILL.md"), []byte("---\nname: pdf\ndescription: PDF\n---\n"), 0644)
+	gitAddCommit(t, work, "init")
+	mirror := filepath.Join(sb.Root, "mirror")
+	os.MkdirAll(mirror, 0755)
+	run(t, "", "git", "clone", "--quiet", "--bare", work, filepath.Join(mirror, "skills.git"))
+
+	sb.WriteConfig(`source: ` + sb.SourcePath + `
+targets: {}
+network:
+  rewrites:
+    - from: https://github.com/acme/
+      to: file://` + mirror + `/
+`)
+
+	sb.RunCLI("install", "acme/skills/pdf").AssertSuccess(t)
+	installed := filepath.Join(sb.SourcePath, "pdf")
+	if !sb.FileExists(filepath.Join(installed, "SKILL.md")) {
+		t.Fatal("skill not installed from mirror")
+	}
+	meta, _ := os.ReadFile(filepath.Join(installed, ".skillshare-meta.json"))
+	if !strings.Contains(string(meta), "github.com/acme/skills") || strings.Contains(string(meta), mirror) {
+		t.Errorf("metadata should keep the canonical URL:\n%s", meta)
+	}
+
+	out := sb.RunCLI("check", "--json")
+	out.AssertSuccess(t)
+	var parsed struct {
+		Skills []struct {
+			Status string `json:"status"`
+		} `json:"skills"`
+	}
+	if err := json.Unmarshal([]byte(out.Stdout), &parsed); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, out.Stdout)
+	}
+	if len(parsed.Skills) != 1 || parsed.Skills[0].Status != "up_to_date" {
+		t.Errorf("check through mirror = %+v, want up_to_date", parsed.Skills)
+	}
+}
+
+func TestNetwork_InvalidConfig(t *testing.T) {
+	sb := testutil.NewSandbox(t)
+	defer sb.Cleanup()
+	sb.WriteConfig(`source: ` + sb.SourcePath + `
+targets: {}
+network:
+  rewrites:
+    - from: https://github.com/
+`)
+	result := sb.RunCLI("list")
+	result.AssertFailure(t)
+	result.AssertAnyOutputContains(t, "invalid network")
+}
@@ -148,3 +148,99 @@ targets: {}
 		t.Error("SKILL.md should have Title Case heading")
 	}
 }
+
+func TestNew_Template_FromTemplatesDir(t *testing.T) {
+	sb := testutil.NewSandbox(t)
+	defer sb.Cleanup()
+
+	sb.WriteConfig(`source: ` + sb.SourcePath + `
+targets: {}
+`)
+
+	tplDir := filepath.Join(filepath.Dir(sb.SourcePath), "templates", "python-tool")
+	sb.WriteFile(filepath.Join(tplDir, "SKILL.md"), "---\nname: placeholder\ntargets: {{targets}}\n---\n\n# {{title}}\nAuthor: {{author}}\n")
+	sb.WriteFile(filepath.Join(tplDir, "scripts", "{{name}}.py"), "# {{name}}\n")
+
+	result := sb.RunCLI("new", "pdf-tools", "--template", "python-tool", "--author", "Ada", "--targets", "claude,cursor")
+	result.AssertSuccess(t)
+	result.AssertOutputContains(t, "template python-tool")
+
+	skill := sb.ReadFile(filepath.Join(sb.SourcePath, "pdf-tools", "SKILL.md"))
+	for _, want := range []string{"name: pdf-tools", "targets: [claude, cursor]", "# Pdf Tools", "Author: Ada"} {
+		if !strings.Contains(skill, want) {
+			t.Errorf("SKILL.md missing %q:\n%s", want, skill)
+		}
+	}
+	if got := sb.ReadFile(filepath.Join(sb.SourcePath, "pdf-tools", "scripts", "pdf-tools.py")); got != "# pdf-tools\n" {
+		t.Errorf("script = %q", got)
+	}
+}
+
+func TestNew_Template_FromMarkedSkillAndGitSource(t *testing.T) {
+	sb := testutil.NewSandbox(t)
+	defer sb.Cleanup()
+
+	sb.WriteConfig(`source: ` + sb.SourcePath + `
+targets: {}
+`)
+
+	sb.CreateSkill("team-base", map[string]string{"SKILL.md": "---\nname: team-base\ntemplate: true\n---\n\n# {{title}}\n"})
+
+	result := sb.RunCLI("new", "--list-templates")
+	result.AssertSuccess(t)
+	result.AssertOutputContains(t, "team-base")
+
+	result = sb.RunCLI("new", "from-skill", "-t", "team-base")
+	result.AssertSuccess(t)
+	skill := sb.ReadFile(filepath.Join(sb.SourcePath, "from-skill", "SKILL.md"))
+	if !strings.Contains(skill, "name: from-skill") || !strings.Contains(skill, "# From Skill") || strings.Contains(skill, "template:") {
+		t.Errorf("SKILL.md from marked skill = %q", skill)
+	}
+
+	repo := filepath.Join(sb.Root, "tpl-repo")
+	sb.WriteFile(filepath.Join(repo, "SKILL.md"), "---\nname: x\n---\n\n# {{title}} from git\n")
+	initGitRepo(t, repo)
+
+	result = sb.RunCLI("new", "from-git", "--template", "file://"+repo)
+	result.AssertSuccess(t)
+	skill = sb.ReadFile(filepath.Join(sb.SourcePath, "from-git", "SKILL.md"))
+	if !strings.Contains(skill, "# From Git from git") {
+		t.Errorf("SKILL.md from git template = %q", skill)
+	}
+	if sb.FileExists(filepath.Join(sb.SourcePath, "from-git", ".git")) {
+		t.Error(".git should not be copied from a git template")
+	}
+}
+
+func TestNew_From_ClonesAndRenames(t *testing.T) {
+	sb := testutil.NewSandbox(t)
+	defer sb.Cleanup()
+
+	sb.WriteConfig(`source: ` + sb.SourcePath + `
+targets: {}
+`)
+
+	sb.CreateSkill("code-review", map[string]string{
+		"SKILL.md": "---\nname: code-review\ndescription: Review code\n---\n\n# Code Review\n",
+	})
+	sb.WriteFile(filepath.Join(sb.SourcePath, "code-review", "references", "a.md"), "ref")
+
+	result := sb.RunCLI("new", "review-v2", "--from", "code-review")
+	result.AssertSuccess(t)
+
+	skill := sb.ReadFile(filepath.Join(sb.SourcePath, "review-v2", "SKILL.md"))
+	if skill != "---\nname: review-v2\ndescription: Review code\n---\n\n# Code Review\n" {
+		t.Errorf("SKILL.md = %q", skill)
+	}
+	if !sb.FileExists(filepath.Join(sb.SourcePath, "review-v2", "references", "a.md")) {
+		t.Error("references should be copied")
+	}
+
+	result = sb.RunCLI("new", "x", "--from", "missing")
+	result.AssertFailure(t)
+	result.AssertAnyOutputContains(t, "not found")
+
+	result = sb.RunCLI("new", "x", "--from", "code-review", "--template", "y")
+	result.AssertFailure(t)
+	result.AssertAnyOutputContains(t, "cannot be used together")
+}
@@ -0,0 +1,118 @@
+//go:build !online
+
+package integration
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+
+	"skillshare/internal/testutil"
+)
+
+func TestOCI_PublishInstallCheck(t *testing.T) {
+	sb := testutil.NewSandbox(t)
+	defer sb.Cleanup()
+	sb.SetEnv("DOCKER_CONFIG", filepath.Join(sb.Root, "docker"))
+	sb.WriteConfig(`source: ` + sb.SourcePath + `
+targets: {}
+`)
+	reg := testutil.NewRegistry(t)
+	repo := "oci://" + reg.Host() + "/team/skills/pdf"
+
+	sb.CreateSkill("pdf", map[string]string{
+		"SKILL.md":     "---\nname: pdf\ndescription: PDF v1\nowner: docs\n---\n",
+		"reference.md": "# Reference\n",
+	})
+
+	dry := sb.RunCLI("publish", "pdf", repo+":1.0.0", "--dry-run")
+	dry.AssertSuccess(t)
+	dry.AssertAnyOutputContains(t, "Would push")
+
+	result := sb.RunCLI("publish", "pdf", repo+":1.0.0")
+	result.AssertSuccess(t)
+	result.AssertAnyOutputContains(t, "Published")
+	digest := regexp.MustCompile(`sha256:[a-f0-9]{64}`).FindString(result.Output())
+	if digest == "" {
+		t.Fatalf("publish output has no digest:\n%s", result.Output())
+	}
+
+	// Install into a second sandbox, as a consumer would.
+	consumer := testutil.NewSandbox(t)
+	defer consumer.Cleanup()
+	consumer.SetEnv("DOCKER_CONFIG", filepath.Join(consumer.Root, "docker"))
+	consumer.WriteConfig(`source: ` + consumer.SourcePath + `
+targets: {}
+`)
+
+	consumer.RunCLI("install", repo+":1.0.0").AssertSuccess(t)
+	installed := filepath.Join(consumer.SourcePath, "pdf")
+	if !consumer.FileExists(filepath.Join(installed, "reference.md")) {
+		t.Fatal("skill files not installed")
+	}
+	meta, _ := os.ReadFile(filepath.Join(installed, ".skillshare-meta.json"))
+	if !strings.Contains(string(meta), `"type": "oci"`) || !strings.Contains(string(meta), digest) {
+		t.Errorf("unexpected metadata:\n%s", meta)
+	}
+
+	type checkOutput struct {
+		Skills []struct {
+			Name      string   `json:"name"`
+			Status    string   `json:"status"`
+			NewerTags []string `json:"newer_tags"`
+		} `json:"skills"`
+	}
+	check := func() checkOutput {
+		t.Helper()
+		out := consumer.RunCLI("check", "--json")
+		out.AssertSuccess(t)
+		var parsed checkOutput
+		if err := json.Unmarshal([]byte(out.Stdout), &parsed); err != nil {
+			t.Fatalf("invalid JSON: %v\n%s", err, out.Stdout)
+		}
+		if len(parsed.Skills) != 1 {
+			t.Fatalf("skills = %+v, want pdf", parsed.Skills)
+		}
+		return parsed
+	}
+	if got := check().Skills[0].Status; got != "up_to_date" {
+		t.Errorf("status = %q, want up_to_date", got)
+	}
+
+	sb.WriteFile(filepath.Join(sb.SourcePath, "pdf", "SKILL.md"), "---\nname: pdf\ndescription: PDF v1.1\n---\n")
+	sb.RunCLI("publish", "pdf", repo+":1.1.0").AssertSuccess(t)
+
+	got := check().Skills[0]
+	if got.Status != "update_available" || len(got.NewerTags) != 1 || got.NewerTags[0] != "1.1.0" {
+		t.Errorf("check = %+v, want update_available with newer tag 1.1.0", got)
+	}
+}
+
+func TestOCI_InstallPinnedDigest(t *testing.T) {
+	sb := testutil.NewSandbox(t)
+	defer sb.Cleanup()
+	sb.SetEnv("DOCKER_CONFIG", filepath.Join(sb.Root, "docker"))
+	sb.WriteConfig(`source: ` + sb.SourcePath + `
+targets: {}
+`)
+	reg := testutil.NewRegistry(t)
+	repo := "oci://" + reg.Host() + "/pdf"
+
+	sb.CreateSkill("pdf-src", map[string]string{
+		"SKILL.md": "---\nname: pdf\ndescription: PDF\n---\n",
+	})
+	result := sb.RunCLI("publish", "pdf-src", repo+":1.0.0")
+	result.AssertSuccess(t)
+	digest := regexp.MustCompile(`sha256:[a-f0-9]{64}`).FindString(result.Output())
+
+	bad := sb.RunCLI("install", repo+"@sha256:"+strings.Repeat("0", 64))
+	bad.AssertFailure(t)
+
+	sb.RunCLI("install", repo+":1.0.0@"+digest).AssertSuccess(t)
+	if !sb.FileExists(filepath.Join(sb.SourcePath, "pdf", "SKILL.md")) {
+		t.Error("pinned skill not installed")
+	}
+}
@@ -0,0 +1,51 @@
+//go:build !online
+
+package integration
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"skillshare/internal/oplock"
+	"skillshare/internal/testutil"
+)
+
+func TestOpLock_BlocksConcurrentMutation(t *testing.T) {
+	sb := testutil.NewSandbox(t)
+	defer sb.Cleanup()
+
+	sb.CreateSkill("locked", map[string]string{"SKILL.md": "# Locked"})
+	targetPath := sb.CreateTarget("claude")
+	sb.WriteConfig(`source: ` + sb.SourcePath + `
+targets:
+  claude:
+    path: ` + targetPath + `
+`)
+	sb.SetEnv("SKILLSHARE_LOCK_TIMEOUT", "300ms")
+
+	// This test process plays the other skillshare process
+	l, err := oplock.Acquire(oplock.Path(sb.ConfigPath), "install", time.Second, nil)
+	if err != nil {
+		t.Fatalf("Acquire() error: %v", err)
+	}
+
+	result := sb.RunCLI("sync")
+	result.AssertFailure(t)
+	result.AssertAnyOutputContains(t, fmt.Sprintf("held by PID %d running `install`", os.Getpid()))
+	if sb.FileExists(filepath.Join(targetPath, "locked")) {
+		t.Error("sync should not run while the lock is held")
+	}
+
+	// Read-only and dry-run commands are not blocked
+	sb.RunCLI("sync", "--dry-run").AssertSuccess(t)
+	sb.RunCLI("status").AssertSuccess(t)
+
+	l.Release()
+	sb.RunCLI("sync").AssertSuccess(t)
+	if !sb.FileExists(filepath.Join(targetPath, "locked")) {
+		t.Error("sync should run after the lock is released")
+	}
+}
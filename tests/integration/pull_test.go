@@ -49,7 +49,7 @@ targets: {}
 	result.AssertOutputContains(t, "No git remote")
 }
 
-func TestPull_UncommittedChanges_Refuses(t *testing.T) {
+func TestPull_UncommittedChanges_AutoStashes(t *testing.T) {
 	sb := testutil.NewSandbox(t)
 	defer sb.Cleanup()
 
@@ -94,7 +94,10 @@ targets: {}
 	result := sb.RunCLI("pull")
 
 	result.AssertSuccess(t)
-	result.AssertOutputContains(t, "Local changes detected")
+	result.AssertOutputContains(t, "Local changes restored")
+	if sb.ReadFile(filepath.Join(sb.SourcePath, "uncommitted-skill", "SKILL.md")) != "# Uncommitted" {
+		t.Error("uncommitted skill should survive the pull")
+	}
 }
 
 func TestPull_DryRun_ShowsActions(t *testing.T) {
@@ -465,3 +468,88 @@ func initLocalRepoWithRemotePull(t *testing.T, sourcePath, remote string) {
 	testutil.RunGit(t, sourcePath, "remote", "add", "origin", remote)
 	testutil.ConfigureGitUser(t, sourcePath)
 }
+
+// setupPullConflict gives the source a local commit and the remote a
+// different commit to the same skill, and returns the target path.
+func setupPullConflict(t *testing.T, sb *testutil.Sandbox) string {
+	t.Helper()
+	targetPath := sb.CreateTarget("claude")
+	sb.WriteConfig(`source: ` + sb.SourcePath + `
+targets:
+  claude:
+    path: ` + targetPath + `
+`)
+
+	bareRepo := testutil.SetupBareRemoteRepo(t, sb.Home)
+	testutil.SeedRemoteBranch(t, sb.Home, bareRepo, "main", map[string]string{
+		"shared/SKILL.md": "# Shared v1\n",
+	})
+	os.RemoveAll(sb.SourcePath)
+	testutil.RunGit(t, "", "clone", bareRepo, sb.SourcePath)
+	testutil.ConfigureGitUser(t, sb.SourcePath)
+
+	other := filepath.Join(sb.Home, "other")
+	testutil.RunGit(t, "", "clone", bareRepo, other)
+	testutil.ConfigureGitUser(t, other)
+	sb.WriteFile(filepath.Join(other, "shared", "SKILL.md"), "# Shared remote\n")
+	testutil.RunGit(t, other, "commit", "-am", "remote edit")
+	testutil.RunGit(t, other, "push", "origin", "HEAD")
+
+	sb.WriteFile(filepath.Join(sb.SourcePath, "shared", "SKILL.md"), "# Shared local\n")
+	testutil.RunGit(t, sb.SourcePath, "commit", "-am", "local edit")
+	return targetPath
+}
+
+func TestPull_Conflict_StopsWithoutSync(t *testing.T) {
+	sb := testutil.NewSandbox(t)
+	defer sb.Cleanup()
+	targetPath := setupPullConflict(t, sb)
+
+	result := sb.RunCLI("pull")
+	result.AssertFailure(t)
+	result.AssertAnyOutputContains(t, "Conflicts in 1 skill(s)")
+	result.AssertAnyOutputContains(t, "shared")
+	if sb.FileExists(filepath.Join(targetPath, "shared")) {
+		t.Error("targets should not be synced while conflicts are open")
+	}
+
+	// A new pull refuses until the old one is finished
+	sb.RunCLI("pull").AssertFailure(t)
+
+	result = sb.RunCLI("pull", "--continue", "--theirs")
+	result.AssertSuccess(t)
+	result.AssertOutputContains(t, "Pull complete")
+	if got := sb.ReadFile(filepath.Join(sb.SourcePath, "shared", "SKILL.md")); got != "# Shared remote\n" {
+		t.Errorf("shared = %q, want remote version", got)
+	}
+	if !sb.FileExists(filepath.Join(targetPath, "shared", "SKILL.md")) {
+		t.Error("skill should be synced after a clean pull")
+	}
+}
+
+func TestPull_Conflict_KeepOurs(t *testing.T) {
+	sb := testutil.NewSandbox(t)
+	defer sb.Cleanup()
+	setupPullConflict(t, sb)
+
+	result := sb.RunCLI("pull", "--merge", "--ours")
+	result.AssertSuccess(t)
+	result.AssertOutputContains(t, "kept local version")
+	if got := sb.ReadFile(filepath.Join(sb.SourcePath, "shared", "SKILL.md")); got != "# Shared local\n" {
+		t.Errorf("shared = %q, want local version", got)
+	}
+}
+
+func TestPull_Conflict_Abort(t *testing.T) {
+	sb := testutil.NewSandbox(t)
+	defer sb.Cleanup()
+	setupPullConflict(t, sb)
+
+	sb.RunCLI("pull").AssertFailure(t)
+	result := sb.RunCLI("pull", "--abort")
+	result.AssertSuccess(t)
+	result.AssertOutputContains(t, "Pull aborted")
+	if got := sb.ReadFile(filepath.Join(sb.SourcePath, "shared", "SKILL.md")); got != "# Shared local\n" {
+		t.Errorf("shared = %q, want local version after abort", got)
+	}
+}
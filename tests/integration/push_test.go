@@ -308,8 +308,170 @@ targets: {}
 	}
 }
 
+func TestPush_PerSkill_CreatesOneCommitPerSkill(t *testing.T) {
+	sb := testutil.NewSandbox(t)
+	defer sb.Cleanup()
+
+	sb.WriteConfig(`source: ` + sb.SourcePath + `
+targets: {}
+`)
+
+	bareRepo := setupTrackedSource(t, sb)
+	sb.CreateSkill("alpha", map[string]string{"SKILL.md": "# Alpha"})
+	sb.CreateSkill("beta", map[string]string{"SKILL.md": "# Beta", "notes.md": "notes"})
+
+	result := sb.RunCLI("push", "--per-skill", "-m", "from laptop")
+	result.AssertSuccess(t)
+	result.AssertOutputContains(t, "Push complete")
+	result.AssertOutputContains(t, "Add beta (2 files, +2)")
+
+	log := testutil.RunGit(t, bareRepo, "log", "--format=%s", "-3")
+	if log != "Add beta (2 files, +2)\nAdd alpha (1 file, +1)\ninitial" {
+		t.Fatalf("remote log = %q", log)
+	}
+	body := testutil.RunGit(t, bareRepo, "log", "-1", "--format=%b")
+	if body != "from laptop" {
+		t.Errorf("commit body = %q, want -m message", body)
+	}
+}
+
+func TestPush_Branch_PushesWithoutSwitchingAndPrintsCompareURL(t *testing.T) {
+	sb := testutil.NewSandbox(t)
+	defer sb.Cleanup()
+
+	sb.WriteConfig(`source: ` + sb.SourcePath + `
+targets: {}
+`)
+
+	bareRepo := setupTrackedSource(t, sb)
+	// Fetch from a GitHub-looking URL, push to the local bare repo.
+	testutil.RunGit(t, sb.SourcePath, "remote", "set-url", "origin", "git@github.com:me/skills.git")
+	testutil.RunGit(t, sb.SourcePath, "remote", "set-url", "--push", "origin", bareRepo)
+	localBranch := testutil.RunGit(t, sb.SourcePath, "branch", "--show-current")
+
+	sb.CreateSkill("alpha", map[string]string{"SKILL.md": "# Alpha"})
+
+	result := sb.RunCLI("push", "--branch", "new-skills", "-m", "Add alpha")
+	result.AssertSuccess(t)
+	result.AssertOutputContains(t, "Pushed to branch new-skills")
+	result.AssertOutputContains(t, "https://github.com/me/skills/compare/"+localBranch+"...new-skills?expand=1")
+
+	if got := testutil.RunGit(t, bareRepo, "log", "-1", "--format=%s", "new-skills"); got != "Add alpha" {
+		t.Errorf("new-skills head = %q, want Add alpha", got)
+	}
+	if got := testutil.RunGit(t, bareRepo, "log", "-1", "--format=%s", localBranch); got != "initial" {
+		t.Errorf("%s head = %q, want untouched", localBranch, got)
+	}
+	if got := testutil.RunGit(t, sb.SourcePath, "branch", "--show-current"); got != localBranch {
+		t.Errorf("current branch = %q, want %q", got, localBranch)
+	}
+	// The commit stays on a local branch of the same name, not the tracked one
+	if got := testutil.RunGit(t, sb.SourcePath, "log", "-1", "--format=%s"); got != "initial" {
+		t.Errorf("local head = %q, want initial", got)
+	}
+	if got := testutil.RunGit(t, sb.SourcePath, "log", "-1", "--format=%s", "new-skills"); got != "Add alpha" {
+		t.Errorf("local new-skills head = %q, want Add alpha", got)
+	}
+	if got := testutil.RunGit(t, sb.SourcePath, "status", "--porcelain"); got != "" {
+		t.Errorf("status = %q, want clean", got)
+	}
+}
+
+func TestPush_Branch_MergeThenPull(t *testing.T) {
+	sb := testutil.NewSandbox(t)
+	defer sb.Cleanup()
+
+	sb.WriteConfig(`source: ` + sb.SourcePath + `
+targets: {}
+`)
+
+	bareRepo := setupTrackedSource(t, sb)
+	localBranch := testutil.RunGit(t, sb.SourcePath, "branch", "--show-current")
+	sb.CreateSkill("alpha", map[string]string{"SKILL.md": "# Alpha"})
+
+	sb.RunCLI("push", "--branch", "new-skills", "-m", "Add alpha").AssertSuccess(t)
+
+	// Merge the pull request on the remote
+	merge := filepath.Join(sb.Home, "merge")
+	testutil.RunGit(t, "", "clone", "--quiet", bareRepo, merge)
+	testutil.ConfigureGitUser(t, merge)
+	testutil.RunGit(t, merge, "merge", "--no-ff", "-m", "Merge new-skills", "origin/new-skills")
+	testutil.RunGit(t, merge, "push", "--quiet", "origin", "HEAD:"+localBranch)
+
+	sb.RunCLI("pull").AssertSuccess(t)
+
+	if got := sb.ReadFile(filepath.Join(sb.SourcePath, "alpha", "SKILL.md")); got != "# Alpha" {
+		t.Errorf("alpha after pull = %q", got)
+	}
+	if got := testutil.RunGit(t, sb.SourcePath, "status", "--porcelain"); got != "" {
+		t.Errorf("status after pull = %q, want clean", got)
+	}
+	if got := testutil.RunGit(t, sb.SourcePath, "stash", "list"); got != "" {
+		t.Errorf("stash list after pull = %q, want empty", got)
+	}
+}
+
+func TestPush_Branch_FailedPushKeepsChanges(t *testing.T) {
+	sb := testutil.NewSandbox(t)
+	defer sb.Cleanup()
+
+	sb.WriteConfig(`source: ` + sb.SourcePath + `
+targets: {}
+`)
+
+	setupTrackedSource(t, sb)
+	localBranch := testutil.RunGit(t, sb.SourcePath, "branch", "--show-current")
+	testutil.RunGit(t, sb.SourcePath, "remote", "set-url", "--push", "origin", filepath.Join(sb.Home, "missing.git"))
+	sb.CreateSkill("alpha", map[string]string{"SKILL.md": "# Alpha"})
+
+	sb.RunCLI("push", "--branch", "new-skills", "-m", "Add alpha")
+
+	if got := testutil.RunGit(t, sb.SourcePath, "branch", "--show-current"); got != localBranch {
+		t.Errorf("current branch = %q, want %q", got, localBranch)
+	}
+	if got := testutil.RunGit(t, sb.SourcePath, "branch", "--list", "new-skills"); got != "" {
+		t.Errorf("local new-skills branch left behind: %q", got)
+	}
+	if got := testutil.RunGit(t, sb.SourcePath, "status", "--porcelain"); !strings.Contains(got, "alpha") {
+		t.Errorf("status = %q, want alpha uncommitted", got)
+	}
+}
+
+func TestPush_Branch_RejectsInvalidName(t *testing.T) {
+	sb := testutil.NewSandbox(t)
+	defer sb.Cleanup()
+
+	for _, name := range []string{"-f", "bad..name", "@{-1}"} {
+		result := sb.RunCLI("push", "--branch="+name)
+		result.AssertFailure(t)
+		result.AssertAnyOutputContains(t, "invalid branch name")
+	}
+}
+
+func TestPush_Branch_RequiresName(t *testing.T) {
+	sb := testutil.NewSandbox(t)
+	defer sb.Cleanup()
+
+	result := sb.RunCLI("push", "--branch")
+	result.AssertFailure(t)
+	result.AssertAnyOutputContains(t, "requires a branch name")
+}
+
 // Helper functions
 
+// setupTrackedSource makes the source a git repo tracking a local bare
+// remote with one pushed commit, and returns the bare repo path.
+func setupTrackedSource(t *testing.T, sb *testutil.Sandbox) string {
+	t.Helper()
+	bareRepo := testutil.SetupBareRemoteRepo(t, sb.Home)
+	testutil.RunGit(t, sb.SourcePath, "init")
+	testutil.RunGit(t, sb.SourcePath, "remote", "add", "origin", bareRepo)
+	testutil.ConfigureGitUser(t, sb.SourcePath)
+	testutil.RunGit(t, sb.SourcePath, "commit", "--allow-empty", "-m", "initial")
+	testutil.RunGit(t, sb.SourcePath, "push", "-u", "origin", "HEAD")
+	return bareRepo
+}
+
 func initGitWithRemote(t *testing.T, sb *testutil.Sandbox) {
 	cmd := exec.Command("git", "init")
 	cmd.Dir = sb.SourcePath
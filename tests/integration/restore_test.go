@@ -188,3 +188,99 @@ targets:
 		t.Error("dry-run should not restore files")
 	}
 }
+
+func TestRestore_Skill_RestoresOnlySelected(t *testing.T) {
+	sb := testutil.NewSandbox(t)
+	defer sb.Cleanup()
+
+	targetPath := sb.CreateTarget("claude")
+	sb.WriteFile(filepath.Join(targetPath, "alpha", "SKILL.md"), "# Alpha v1")
+	sb.WriteFile(filepath.Join(targetPath, "beta", "SKILL.md"), "# Beta v1")
+
+	sb.WriteConfig(`source: ` + sb.SourcePath + `
+targets:
+  claude:
+    path: ` + targetPath + `
+`)
+
+	sb.RunCLI("backup").AssertSuccess(t)
+
+	sb.WriteFile(filepath.Join(targetPath, "alpha", "SKILL.md"), "# Alpha v2")
+	sb.WriteFile(filepath.Join(targetPath, "beta", "SKILL.md"), "# Beta v2")
+
+	result := sb.RunCLI("restore", "claude", "--skill", "alpha", "--force")
+	result.AssertSuccess(t)
+	result.AssertOutputContains(t, "Restored alpha")
+
+	if got := sb.ReadFile(filepath.Join(targetPath, "alpha", "SKILL.md")); got != "# Alpha v1" {
+		t.Errorf("alpha = %q, want restored v1", got)
+	}
+	if got := sb.ReadFile(filepath.Join(targetPath, "beta", "SKILL.md")); got != "# Beta v2" {
+		t.Errorf("beta = %q, should be untouched", got)
+	}
+}
+
+func TestRestore_Skill_ToSource(t *testing.T) {
+	sb := testutil.NewSandbox(t)
+	defer sb.Cleanup()
+
+	targetPath := sb.CreateTarget("claude")
+	sb.WriteFile(filepath.Join(targetPath, "local-only", "SKILL.md"), "# Local")
+
+	sb.WriteConfig(`source: ` + sb.SourcePath + `
+targets:
+  claude:
+    path: ` + targetPath + `
+`)
+
+	sb.RunCLI("backup").AssertSuccess(t)
+
+	result := sb.RunCLI("restore", "claude", "--skill", "local-only", "--to-source")
+	result.AssertSuccess(t)
+
+	if got := sb.ReadFile(filepath.Join(sb.SourcePath, "local-only", "SKILL.md")); got != "# Local" {
+		t.Errorf("source skill = %q, want restored content", got)
+	}
+}
+
+func TestRestore_ToSource_RequiresSkill(t *testing.T) {
+	sb := testutil.NewSandbox(t)
+	defer sb.Cleanup()
+
+	targetPath := sb.CreateTarget("claude")
+	sb.WriteConfig(`source: ` + sb.SourcePath + `
+targets:
+  claude:
+    path: ` + targetPath + `
+`)
+
+	result := sb.RunCLI("restore", "claude", "--to-source")
+	result.AssertFailure(t)
+	result.AssertAnyOutputContains(t, "--to-source requires --skill")
+}
+
+func TestBackupShow_ListsSkills(t *testing.T) {
+	sb := testutil.NewSandbox(t)
+	defer sb.Cleanup()
+
+	targetPath := sb.CreateTarget("claude")
+	sb.WriteFile(filepath.Join(targetPath, "shown-skill", "SKILL.md"), "# Shown")
+
+	sb.WriteConfig(`source: ` + sb.SourcePath + `
+targets:
+  claude:
+    path: ` + targetPath + `
+`)
+
+	sb.RunCLI("backup").AssertSuccess(t)
+
+	backups, _ := filepath.Glob(filepath.Join(sb.Home, ".local", "share", "skillshare", "backups", "2*"))
+	if len(backups) != 1 {
+		t.Fatalf("expected one backup, got %d", len(backups))
+	}
+
+	result := sb.RunCLI("backup", "show", filepath.Base(backups[0]))
+	result.AssertSuccess(t)
+	result.AssertOutputContains(t, "claude")
+	result.AssertOutputContains(t, "shown-skill")
+}
@@ -0,0 +1,110 @@
+//go:build !online
+
+package integration
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"testing"
+
+	"skillshare/internal/testutil"
+)
+
+func setupReviewSandbox(t *testing.T, extraConfig string) *testutil.Sandbox {
+	t.Helper()
+	sb := testutil.NewSandbox(t)
+	sb.WriteConfig(`source: ` + sb.SourcePath + `
+mode: merge
+targets:
+  claude:
+    path: ` + filepath.Join(sb.Home, ".claude", "skills") + `
+` + extraConfig)
+	sb.CreateSkill("overdue", map[string]string{
+		"SKILL.md": "---\nname: overdue\ndescription: Overdue skill. Use when testing reviews.\nowner: docs-team\nreview-by: 2020-01-31\n---\nBody.\n",
+	})
+	sb.CreateSkill("later", map[string]string{
+		"SKILL.md": "---\nname: later\ndescription: Reviewed later. Use when testing reviews.\nreview-by: 2999-01-01\n---\nBody.\n",
+	})
+	return sb
+}
+
+func TestReview_ListsAndMarksOverdue(t *testing.T) {
+	sb := setupReviewSandbox(t, "")
+	defer sb.Cleanup()
+
+	result := sb.RunCLI("review")
+	result.AssertSuccess(t)
+	result.AssertOutputContains(t, "overdue")
+	result.AssertOutputContains(t, "review was due 2020-01-31")
+	result.AssertOutputContains(t, "owner: docs-team")
+	result.AssertOutputNotContains(t, "later")
+
+	status := sb.RunCLI("status")
+	status.AssertSuccess(t)
+	status.AssertOutputContains(t, "Due for review (1)")
+
+	doctor := sb.RunCLI("doctor")
+	doctor.AssertSuccess(t)
+	doctor.AssertOutputContains(t, "Skills due for review: overdue")
+
+	mark := sb.RunCLI("review", "overdue")
+	mark.AssertSuccess(t)
+	mark.AssertOutputContains(t, "overdue marked reviewed")
+	if !sb.FileExists(filepath.Join(sb.Home, ".local", "state", "skillshare", "reviews.json")) {
+		t.Error("reviews.json not written to the state directory")
+	}
+
+	after := sb.RunCLI("review")
+	after.AssertSuccess(t)
+	after.AssertOutputContains(t, "No skills are due for review")
+	sb.RunCLI("status").AssertOutputNotContains(t, "Due for review")
+}
+
+func TestReview_MaxAgeUsesInstallTime(t *testing.T) {
+	sb := setupReviewSandbox(t, "review:\n  max_age_months: 6\n")
+	defer sb.Cleanup()
+	sb.CreateSkill("installed", map[string]string{
+		"SKILL.md":              "---\nname: installed\ndescription: Installed long ago. Use when testing reviews.\n---\nBody.\n",
+		".skillshare-meta.json": `{"source":"github.com/x/y","type":"github","installed_at":"2021-03-01T00:00:00Z"}`,
+	})
+
+	result := sb.RunCLI("check", "--json")
+	result.AssertSuccess(t)
+	var out struct {
+		Stale []struct {
+			Name        string `json:"name"`
+			ChangedFrom string `json:"changedFrom"`
+			Reason      string `json:"reason"`
+		} `json:"stale"`
+	}
+	if err := json.Unmarshal([]byte(result.Stdout), &out); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, result.Stdout)
+	}
+	var names []string
+	for _, s := range out.Stale {
+		names = append(names, s.Name)
+		if s.Name == "installed" && s.ChangedFrom != "installed" {
+			t.Errorf("installed changed from %q, want install time", s.ChangedFrom)
+		}
+	}
+	if len(names) != 2 || names[0] != "installed" || names[1] != "overdue" {
+		t.Errorf("stale = %v, want [installed overdue]", names)
+	}
+}
+
+func TestReview_UnknownSkill(t *testing.T) {
+	sb := setupReviewSandbox(t, "")
+	defer sb.Cleanup()
+
+	result := sb.RunCLI("review", "missing")
+	result.AssertFailure(t)
+	result.AssertAnyOutputContains(t, "skill 'missing' not found")
+}
+
+func TestReview_FrontmatterKeysPassLint(t *testing.T) {
+	sb := setupReviewSandbox(t, "")
+	defer sb.Cleanup()
+
+	result := sb.RunCLI("lint", "overdue")
+	result.AssertOutputNotContains(t, "unknown frontmatter key")
+}
@@ -0,0 +1,92 @@
+//go:build !online
+
+package integration
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"skillshare/internal/testutil"
+)
+
+func setupStatsSandbox(t *testing.T) *testutil.Sandbox {
+	t.Helper()
+	sb := testutil.NewSandbox(t)
+	sb.WriteConfig(`source: ` + sb.SourcePath + `
+mode: merge
+targets:
+  claude:
+    path: ` + filepath.Join(sb.Home, ".claude", "skills") + `
+  cursor:
+    path: ` + filepath.Join(sb.Home, ".cursor", "skills") + `
+    exclude: [big]
+stats:
+  budget: 100
+`)
+	sb.CreateSkill("big", map[string]string{
+		"SKILL.md": "---\nname: big\ndescription: A large skill.\n---\n" + strings.Repeat("word ", 200),
+	})
+	sb.CreateSkill("small", map[string]string{
+		"SKILL.md": "---\nname: small\ndescription: A small skill.\n---\nShort body.\n",
+	})
+	return sb
+}
+
+func TestStats_TextFlagsOverBudget(t *testing.T) {
+	sb := setupStatsSandbox(t)
+	defer sb.Cleanup()
+
+	result := sb.RunCLI("stats")
+	result.AssertSuccess(t)
+	result.AssertOutputContains(t, "claude")
+	result.AssertOutputContains(t, "cursor")
+	result.AssertOutputContains(t, "over budget")
+	result.AssertOutputContains(t, "1 skill(s) over the 100-token budget: big")
+}
+
+func TestStats_JSONPerTarget(t *testing.T) {
+	sb := setupStatsSandbox(t)
+	defer sb.Cleanup()
+
+	result := sb.RunCLI("stats", "--json", "--budget", "1000")
+	result.AssertSuccess(t)
+
+	var report struct {
+		Budget  int `json:"budget"`
+		Targets []struct {
+			Name       string   `json:"name"`
+			Skills     int      `json:"skills"`
+			OverBudget []string `json:"overBudget"`
+			Top        []struct {
+				Name string `json:"name"`
+			} `json:"top"`
+		} `json:"targets"`
+	}
+	if err := json.Unmarshal([]byte(result.Stdout), &report); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, result.Stdout)
+	}
+	if report.Budget != 1000 {
+		t.Errorf("budget = %d, want 1000 (flag overrides config)", report.Budget)
+	}
+	if len(report.Targets) != 2 {
+		t.Fatalf("targets = %+v", report.Targets)
+	}
+	claude, cursor := report.Targets[0], report.Targets[1]
+	if claude.Skills != 2 || claude.Top[0].Name != "big" || len(claude.OverBudget) != 0 {
+		t.Errorf("claude = %+v", claude)
+	}
+	if cursor.Skills != 1 || cursor.Top[0].Name != "small" {
+		t.Errorf("cursor = %+v, want only small", cursor)
+	}
+}
+
+func TestStats_UnknownTarget(t *testing.T) {
+	sb := setupStatsSandbox(t)
+	defer sb.Cleanup()
+
+	result := sb.RunCLI("stats", "--target", "nope")
+	result.AssertFailure(t)
+	result.AssertAnyOutputContains(t, "target 'nope' not found")
+}
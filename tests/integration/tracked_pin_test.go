@@ -0,0 +1,103 @@
+//go:build !online
+
+package integration
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"skillshare/internal/testutil"
+)
+
+// createTaggedRepo creates a tracked-repo origin with tags v1.0.0 and
+// v1.1.0 on main and a branch "next" one commit ahead.
+func createTaggedRepo(t *testing.T, sb *testutil.Sandbox) string {
+	t.Helper()
+	repo := createMultiSkillGitRepo(t, sb, "team-repo", []string{"alpha"})
+	run(t, repo, "git", "branch", "-M", "main")
+	run(t, repo, "git", "tag", "v1.0.0")
+
+	os.WriteFile(filepath.Join(repo, "alpha", "SKILL.md"), []byte("# alpha v1.1"), 0644)
+	run(t, repo, "git", "commit", "-am", "v1.1")
+	run(t, repo, "git", "tag", "v1.1.0")
+
+	run(t, repo, "git", "checkout", "-q", "-b", "next")
+	os.WriteFile(filepath.Join(repo, "alpha", "SKILL.md"), []byte("# alpha next"), 0644)
+	run(t, repo, "git", "commit", "-am", "next")
+	run(t, repo, "git", "checkout", "-q", "main")
+	return repo
+}
+
+func TestTrackedPin_InstallCheckUpdate(t *testing.T) {
+	sb := testutil.NewSandbox(t)
+	defer sb.Cleanup()
+	sb.WriteConfig("source: " + sb.SourcePath + "\ntargets: {}\n")
+	repo := createTaggedRepo(t, sb)
+	skill := filepath.Join(sb.SourcePath, "_team-repo", "alpha", "SKILL.md")
+
+	sb.RunCLI("install", "file://"+repo, "--track", "--name", "team-repo", "--ref", "v1.0.0").AssertSuccess(t)
+	if got := sb.ReadFile(skill); got != "# alpha" {
+		t.Fatalf("install --ref v1.0.0 checked out %q", got)
+	}
+	if cfg := sb.ReadFile(sb.ConfigPath); !strings.Contains(cfg, "ref: v1.0.0") {
+		t.Errorf("config should record the pin:\n%s", cfg)
+	}
+
+	result := sb.RunCLI("check")
+	result.AssertSuccess(t)
+	result.AssertAnyOutputContains(t, "pinned at v1.0.0, v1.1.0 available")
+
+	result = sb.RunCLI("update", "team-repo")
+	result.AssertSuccess(t)
+	result.AssertAnyOutputContains(t, "pinned at v1.0.0")
+	if got := sb.ReadFile(skill); got != "# alpha" {
+		t.Fatalf("update without --latest moved the pin: %q", got)
+	}
+
+	sb.RunCLI("update", "team-repo", "--latest").AssertSuccess(t)
+	if got := sb.ReadFile(skill); got != "# alpha v1.1" {
+		t.Fatalf("update --latest should move to v1.1.0, got %q", got)
+	}
+	if cfg := sb.ReadFile(sb.ConfigPath); !strings.Contains(cfg, "ref: v1.1.0") {
+		t.Errorf("config should record the moved pin:\n%s", cfg)
+	}
+}
+
+func TestTrackedPin_RepoCheckoutBranch(t *testing.T) {
+	sb := testutil.NewSandbox(t)
+	defer sb.Cleanup()
+	sb.WriteConfig("source: " + sb.SourcePath + "\ntargets: {}\n")
+	repo := createTaggedRepo(t, sb)
+	skill := filepath.Join(sb.SourcePath, "_team-repo", "alpha", "SKILL.md")
+
+	sb.RunCLI("install", "file://"+repo, "--track", "--name", "team-repo", "--ref", "v1.0.0").AssertSuccess(t)
+
+	result := sb.RunCLI("repo", "checkout", "team-repo", "next")
+	result.AssertSuccess(t)
+	result.AssertAnyOutputContains(t, "follows branch next")
+	if got := sb.ReadFile(skill); got != "# alpha next" {
+		t.Fatalf("repo checkout next checked out %q", got)
+	}
+	cfg := sb.ReadFile(sb.ConfigPath)
+	if !strings.Contains(cfg, "branch: next") || strings.Contains(cfg, "ref:") {
+		t.Errorf("config should follow branch next without a pin:\n%s", cfg)
+	}
+
+	sb.RunCLI("repo", "checkout", "team-repo", "no-such-ref").AssertFailure(t)
+}
+
+func TestTrackedPin_FlagValidation(t *testing.T) {
+	sb := testutil.NewSandbox(t)
+	defer sb.Cleanup()
+	sb.WriteConfig("source: " + sb.SourcePath + "\ntargets: {}\n")
+
+	result := sb.RunCLI("install", "github.com/team/skills", "--ref", "v1.0.0")
+	result.AssertFailure(t)
+	result.AssertAnyOutputContains(t, "require --track")
+
+	result = sb.RunCLI("update", "--all", "--to", "v1.0.0")
+	result.AssertFailure(t)
+	result.AssertAnyOutputContains(t, "--to needs exactly one tracked repository")
+}
@@ -0,0 +1,174 @@
+//go:build !online
+
+package integration
+
+import (
+	"path/filepath"
+	"testing"
+
+	"skillshare/internal/testutil"
+)
+
+func TestUndo_NothingToUndo(t *testing.T) {
+	sb := testutil.NewSandbox(t)
+	defer sb.Cleanup()
+
+	sb.WriteConfig(`source: ` + sb.SourcePath + `
+targets: {}
+`)
+
+	result := sb.RunCLI("undo", "--yes")
+	result.AssertFailure(t)
+	result.AssertAnyOutputContains(t, "no operation to undo")
+}
+
+func TestUndo_Uninstall_RestoresSkill(t *testing.T) {
+	sb := testutil.NewSandbox(t)
+	defer sb.Cleanup()
+
+	sb.CreateSkill("undo-me", map[string]string{"SKILL.md": "# Undo Me"})
+	sb.WriteConfig(`source: ` + sb.SourcePath + `
+targets: {}
+`)
+
+	sb.RunCLI("uninstall", "undo-me", "--force").AssertSuccess(t)
+	if sb.FileExists(filepath.Join(sb.SourcePath, "undo-me")) {
+		t.Fatal("skill should be removed by uninstall")
+	}
+
+	result := sb.RunCLI("undo", "--yes")
+	result.AssertSuccess(t)
+	result.AssertOutputContains(t, "Restored undo-me")
+
+	if sb.ReadFile(filepath.Join(sb.SourcePath, "undo-me", "SKILL.md")) != "# Undo Me" {
+		t.Error("skill should be restored with its content")
+	}
+
+	// Nothing left to undo
+	sb.RunCLI("undo", "--yes").AssertFailure(t)
+}
+
+func TestUndo_Install_RemovesSkill(t *testing.T) {
+	sb := testutil.NewSandbox(t)
+	defer sb.Cleanup()
+
+	localSkill := filepath.Join(sb.Root, "local-skill")
+	sb.WriteFile(filepath.Join(localSkill, "SKILL.md"), "# Local")
+	sb.WriteConfig(`source: ` + sb.SourcePath + `
+targets: {}
+`)
+
+	sb.RunCLI("install", localSkill).AssertSuccess(t)
+	if !sb.FileExists(filepath.Join(sb.SourcePath, "local-skill", "SKILL.md")) {
+		t.Fatal("skill should be installed")
+	}
+
+	result := sb.RunCLI("undo", "--yes")
+	result.AssertSuccess(t)
+	result.AssertOutputContains(t, "Removed local-skill")
+
+	if sb.FileExists(filepath.Join(sb.SourcePath, "local-skill")) {
+		t.Error("installed skill should be removed by undo")
+	}
+}
+
+func TestUndo_DryRun_NoChanges(t *testing.T) {
+	sb := testutil.NewSandbox(t)
+	defer sb.Cleanup()
+
+	sb.CreateSkill("keep-gone", map[string]string{"SKILL.md": "# Gone"})
+	sb.WriteConfig(`source: ` + sb.SourcePath + `
+targets: {}
+`)
+
+	sb.RunCLI("uninstall", "keep-gone", "--force").AssertSuccess(t)
+
+	result := sb.RunCLI("undo", "--dry-run")
+	result.AssertSuccess(t)
+	result.AssertOutputContains(t, "keep-gone")
+	result.AssertOutputContains(t, "Dry run")
+
+	if sb.FileExists(filepath.Join(sb.SourcePath, "keep-gone")) {
+		t.Error("dry run should not restore the skill")
+	}
+}
+
+func TestUndo_ConflictRequiresForce(t *testing.T) {
+	sb := testutil.NewSandbox(t)
+	defer sb.Cleanup()
+
+	localSkill := filepath.Join(sb.Root, "edited")
+	sb.WriteFile(filepath.Join(localSkill, "SKILL.md"), "# v1")
+	sb.WriteConfig(`source: ` + sb.SourcePath + `
+targets: {}
+`)
+
+	sb.RunCLI("install", localSkill).AssertSuccess(t)
+
+	// Hand edit after install
+	sb.WriteFile(filepath.Join(sb.SourcePath, "edited", "SKILL.md"), "# edited")
+
+	result := sb.RunCLI("undo", "--yes")
+	result.AssertFailure(t)
+	result.AssertAnyOutputContains(t, "changed after the operation")
+
+	sb.RunCLI("undo", "--yes", "--force").AssertSuccess(t)
+	if sb.FileExists(filepath.Join(sb.SourcePath, "edited")) {
+		t.Error("forced undo should remove the skill")
+	}
+}
+
+func TestUndo_List(t *testing.T) {
+	sb := testutil.NewSandbox(t)
+	defer sb.Cleanup()
+
+	sb.CreateSkill("listed", map[string]string{"SKILL.md": "# Listed"})
+	sb.WriteConfig(`source: ` + sb.SourcePath + `
+targets: {}
+`)
+
+	sb.RunCLI("uninstall", "listed", "--force").AssertSuccess(t)
+
+	result := sb.RunCLI("undo", "--list")
+	result.AssertSuccess(t)
+	result.AssertOutputContains(t, "uninstall")
+	result.AssertOutputContains(t, "1 removed")
+}
+
+func TestUndo_Sync_KeepsTargetLinks(t *testing.T) {
+	sb := testutil.NewSandbox(t)
+	defer sb.Cleanup()
+
+	sb.CreateSkill("alpha", map[string]string{"SKILL.md": "# Alpha"})
+	sb.CreateSkill("beta", map[string]string{"SKILL.md": "# Beta"})
+	target := sb.CreateTarget("claude")
+	sb.WriteConfig(`source: ` + sb.SourcePath + `
+targets:
+  claude:
+    path: ` + target + `
+`)
+	sb.RunCLI("sync").AssertSuccess(t)
+
+	// A local skill that a later forced sync replaces with a link
+	sb.WriteFile(filepath.Join(target, "mine", "SKILL.md"), "# Local Mine")
+	sb.CreateSkill("mine", map[string]string{"SKILL.md": "# Source Mine"})
+	sb.RunCLI("sync", "--force").AssertSuccess(t)
+	if !sb.IsSymlink(filepath.Join(target, "mine")) {
+		t.Fatal("sync --force should replace the local skill with a link")
+	}
+
+	sb.RunCLI("undo", "--yes").AssertSuccess(t)
+
+	for _, name := range []string{"alpha", "beta"} {
+		if !sb.IsSymlink(filepath.Join(target, name)) {
+			t.Errorf("%s should still be linked after undo", name)
+		}
+	}
+	mine := filepath.Join(target, "mine")
+	if sb.IsSymlink(mine) || sb.ReadFile(filepath.Join(mine, "SKILL.md")) != "# Local Mine" {
+		t.Error("undo should restore the local skill")
+	}
+	if sb.ReadFile(filepath.Join(sb.SourcePath, "mine", "SKILL.md")) != "# Source Mine" {
+		t.Error("undo must not write through links into the source")
+	}
+}
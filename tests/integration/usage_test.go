@@ -0,0 +1,106 @@
+//go:build !online
+
+package integration
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"skillshare/internal/testutil"
+)
+
+// setupUsageSandbox configures a claude target and writes a transcript in
+// its default location that invokes "pdf" twice and reads docx's SKILL.md.
+func setupUsageSandbox(t *testing.T) *testutil.Sandbox {
+	t.Helper()
+	sb := testutil.NewSandbox(t)
+	sb.WriteConfig(`source: ` + sb.SourcePath + `
+mode: merge
+targets:
+  claude:
+    path: ` + filepath.Join(sb.Home, ".claude", "skills") + `
+`)
+	sb.CreateSkill("pdf", map[string]string{"SKILL.md": "---\nname: pdf\ndescription: PDF tools.\n---\nBody.\n"})
+	sb.CreateSkill("docx", map[string]string{"SKILL.md": "---\nname: docx\ndescription: Word tools.\n---\nBody.\n"})
+	sb.CreateSkill("unused", map[string]string{"SKILL.md": "---\nname: unused\ndescription: Never used.\n---\nBody.\n"})
+
+	ts := time.Now().UTC().Add(-time.Hour).Format(time.RFC3339)
+	line := func(uuid, tool, input string) string {
+		return fmt.Sprintf(`{"type":"assistant","uuid":%q,"sessionId":"s1","timestamp":%q,"message":{"content":[{"type":"tool_use","name":%q,"input":%s}]}}`, uuid, ts, tool, input)
+	}
+	sb.WriteFile(filepath.Join(sb.Home, ".claude", "projects", "app", "s1.jsonl"),
+		line("u1", "Skill", `{"skill":"pdf"}`)+"\n"+
+			line("u2", "Skill", `{"skill":"pdf"}`)+"\n"+
+			line("u3", "Read", `{"file_path":"/x/.claude/skills/docx/SKILL.md"}`)+"\n")
+	return sb
+}
+
+func TestUsage_OffUntilEnabled(t *testing.T) {
+	sb := setupUsageSandbox(t)
+	defer sb.Cleanup()
+
+	result := sb.RunCLI("usage")
+	result.AssertSuccess(t)
+	result.AssertOutputContains(t, "Usage tracking is off")
+	if sb.FileExists(filepath.Join(sb.Home, ".local", "state", "skillshare", "usage", "events.jsonl")) {
+		t.Error("transcripts were read before opting in")
+	}
+
+	status := sb.RunCLI("status")
+	status.AssertSuccess(t)
+	status.AssertOutputNotContains(t, "Usage (last")
+}
+
+func TestUsage_EnableCountsInvocations(t *testing.T) {
+	sb := setupUsageSandbox(t)
+	defer sb.Cleanup()
+
+	enable := sb.RunCLI("usage", "enable")
+	enable.AssertSuccess(t)
+	enable.AssertOutputContains(t, "3 new invocation(s)")
+
+	result := sb.RunCLI("usage", "--json")
+	result.AssertSuccess(t)
+	var report struct {
+		Skills []struct {
+			Skill   string         `json:"skill"`
+			Total   int            `json:"total"`
+			Targets map[string]int `json:"targets"`
+		} `json:"skills"`
+		Unused []string `json:"unused"`
+	}
+	if err := json.Unmarshal([]byte(result.Stdout), &report); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, result.Stdout)
+	}
+	if len(report.Skills) != 2 || report.Skills[0].Skill != "pdf" || report.Skills[0].Total != 2 || report.Skills[0].Targets["claude"] != 2 {
+		t.Errorf("skills = %+v", report.Skills)
+	}
+	if len(report.Unused) != 1 || report.Unused[0] != "unused" {
+		t.Errorf("unused = %v", report.Unused)
+	}
+
+	// Rescanning the same transcript adds nothing.
+	sb.RunCLI("usage", "scan").AssertOutputContains(t, "0 new invocation(s)")
+
+	list := sb.RunCLI("list", "--verbose")
+	list.AssertSuccess(t)
+	list.AssertOutputContains(t, "Used:")
+	list.AssertOutputContains(t, "2× in 30 days")
+
+	status := sb.RunCLI("status")
+	status.AssertSuccess(t)
+	status.AssertOutputContains(t, "Usage (last 30 days)")
+	status.AssertOutputContains(t, "2 of 3 skills used")
+}
+
+func TestUsage_ProjectModeRejected(t *testing.T) {
+	sb := setupUsageSandbox(t)
+	defer sb.Cleanup()
+
+	result := sb.RunCLI("usage", "-p")
+	result.AssertFailure(t)
+	result.AssertAnyOutputContains(t, "global only")
+}
@@ -18,6 +18,7 @@ const InstallPage = lazy(() => import('./pages/InstallPage'));
 const TrashPage = lazy(() => import('./pages/TrashPage'));
 const AuditPage = lazy(() => import('./pages/AuditPage'));
 const AuditRulesPage = lazy(() => import('./pages/AuditRulesPage'));
+const UsagePage = lazy(() => import('./pages/UsagePage'));
 const LogPage = lazy(() => import('./pages/LogPage'));
 const ConfigPage = lazy(() => import('./pages/ConfigPage'));
 
@@ -45,6 +46,7 @@ export default function App() {
               <Route path="install" element={<Lazy><InstallPage /></Lazy>} />
               <Route path="audit" element={<Lazy><AuditPage /></Lazy>} />
               <Route path="audit/rules" element={<Lazy><AuditRulesPage /></Lazy>} />
+              <Route path="usage" element={<Lazy><UsagePage /></Lazy>} />
               <Route path="log" element={<Lazy><LogPage /></Lazy>} />
               <Route path="config" element={<Lazy><ConfigPage /></Lazy>} />
             </Route>
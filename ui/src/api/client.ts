@@ -90,7 +90,7 @@ export const api = {
       method: 'POST',
       body: JSON.stringify(opts),
     }),
-  installBatch: (opts: { source: string; skills: DiscoveredSkill[]; force?: boolean; skipAudit?: boolean; into?: string }) =>
+  installBatch: (opts: { source: string; skills: DiscoveredSkill[]; force?: boolean; skipAudit?: boolean; into?: string; allowPartial?: boolean }) =>
     apiFetch<BatchInstallResult>('/install/batch', {
       method: 'POST',
       body: JSON.stringify(opts),
@@ -141,8 +141,16 @@ export const api = {
     }),
   cleanupBackups: () =>
     apiFetch<{ success: boolean; removed: number }>('/backup/cleanup', { method: 'POST' }),
-  restore: (opts: { timestamp: string; target: string; force?: boolean }) =>
-    apiFetch<{ success: boolean; target: string; timestamp: string }>('/restore', {
+  showBackup: (timestamp: string) =>
+    apiFetch<BackupShowResponse>(`/backups/${encodeURIComponent(timestamp)}`),
+  restore: (opts: {
+    timestamp: string;
+    target: string;
+    force?: boolean;
+    skills?: string[];
+    toSource?: boolean;
+  }) =>
+    apiFetch<{ success: boolean; target: string; timestamp: string; skills?: string[] }>('/restore', {
       method: 'POST',
       body: JSON.stringify(opts),
     }),
@@ -185,6 +193,29 @@ export const api = {
       method: 'POST',
     }),
 
+  // Stats
+  getStats: (opts?: { budget?: number; top?: number }) => {
+    const params = new URLSearchParams();
+    if (opts?.budget) params.set('budget', String(opts.budget));
+    if (opts?.top) params.set('top', String(opts.top));
+    const qs = params.toString();
+    return apiFetch<StatsResponse>(qs ? `/stats?${qs}` : '/stats');
+  },
+
+  // Review
+  getReview: (all?: boolean) =>
+    apiFetch<ReviewResponse>(all ? '/review?all=true' : '/review'),
+  markReviewed: (name: string) =>
+    apiFetch<{ success: boolean; reviewedAt: string }>(`/review/${encodeURIComponent(name)}`, {
+      method: 'POST',
+    }),
+
+  // Usage
+  getUsage: (days?: number) =>
+    apiFetch<UsageResponse>(days !== undefined ? `/usage?days=${days}` : '/usage'),
+  scanUsage: () =>
+    apiFetch<{ added: number }>('/usage/scan', { method: 'POST' }),
+
   // Git
   gitStatus: () => apiFetch<GitStatus>('/git/status'),
   push: (opts: { message?: string; dryRun?: boolean }) =>
@@ -192,7 +223,13 @@ export const api = {
       method: 'POST',
       body: JSON.stringify(opts),
     }),
-  pull: (opts?: { dryRun?: boolean }) =>
+  pull: (opts?: {
+    dryRun?: boolean;
+    strategy?: 'rebase' | 'merge';
+    resolutions?: Record<string, 'ours' | 'theirs'>;
+    resolveAll?: 'ours' | 'theirs';
+    abort?: boolean;
+  }) =>
     apiFetch<PullResponse>('/pull', {
       method: 'POST',
       body: JSON.stringify(opts ?? {}),
@@ -216,6 +253,23 @@ export interface Overview {
   trackedRepos: TrackedRepo[];
   isProjectMode: boolean;
   projectRoot?: string;
+  daemon?: DaemonStatus;
+}
+
+export interface DaemonTask {
+  name: string;
+  interval: string;
+  running?: boolean;
+  lastRun?: string;
+  lastStatus?: string;
+  lastMessage?: string;
+  nextRun?: string;
+}
+
+export interface DaemonStatus {
+  running: boolean;
+  pid?: number;
+  tasks: DaemonTask[];
 }
 
 export interface VersionCheck {
@@ -333,6 +387,7 @@ export interface BatchInstallResultItem {
 export interface BatchInstallResult {
   results: BatchInstallResultItem[];
   summary: string;
+  rolledBack?: boolean;
 }
 
 export interface LocalSkillInfo {
@@ -387,11 +442,24 @@ export interface BackupListResponse {
   totalSizeMB: number;
 }
 
+export interface BackupSkill {
+  name: string;
+  files: number;
+  sizeKB: number;
+}
+
+export interface BackupShowResponse {
+  backup: BackupInfo;
+  targets: Record<string, BackupSkill[]>;
+}
+
 // Check types
 export interface RepoCheckResult {
   name: string;
   status: string;
   behind: number;
+  pinned?: string;
+  latest?: string;
   message?: string;
 }
 
@@ -432,6 +500,14 @@ export interface PullResponse {
   syncResults: SyncResult[];
   dryRun?: boolean;
   message?: string;
+  stashed?: boolean;
+  stage?: 'rebase' | 'merge' | 'stash';
+  conflicts?: PullConflict[];
+}
+
+export interface PullConflict {
+  skill: string;
+  files: string[];
 }
 
 // Log types
@@ -503,6 +579,73 @@ export interface AuditRulesResponse {
   path: string;
 }
 
+// Stats types (approximate token counts)
+export interface SkillCost {
+  name: string;
+  relPath: string;
+  description: number;
+  body: number;
+  references: number;
+  total: number;
+  overBudget?: boolean;
+}
+
+export interface TargetStats {
+  name: string;
+  mode: string;
+  skills: number;
+  description: number;
+  body: number;
+  references: number;
+  total: number;
+  overBudget: string[];
+  top: SkillCost[];
+}
+
+export interface StatsResponse {
+  budget: number;
+  skills: SkillCost[];
+  targets: TargetStats[];
+}
+
+export interface ReviewStatus {
+  name: string;
+  relPath: string;
+  owner?: string;
+  reviewBy?: string;
+  reviewedAt?: string;
+  lastChanged?: string;
+  changedFrom?: string;
+  stale: boolean;
+  reason?: string;
+}
+
+export interface ReviewResponse {
+  maxAgeMonths: number;
+  skills: ReviewStatus[];
+}
+
+export interface SkillUsage {
+  skill: string;
+  total: number;
+  targets: Record<string, number>;
+  days: Record<string, number>;
+  lastUsed: string;
+}
+
+export interface UsageSource {
+  target: string;
+  patterns: string[];
+}
+
+export interface UsageResponse {
+  enabled: boolean;
+  days: number;
+  skills: SkillUsage[];
+  unused: string[];
+  sources: UsageSource[];
+}
+
 // Hub saved config types
 export interface HubSavedEntry {
   label: string;
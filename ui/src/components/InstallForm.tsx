@@ -218,7 +218,7 @@ export default function InstallForm({
           }
           if (item.warnings?.length) allWarnings.push(...item.warnings.map((w) => `${item.name}: ${w}`));
         }
-        toast(res.summary, auditBlockedSkills.length > 0 ? 'warning' : 'success');
+        toast(res.summary, res.rolledBack || auditBlockedSkills.length > 0 ? 'warning' : 'success');
         if (allWarnings.length > 0) setWarningDialog(allWarnings);
         resetForm();
         onSuccess?.({ action: 'installed', warnings: [], skillName: res.summary });
@@ -272,16 +272,17 @@ export default function InstallForm({
         if (item.warnings?.length) allWarnings.push(...item.warnings.map((w) => `${item.name}: ${w}`));
       }
       // Always show summary toast
-      toast(res.summary, auditBlockedSkills.length > 0 ? 'warning' : 'success');
+      toast(res.summary, res.rolledBack || auditBlockedSkills.length > 0 ? 'warning' : 'success');
       if (allWarnings.length > 0) setWarningDialog(allWarnings);
       setShowPicker(false);
       resetForm();
       onSuccess?.({ action: 'installed', warnings: [], skillName: res.summary });
-      // Show audit dialog for blocked items only (force-retry targets just those)
+      // Show audit dialog for blocked items. Force-retry targets just those,
+      // or every selected skill when the batch was rolled back.
       if (auditBlockedSkills.length > 0) {
         setAuditDialog({
           findings: auditFindings,
-          pending: { type: 'batch', source: pendingSource, skills: auditBlockedSkills },
+          pending: { type: 'batch', source: pendingSource, skills: res.rolledBack ? selected : auditBlockedSkills },
         });
       }
     } catch (e: unknown) {
@@ -13,6 +13,7 @@ import {
   Download,
   ShieldCheck,
   ScrollText,
+  BarChart3,
   Settings,
   Menu,
   X,
@@ -32,6 +33,7 @@ const allNavItems = [
   { to: '/backup', icon: Archive, label: 'Backup' },
   { to: '/trash', icon: Trash2, label: 'Trash' },
   { to: '/audit', icon: ShieldCheck, label: 'Audit' },
+  { to: '/usage', icon: BarChart3, label: 'Usage' },
   { to: '/log', icon: ScrollText, label: 'Log' },
   { to: '/config', icon: Settings, label: 'Config' },
 ];
@@ -42,7 +44,7 @@ export default function Layout() {
 
   const navItems = useMemo(() => {
     if (isProjectMode) {
-      return allNavItems.filter((item) => item.to !== '/git' && item.to !== '/backup');
+      return allNavItems.filter((item) => item.to !== '/git' && item.to !== '/backup' && item.to !== '/usage');
     }
     return allNavItems;
   }, [isProjectMode]);
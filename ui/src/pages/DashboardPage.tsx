@@ -16,6 +16,7 @@ import {
   Package,
   Zap,
   ShieldCheck,
+  CalendarClock,
 } from 'lucide-react';
 import Card from '../components/Card';
 import Badge from '../components/Badge';
@@ -249,6 +250,9 @@ export default function DashboardPage() {
       {/* Security Audit */}
       <SecurityAuditSection />
 
+      {/* Stale Skills */}
+      <StaleSkillsSection />
+
       {/* Targets Health */}
       <TargetsHealthSection />
 
@@ -545,6 +549,11 @@ function SkillUpdatesSection() {
               </div>
               {repo.status === 'up_to_date' && <Badge variant="success">Up to date</Badge>}
               {repo.status === 'behind' && <Badge variant="warning">{repo.behind} behind</Badge>}
+              {repo.status === 'pinned' && (
+                <Badge variant={repo.latest ? 'warning' : 'success'}>
+                  {repo.latest ? `${repo.pinned} → ${repo.latest}` : `Pinned ${repo.pinned}`}
+                </Badge>
+              )}
               {repo.status === 'dirty' && <Badge variant="default">Modified</Badge>}
               {repo.status === 'error' && <Badge variant="danger">Error</Badge>}
             </div>
@@ -695,6 +704,91 @@ function SecurityAuditSection() {
   );
 }
 
+/* ── Stale Skills Section ─────────────────────────────── */
+
+function StaleSkillsSection() {
+  const { data, loading, refetch } = useApi(() => api.getReview());
+  const [marking, setMarking] = useState<string | null>(null);
+  const { toast } = useToast();
+
+  const handleReviewed = async (name: string) => {
+    setMarking(name);
+    try {
+      await api.markReviewed(name);
+      toast(`Marked "${name}" reviewed`, 'success');
+      refetch();
+    } catch (e: unknown) {
+      toast((e as Error).message, 'error');
+    } finally {
+      setMarking(null);
+    }
+  };
+
+  const skills = data?.skills ?? [];
+
+  return (
+    <Card className="mb-8">
+      <div className="flex items-center gap-2 mb-4">
+        <CalendarClock size={20} strokeWidth={2.5} className="text-blue" />
+        <h3
+          className="text-lg font-bold text-pencil"
+          style={{ fontFamily: 'var(--font-heading)' }}
+        >
+          Stale Skills
+        </h3>
+        {skills.length > 0 && <Badge variant="warning">{skills.length} due</Badge>}
+      </div>
+
+      {loading ? (
+        <div className="space-y-3">
+          <Skeleton className="w-full h-8" />
+          <Skeleton className="w-3/4 h-8" />
+        </div>
+      ) : skills.length === 0 ? (
+        <p className="text-pencil-light text-sm">
+          No skills are due for review. Set <code>review-by:</code> in a skill&apos;s frontmatter
+          {data && data.maxAgeMonths > 0
+            ? `; skills unchanged for ${data.maxAgeMonths} months also show up here.`
+            : ', or review.max_age_months in config, to get reminders.'}
+        </p>
+      ) : (
+        <div className="space-y-2">
+          {skills.map((s) => (
+            <div
+              key={s.name}
+              className="flex items-center justify-between gap-3 py-2 px-3 bg-paper-warm border border-muted"
+              style={{ borderRadius: wobbly.sm }}
+            >
+              <div className="min-w-0">
+                <Link
+                  to={`/skills/${encodeURIComponent(s.name)}`}
+                  className="font-medium text-pencil hover:underline"
+                  style={{ fontFamily: 'var(--font-hand)' }}
+                >
+                  {s.name}
+                </Link>
+                <p className="text-xs text-pencil-light truncate">
+                  {s.reason}
+                  {s.owner && ` · owner: ${s.owner}`}
+                </p>
+              </div>
+              <button
+                onClick={() => handleReviewed(s.name)}
+                disabled={marking === s.name}
+                className="text-sm text-blue hover:underline disabled:opacity-50 shrink-0"
+                style={{ fontFamily: 'var(--font-hand)' }}
+              >
+                <Check size={14} strokeWidth={2.5} className="inline mr-1" />
+                Mark reviewed
+              </button>
+            </div>
+          ))}
+        </div>
+      )}
+    </Card>
+  );
+}
+
 /* ── Targets Health Section ───────────────────────────── */
 
 function TargetsHealthSection() {
@@ -0,0 +1,185 @@
+import { useState } from 'react';
+import { BarChart3, RefreshCw } from 'lucide-react';
+import { api } from '../api/client';
+import type { SkillUsage } from '../api/client';
+import { useApi } from '../hooks/useApi';
+import Card from '../components/Card';
+import HandButton from '../components/HandButton';
+import Badge from '../components/Badge';
+import EmptyState from '../components/EmptyState';
+import { PageSkeleton } from '../components/Skeleton';
+import { useToast } from '../components/Toast';
+
+const windows = [7, 30, 90, 0];
+
+function windowLabel(days: number): string {
+  return days === 0 ? 'all time' : `last ${days} days`;
+}
+
+export default function UsagePage() {
+  const { toast } = useToast();
+  const [days, setDays] = useState(30);
+  const [scanning, setScanning] = useState(false);
+  const { data, loading, error, refetch } = useApi(() => api.getUsage(days), [days]);
+
+  const handleScan = async () => {
+    setScanning(true);
+    try {
+      const res = await api.scanUsage();
+      toast(`Scanned transcripts: ${res.added} new invocation${res.added !== 1 ? 's' : ''}`, 'success');
+      refetch();
+    } catch (e: any) {
+      toast(e.message, 'error');
+    } finally {
+      setScanning(false);
+    }
+  };
+
+  if (loading) return <PageSkeleton />;
+
+  if (error) {
+    return (
+      <Card>
+        <p className="text-danger">{error}</p>
+      </Card>
+    );
+  }
+
+  const skills = data?.skills ?? [];
+  const unused = data?.unused ?? [];
+  const max = skills.reduce((m, s) => Math.max(m, s.total), 0);
+
+  return (
+    <div className="space-y-6">
+      {/* Header */}
+      <div>
+        <h2
+          className="text-3xl font-bold text-pencil"
+          style={{ fontFamily: 'var(--font-heading)' }}
+        >
+          Usage
+        </h2>
+        <p
+          className="text-pencil-light mt-1"
+          style={{ fontFamily: 'var(--font-hand)' }}
+        >
+          How often agents invoke each skill, counted locally from their session transcripts
+        </p>
+      </div>
+
+      {!data?.enabled ? (
+        <EmptyState
+          icon={BarChart3}
+          title="Usage tracking is off"
+          description="Nothing is read until you opt in with: skillshare usage enable"
+        />
+      ) : (
+        <>
+          {/* Summary Card */}
+          <Card variant="postit">
+            <div className="flex flex-col sm:flex-row items-start sm:items-center justify-between gap-4">
+              <div>
+                <p
+                  className="text-lg font-medium text-pencil"
+                  style={{ fontFamily: 'var(--font-hand)' }}
+                >
+                  {skills.length} skill{skills.length !== 1 ? 's' : ''} used, {unused.length} unused ({windowLabel(days)})
+                </p>
+                <p className="text-sm text-pencil-light">
+                  {data.sources.length === 0
+                    ? 'No target has known transcript locations'
+                    : `Reading ${data.sources.map((s) => s.target).join(', ')}`}
+                </p>
+              </div>
+              <div className="flex items-center gap-2">
+                {windows.map((w) => (
+                  <HandButton
+                    key={w}
+                    size="sm"
+                    variant={w === days ? 'primary' : 'ghost'}
+                    onClick={() => setDays(w)}
+                  >
+                    {w === 0 ? 'All' : `${w}d`}
+                  </HandButton>
+                ))}
+                <HandButton variant="secondary" size="sm" onClick={handleScan} disabled={scanning}>
+                  <RefreshCw size={16} strokeWidth={2.5} className={scanning ? 'animate-spin' : ''} /> Scan
+                </HandButton>
+              </div>
+            </div>
+          </Card>
+
+          {/* Skill List */}
+          {skills.length === 0 ? (
+            <EmptyState
+              icon={BarChart3}
+              title="No invocations recorded"
+              description="Scan again after your agents have used some skills"
+            />
+          ) : (
+            <Card>
+              <div className="space-y-3">
+                {skills.map((s) => (
+                  <UsageRow key={s.skill} usage={s} max={max} />
+                ))}
+              </div>
+            </Card>
+          )}
+
+          {/* Unused */}
+          {unused.length > 0 && (
+            <Card>
+              <p
+                className="text-lg text-pencil mb-2"
+                style={{ fontFamily: 'var(--font-heading)' }}
+              >
+                Unused in the {windowLabel(days)}
+              </p>
+              <p className="text-sm text-pencil-light mb-3">
+                Candidates for uninstall
+              </p>
+              <div className="flex flex-wrap gap-2">
+                {unused.map((name) => (
+                  <Badge key={name} variant="warning">{name}</Badge>
+                ))}
+              </div>
+            </Card>
+          )}
+        </>
+      )}
+    </div>
+  );
+}
+
+function UsageRow({ usage, max }: { usage: SkillUsage; max: number }) {
+  const targets = Object.entries(usage.targets).sort((a, b) => b[1] - a[1]);
+  return (
+    <div className="space-y-1">
+      <div className="flex items-center justify-between gap-2">
+        <span
+          className="font-medium text-pencil"
+          style={{ fontFamily: 'var(--font-hand)' }}
+        >
+          {usage.skill}
+        </span>
+        <div className="flex items-center gap-2">
+          {targets.map(([name, count]) => (
+            <Badge key={name} variant="info">{name} {count}</Badge>
+          ))}
+          <span className="text-sm text-pencil-light">
+            last {new Date(usage.lastUsed).toLocaleDateString()}
+          </span>
+        </div>
+      </div>
+      <div className="flex items-center gap-2">
+        <div className="flex-1 h-2 bg-muted rounded">
+          <div
+            className="h-2 bg-blue rounded"
+            style={{ width: `${max > 0 ? (usage.total / max) * 100 : 0}%` }}
+          />
+        </div>
+        <span className="text-sm text-pencil w-10 text-right">{usage.total}</span>
+      </div>
+    </div>
+  );
+}
@@ -98,9 +98,16 @@ const sidebars: SidebarsConfig = {
           label: 'Skill Management',
           items: [
             'commands/new',
+            'commands/import',
             'commands/check',
             'commands/update',
+            'commands/repo',
             'commands/upgrade',
+            'commands/dedupe',
+            'commands/review',
+            'commands/publish',
+            'commands/hooks',
+            'commands/cache',
           ],
         },
         {
@@ -119,6 +126,7 @@ const sidebars: SidebarsConfig = {
             'commands/backup',
             'commands/restore',
             'commands/trash',
+            'commands/undo',
             'commands/push',
             'commands/pull',
           ],
@@ -128,8 +136,12 @@ const sidebars: SidebarsConfig = {
           label: 'Security & Utilities',
           items: [
             'commands/audit',
+            'commands/lint',
+            'commands/stats',
+            'commands/usage',
             'commands/hub',
             'commands/log',
+            'commands/daemon',
             'commands/doctor',
             'commands/ui',
             'commands/version',